@@ -44,6 +44,9 @@ type EventingRules map[string]*Rule // Key here is resource id --> clusterId--pr
 // EventingTriggers is a map which stores database config information
 type EventingTriggers map[string]*EventingTrigger // Key here is resource id --> clusterId--projectId--resourceType--triggerId
 
+// EventingSources is a map which stores the message brokers events are consumed from
+type EventingSources map[string]*EventingSource // Key here is resource id --> clusterId--projectId--resourceType--sourceId
+
 // FileStoreRules is a map which stores database config information
 type FileStoreRules map[string]*FileRule // Key here is resource id --> clusterId--projectId--resourceType--fileRuleId
 
@@ -63,11 +66,15 @@ type Project struct {
 	EventingSchemas  EventingSchemas  `json:"eventingSchemas" yaml:"eventingSchemas" mapstructure:"eventingSchemas"`
 	EventingRules    EventingRules    `json:"eventingRules" yaml:"eventingRules" mapstructure:"eventingRules"`
 	EventingTriggers EventingTriggers `json:"eventingTriggers" yaml:"eventingTriggers" mapstructure:"eventingTriggers"`
+	EventingSources  EventingSources  `json:"eventingSources" yaml:"eventingSources" mapstructure:"eventingSources"`
 
 	FileStoreConfig *FileStoreConfig `json:"fileStoreConfig" yaml:"fileStoreConfig" mapstructure:"fileStoreConfig"`
 	FileStoreRules  FileStoreRules   `json:"fileStoreRules" yaml:"fileStoreRules" mapstructure:"fileStoreRules"`
 
-	Auths Auths `json:"auths" yaml:"auths" mapstructure:"auths"`
+	Auths         Auths         `json:"auths" yaml:"auths" mapstructure:"auths"`
+	SecurityRoles SecurityRoles `json:"securityRoles" yaml:"securityRoles" mapstructure:"securityRoles"`
+
+	GraphQLQueries GraphQLQueries `json:"graphqlQueries" yaml:"graphqlQueries" mapstructure:"graphqlQueries"`
 
 	LetsEncrypt *LetsEncrypt `json:"letsencrypt" yaml:"letsencrypt" mapstructure:"letsencrypt"`
 
@@ -79,15 +86,65 @@ type Project struct {
 
 // ProjectConfig stores information of individual project
 type ProjectConfig struct {
-	ID                 string    `json:"id,omitempty" yaml:"id,omitempty" mapstructure:"id"`
-	Name               string    `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name"`
-	Secrets            []*Secret `json:"secrets,omitempty" yaml:"secrets,omitempty" mapstructure:"secrets"`
-	SecretSource       string    `json:"secretSource,omitempty" yaml:"secretSource,omitempty" mapstructure:"secretSource"`
-	IsIntegration      bool      `json:"isIntegration,omitempty" yaml:"isIntegration,omitempty" mapstructure:"isIntegration"`
-	AESKey             string    `json:"aesKey,omitempty" yaml:"aesKey,omitempty" mapstructure:"aesKey"`
-	DockerRegistry     string    `json:"dockerRegistry,omitempty" yaml:"dockerRegistry,omitempty" mapstructure:"dockerRegistry"`
-	ContextTimeGraphQL int       `json:"contextTimeGraphQL,omitempty" yaml:"contextTimeGraphQL,omitempty" mapstructure:"contextTimeGraphQL"` // contextTime sets the timeout of query
-}
+	ID                 string         `json:"id,omitempty" yaml:"id,omitempty" mapstructure:"id"`
+	Name               string         `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name"`
+	Secrets            []*Secret      `json:"secrets,omitempty" yaml:"secrets,omitempty" mapstructure:"secrets"`
+	SecretSource       string         `json:"secretSource,omitempty" yaml:"secretSource,omitempty" mapstructure:"secretSource"`
+	IsIntegration      bool           `json:"isIntegration,omitempty" yaml:"isIntegration,omitempty" mapstructure:"isIntegration"`
+	AESKey             string         `json:"aesKey,omitempty" yaml:"aesKey,omitempty" mapstructure:"aesKey"`
+	DockerRegistry     string         `json:"dockerRegistry,omitempty" yaml:"dockerRegistry,omitempty" mapstructure:"dockerRegistry"`
+	ContextTimeGraphQL int            `json:"contextTimeGraphQL,omitempty" yaml:"contextTimeGraphQL,omitempty" mapstructure:"contextTimeGraphQL"` // contextTime sets the timeout of query
+	GraphQLAllowList   bool           `json:"graphqlAllowList,omitempty" yaml:"graphqlAllowList,omitempty" mapstructure:"graphqlAllowList"`       // accept only the registered graphql queries
+	GraphQLLimits      *GraphQLLimits `json:"graphqlLimits,omitempty" yaml:"graphqlLimits,omitempty" mapstructure:"graphqlLimits"`
+}
+
+// GraphQLLimits holds the limits enforced on graphql queries before they get executed
+type GraphQLLimits struct {
+	// MaxDepth is the maximum nesting of selection sets allowed in a query
+	MaxDepth int `json:"maxDepth,omitempty" yaml:"maxDepth,omitempty" mapstructure:"maxDepth"`
+	// MaxCost is the maximum cost allowed for a query
+	MaxCost int `json:"maxCost,omitempty" yaml:"maxCost,omitempty" mapstructure:"maxCost"`
+	// FieldWeights overrides the cost of fields. The key is either the field name or `parent.field`
+	FieldWeights map[string]int `json:"fieldWeights,omitempty" yaml:"fieldWeights,omitempty" mapstructure:"fieldWeights"`
+	// DefaultListSize is the number of items assumed for lists which aren't bounded by a limit argument
+	DefaultListSize int `json:"defaultListSize,omitempty" yaml:"defaultListSize,omitempty" mapstructure:"defaultListSize"`
+	// RateLimits are token buckets kept for every value of a jwt claim
+	RateLimits []*GraphQLRateLimit `json:"rateLimits,omitempty" yaml:"rateLimits,omitempty" mapstructure:"rateLimits"`
+}
+
+// GraphQLRateLimit is a token bucket kept for every value of the jwt claim. Requests without the claim share a bucket
+type GraphQLRateLimit struct {
+	Claim string  `json:"claim" yaml:"claim" mapstructure:"claim"`
+	Rate  float64 `json:"rate" yaml:"rate" mapstructure:"rate"`    // tokens added to the bucket every second
+	Burst int     `json:"burst" yaml:"burst" mapstructure:"burst"` // maximum tokens in the bucket
+	// UseCost consumes as many tokens as the cost of the query instead of a single token per request
+	UseCost bool `json:"useCost,omitempty" yaml:"useCost,omitempty" mapstructure:"useCost"`
+}
+
+// RateLimit limits the rate of requests. A separate limit is kept for every value of the key. Requests without a
+// value for the key share a limit
+type RateLimit struct {
+	// Key is a path in the attributes of the request which are `args.ip`, `args.auth` (jwt claims), `args.token` and
+	// `args.headers` (lower case names). It defaults to `args.ip`
+	Key       string             `json:"key,omitempty" yaml:"key,omitempty" mapstructure:"key"`
+	Algorithm RateLimitAlgorithm `json:"algorithm,omitempty" yaml:"algorithm,omitempty" mapstructure:"algorithm"`
+	Limit     int                `json:"limit" yaml:"limit" mapstructure:"limit"`    // requests allowed in a window, or the size of the token bucket
+	Window    int                `json:"window" yaml:"window" mapstructure:"window"` // window in seconds. A token bucket gets refilled completely in a window
+}
+
+// RateLimitAlgorithm describes how requests are counted against a rate limit
+type RateLimitAlgorithm string
+
+const (
+	// RateLimitFixedWindow counts the requests made in a window which starts with the first request. It is the default
+	RateLimitFixedWindow RateLimitAlgorithm = "fixed-window"
+
+	// RateLimitSlidingWindow weighs the count of the previous window by how much of it overlaps the sliding window
+	RateLimitSlidingWindow RateLimitAlgorithm = "sliding-window"
+
+	// RateLimitTokenBucket allows bursts of requests as large as the limit while refilling tokens at a steady rate
+	RateLimitTokenBucket RateLimitAlgorithm = "token-bucket"
+)
 
 // DriverConfig stores the parameters for drivers of Databases.
 type DriverConfig struct {
@@ -125,6 +182,25 @@ type DatabaseRule struct {
 	IsRealTimeEnabled       bool             `json:"isRealtimeEnabled,omitempty" yaml:"isRealtimeEnabled" mapstructure:"isRealtimeEnabled"`
 	EnableCacheInvalidation bool             `json:"enableCacheInvalidation,omitempty" yaml:"enableCacheInvalidation" mapstructure:"enableCacheInvalidation"`
 	Rules                   map[string]*Rule `json:"rules,omitempty" yaml:"rules" mapstructure:"rules"`
+	FieldPolicies           FieldPolicies    `json:"fieldPolicies,omitempty" yaml:"fieldPolicies,omitempty" mapstructure:"fieldPolicies"`
+}
+
+// FieldPolicies holds the read policies of the fields of a table
+type FieldPolicies map[string]*FieldPolicy // The key here is the field name
+
+// FieldPolicy decides how a field is returned on reads. The field is returned as is if the return rule is satisfied,
+// masked if the mask rule is satisfied and omitted otherwise
+type FieldPolicy struct {
+	Return *Rule      `json:"return,omitempty" yaml:"return,omitempty" mapstructure:"return"`
+	Mask   *Rule      `json:"mask,omitempty" yaml:"mask,omitempty" mapstructure:"mask"`
+	Format *FieldMask `json:"format,omitempty" yaml:"format,omitempty" mapstructure:"format"`
+}
+
+// FieldMask describes how a field is masked. All the characters other than the first and last few are replaced
+type FieldMask struct {
+	ShowFirst int    `json:"showFirst,omitempty" yaml:"showFirst,omitempty" mapstructure:"showFirst"`
+	ShowLast  int    `json:"showLast,omitempty" yaml:"showLast,omitempty" mapstructure:"showLast"`
+	Char      string `json:"char,omitempty" yaml:"char,omitempty" mapstructure:"char"`
 }
 
 // EventingConfig stores information of eventing config
@@ -287,11 +363,61 @@ type Rule struct {
 // Auths holds the mapping of the sign in method
 type Auths map[string]*AuthStub // The key here is the sign in method
 
-// AuthStub holds the config at a single sign in level
+// AuthStub holds the config at a single sign in level. Sign in methods other than email use the OAuth2 authorization
+// code flow, in which case the secret is the client secret of the provider. The endpoints of the provider are either
+// configured directly or loaded from its OIDC discovery url
 type AuthStub struct {
 	ID      string `json:"id" yaml:"id" mapstructure:"id"`
 	Enabled bool   `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
 	Secret  string `json:"secret" yaml:"secret" mapstructure:"secret"`
+
+	ClientID     string   `json:"clientId,omitempty" yaml:"clientId,omitempty" mapstructure:"clientId"`
+	DiscoveryURL string   `json:"discoveryUrl,omitempty" yaml:"discoveryUrl,omitempty" mapstructure:"discoveryUrl"`
+	AuthURL      string   `json:"authUrl,omitempty" yaml:"authUrl,omitempty" mapstructure:"authUrl"`
+	TokenURL     string   `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty" mapstructure:"tokenUrl"`
+	UserInfoURL  string   `json:"userInfoUrl,omitempty" yaml:"userInfoUrl,omitempty" mapstructure:"userInfoUrl"`
+	RedirectURL  string   `json:"redirectUrl,omitempty" yaml:"redirectUrl,omitempty" mapstructure:"redirectUrl"`
+	Scopes       []string `json:"scopes,omitempty" yaml:"scopes,omitempty" mapstructure:"scopes"`
+	DefaultRole  string   `json:"defaultRole,omitempty" yaml:"defaultRole,omitempty" mapstructure:"defaultRole"`
+
+	Delivery            *AuthDelivery `json:"delivery,omitempty" yaml:"delivery,omitempty" mapstructure:"delivery"`
+	RequireVerification bool          `json:"requireVerification,omitempty" yaml:"requireVerification,omitempty" mapstructure:"requireVerification"`
+	MFAIssuer           string        `json:"mfaIssuer,omitempty" yaml:"mfaIssuer,omitempty" mapstructure:"mfaIssuer"`
+}
+
+// AuthDelivery describes how the verification and password reset mails of the email sign in method are sent.
+// The message is either sent to an endpoint of a remote service or queued as an event of the provided type
+type AuthDelivery struct {
+	Service   string `json:"service,omitempty" yaml:"service,omitempty" mapstructure:"service"`
+	Endpoint  string `json:"endpoint,omitempty" yaml:"endpoint,omitempty" mapstructure:"endpoint"`
+	EventType string `json:"eventType,omitempty" yaml:"eventType,omitempty" mapstructure:"eventType"`
+}
+
+// SecurityRoles holds the roles used by the rbac security rule
+type SecurityRoles map[string]*SecurityRole // The key here is resource id --> clusterId--projectId--resourceType--roleId
+
+// SecurityRole holds the permissions granted to a role. A role also gets all the permissions of the roles it inherits
+type SecurityRole struct {
+	ID          string                `json:"id" yaml:"id" mapstructure:"id"`
+	Inherits    []string              `json:"inherits,omitempty" yaml:"inherits,omitempty" mapstructure:"inherits"`
+	Permissions []*SecurityPermission `json:"permissions,omitempty" yaml:"permissions,omitempty" mapstructure:"permissions"`
+}
+
+// SecurityPermission grants access to the operations of a resource. Resources are of the form `type:segment:segment`
+// (e.g. `db:mydb:users`) and any segment or operation can be `*` to match all of them
+type SecurityPermission struct {
+	Resource string   `json:"resource" yaml:"resource" mapstructure:"resource"`
+	Ops      []string `json:"ops" yaml:"ops" mapstructure:"ops"`
+}
+
+// GraphQLQueries holds the queries registered for the graphql endpoint
+type GraphQLQueries map[string]*GraphQLQuery // The key here is resource id --> clusterId--projectId--resourceType--queryId
+
+// GraphQLQuery is a query registered for the graphql endpoint. Registered queries can be executed by their sha256 hash
+// and are the only ones accepted once the graphql allow list of the project is enabled
+type GraphQLQuery struct {
+	ID    string `json:"id" yaml:"id" mapstructure:"id"`
+	Query string `json:"query" yaml:"query" mapstructure:"query"`
 }
 
 // ServicesModule holds the config for the service module
@@ -331,6 +457,9 @@ type Endpoint struct {
 	Headers          Headers  `json:"headers,omitempty" yaml:"headers,omitempty" mapstructure:"headers"`
 	Timeout          int      `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout"` // Timeout is in seconds
 	CacheOptions     []string `json:"cacheOptions" yaml:"cacheOptions" mapstructure:"cacheOptions"`
+
+	// RateLimits are applied on the calls made by clients. Internal calls, like the ones made by eventing, aren't limited
+	RateLimits []*RateLimit `json:"rateLimits,omitempty" yaml:"rateLimits,omitempty" mapstructure:"rateLimits"`
 }
 
 // EndpointKind describes the type of endpoint. Default value - internal
@@ -445,8 +574,142 @@ type EventingTrigger struct {
 	Claims          string            `json:"claims" yaml:"claims" mapstructure:"claims"`
 	Filter          *Rule             `json:"filter" yaml:"filter" mapstructure:"filter"`
 	TriggerType     string            `json:"triggerType" yaml:"triggerType" mapstructure:"triggerType"`
+	Cron            string            `json:"cron,omitempty" yaml:"cron,omitempty" mapstructure:"cron"`             // Schedule of CRON triggers
+	Timezone        string            `json:"timezone,omitempty" yaml:"timezone,omitempty" mapstructure:"timezone"` // Timezone the schedule is evaluated in. Defaults to UTC
+	Backoff         *EventingBackoff  `json:"backoff,omitempty" yaml:"backoff,omitempty" mapstructure:"backoff"`
+	DLQ             *EventingDLQ      `json:"dlq,omitempty" yaml:"dlq,omitempty" mapstructure:"dlq"`
+	PartitionKey    string            `json:"partitionKey,omitempty" yaml:"partitionKey,omitempty" mapstructure:"partitionKey"` // Go template evaluated over the payload. Events with the same key are delivered in order
+	Target          *EventingTarget   `json:"target,omitempty" yaml:"target,omitempty" mapstructure:"target"`                   // Events are published to the target instead of invoking the url if provided
 }
 
+// EventingTarget describes a message broker the events of a trigger get published to
+type EventingTarget struct {
+	Type    EventingTargetType `json:"type" yaml:"type" mapstructure:"type"`
+	Brokers []string           `json:"brokers,omitempty" yaml:"brokers,omitempty" mapstructure:"brokers"` // Addresses of the kafka brokers used to discover the cluster
+	Topic   string             `json:"topic,omitempty" yaml:"topic,omitempty" mapstructure:"topic"`
+	URL     string             `json:"url,omitempty" yaml:"url,omitempty" mapstructure:"url"` // Url of the nats server. Credentials can be provided as user info
+	Subject string             `json:"subject,omitempty" yaml:"subject,omitempty" mapstructure:"subject"`
+	TLS     *EventingTLS       `json:"tls,omitempty" yaml:"tls,omitempty" mapstructure:"tls"`    // Connections to the broker are made over tls if provided
+	Auth    *EventingAuth      `json:"auth,omitempty" yaml:"auth,omitempty" mapstructure:"auth"` // Credentials used to authenticate with the broker
+}
+
+// EventingTLS describes the tls connections made to a message broker
+type EventingTLS struct {
+	CACert             string `json:"caCert,omitempty" yaml:"caCert,omitempty" mapstructure:"caCert"` // PEM encoded certificates used to verify the broker instead of the ones of the system
+	Cert               string `json:"cert,omitempty" yaml:"cert,omitempty" mapstructure:"cert"`       // PEM encoded client certificate. Required by brokers which use mutual tls
+	Key                string `json:"key,omitempty" yaml:"key,omitempty" mapstructure:"key"`          // PEM encoded private key of the client certificate
+	ServerName         string `json:"serverName,omitempty" yaml:"serverName,omitempty" mapstructure:"serverName"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty" yaml:"insecureSkipVerify,omitempty" mapstructure:"insecureSkipVerify"`
+}
+
+// EventingAuth describes the credentials used to authenticate with a message broker
+type EventingAuth struct {
+	Mechanism EventingAuthMechanism `json:"mechanism,omitempty" yaml:"mechanism,omitempty" mapstructure:"mechanism"` // SASL mechanism used by kafka. Defaults to plain
+	Username  string                `json:"username,omitempty" yaml:"username,omitempty" mapstructure:"username"`
+	Password  string                `json:"password,omitempty" yaml:"password,omitempty" mapstructure:"password"`
+	Token     string                `json:"token,omitempty" yaml:"token,omitempty" mapstructure:"token"` // Token used by nats instead of the username and password
+}
+
+// EventingAuthMechanism describes the SASL mechanism used to authenticate with kafka
+type EventingAuthMechanism string
+
+const (
+	// EventingAuthPlain sends the username and password as is. It must only be used over tls
+	EventingAuthPlain EventingAuthMechanism = "plain"
+
+	// EventingAuthScramSHA256 authenticates with SCRAM-SHA-256
+	EventingAuthScramSHA256 EventingAuthMechanism = "scram-sha-256"
+
+	// EventingAuthScramSHA512 authenticates with SCRAM-SHA-512
+	EventingAuthScramSHA512 EventingAuthMechanism = "scram-sha-512"
+)
+
+// EventingTargetType describes the kind of message broker
+type EventingTargetType string
+
+const (
+	// EventingTargetKafka publishes events to a kafka topic
+	EventingTargetKafka EventingTargetType = "kafka"
+
+	// EventingTargetNATS publishes events to a nats subject
+	EventingTargetNATS EventingTargetType = "nats"
+)
+
+// EventingSource describes a message broker whose messages are queued as events of a custom event type. The messages
+// are validated against the schema of the event type and go through its security rules just like queued events
+type EventingSource struct {
+	ID        string             `json:"id" yaml:"id" mapstructure:"id"`
+	Type      EventingSourceType `json:"type" yaml:"type" mapstructure:"type"`
+	EventType string             `json:"eventType" yaml:"eventType" mapstructure:"eventType"`
+	Token     string             `json:"token,omitempty" yaml:"token,omitempty" mapstructure:"token"` // Token the security rules of the event type are evaluated with
+	URL       string             `json:"url,omitempty" yaml:"url,omitempty" mapstructure:"url"`       // Url of the redis or nats server
+	Stream    string             `json:"stream,omitempty" yaml:"stream,omitempty" mapstructure:"stream"`
+	Subject   string             `json:"subject,omitempty" yaml:"subject,omitempty" mapstructure:"subject"`
+	Brokers   []string           `json:"brokers,omitempty" yaml:"brokers,omitempty" mapstructure:"brokers"`
+	Topic     string             `json:"topic,omitempty" yaml:"topic,omitempty" mapstructure:"topic"`
+	Group     string             `json:"group,omitempty" yaml:"group,omitempty" mapstructure:"group"` // Consumer group of the source. Defaults to the project and id of the source
+}
+
+// EventingSourceType describes the kind of message broker
+type EventingSourceType string
+
+const (
+	// EventingSourceRedis consumes the entries of a redis stream
+	EventingSourceRedis EventingSourceType = "redis"
+
+	// EventingSourceNATS consumes the messages of a nats subject
+	EventingSourceNATS EventingSourceType = "nats"
+
+	// EventingSourceKafka consumes the messages of a kafka topic
+	EventingSourceKafka EventingSourceType = "kafka"
+)
+
+// EventingBackoff describes the interval between the retries of a trigger
+type EventingBackoff struct {
+	Strategy    BackoffStrategy `json:"strategy" yaml:"strategy" mapstructure:"strategy"`
+	Interval    int             `json:"interval" yaml:"interval" mapstructure:"interval"`          // Interval before the first retry in milliseconds
+	MaxInterval int             `json:"maxInterval" yaml:"maxInterval" mapstructure:"maxInterval"` // Upper limit of the interval in milliseconds
+	Multiplier  float64         `json:"multiplier" yaml:"multiplier" mapstructure:"multiplier"`    // Growth of the interval after every retry. Defaults to 2
+}
+
+// BackoffStrategy describes how the interval between retries grows
+type BackoffStrategy string
+
+const (
+	// BackoffStrategyFixed retries after the same interval every time
+	BackoffStrategyFixed BackoffStrategy = "fixed"
+
+	// BackoffStrategyExponential multiplies the interval after every retry
+	BackoffStrategyExponential BackoffStrategy = "exponential"
+
+	// BackoffStrategyJitter picks a random interval between zero and the exponential interval
+	BackoffStrategyJitter BackoffStrategy = "jitter"
+)
+
+// EventingDLQ describes where the events of a trigger end up once all the retries have failed
+type EventingDLQ struct {
+	Type     DLQType `json:"type" yaml:"type" mapstructure:"type"`
+	Trigger  string  `json:"trigger,omitempty" yaml:"trigger,omitempty" mapstructure:"trigger"`
+	DBAlias  string  `json:"db,omitempty" yaml:"db,omitempty" mapstructure:"db"`
+	Table    string  `json:"table,omitempty" yaml:"table,omitempty" mapstructure:"table"`
+	Service  string  `json:"service,omitempty" yaml:"service,omitempty" mapstructure:"service"`
+	Endpoint string  `json:"endpoint,omitempty" yaml:"endpoint,omitempty" mapstructure:"endpoint"`
+}
+
+// DLQType describes the kind of destination of failed events
+type DLQType string
+
+const (
+	// DLQTypeTrigger queues the failed event for another trigger
+	DLQTypeTrigger DLQType = "trigger"
+
+	// DLQTypeDatabase inserts the failed event in a table
+	DLQTypeDatabase DLQType = "database"
+
+	// DLQTypeService sends the failed event to an endpoint of a remote service
+	DLQTypeService DLQType = "service"
+)
+
 // SchemaObject is the body of the request for adding schema
 type SchemaObject struct {
 	ID     string `json:"id,omitempty" yaml:"id,omitempty" mapstructure:"id"`
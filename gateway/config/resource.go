@@ -18,10 +18,13 @@ var ResourceFetchingOrder = []Resource{
 	ResourceEventingTrigger,
 	ResourceEventingRule,
 	ResourceEventingSchema,
+	ResourceEventingSource,
 	ResourceRemoteService,
 	ResourceIngressGlobal,
 	ResourceIngressRoute,
 	ResourceAuthProvider,
+	ResourceSecurityRole,
+	ResourceGraphQLQuery,
 	ResourceProjectLetsEncrypt,
 	ResourceCluster,
 	ResourceIntegration,
@@ -35,6 +38,10 @@ type Resource string
 const (
 	// ResourceAuthProvider is a resource
 	ResourceAuthProvider Resource = "auth-provider"
+	// ResourceSecurityRole is a resource
+	ResourceSecurityRole Resource = "security-role"
+	// ResourceGraphQLQuery is a resource
+	ResourceGraphQLQuery Resource = "graphql-query"
 
 	// ResourceProject is a resource
 	ResourceProject Resource = "project"
@@ -56,6 +63,8 @@ const (
 	ResourceEventingTrigger Resource = "eventing-trigger"
 	// ResourceEventingRule is a resource
 	ResourceEventingRule Resource = "eventing-rule"
+	// ResourceEventingSource is a resource
+	ResourceEventingSource Resource = "eventing-source"
 
 	// ResourceFileStoreConfig is a resource
 	ResourceFileStoreConfig Resource = "filestore-config"
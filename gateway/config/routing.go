@@ -24,7 +24,8 @@ func (a Routes) Len() int { return len(a) }
 // Swap swaps two element of the array
 func (a Routes) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 
-// Less compares two elements of the array
+// Less compares two elements of the array. Routes with deeper urls come first, followed by the ones restricted by
+// more headers, cookies, query params and claims. The id of the routes breaks the remaining ties
 func (a Routes) Less(i, j int) bool {
 	arrayI := strings.Split(a[i].Source.URL, "/")
 	arrayJ := strings.Split(a[j].Source.URL, "/")
@@ -38,8 +39,18 @@ func (a Routes) Less(i, j int) bool {
 	if arrayJ[lenJ-1] == "" {
 		lenJ--
 	}
+	if lenI != lenJ {
+		return lenI > lenJ
+	}
+
+	if matchersI, matchersJ := a[i].Source.matchers(), a[j].Source.matchers(); matchersI != matchersJ {
+		return matchersI > matchersJ
+	}
 
-	return lenI > lenJ
+	if a[i].ID != a[j].ID {
+		return a[i].ID < a[j].ID
+	}
+	return a[i].Project < a[j].Project
 }
 
 // Route describes the parameters of a single route
@@ -51,7 +62,21 @@ type Route struct {
 	Rule             *Rule         `json:"rule" yaml:"rule" mapstructure:"rule"`
 	IsRouteCacheable bool          `json:"isRouteCacheable" yaml:"isRouteCacheable" mapstructure:"isRouteCacheable"`
 	CacheOptions     []string      `json:"cacheOptions" yaml:"cacheOptions" mapstructure:"cacheOptions"`
-	Modify           struct {
+
+	// HealthCheck and OutlierDetection are optional. Targets which fail them are skipped while selecting a target
+	HealthCheck      *RouteHealthCheck      `json:"healthCheck,omitempty" yaml:"healthCheck,omitempty" mapstructure:"healthCheck"`
+	OutlierDetection *RouteOutlierDetection `json:"outlierDetection,omitempty" yaml:"outlierDetection,omitempty" mapstructure:"outlierDetection"`
+
+	// Timeout is the number of milliseconds within which the response headers must be received, including the retries.
+	// Reading the body isn't limited so that streamed responses aren't cut. There is no timeout by default
+	Timeout int           `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout"`
+	Retries *RouteRetries `json:"retries,omitempty" yaml:"retries,omitempty" mapstructure:"retries"`
+	Hedging *RouteHedging `json:"hedging,omitempty" yaml:"hedging,omitempty" mapstructure:"hedging"`
+
+	// RateLimits are applied before the request is authorised, so that clients can't overwhelm the rules either
+	RateLimits []*RateLimit `json:"rateLimits,omitempty" yaml:"rateLimits,omitempty" mapstructure:"rateLimits"`
+
+	Modify struct {
 		Tmpl            TemplatingEngine `json:"template,omitempty" yaml:"template,omitempty" mapstructure:"template"`
 		ReqTmpl         string           `json:"requestTemplate" yaml:"requestTemplate" mapstructure:"requestTemplate"`
 		ResTmpl         string           `json:"responseTemplate" yaml:"responseTemplate" mapstructure:"responseTemplate"`
@@ -83,6 +108,95 @@ func (r *Route) SelectTarget(ctx context.Context, weight int32) (RouteTarget, er
 	return RouteTarget{}, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("No target found for route (%s) - make sure you have defined atleast one target with proper weights", r.Source.URL), nil, nil)
 }
 
+// SelectAvailableTarget returns a target based on the weights assigned to the targets which are available. The
+// weights of the available targets are scaled up so that they add up to 100
+func (r *Route) SelectAvailableTarget(ctx context.Context, isAvailable func(target RouteTarget) bool) (RouteTarget, error) {
+	targets := make([]RouteTarget, 0, len(r.Targets))
+	var totalWeight int32
+	for _, target := range r.Targets {
+		if target.Weight > 0 && isAvailable(target) {
+			targets = append(targets, target)
+			totalWeight += target.Weight
+		}
+	}
+
+	if totalWeight == 0 {
+		return RouteTarget{}, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("No healthy target available for route (%s)", r.Source.URL), nil, nil)
+	}
+
+	weight := rand.Int31n(totalWeight)
+
+	var cumulativeWeight int32
+	for _, target := range targets {
+		cumulativeWeight += target.Weight
+		if weight < cumulativeWeight {
+			return target, nil
+		}
+	}
+	return targets[len(targets)-1], nil
+}
+
+// RouteHealthCheck describes the active health check of the targets of a route
+type RouteHealthCheck struct {
+	// Path is requested with a GET request. Targets are healthy if they respond with a 2xx or 3xx status code
+	Path string `json:"path" yaml:"path" mapstructure:"path"`
+
+	// Interval and Timeout are in seconds. They default to 10 and 2 seconds respectively
+	Interval int `json:"interval,omitempty" yaml:"interval,omitempty" mapstructure:"interval"`
+	Timeout  int `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout"`
+
+	// HealthyThreshold and UnhealthyThreshold are the number of consecutive checks after which a target is marked
+	// healthy or unhealthy. They default to 2 and 3 respectively
+	HealthyThreshold   int `json:"healthyThreshold,omitempty" yaml:"healthyThreshold,omitempty" mapstructure:"healthyThreshold"`
+	UnhealthyThreshold int `json:"unhealthyThreshold,omitempty" yaml:"unhealthyThreshold,omitempty" mapstructure:"unhealthyThreshold"`
+}
+
+// RouteOutlierDetection describes when a target is ejected based on the responses of the requests routed to it.
+// Ejected targets get a single probe request once the ejection time elapses. The target is restored if the probe
+// succeeds and ejected again for a longer duration otherwise
+type RouteOutlierDetection struct {
+	// ConsecutiveErrors is the number of consecutive 5xx responses or connection errors after which a target is
+	// ejected. It defaults to 5
+	ConsecutiveErrors int `json:"consecutiveErrors,omitempty" yaml:"consecutiveErrors,omitempty" mapstructure:"consecutiveErrors"`
+
+	// EjectionTime is the number of seconds a target is ejected for the first time. It defaults to 30 seconds
+	EjectionTime int `json:"ejectionTime,omitempty" yaml:"ejectionTime,omitempty" mapstructure:"ejectionTime"`
+}
+
+// RouteRetries describes when a failed request is sent again. Only requests with idempotent methods are retried
+// and a different target is preferred for every retry
+type RouteRetries struct {
+	// Attempts is the number of times a request is retried
+	Attempts int `json:"attempts" yaml:"attempts" mapstructure:"attempts"`
+
+	// RetryOn are the failures which are retried. All of them are retried if none are provided
+	RetryOn []RouteRetryOn `json:"retryOn,omitempty" yaml:"retryOn,omitempty" mapstructure:"retryOn"`
+}
+
+// RouteRetryOn describes a failure on which a request is retried
+type RouteRetryOn string
+
+const (
+	// RouteRetryOnConnectFailure retries requests if the connection to the target couldn't be established
+	RouteRetryOnConnectFailure RouteRetryOn = "connect-failure"
+
+	// RouteRetryOnBadGateway retries requests if the target responds with a 502 status code
+	RouteRetryOnBadGateway RouteRetryOn = "502"
+
+	// RouteRetryOnServiceUnavailable retries requests if the target responds with a 503 status code
+	RouteRetryOnServiceUnavailable RouteRetryOn = "503"
+
+	// RouteRetryOnGatewayTimeout retries requests if the target responds with a 504 status code
+	RouteRetryOnGatewayTimeout RouteRetryOn = "504"
+)
+
+// RouteHedging describes how GET requests are hedged. A hedged request is sent to another target if the first one
+// doesn't respond in time. The response received first is used and the other request is cancelled
+type RouteHedging struct {
+	// Delay is the number of milliseconds after which the hedged request is sent. It defaults to 100 milliseconds
+	Delay int `json:"delay,omitempty" yaml:"delay,omitempty" mapstructure:"delay"`
+}
+
 // RouteSource is the source of routing
 type RouteSource struct {
 	Hosts      []string     `json:"hosts" yaml:"hosts" mapstructure:"hosts"`
@@ -91,8 +205,45 @@ type RouteSource struct {
 	RewriteURL string       `json:"rewrite" yaml:"rewrite" mapstructure:"rewrite"`
 	Type       RouteURLType `json:"type" yaml:"type" mapstructure:"type"`
 	Port       int32        `json:"port" yaml:"port" mapstructure:"port"`
+
+	// Headers, Cookies, Query and Claims restrict the requests matched by the route. All of them need to match.
+	// The keys of claims are paths in the claims of the JWT token of the request, like `role` or `meta.cohort`
+	Headers []*RouteMatcher `json:"headers,omitempty" yaml:"headers,omitempty" mapstructure:"headers"`
+	Cookies []*RouteMatcher `json:"cookies,omitempty" yaml:"cookies,omitempty" mapstructure:"cookies"`
+	Query   []*RouteMatcher `json:"query,omitempty" yaml:"query,omitempty" mapstructure:"query"`
+	Claims  []*RouteMatcher `json:"claims,omitempty" yaml:"claims,omitempty" mapstructure:"claims"`
 }
 
+// matchers returns the number of attributes of a request the source is restricted by
+func (s RouteSource) matchers() int {
+	return len(s.Headers) + len(s.Cookies) + len(s.Query) + len(s.Claims)
+}
+
+// RouteMatcher matches an attribute of a request, like a header
+type RouteMatcher struct {
+	Key        string           `json:"key" yaml:"key" mapstructure:"key"`
+	Value      string           `json:"value,omitempty" yaml:"value,omitempty" mapstructure:"value"`
+	Type       RouteMatcherType `json:"type,omitempty" yaml:"type,omitempty" mapstructure:"type"`
+	IgnoreCase bool             `json:"ignoreCase,omitempty" yaml:"ignoreCase,omitempty" mapstructure:"ignoreCase"`
+}
+
+// RouteMatcherType describes how the value of an attribute of a request is matched
+type RouteMatcherType string
+
+const (
+	// RouteMatcherExact is used for matching the value exactly as it is. Matchers are exact by default
+	RouteMatcherExact RouteMatcherType = "exact"
+
+	// RouteMatcherPrefix is used for prefix matching
+	RouteMatcherPrefix RouteMatcherType = "prefix"
+
+	// RouteMatcherRegex is used for matching the value with a regular expression
+	RouteMatcherRegex RouteMatcherType = "regex"
+
+	// RouteMatcherPresent is used for only checking if the attribute is present
+	RouteMatcherPresent RouteMatcherType = "present"
+)
+
 // RouteTarget is the destination of routing
 type RouteTarget struct {
 	Host    string          `json:"host" yaml:"host" mapstructure:"host"`
@@ -112,6 +263,10 @@ const (
 
 	// RouteExact is used for matching the url exactly as it is
 	RouteExact RouteURLType = "exact"
+
+	// RouteRegex is used for matching the url with a regular expression. The groups captured can be used in the
+	// rewrite url as `$1` or `${name}`
+	RouteRegex RouteURLType = "regex"
 )
 
 // RouteTargetType describes how the target should be selected
@@ -124,3 +279,7 @@ const (
 	// RouteTargetExternal is used to route to external services
 	RouteTargetExternal RouteTargetType = "external"
 )
+
+// RouteTargetSchemeH2C is the scheme of targets which accept HTTP/2 requests without TLS, like gRPC services. gRPC
+// requests are sent to targets with the http scheme over HTTP/2 as well
+const RouteTargetSchemeH2C = "h2c"
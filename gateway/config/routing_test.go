@@ -75,3 +75,61 @@ func TestRoute_SelectTarget(t *testing.T) {
 		})
 	}
 }
+
+func TestRoute_SelectAvailableTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		targets     []RouteTarget
+		unavailable []string
+		want        RouteTarget
+		wantErr     bool
+	}{
+		{
+			name:    "only target available",
+			targets: []RouteTarget{{Host: "1", Weight: 100}},
+			want:    RouteTarget{Host: "1", Weight: 100},
+		},
+		{
+			name:        "skip unavailable targets",
+			targets:     []RouteTarget{{Host: "1", Weight: 40}, {Host: "2", Weight: 30}, {Host: "3", Weight: 30}},
+			unavailable: []string{"1", "3"},
+			want:        RouteTarget{Host: "2", Weight: 30},
+		},
+		{
+			name:    "skip targets without weight",
+			targets: []RouteTarget{{Host: "1", Weight: 0}, {Host: "2", Weight: 100}},
+			want:    RouteTarget{Host: "2", Weight: 100},
+		},
+		{
+			name:        "no target available",
+			targets:     []RouteTarget{{Host: "1", Weight: 50}, {Host: "2", Weight: 50}},
+			unavailable: []string{"1", "2"},
+			wantErr:     true,
+		},
+		{
+			name:    "no targets provided",
+			targets: []RouteTarget{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Route{Targets: tt.targets}
+			got, err := r.SelectAvailableTarget(context.Background(), func(target RouteTarget) bool {
+				for _, host := range tt.unavailable {
+					if target.Host == host {
+						return false
+					}
+				}
+				return true
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SelectAvailableTarget() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectAvailableTarget() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -27,13 +27,18 @@ require (
 	github.com/huandu/xstrings v1.3.2 // indirect
 	github.com/imdario/mergo v0.3.11 // indirect
 	github.com/jmoiron/sqlx v1.3.1
+	github.com/klauspost/compress v1.15.1 // indirect
 	github.com/lestrrat-go/jwx v1.0.4
 	github.com/lib/pq v1.10.0
+	github.com/mattn/go-sqlite3 v1.14.16
 	github.com/mitchellh/copystructure v1.1.1 // indirect
 	github.com/mitchellh/mapstructure v1.3.3
+	github.com/nats-io/nats-server/v2 v2.2.6
+	github.com/nats-io/nats.go v1.11.0
 	github.com/opentracing/opentracing-go v1.1.0 // indirect
 	github.com/rs/cors v1.7.0
 	github.com/satori/go.uuid v1.2.0
+	github.com/segmentio/kafka-go v0.4.30
 	github.com/segmentio/ksuid v1.0.3
 	github.com/spaceuptech/helpers v0.2.1
 	github.com/spaceuptech/space-api-go v0.18.1
@@ -41,9 +46,9 @@ require (
 	github.com/urfave/cli v1.22.2
 	go.etcd.io/bbolt v1.3.5
 	go.mongodb.org/mongo-driver v1.7.1
-	golang.org/x/crypto v0.0.0-20210220033148-5ea612d1eb83
+	golang.org/x/crypto v0.0.0-20210314154223-e6e6c4f2bb5b
 	golang.org/x/mod v0.3.1-0.20200828183125-ce943fd02449 // indirect
-	golang.org/x/net v0.0.0-20210224082022-3d97a244fca7
+	golang.org/x/net v0.0.0-20210226172049-e18ecbb05110
 	golang.org/x/sync v0.0.0-20201207232520-09787c993a3a // indirect
 	golang.org/x/tools v0.1.0 // indirect
 	google.golang.org/api v0.20.0
@@ -51,7 +56,6 @@ require (
 	k8s.io/api v0.21.0
 	k8s.io/apimachinery v0.21.0
 	k8s.io/client-go v0.21.0
-
 )
 
 go 1.15
@@ -69,6 +69,11 @@ var essentialFlags = []cli.Flag{
 		Usage:  "Comma separated values of the hosts to restrict mission-control to",
 		Value:  "*",
 	},
+	cli.StringFlag{
+		Name:   "trusted-proxies",
+		EnvVar: "TRUSTED_PROXIES",
+		Usage:  "Comma separated ip addresses or cidr ranges of the proxies whose forwarded headers can be trusted",
+	},
 	cli.StringFlag{
 		Name:   "runner-addr",
 		Usage:  "The address used to reach the runner",
@@ -177,6 +182,11 @@ func actionRun(c *cli.Context) error {
 
 	runnerAddr := c.String("runner-addr")
 
+	// Load the proxies whose forwarded headers can be trusted
+	if err := utils.SetTrustedProxies(strings.Split(c.String("trusted-proxies"), ",")); err != nil {
+		return err
+	}
+
 	// Load flags related to ssl
 	sslEnable := c.Bool("ssl-enable")
 	sslKey := c.String("ssl-key")
@@ -173,6 +173,32 @@ func (s *Manager) validateResource(ctx context.Context, eventType string, resour
 			}
 		}
 		return false, nil
+	case config.ResourceSecurityRole:
+		switch eventType {
+		case config.ResourceAddEvent, config.ResourceUpdateEvent:
+			value := new(config.SecurityRole)
+			if err := mapstructure.Decode(resource, value); err != nil {
+				return false, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid type provided for resource (%s) expecting (%v) got (%v)", resourceType, "config.SecurityRole{}", reflect.TypeOf(resource)), nil, nil)
+			}
+
+			if reflect.DeepEqual(project.SecurityRoles[resourceID], value) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case config.ResourceGraphQLQuery:
+		switch eventType {
+		case config.ResourceAddEvent, config.ResourceUpdateEvent:
+			value := new(config.GraphQLQuery)
+			if err := mapstructure.Decode(resource, value); err != nil {
+				return false, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid type provided for resource (%s) expecting (%v) got (%v)", resourceType, "config.GraphQLQuery{}", reflect.TypeOf(resource)), nil, nil)
+			}
+
+			if reflect.DeepEqual(project.GraphQLQueries[resourceID], value) {
+				return true, nil
+			}
+		}
+		return false, nil
 	case config.ResourceDatabaseConfig:
 		switch eventType {
 		case config.ResourceAddEvent, config.ResourceUpdateEvent:
@@ -278,6 +304,19 @@ func (s *Manager) validateResource(ctx context.Context, eventType string, resour
 			}
 		}
 		return false, nil
+	case config.ResourceEventingSource:
+		switch eventType {
+		case config.ResourceAddEvent, config.ResourceUpdateEvent:
+			value := new(config.EventingSource)
+			if err := mapstructure.Decode(resource, value); err != nil {
+				return false, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid type provided for resource (%s) expecting (%v) got (%v)", resourceType, "config.EventingSource{}", reflect.TypeOf(resource)), nil, nil)
+			}
+
+			if reflect.DeepEqual(project.EventingSources[resourceID], value) {
+				return true, nil
+			}
+		}
+		return false, nil
 	case config.ResourceFileStoreConfig:
 		switch eventType {
 		case config.ResourceAddEvent, config.ResourceUpdateEvent:
@@ -492,6 +531,44 @@ func updateResource(ctx context.Context, eventType string, globalConfig *config.
 
 		return nil
 
+	case config.ResourceSecurityRole:
+		switch eventType {
+		case config.ResourceAddEvent, config.ResourceUpdateEvent:
+			value := new(config.SecurityRole)
+			if err := mapstructure.Decode(resource, value); err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid type provided for resource (%s) expecting (%v) got (%v)", resourceType, "config.SecurityRole{}", reflect.TypeOf(resource)), nil, nil)
+			}
+
+			if project.SecurityRoles == nil {
+				project.SecurityRoles = config.SecurityRoles{resourceID: value}
+			} else {
+				project.SecurityRoles[resourceID] = value
+			}
+		case config.ResourceDeleteEvent:
+			delete(project.SecurityRoles, resourceID)
+		}
+
+		return nil
+
+	case config.ResourceGraphQLQuery:
+		switch eventType {
+		case config.ResourceAddEvent, config.ResourceUpdateEvent:
+			value := new(config.GraphQLQuery)
+			if err := mapstructure.Decode(resource, value); err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid type provided for resource (%s) expecting (%v) got (%v)", resourceType, "config.GraphQLQuery{}", reflect.TypeOf(resource)), nil, nil)
+			}
+
+			if project.GraphQLQueries == nil {
+				project.GraphQLQueries = config.GraphQLQueries{resourceID: value}
+			} else {
+				project.GraphQLQueries[resourceID] = value
+			}
+		case config.ResourceDeleteEvent:
+			delete(project.GraphQLQueries, resourceID)
+		}
+
+		return nil
+
 	case config.ResourceDatabaseConfig:
 		switch eventType {
 		case config.ResourceAddEvent, config.ResourceUpdateEvent:
@@ -640,6 +717,25 @@ func updateResource(ctx context.Context, eventType string, globalConfig *config.
 
 		return nil
 
+	case config.ResourceEventingSource:
+		switch eventType {
+		case config.ResourceAddEvent, config.ResourceUpdateEvent:
+			value := new(config.EventingSource)
+			if err := mapstructure.Decode(resource, value); err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid type provided for resource (%s) expecting (%v) got (%v)", resourceType, "config.EventingSource{}", reflect.TypeOf(resource)), nil, nil)
+			}
+
+			if project.EventingSources == nil {
+				project.EventingSources = config.EventingSources{resourceID: value}
+			} else {
+				project.EventingSources[resourceID] = value
+			}
+		case config.ResourceDeleteEvent:
+			delete(project.EventingSources, resourceID)
+		}
+
+		return nil
+
 	case config.ResourceFileStoreConfig:
 		switch eventType {
 		case config.ResourceAddEvent, config.ResourceUpdateEvent:
@@ -188,6 +188,12 @@ func (s *Manager) Start(port int) error {
 		case config.ResourceAuthProvider:
 			_ = s.modules.SetUsermanConfig(ctx, projectID, s.projectConfig.Projects[projectID].Auths)
 
+		case config.ResourceSecurityRole:
+			_ = s.modules.SetSecurityRolesConfig(ctx, projectID, s.projectConfig.Projects[projectID].SecurityRoles)
+
+		case config.ResourceGraphQLQuery:
+			_ = s.modules.SetGraphQLQueriesConfig(ctx, projectID, s.projectConfig.Projects[projectID].GraphQLQueries)
+
 		case config.ResourceDatabaseConfig:
 			p := s.projectConfig.Projects[projectID]
 			_ = s.modules.SetDatabaseConfig(ctx, projectID, p.DatabaseConfigs, p.DatabaseSchemas, p.DatabaseRules, p.DatabasePreparedQueries)
@@ -214,6 +220,9 @@ func (s *Manager) Start(port int) error {
 		case config.ResourceEventingTrigger:
 			_ = s.modules.SetEventingTriggerConfig(ctx, projectID, s.projectConfig.Projects[projectID].EventingTriggers)
 
+		case config.ResourceEventingSource:
+			_ = s.modules.SetEventingSourceConfig(ctx, projectID, s.projectConfig.Projects[projectID].EventingSources)
+
 		case config.ResourceFileStoreConfig:
 			_ = s.modules.SetFileStoreConfig(ctx, projectID, s.projectConfig.Projects[projectID].FileStoreConfig)
 
@@ -0,0 +1,134 @@
+package syncman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// SetEventingSource adds a message broker events are consumed from
+func (s *Manager) SetEventingSource(ctx context.Context, project, sourceID string, value *config.EventingSource, reqParams model.RequestParams) (int, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, reqParams)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), nil
+	}
+
+	// Acquire a lock
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	value.ID = sourceID
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceEventingSource, sourceID)
+	sources := make(config.EventingSources, len(projectConfig.EventingSources)+1)
+	for id, v := range projectConfig.EventingSources {
+		sources[id] = v
+	}
+	sources[resourceID] = value
+
+	// The eventing module rejects invalid sources, in which case the existing sources are kept
+	if err := s.modules.SetEventingSourceConfig(ctx, project, sources); err != nil {
+		return http.StatusBadRequest, err
+	}
+	projectConfig.EventingSources = sources
+
+	if err := s.store.SetResource(ctx, resourceID, value); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// GetEventingSources gets the message brokers events are consumed from
+func (s *Manager) GetEventingSources(ctx context.Context, project, sourceID string, params model.RequestParams) (int, []interface{}, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), nil, err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), hookResponse.Result().([]interface{}), nil
+	}
+
+	// Acquire a lock
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	if sourceID != "*" {
+		source, ok := projectConfig.EventingSources[config.GenerateResourceID(s.clusterID, project, config.ResourceEventingSource, sourceID)]
+		if !ok {
+			return http.StatusBadRequest, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("eventing source with id (%s) does not exist", sourceID), nil, nil)
+		}
+
+		return http.StatusOK, []interface{}{source}, nil
+	}
+
+	sources := []interface{}{}
+	for _, value := range projectConfig.EventingSources {
+		sources = append(sources, value)
+	}
+
+	return http.StatusOK, sources, nil
+}
+
+// DeleteEventingSource deletes a message broker events are consumed from
+func (s *Manager) DeleteEventingSource(ctx context.Context, project, sourceID string, reqParams model.RequestParams) (int, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, reqParams)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), nil
+	}
+
+	// Acquire a lock
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceEventingSource, sourceID)
+
+	delete(projectConfig.EventingSources, resourceID)
+
+	// Stop consuming from the source right away
+	if err := s.modules.SetEventingSourceConfig(ctx, project, projectConfig.EventingSources); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
@@ -0,0 +1,108 @@
+package syncman
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestManager_SetEventingSource(t *testing.T) {
+
+	type mockArgs struct {
+		method         string
+		args           []interface{}
+		paramsReturned []interface{}
+	}
+	type args struct {
+		project  string
+		sourceID string
+		value    *config.EventingSource
+	}
+	ordersResourceID := config.GenerateResourceID("chicago", "1", config.ResourceEventingSource, "orders")
+	paymentsResourceID := config.GenerateResourceID("chicago", "1", config.ResourceEventingSource, "payments")
+	ordersSource := &config.EventingSource{ID: "orders", Type: config.EventingSourceRedis, EventType: "order-created", URL: "redis://localhost:6379", Stream: "orders"}
+	tests := []struct {
+		name            string
+		s               *Manager
+		args            args
+		modulesMockArgs []mockArgs
+		storeMockArgs   []mockArgs
+		wantSources     config.EventingSources
+		wantErr         bool
+	}{
+		{
+			name:    "unable to get project config",
+			s:       &Manager{clusterID: "chicago", projectConfig: &config.Config{Projects: config.Projects{"1": &config.Project{ProjectConfig: &config.ProjectConfig{ID: "1"}}}}},
+			args:    args{project: "2", sourceID: "payments", value: &config.EventingSource{}},
+			wantErr: true,
+		},
+		{
+			name: "invalid sources are not saved",
+			s:    &Manager{clusterID: "chicago", projectConfig: &config.Config{Projects: config.Projects{"1": &config.Project{ProjectConfig: &config.ProjectConfig{ID: "1"}, EventingSources: config.EventingSources{ordersResourceID: ordersSource}}}}},
+			args: args{project: "1", sourceID: "payments", value: &config.EventingSource{Type: config.EventingSourceKafka, EventType: "payment-received"}},
+			modulesMockArgs: []mockArgs{
+				{
+					method:         "SetEventingSourceConfig",
+					args:           []interface{}{mock.Anything, "1", config.EventingSources{ordersResourceID: ordersSource, paymentsResourceID: &config.EventingSource{ID: "payments", Type: config.EventingSourceKafka, EventType: "payment-received"}}},
+					paramsReturned: []interface{}{errors.New("brokers and topic of the kafka source have not been provided")},
+				},
+			},
+			wantSources: config.EventingSources{ordersResourceID: ordersSource},
+			wantErr:     true,
+		},
+		{
+			name: "source is registered",
+			s:    &Manager{clusterID: "chicago", projectConfig: &config.Config{Projects: config.Projects{"1": &config.Project{ProjectConfig: &config.ProjectConfig{ID: "1"}, EventingSources: config.EventingSources{ordersResourceID: ordersSource}}}}},
+			args: args{project: "1", sourceID: "payments", value: &config.EventingSource{Type: config.EventingSourceKafka, EventType: "payment-received", Brokers: []string{"localhost:9092"}, Topic: "payments"}},
+			modulesMockArgs: []mockArgs{
+				{
+					method:         "SetEventingSourceConfig",
+					args:           []interface{}{mock.Anything, "1", config.EventingSources{ordersResourceID: ordersSource, paymentsResourceID: &config.EventingSource{ID: "payments", Type: config.EventingSourceKafka, EventType: "payment-received", Brokers: []string{"localhost:9092"}, Topic: "payments"}}},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+			storeMockArgs: []mockArgs{
+				{
+					method:         "SetResource",
+					args:           []interface{}{mock.Anything, paymentsResourceID, &config.EventingSource{ID: "payments", Type: config.EventingSourceKafka, EventType: "payment-received", Brokers: []string{"localhost:9092"}, Topic: "payments"}},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			mockModules := mockModulesInterface{}
+			mockStore := mockStoreInterface{}
+
+			for _, m := range tt.modulesMockArgs {
+				mockModules.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+			for _, m := range tt.storeMockArgs {
+				mockStore.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+
+			tt.s.modules = &mockModules
+			tt.s.store = &mockStore
+			tt.s.integrationMan = &mockIntegrationManager{skip: true}
+
+			if _, err := tt.s.SetEventingSource(context.Background(), tt.args.project, tt.args.sourceID, tt.args.value, model.RequestParams{}); (err != nil) != tt.wantErr {
+				t.Errorf("Manager.SetEventingSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantSources != nil && !reflect.DeepEqual(tt.s.projectConfig.Projects[tt.args.project].EventingSources, tt.wantSources) {
+				t.Errorf("Manager.SetEventingSource() EventingSources = %v, want %v", tt.s.projectConfig.Projects[tt.args.project].EventingSources, tt.wantSources)
+			}
+
+			mockModules.AssertExpectations(t)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package syncman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// SetGraphQLQuery registers a query for the graphql endpoint
+func (s *Manager) SetGraphQLQuery(ctx context.Context, project, queryID string, value *config.GraphQLQuery, reqParams model.RequestParams) (int, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, reqParams)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), nil
+	}
+
+	// Acquire a lock
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	value.ID = queryID
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceGraphQLQuery, queryID)
+	queries := make(config.GraphQLQueries, len(projectConfig.GraphQLQueries)+1)
+	for id, v := range projectConfig.GraphQLQueries {
+		queries[id] = v
+	}
+	queries[resourceID] = value
+
+	// The graphql module rejects invalid queries, in which case the existing queries are kept
+	if err := s.modules.SetGraphQLQueriesConfig(ctx, project, queries); err != nil {
+		return http.StatusBadRequest, err
+	}
+	projectConfig.GraphQLQueries = queries
+
+	if err := s.store.SetResource(ctx, resourceID, value); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// GetGraphQLQueries gets the queries registered for the graphql endpoint
+func (s *Manager) GetGraphQLQueries(ctx context.Context, project, queryID string, params model.RequestParams) (int, []interface{}, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), nil, err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), hookResponse.Result().([]interface{}), nil
+	}
+
+	// Acquire a lock
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	if queryID != "*" {
+		query, ok := projectConfig.GraphQLQueries[config.GenerateResourceID(s.clusterID, project, config.ResourceGraphQLQuery, queryID)]
+		if !ok {
+			return http.StatusBadRequest, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("graphql query with id (%s) does not exist", queryID), nil, nil)
+		}
+
+		return http.StatusOK, []interface{}{query}, nil
+	}
+
+	queries := []interface{}{}
+	for _, value := range projectConfig.GraphQLQueries {
+		queries = append(queries, value)
+	}
+
+	return http.StatusOK, queries, nil
+}
+
+// DeleteGraphQLQuery deletes a query registered for the graphql endpoint
+func (s *Manager) DeleteGraphQLQuery(ctx context.Context, project, queryID string, reqParams model.RequestParams) (int, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, reqParams)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), nil
+	}
+
+	// Acquire a lock
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceGraphQLQuery, queryID)
+
+	delete(projectConfig.GraphQLQueries, resourceID)
+
+	// Stop accepting the query right away
+	if err := s.modules.SetGraphQLQueriesConfig(ctx, project, projectConfig.GraphQLQueries); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
@@ -0,0 +1,108 @@
+package syncman
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestManager_SetGraphQLQuery(t *testing.T) {
+
+	type mockArgs struct {
+		method         string
+		args           []interface{}
+		paramsReturned []interface{}
+	}
+	type args struct {
+		project string
+		queryID string
+		value   *config.GraphQLQuery
+	}
+	usersResourceID := config.GenerateResourceID("chicago", "1", config.ResourceGraphQLQuery, "users")
+	postsResourceID := config.GenerateResourceID("chicago", "1", config.ResourceGraphQLQuery, "posts")
+	usersQuery := &config.GraphQLQuery{ID: "users", Query: "query { users @db { id } }"}
+	tests := []struct {
+		name            string
+		s               *Manager
+		args            args
+		modulesMockArgs []mockArgs
+		storeMockArgs   []mockArgs
+		wantQueries     config.GraphQLQueries
+		wantErr         bool
+	}{
+		{
+			name:    "unable to get project config",
+			s:       &Manager{clusterID: "chicago", projectConfig: &config.Config{Projects: config.Projects{"1": &config.Project{ProjectConfig: &config.ProjectConfig{ID: "1"}}}}},
+			args:    args{project: "2", queryID: "posts", value: &config.GraphQLQuery{}},
+			wantErr: true,
+		},
+		{
+			name: "invalid queries are not saved",
+			s:    &Manager{clusterID: "chicago", projectConfig: &config.Config{Projects: config.Projects{"1": &config.Project{ProjectConfig: &config.ProjectConfig{ID: "1"}, GraphQLQueries: config.GraphQLQueries{usersResourceID: usersQuery}}}}},
+			args: args{project: "1", queryID: "posts", value: &config.GraphQLQuery{Query: "query { posts"}},
+			modulesMockArgs: []mockArgs{
+				{
+					method:         "SetGraphQLQueriesConfig",
+					args:           []interface{}{mock.Anything, "1", config.GraphQLQueries{usersResourceID: usersQuery, postsResourceID: &config.GraphQLQuery{ID: "posts", Query: "query { posts"}}},
+					paramsReturned: []interface{}{errors.New("Invalid graphql query (posts) provided")},
+				},
+			},
+			wantQueries: config.GraphQLQueries{usersResourceID: usersQuery},
+			wantErr:     true,
+		},
+		{
+			name: "query is registered",
+			s:    &Manager{clusterID: "chicago", projectConfig: &config.Config{Projects: config.Projects{"1": &config.Project{ProjectConfig: &config.ProjectConfig{ID: "1"}, GraphQLQueries: config.GraphQLQueries{usersResourceID: usersQuery}}}}},
+			args: args{project: "1", queryID: "posts", value: &config.GraphQLQuery{Query: "query { posts @db { id } }"}},
+			modulesMockArgs: []mockArgs{
+				{
+					method:         "SetGraphQLQueriesConfig",
+					args:           []interface{}{mock.Anything, "1", config.GraphQLQueries{usersResourceID: usersQuery, postsResourceID: &config.GraphQLQuery{ID: "posts", Query: "query { posts @db { id } }"}}},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+			storeMockArgs: []mockArgs{
+				{
+					method:         "SetResource",
+					args:           []interface{}{mock.Anything, postsResourceID, &config.GraphQLQuery{ID: "posts", Query: "query { posts @db { id } }"}},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			mockModules := mockModulesInterface{}
+			mockStore := mockStoreInterface{}
+
+			for _, m := range tt.modulesMockArgs {
+				mockModules.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+			for _, m := range tt.storeMockArgs {
+				mockStore.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+
+			tt.s.modules = &mockModules
+			tt.s.store = &mockStore
+			tt.s.integrationMan = &mockIntegrationManager{skip: true}
+
+			if _, err := tt.s.SetGraphQLQuery(context.Background(), tt.args.project, tt.args.queryID, tt.args.value, model.RequestParams{}); (err != nil) != tt.wantErr {
+				t.Errorf("Manager.SetGraphQLQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantQueries != nil && !reflect.DeepEqual(tt.s.projectConfig.Projects[tt.args.project].GraphQLQueries, tt.wantQueries) {
+				t.Errorf("Manager.SetGraphQLQuery() GraphQLQueries = %v, want %v", tt.s.projectConfig.Projects[tt.args.project].GraphQLQueries, tt.wantQueries)
+			}
+
+			mockModules.AssertExpectations(t)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
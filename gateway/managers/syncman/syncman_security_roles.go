@@ -0,0 +1,133 @@
+package syncman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// SetSecurityRole sets a role used by the rbac security rule
+func (s *Manager) SetSecurityRole(ctx context.Context, project, roleID string, value *config.SecurityRole, reqParams model.RequestParams) (int, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, reqParams)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), nil
+	}
+
+	// Acquire a lock
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	value.ID = roleID
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceSecurityRole, roleID)
+	if projectConfig.SecurityRoles == nil {
+		projectConfig.SecurityRoles = config.SecurityRoles{resourceID: value}
+	} else {
+		projectConfig.SecurityRoles[resourceID] = value
+	}
+
+	// The auth module rejects roles with an invalid inheritance
+	if err := s.modules.SetSecurityRolesConfig(ctx, project, projectConfig.SecurityRoles); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := s.store.SetResource(ctx, resourceID, value); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// GetSecurityRoles gets the roles used by the rbac security rule
+func (s *Manager) GetSecurityRoles(ctx context.Context, project, roleID string, params model.RequestParams) (int, []interface{}, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), nil, err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), hookResponse.Result().([]interface{}), nil
+	}
+
+	// Acquire a lock
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	if roleID != "*" {
+		role, ok := projectConfig.SecurityRoles[config.GenerateResourceID(s.clusterID, project, config.ResourceSecurityRole, roleID)]
+		if !ok {
+			return http.StatusBadRequest, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("security role with id (%s) does not exist", roleID), nil, nil)
+		}
+
+		return http.StatusOK, []interface{}{role}, nil
+	}
+
+	roles := []interface{}{}
+	for _, value := range projectConfig.SecurityRoles {
+		roles = append(roles, value)
+	}
+
+	return http.StatusOK, roles, nil
+}
+
+// DeleteSecurityRole deletes a role used by the rbac security rule
+func (s *Manager) DeleteSecurityRole(ctx context.Context, project, roleID string, reqParams model.RequestParams) (int, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, reqParams)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), nil
+	}
+
+	// Acquire a lock
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceSecurityRole, roleID)
+
+	delete(projectConfig.SecurityRoles, resourceID)
+
+	// A role cannot be deleted while other roles inherit it
+	if err := s.modules.SetSecurityRolesConfig(ctx, project, projectConfig.SecurityRoles); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
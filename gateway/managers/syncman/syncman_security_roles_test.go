@@ -0,0 +1,101 @@
+package syncman
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestManager_SetSecurityRole(t *testing.T) {
+
+	type mockArgs struct {
+		method         string
+		args           []interface{}
+		paramsReturned []interface{}
+	}
+	type args struct {
+		project string
+		roleID  string
+		value   *config.SecurityRole
+	}
+	adminResourceID := config.GenerateResourceID("chicago", "1", config.ResourceSecurityRole, "admin")
+	userResourceID := config.GenerateResourceID("chicago", "1", config.ResourceSecurityRole, "user")
+	userRole := &config.SecurityRole{ID: "user", Permissions: []*config.SecurityPermission{{Resource: "db:mydb:*", Ops: []string{"read"}}}}
+	tests := []struct {
+		name            string
+		s               *Manager
+		args            args
+		modulesMockArgs []mockArgs
+		storeMockArgs   []mockArgs
+		wantErr         bool
+	}{
+		{
+			name:    "unable to get project config",
+			s:       &Manager{clusterID: "chicago", projectConfig: &config.Config{Projects: config.Projects{"1": &config.Project{ProjectConfig: &config.ProjectConfig{ID: "1"}}}}},
+			args:    args{project: "2", roleID: "admin", value: &config.SecurityRole{}},
+			wantErr: true,
+		},
+		{
+			name: "invalid roles are not saved",
+			s:    &Manager{clusterID: "chicago", projectConfig: &config.Config{Projects: config.Projects{"1": &config.Project{ProjectConfig: &config.ProjectConfig{ID: "1"}, SecurityRoles: config.SecurityRoles{userResourceID: userRole}}}}},
+			args: args{project: "1", roleID: "admin", value: &config.SecurityRole{Inherits: []string{"manager"}}},
+			modulesMockArgs: []mockArgs{
+				{
+					method:         "SetSecurityRolesConfig",
+					args:           []interface{}{mock.Anything, "1", config.SecurityRoles{userResourceID: userRole, adminResourceID: &config.SecurityRole{ID: "admin", Inherits: []string{"manager"}}}},
+					paramsReturned: []interface{}{errors.New("security role (manager) inherited by role (admin) does not exist")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "role is set",
+			s:    &Manager{clusterID: "chicago", projectConfig: &config.Config{Projects: config.Projects{"1": &config.Project{ProjectConfig: &config.ProjectConfig{ID: "1"}, SecurityRoles: config.SecurityRoles{userResourceID: userRole}}}}},
+			args: args{project: "1", roleID: "admin", value: &config.SecurityRole{Inherits: []string{"user"}}},
+			modulesMockArgs: []mockArgs{
+				{
+					method:         "SetSecurityRolesConfig",
+					args:           []interface{}{mock.Anything, "1", config.SecurityRoles{userResourceID: userRole, adminResourceID: &config.SecurityRole{ID: "admin", Inherits: []string{"user"}}}},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+			storeMockArgs: []mockArgs{
+				{
+					method:         "SetResource",
+					args:           []interface{}{mock.Anything, adminResourceID, &config.SecurityRole{ID: "admin", Inherits: []string{"user"}}},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			mockModules := mockModulesInterface{}
+			mockStore := mockStoreInterface{}
+
+			for _, m := range tt.modulesMockArgs {
+				mockModules.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+			for _, m := range tt.storeMockArgs {
+				mockStore.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+
+			tt.s.modules = &mockModules
+			tt.s.store = &mockStore
+			tt.s.integrationMan = &mockIntegrationManager{skip: true}
+
+			if _, err := tt.s.SetSecurityRole(context.Background(), tt.args.project, tt.args.roleID, tt.args.value, model.RequestParams{}); (err != nil) != tt.wantErr {
+				t.Errorf("Manager.SetSecurityRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			mockModules.AssertExpectations(t)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
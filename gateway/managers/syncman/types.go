@@ -60,10 +60,17 @@ type ModulesInterface interface {
 	SetEventingSchemaConfig(ctx context.Context, projectID string, schemaObj config.EventingSchemas) error
 	SetEventingTriggerConfig(ctx context.Context, projectID string, triggerObj config.EventingTriggers) error
 	SetEventingRuleConfig(ctx context.Context, projectID string, secureObj config.EventingRules) error
+	SetEventingSourceConfig(ctx context.Context, projectID string, sources config.EventingSources) error
 
 	// SetUsermanConfig set the config of the userman module
 	SetUsermanConfig(ctx context.Context, projectID string, auth config.Auths) error
 
+	// SetSecurityRolesConfig sets the roles used by the rbac security rule
+	SetSecurityRolesConfig(ctx context.Context, projectID string, roles config.SecurityRoles) error
+
+	// SetGraphQLQueriesConfig sets the queries registered for the graphql endpoint
+	SetGraphQLQueriesConfig(ctx context.Context, projectID string, queries config.GraphQLQueries) error
+
 	// Getters
 	GetSchemaModuleForSyncMan(projectID string) (model.SchemaEventingInterface, error)
 	GetAuthModuleForSyncMan(projectID string) (model.AuthSyncManInterface, error)
@@ -199,6 +199,18 @@ func (m *mockModulesInterface) SetUsermanConfig(ctx context.Context, projectID s
 	return m.Called(ctx, projectID, auth).Error(0)
 }
 
+func (m *mockModulesInterface) SetSecurityRolesConfig(ctx context.Context, projectID string, roles config.SecurityRoles) error {
+	return m.Called(ctx, projectID, roles).Error(0)
+}
+
+func (m *mockModulesInterface) SetEventingSourceConfig(ctx context.Context, projectID string, sources config.EventingSources) error {
+	return m.Called(ctx, projectID, sources).Error(0)
+}
+
+func (m *mockModulesInterface) SetGraphQLQueriesConfig(ctx context.Context, projectID string, queries config.GraphQLQueries) error {
+	return m.Called(ctx, projectID, queries).Error(0)
+}
+
 func (m *mockModulesInterface) LetsEncrypt() *letsencrypt.LetsEncrypt {
 	return m.Called().Get(0).(*letsencrypt.LetsEncrypt)
 }
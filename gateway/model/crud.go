@@ -1,6 +1,10 @@
 package model
 
-import "github.com/spaceuptech/space-cloud/gateway/config"
+import (
+	"context"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
 
 // CreateRequest is the http body received for a create request
 type CreateRequest struct {
@@ -33,6 +37,7 @@ type ReadOptions struct {
 	Skip       *int64           `json:"skip"`
 	Limit      *int64           `json:"limit"`
 	Distinct   *string          `json:"distinct"`
+	Cursor     string           `json:"cursor"` // opaque cursor returned by an earlier read with the same sort
 	Join       []*JoinOption    `json:"join"`
 	ReturnType string           `json:"returnType"`
 	HasOptions bool             `json:"hasOptions"` // used internally
@@ -89,6 +94,22 @@ type AllRequest struct {
 	Type      string                 `json:"type"`
 	DBAlias   string                 `json:"dBAlias"`
 	Extras    map[string]interface{} `json:"extras"`
+
+	// The following fields are only used in a batch request
+	ID         string       `json:"id"`
+	Options    *ReadOptions `json:"options"`
+	Savepoint  string       `json:"savepoint"`
+	RollbackTo string       `json:"rollbackTo"`
+
+	// MatchWhere and PostProcess are populated by the security rules of read requests
+	MatchWhere  []map[string]interface{} `json:"-"`
+	PostProcess map[string]*PostProcess  `json:"-"`
+
+	// Authorise checks the security rules of a step once its references to earlier steps have been resolved. It is
+	// invoked with the resolved step. ProcessResult applies the post processing of the security rules to the result of
+	// a read step before the later steps get to reference it. Both are populated by the handler of batch requests
+	Authorise     func(ctx context.Context, req *AllRequest) error                     `json:"-"`
+	ProcessResult func(ctx context.Context, req *AllRequest, result interface{}) error `json:"-"`
 }
 
 // SQLMetaData stores sql query information
@@ -105,6 +126,9 @@ type BatchRequest struct {
 	Requests []*AllRequest `json:"reqs"`
 }
 
+// BatchSavepoint is the type of a batch request step which creates a named savepoint
+const BatchSavepoint = "savepoint"
+
 // DBType is the type of database used for a particular crud operation
 type DBType string
 
@@ -124,6 +148,9 @@ const (
 	// SQLServer is the type used for MsSQL
 	SQLServer DBType = "sqlserver"
 
+	// SQLite is the type used for SQLite
+	SQLite DBType = "sqlite"
+
 	// DefaultValidate is used for default validation operation
 	DefaultValidate = "default"
 
@@ -16,6 +16,7 @@ type EventDocument struct {
 	Status         string      `structs:"status" json:"status" bson:"status" mapstructure:"status"`
 	Remark         string      `structs:"remark" json:"remark" bson:"remark" mapstructure:"remark"`
 	TriggerType    string      `structs:"trigger_type,omitempty" json:"trigger_type,omitempty" bson:"trigger_type" mapstructure:"trigger_type"`
+	PartitionKey   string      `structs:"partition_key,omitempty" json:"partition_key,omitempty" bson:"partition_key,omitempty" mapstructure:"partition_key"` // Events with the same key are delivered in order
 }
 
 // InvocationDocument is the format in which the invocation are persistent on disk
@@ -58,6 +59,14 @@ type QueueEventRequest struct {
 	IsSynchronous bool              `json:"isSynchronous"` // if true then client will wait for response of event
 }
 
+// ReplayEventsRequest is the payload to queue logged events again with their original payloads
+type ReplayEventsRequest struct {
+	IDs     []string `json:"ids,omitempty"`     // The events to be replayed. All the events matching the other fields are replayed if empty
+	Trigger string   `json:"trigger,omitempty"` // Replays the events of this trigger only
+	Status  string   `json:"status,omitempty"`  // Status of the events to be replayed. Defaults to failed
+	Limit   int64    `json:"limit,omitempty"`   // Maximum number of events to be replayed. Defaults to 1000
+}
+
 // EventIntent describes an intent made in the eventing system
 type EventIntent struct {
 	BatchID string
@@ -72,6 +81,7 @@ type DatabaseEventMessage struct {
 	Col    string      `json:"col" mapstructure:"col"`
 	Doc    interface{} `json:"doc" mapstructure:"doc"`
 	Find   interface{} `json:"find" mapstructure:"find"`
+	Offset int64       `json:"offset,omitempty" mapstructure:"offset"`
 }
 
 // EventResponseMessage describes the format for event response message
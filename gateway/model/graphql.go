@@ -7,6 +7,18 @@ type GraphQLRequest struct {
 	Query         string                 `json:"query"`
 	OperationName string                 `json:"operationName"`
 	Variables     map[string]interface{} `json:"variables"`
+	Extensions    *GraphQLExtensions     `json:"extensions,omitempty"`
+}
+
+// GraphQLExtensions holds the protocol extensions of a graphql request
+type GraphQLExtensions struct {
+	PersistedQuery *PersistedQuery `json:"persistedQuery,omitempty"`
+}
+
+// PersistedQuery refers to a query by its sha256 hash instead of sending the complete query
+type PersistedQuery struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
 }
 
 // ReadRequestKey is the key type for the dataloader
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// RateLimitStatus describes the state of a rate limit after a request has been counted against it
+type RateLimitStatus struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // time after which the limit is fully available again
+	RetryAfter time.Duration // time after which a denied request can be retried
+}
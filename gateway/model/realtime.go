@@ -10,6 +10,9 @@ type FeedData struct {
 	DBType    string      `json:"dbType,omitempty" structs:"dbType"`
 	TypeName  string      `json:"__typename,omitempty" structs:"__typename,omitempty"`
 	Find      interface{} `json:"find,omitempty" structs:"find"`
+
+	// Offset is the monotonic position of the change in its group. It is used to resume subscriptions
+	Offset int64 `json:"offset,omitempty" structs:"offset"`
 }
 
 // RealtimeRequest is the object sent for realtime requests
@@ -22,6 +25,9 @@ type RealtimeRequest struct {
 	ID      string                 `json:"id"`    // id is the query id
 	Where   map[string]interface{} `json:"where"`
 	Options LiveQueryOptions       `json:"options"`
+
+	// ResumeFrom is the offset of the last feed received by the client. Only the feeds missed since then are sent
+	ResumeFrom *int64 `json:"resumeFrom,omitempty"`
 }
 
 // RealtimeResponse is the object sent for realtime requests
@@ -43,6 +49,11 @@ type Message struct {
 // LiveQueryOptions is to set the options for realtime requests
 type LiveQueryOptions struct {
 	SkipInitial bool `json:"skipInitial"`
+
+	// Sort and limit restrict the live query to a window of rows (for example the top 20 by score)
+	Sort  []string      `json:"sort,omitempty"`
+	Limit *int64        `json:"limit,omitempty"`
+	Join  []*JoinOption `json:"join,omitempty"`
 }
 
 // SendFeed is the function called whenever a data point (feed) is to be sent
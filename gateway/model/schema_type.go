@@ -49,7 +49,9 @@ type (
 		// IsIndex tells us if this is an indexed column
 		IsIndex bool `json:"isIndex"`
 		// IsUnique tells us if this is an unique indexed column
-		IsUnique       bool `json:"isUnique"`
+		IsUnique bool `json:"isUnique"`
+		// IsFullText tells us if this is a full text search indexed column
+		IsFullText     bool `json:"isFullText"`
 		From           string
 		To             string
 		Table          string
@@ -107,6 +109,8 @@ const (
 	DirectiveUnique string = "unique"
 	// DirectiveIndex is used in schema module to add index
 	DirectiveIndex string = "index"
+	// DirectiveFullText is used in schema module to add full text search index
+	DirectiveFullText string = "fulltext"
 	// DirectiveForeign is used in schema module to add foreign key
 	DirectiveForeign string = "foreign"
 	// DirectivePrimary is used in schema module to add primary key
@@ -130,6 +134,9 @@ const (
 	DefaultIndexSort string = "asc"
 	// DefaultIndexOrder specifies default order of order
 	DefaultIndexOrder int = 1
+	// FullTextSearchLanguage is the text search configuration used by postgres for full text search queries & indexes.
+	// The index is only used if the query uses the same configuration
+	FullTextSearchLanguage string = "english"
 
 	// DefaultScale specifies the default scale to be used for sql column types float,date,datetime if not provided
 	DefaultScale int = 10
@@ -183,4 +190,6 @@ type IndexType struct {
 	IsUnique bool `db:"IS_UNIQUE"`
 	// IsPrimary specifies whether the column has a index
 	IsPrimary bool `db:"IS_PRIMARY"`
+	// IsFullText specifies whether the column has a full text search index
+	IsFullText bool `db:"IS_FULLTEXT"`
 }
@@ -0,0 +1,44 @@
+package model
+
+// Types of resources whose security rules can be explained
+const (
+	SecurityExplainDatabase = "db"
+	SecurityExplainFile     = "file"
+	SecurityExplainFunction = "function"
+	SecurityExplainEvent    = "event"
+)
+
+// SecurityExplainRequest describes the operation whose security rule needs to be evaluated
+type SecurityExplainRequest struct {
+	Type     string                  `json:"type"`
+	Token    string                  `json:"token"`
+	Resource SecurityExplainResource `json:"resource"`
+	Op       string                  `json:"op"`
+	Args     map[string]interface{}  `json:"args"`
+}
+
+// SecurityExplainResource identifies the resource on which the operation is performed. Only the fields
+// relevant to the type of the request need to be provided
+type SecurityExplainResource struct {
+	DbAlias   string `json:"db,omitempty"`
+	Col       string `json:"col,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Service   string `json:"service,omitempty"`
+	Endpoint  string `json:"endpoint,omitempty"`
+	EventType string `json:"eventType,omitempty"`
+}
+
+// RuleTrace is the outcome of evaluating a single node of a security rule
+type RuleTrace struct {
+	Rule    string              `json:"rule"`
+	Name    string              `json:"name,omitempty"`
+	Eval    string              `json:"eval,omitempty"`
+	Type    string              `json:"type,omitempty"`
+	F1      interface{}         `json:"f1,omitempty"`
+	F2      interface{}         `json:"f2,omitempty"`
+	Passed  bool                `json:"passed"`
+	Error   string              `json:"error,omitempty"`
+	Result  interface{}         `json:"result,omitempty"`
+	Actions []PostProcessAction `json:"actions,omitempty"`
+	Clauses []*RuleTrace        `json:"clauses,omitempty"`
+}
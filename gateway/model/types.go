@@ -24,6 +24,7 @@ type SchemaCrudInterface interface {
 // CrudAuthInterface is an interface consisting of functions of crud module used by auth module
 type CrudAuthInterface interface {
 	Read(ctx context.Context, dbAlias, col string, req *ReadRequest, params RequestParams) (interface{}, *SQLMetaData, error)
+	GetDBType(dbAlias string) (string, error)
 }
 
 // SchemaEventingInterface is an interface consisting of functions of schema module used by eventing module
@@ -88,6 +89,7 @@ type EventingRealtimeInterface interface {
 // AuthRealtimeInterface is an interface consisting of functions of auth module used by RealTime module
 type AuthRealtimeInterface interface {
 	IsReadOpAuthorised(ctx context.Context, project, dbType, col, token string, req *ReadRequest, stub ReturnWhereStub) (*PostProcess, RequestParams, error)
+	RunAuthForJoins(ctx context.Context, project, dbType, dbAlias, token string, req *ReadRequest, join []*JoinOption) error
 	GetInternalAccessToken(ctx context.Context) (string, error)
 	GetSCAccessToken(ctx context.Context) (string, error)
 }
@@ -102,6 +104,7 @@ type CrudSchemaInterface interface {
 	GetDBType(dbAlias string) (string, error)
 	// CreateProjectIfNotExists(ctx context.Context, project, dbAlias string) error
 	RawBatch(ctx context.Context, dbAlias string, batchedQueries []string) error
+	CreateTextIndex(ctx context.Context, dbAlias, col string, fields []string) error
 	DescribeTable(ctx context.Context, dbAlias, col string) ([]InspectorFieldType, []IndexType, error)
 }
 
@@ -118,6 +121,24 @@ type AuthUserInterface interface {
 	IsReadOpAuthorised(ctx context.Context, project, dbType, col, token string, req *ReadRequest, stub ReturnWhereStub) (*PostProcess, RequestParams, error)
 	CreateToken(ctx context.Context, tokenClaims TokenClaims) (string, error)
 	IsUpdateOpAuthorised(ctx context.Context, project, dbType, col, token string, req *UpdateRequest) (RequestParams, error)
+	ParseToken(ctx context.Context, token string) (map[string]interface{}, error)
+	RevokeSessions(ctx context.Context, sessions []string) error
+	GetInternalAccessToken(ctx context.Context) (string, error)
+}
+
+// FunctionsUserInterface is an interface consisting of functions of functions module used by User module
+type FunctionsUserInterface interface {
+	CallWithContext(ctx context.Context, service, function, token string, reqParams RequestParams, req *FunctionsRequest) (int, interface{}, error)
+}
+
+// EventingUserInterface is an interface consisting of functions of eventing module used by User module
+type EventingUserInterface interface {
+	QueueAdminEvent(ctx context.Context, reqs []*QueueEventRequest) error
+}
+
+// FunctionsEventingInterface is an interface consisting of functions of functions module used by eventing module
+type FunctionsEventingInterface interface {
+	CallWithContext(ctx context.Context, service, function, token string, reqParams RequestParams, req *FunctionsRequest) (int, interface{}, error)
 }
 
 // SyncmanEventingInterface is an interface consisting of functions of syncman module used by eventing module
@@ -127,6 +148,7 @@ type SyncmanEventingInterface interface {
 	GetEventSource() string
 	GetSpaceCloudPort() int
 	GetNodeID() string
+	CheckIfLeaderGateway(nodeID string) (bool, error)
 	MakeHTTPRequest(ctx context.Context, method, url, token, scToken string, params, vPtr interface{}) error
 }
 
@@ -155,6 +177,15 @@ type PostProcessAction struct {
 // TokenClaims specifies the tokens and its claims
 type TokenClaims map[string]interface{}
 
+// SessionClaim is the claim holding the id of the session an end user token has been issued for
+const SessionClaim = "sid"
+
+// SessionDBClaim is the claim holding the alias of the database in which the session of an end user token is stored
+const SessionDBClaim = "sdb"
+
+// SessionsCol is the table in which the sessions of the end users are stored
+const SessionsCol = "sessions"
+
 // Response is the object returned by every handler to client
 type Response struct {
 	Error  string      `json:"error,omitempty"`
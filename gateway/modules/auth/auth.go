@@ -12,6 +12,7 @@ import (
 
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 	jwtUtils "github.com/spaceuptech/space-cloud/gateway/utils/jwt"
+	"github.com/spaceuptech/space-cloud/gateway/utils/pubsub"
 )
 
 // Module is responsible for authentication and authorisation
@@ -26,6 +27,9 @@ type Module struct {
 	fileRules        []*config.FileRule
 	funcRules        config.Services
 	eventingRules    map[string]*config.Rule
+	rbac             *rbacPermissions
+	revocations      *revocationList
+	pubsubClient     *pubsub.Module
 	project          string
 	fileStoreType    string
 	makeHTTPRequest  utils.TypeMakeHTTPRequest
@@ -38,7 +42,7 @@ type Module struct {
 
 // Init creates a new instance of the auth object
 func Init(clusterID, nodeID string, crud model.CrudAuthInterface, adminMan adminMan, integrationMan integrationManagerInterface) *Module {
-	return &Module{clusterID: clusterID, nodeID: nodeID, dbRules: make(config.DatabaseRules), dbPrepQueryRules: make(config.DatabasePreparedQueries), crud: crud, adminMan: adminMan, jwt: jwtUtils.New(), integrationMan: integrationMan}
+	return &Module{clusterID: clusterID, nodeID: nodeID, dbRules: make(config.DatabaseRules), dbPrepQueryRules: make(config.DatabasePreparedQueries), rbac: newRBACPermissions(), revocations: newRevocationList(), crud: crud, adminMan: adminMan, jwt: jwtUtils.New(), integrationMan: integrationMan}
 }
 
 // GetInternalAccessToken returns the token that can be used internally by Space Cloud
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+type ruleTraceKey struct{}
+
+// withRuleTrace makes the rules evaluated with the returned context get added as clauses of the provided trace
+func withRuleTrace(ctx context.Context, trace *model.RuleTrace) context.Context {
+	return context.WithValue(ctx, ruleTraceKey{}, trace)
+}
+
+func getRuleTrace(ctx context.Context) (*model.RuleTrace, bool) {
+	trace, ok := ctx.Value(ruleTraceKey{}).(*model.RuleTrace)
+	return trace, ok
+}
+
+// recordRuleResult stores the result of a query or a webhook in the trace of the rule being evaluated
+func recordRuleResult(ctx context.Context, result interface{}) {
+	if trace, ok := getRuleTrace(ctx); ok {
+		trace.Result = result
+	}
+}
+
+// traceRule evaluates the rule while recording the outcome in the trace present in the context
+func (m *Module) traceRule(ctx context.Context, parent *model.RuleTrace, project string, rule *config.Rule, args, auth map[string]interface{}, returnWhere model.ReturnWhereStub) (*model.PostProcess, error) {
+	trace := &model.RuleTrace{Rule: rule.Rule, Name: rule.Name}
+	parent.Clauses = append(parent.Clauses, trace)
+
+	if rule.Rule == "match" {
+		trace.Eval = rule.Eval
+		trace.Type = rule.Type
+		trace.F1 = resolveTraceField(rule.F1, args)
+		trace.F2 = resolveTraceField(rule.F2, args)
+	}
+
+	actions, err := m.evaluateRule(withRuleTrace(ctx, trace), project, rule, args, auth, returnWhere)
+	trace.Passed = err == nil
+	if err != nil {
+		trace.Error = err.Error()
+	}
+	if actions != nil {
+		trace.Actions = actions.PostProcessAction
+	}
+	return actions, err
+}
+
+// resolveTraceField returns the value a field of the match rule refers to. The field is returned as is when
+// it isn't a variable
+func resolveTraceField(field interface{}, args map[string]interface{}) interface{} {
+	v, ok := field.(string)
+	if !ok || !(strings.HasPrefix(v, "args.") || strings.HasPrefix(v, "utils.")) {
+		return field
+	}
+	value, err := utils.LoadValue(v, args)
+	if err != nil {
+		return field
+	}
+	return value
+}
+
+// ExplainRule evaluates the security rule of the operation described in the request and returns the evaluation tree.
+// The operation itself is not performed
+func (m *Module) ExplainRule(ctx context.Context, project string, req *model.SecurityExplainRequest) (*model.RuleTrace, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	if m.project != project {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Invalid project details provided", nil, nil)
+	}
+
+	args := map[string]interface{}{}
+	for k, v := range req.Args {
+		args[k] = v
+	}
+
+	var rule *config.Rule
+	var err error
+	switch req.Type {
+	case model.SecurityExplainDatabase:
+		rule, err = m.getCrudRule(ctx, project, req.Resource.DbAlias, req.Resource.Col, model.OperationType(req.Op))
+		ctx = withRBACTarget(ctx, crudRBACResource(req.Resource.DbAlias, req.Resource.Col), req.Op)
+
+	case model.SecurityExplainFile:
+		var params map[string]interface{}
+		var fileRule *config.FileRule
+		params, fileRule, err = m.getFileRule(req.Resource.Path)
+		if err == nil {
+			var p bool
+			if rule, p = fileRule.Rule[req.Op]; !p {
+				err = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("No security rule provided for operation (%s) on path (%s)", req.Op, req.Resource.Path), nil, nil)
+			}
+			ctx = withRBACTarget(ctx, fileRBACResource(fileRule.ID), req.Op)
+		}
+		args["params"] = params
+
+	case model.SecurityExplainFunction:
+		rule, err = m.getFunctionRule(ctx, project, req.Resource.Service, req.Resource.Endpoint)
+		ctx = withRBACTarget(ctx, functionRBACResource(req.Resource.Service, req.Resource.Endpoint), "access")
+
+	case model.SecurityExplainEvent:
+		rule, err = m.getEventingRule(ctx, project, req.Resource.EventType)
+		ctx = withRBACTarget(ctx, eventingRBACResource(req.Resource.EventType), "queue")
+
+	default:
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid type (%s) provided for explaining security rules", req.Type), nil, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var auth map[string]interface{}
+	if rule.Rule != "allow" {
+		auth, err = m.ParseToken(ctx, req.Token)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["auth"] = auth
+	args["token"] = req.Token
+
+	root := &model.RuleTrace{}
+	_, _ = m.traceRule(ctx, root, project, rule, map[string]interface{}{"args": args}, auth, model.ReturnWhereStub{})
+	return root.Clauses[0], nil
+}
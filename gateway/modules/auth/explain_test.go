@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/modules/crud"
+)
+
+func TestModule_ExplainRule(t *testing.T) {
+	dbRules := config.DatabaseRules{
+		config.GenerateResourceID("chicago", "project", config.ResourceDatabaseRule, "mongo", "tweet", "rule"): &config.DatabaseRule{Rules: map[string]*config.Rule{
+			"read": {Rule: "and", Clauses: []*config.Rule{
+				{Rule: "or", Clauses: []*config.Rule{
+					{Rule: "match", Name: "is admin", Eval: "==", Type: "string", F1: "args.auth.role", F2: "admin"},
+					{Rule: "match", Name: "is owner", Eval: "==", Type: "string", F1: "args.auth.id", F2: "args.find.userId"},
+				}},
+				{Rule: "remove", Fields: []interface{}{"res.password"}},
+			}},
+			"delete": {Rule: "match", Eval: "==", Type: "string", F1: "args.auth.role", F2: "admin"},
+		}},
+	}
+	eventingRules := config.EventingRules{
+		config.GenerateResourceID("chicago", "project", config.ResourceEventingRule, "default"): &config.Rule{Rule: "allow"},
+	}
+
+	m := Init("chicago", "1", &crud.Module{}, nil, nil)
+	if err := m.SetConfig(context.TODO(), "local", &config.ProjectConfig{ID: "project", Secrets: []*config.Secret{{IsPrimary: true, Secret: "mySecretkey"}}}, dbRules, config.DatabasePreparedQueries{}, config.FileStoreRules{}, config.Services{}, eventingRules); err != nil {
+		t.Fatalf("error setting config of auth module - %s", err.Error())
+	}
+	token, err := m.CreateToken(context.TODO(), map[string]interface{}{"id": "1", "role": "user"})
+	if err != nil {
+		t.Fatalf("error creating token - %s", err.Error())
+	}
+
+	tests := []struct {
+		name    string
+		project string
+		req     *model.SecurityExplainRequest
+		want    *model.RuleTrace
+		wantErr bool
+	}{
+		{
+			name:    "nested rule which passes",
+			project: "project",
+			req:     &model.SecurityExplainRequest{Type: model.SecurityExplainDatabase, Token: token, Resource: model.SecurityExplainResource{DbAlias: "mongo", Col: "tweet"}, Op: "read", Args: map[string]interface{}{"find": map[string]interface{}{"userId": "1"}}},
+			want: &model.RuleTrace{Rule: "and", Passed: true, Actions: []model.PostProcessAction{{Action: "remove", Field: "res.password"}}, Clauses: []*model.RuleTrace{
+				{Rule: "or", Passed: true, Clauses: []*model.RuleTrace{
+					{Rule: "match", Name: "is admin", Eval: "==", Type: "string", F1: "user", F2: "admin", Error: "auth: The two fields do not match"},
+					{Rule: "match", Name: "is owner", Eval: "==", Type: "string", F1: "1", F2: "1", Passed: true},
+				}},
+				{Rule: "remove", Passed: true, Actions: []model.PostProcessAction{{Action: "remove", Field: "res.password"}}},
+			}},
+		},
+		{
+			name:    "rule which fails",
+			project: "project",
+			req:     &model.SecurityExplainRequest{Type: model.SecurityExplainDatabase, Token: token, Resource: model.SecurityExplainResource{DbAlias: "mongo", Col: "tweet"}, Op: "delete"},
+			want:    &model.RuleTrace{Rule: "match", Eval: "==", Type: "string", F1: "user", F2: "admin", Error: "auth: The two fields do not match"},
+		},
+		{
+			name:    "allow rule does not need a token",
+			project: "project",
+			req:     &model.SecurityExplainRequest{Type: model.SecurityExplainEvent, Resource: model.SecurityExplainResource{EventType: "order-placed"}},
+			want:    &model.RuleTrace{Rule: "allow", Passed: true},
+		},
+		{
+			name:    "rule not defined for operation",
+			project: "project",
+			req:     &model.SecurityExplainRequest{Type: model.SecurityExplainDatabase, Token: token, Resource: model.SecurityExplainResource{DbAlias: "mongo", Col: "tweet"}, Op: "update"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid type",
+			project: "project",
+			req:     &model.SecurityExplainRequest{Type: "cache", Token: token},
+			wantErr: true,
+		},
+		{
+			name:    "invalid project",
+			project: "other",
+			req:     &model.SecurityExplainRequest{Type: model.SecurityExplainDatabase, Token: token, Resource: model.SecurityExplainResource{DbAlias: "mongo", Col: "tweet"}, Op: "read"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.ExplainRule(context.Background(), tt.project, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExplainRule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				gotJSON, _ := json.Marshal(got)
+				wantJSON, _ := json.Marshal(tt.want)
+				t.Errorf("ExplainRule() got = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
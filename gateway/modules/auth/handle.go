@@ -18,7 +18,7 @@ func (m *Module) AuthorizeRequest(ctx context.Context, rule *config.Rule, projec
 	}
 
 	// Parse token
-	auth, err := m.jwt.ParseToken(ctx, token)
+	auth, err := m.ParseToken(ctx, token)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,8 @@ package auth
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/spaceuptech/helpers"
 
@@ -37,6 +39,7 @@ func (m *Module) IsCreateOpAuthorised(ctx context.Context, project, dbAlias, col
 		}
 	}
 
+	ctx = withRBACTarget(ctx, crudRBACResource(dbAlias, col), string(model.Create))
 	args := map[string]interface{}{"op": req.Operation, "auth": auth, "token": token}
 
 	var rows []interface{}
@@ -100,12 +103,21 @@ func (m *Module) IsReadOpAuthorised(ctx context.Context, project, dbAlias, col,
 			opts["skip"] = *req.Options.Skip
 		}
 	}
+	ctx = withRBACTarget(ctx, crudRBACResource(dbAlias, col), string(model.Read))
 	args := map[string]interface{}{"op": req.Operation, "auth": auth, "find": req.Find, "token": token, "opts": opts}
 	actions, err := m.matchRule(ctx, project, rule, map[string]interface{}{"args": args}, auth, stub)
 	if err != nil {
 		return nil, model.RequestParams{}, err
 	}
 
+	// Enforce the read policies of the fields
+	if policyActions := m.matchFieldPolicies(ctx, project, dbAlias, col, token, args, auth); len(policyActions) > 0 {
+		if actions == nil {
+			actions = &model.PostProcess{}
+		}
+		actions.PostProcessAction = append(actions.PostProcessAction, policyActions...)
+	}
+
 	attr := map[string]string{"project": project, "db": dbAlias, "col": col}
 	return actions, model.RequestParams{Claims: auth, Resource: "db-read", Op: "access", Attributes: attr}, nil
 }
@@ -136,6 +148,7 @@ func (m *Module) IsUpdateOpAuthorised(ctx context.Context, project, dbAlias, col
 		}
 	}
 
+	ctx = withRBACTarget(ctx, crudRBACResource(dbAlias, col), string(model.Update))
 	args := map[string]interface{}{"op": req.Operation, "auth": auth, "find": req.Find, "update": req.Update, "token": token}
 	_, err = m.matchRule(ctx, project, rule, map[string]interface{}{"args": args}, auth, model.ReturnWhereStub{})
 	if err != nil {
@@ -172,6 +185,7 @@ func (m *Module) IsDeleteOpAuthorised(ctx context.Context, project, dbAlias, col
 		}
 	}
 
+	ctx = withRBACTarget(ctx, crudRBACResource(dbAlias, col), string(model.Delete))
 	args := map[string]interface{}{"op": req.Operation, "auth": auth, "find": req.Find, "token": token}
 	_, err = m.matchRule(ctx, project, rule, map[string]interface{}{"args": args}, auth, model.ReturnWhereStub{})
 	if err != nil {
@@ -208,12 +222,21 @@ func (m *Module) IsAggregateOpAuthorised(ctx context.Context, project, dbAlias,
 		}
 	}
 
+	ctx = withRBACTarget(ctx, crudRBACResource(dbAlias, col), string(model.Aggregation))
 	args := map[string]interface{}{"op": req.Operation, "auth": auth, "pipeline": req.Pipeline, "token": token}
 	_, err = m.matchRule(ctx, project, rule, map[string]interface{}{"args": args}, auth, model.ReturnWhereStub{})
 	if err != nil {
 		return model.RequestParams{}, err
 	}
 
+	// Enforce the read policies of the fields. The pipeline can reshape the documents, hence the fields the caller
+	// isn't allowed to see as is get excluded before the first stage instead of being post processed
+	if policyActions := m.matchFieldPolicies(ctx, project, dbAlias, col, token, args, auth); len(policyActions) > 0 {
+		if err := excludePolicyFields(ctx, req, policyActions); err != nil {
+			return model.RequestParams{}, err
+		}
+	}
+
 	attr := map[string]string{"project": project, "db": dbAlias, "col": col}
 	return model.RequestParams{Claims: auth, Resource: "db-aggregate", Op: "access", Attributes: attr}, nil
 }
@@ -244,12 +267,21 @@ func (m *Module) IsPreparedQueryAuthorised(ctx context.Context, project, dbAlias
 		}
 	}
 
+	ctx = withRBACTarget(ctx, preparedQueryRBACResource(dbAlias, id), "execute")
 	args := map[string]interface{}{"auth": auth, "params": req.Params, "token": token}
 	actions, err := m.matchRule(ctx, project, rule, map[string]interface{}{"args": args}, auth, model.ReturnWhereStub{})
 	if err != nil {
 		return nil, model.RequestParams{}, err
 	}
 
+	// Enforce the read policies of the fields of the tables the prepared query can read from
+	if policyActions := m.matchPreparedQueryFieldPolicies(ctx, project, dbAlias, token, args, auth); len(policyActions) > 0 {
+		if actions == nil {
+			actions = &model.PostProcess{}
+		}
+		actions.PostProcessAction = append(actions.PostProcessAction, policyActions...)
+	}
+
 	attr := map[string]string{"project": project, "db": dbAlias}
 	return actions, model.RequestParams{Claims: auth, Resource: "db-prepared-query", Op: "access", Attributes: attr}, nil
 }
@@ -290,7 +322,7 @@ func (m *Module) authenticateCrudRequest(ctx context.Context, projectID, dbAlias
 	}
 
 	// Parse token
-	auth, err = m.jwt.ParseToken(ctx, token)
+	auth, err = m.ParseToken(ctx, token)
 	return
 }
 
@@ -307,7 +339,7 @@ func (m *Module) authenticatePreparedQueryRequest(ctx context.Context, projectID
 	}
 
 	// Parse token
-	auth, err = m.jwt.ParseToken(ctx, token)
+	auth, err = m.ParseToken(ctx, token)
 	return
 }
 
@@ -340,3 +372,126 @@ func (m *Module) getPrepareQueryRule(ctx context.Context, projectID, dbAlias, id
 	}
 	return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("No security rule found for prepared Query (%s) in database with alias (%s)", id, dbAlias), nil, nil)
 }
+
+func (m *Module) getFieldPolicies(projectID, dbAlias, col string) config.FieldPolicies {
+	resourceIDs := []string{
+		config.GenerateResourceID(m.clusterID, projectID, config.ResourceDatabaseRule, dbAlias, col, "rule"),
+		config.GenerateResourceID(m.clusterID, projectID, config.ResourceDatabaseRule, dbAlias, "default", "rule"),
+	}
+	for _, resourceID := range resourceIDs {
+		if rule, ok := m.dbRules[resourceID]; ok && len(rule.FieldPolicies) > 0 {
+			return rule.FieldPolicies
+		}
+	}
+	return nil
+}
+
+// matchFieldPolicies returns the post process actions which mask or omit the fields the caller isn't allowed to see as is
+func (m *Module) matchFieldPolicies(ctx context.Context, project, dbAlias, col, token string, args, auth map[string]interface{}) []model.PostProcessAction {
+	policies := m.getFieldPolicies(project, dbAlias, col)
+	if len(policies) == 0 {
+		return nil
+	}
+
+	auth = m.parseTokenForFieldPolicies(ctx, token, args, auth)
+	return m.evaluateFieldPolicies(ctx, project, policies, args, auth)
+}
+
+// matchPreparedQueryFieldPolicies returns the post process actions for the rows of a prepared query. The rows can come
+// from any table of the database, hence the policies of all its tables are enforced on the fields having the same name.
+// A field is removed if any of the policies removes it and masked if any of them masks it
+func (m *Module) matchPreparedQueryFieldPolicies(ctx context.Context, project, dbAlias, token string, args, auth map[string]interface{}) []model.PostProcessAction {
+	prefix := config.GenerateResourceID(m.clusterID, project, config.ResourceDatabaseRule, dbAlias) + "-"
+
+	// Sort the resources to merge the actions in a deterministic order
+	resourceIDs := make([]string, 0)
+	for resourceID, rule := range m.dbRules {
+		if strings.HasPrefix(resourceID, prefix) && (rule.DbAlias == "" || rule.DbAlias == dbAlias) && len(rule.FieldPolicies) > 0 {
+			resourceIDs = append(resourceIDs, resourceID)
+		}
+	}
+	if len(resourceIDs) == 0 {
+		return nil
+	}
+	sort.Strings(resourceIDs)
+
+	auth = m.parseTokenForFieldPolicies(ctx, token, args, auth)
+	merged := map[string]model.PostProcessAction{}
+	for _, resourceID := range resourceIDs {
+		for _, action := range m.evaluateFieldPolicies(ctx, project, m.dbRules[resourceID].FieldPolicies, args, auth) {
+			if existing, p := merged[action.Field]; p && (existing.Action == "remove" || action.Action == "mask") {
+				continue
+			}
+			merged[action.Field] = action
+		}
+	}
+
+	fields := make([]string, 0, len(merged))
+	for field := range merged {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	actions := make([]model.PostProcessAction, len(fields))
+	for i, field := range fields {
+		actions[i] = merged[field]
+	}
+	return actions
+}
+
+// parseTokenForFieldPolicies returns the claims the field policies are evaluated with. The token isn't parsed when
+// the rule of the operation is allow. Policies are evaluated without claims if the token is invalid
+func (m *Module) parseTokenForFieldPolicies(ctx context.Context, token string, args, auth map[string]interface{}) map[string]interface{} {
+	if auth == nil && token != "" {
+		if claims, err := m.ParseToken(ctx, token); err == nil {
+			args["auth"] = claims
+			return claims
+		}
+	}
+	return auth
+}
+
+// evaluateFieldPolicies returns the actions which mask or omit the fields of the policies the caller doesn't satisfy
+func (m *Module) evaluateFieldPolicies(ctx context.Context, project string, policies config.FieldPolicies, args, auth map[string]interface{}) []model.PostProcessAction {
+	// Sort the fields to generate the actions in a deterministic order
+	fields := make([]string, 0, len(policies))
+	for field := range policies {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	actions := make([]model.PostProcessAction, 0)
+	for _, field := range fields {
+		policy := policies[field]
+		if policy.Return != nil {
+			if _, err := m.matchRule(ctx, project, policy.Return, map[string]interface{}{"args": args}, auth, model.ReturnWhereStub{}); err == nil {
+				continue
+			}
+		}
+
+		if policy.Mask != nil {
+			if _, err := m.matchRule(ctx, project, policy.Mask, map[string]interface{}{"args": args}, auth, model.ReturnWhereStub{}); err == nil {
+				actions = append(actions, model.PostProcessAction{Action: "mask", Field: "res." + field, Value: policy.Format})
+				continue
+			}
+		}
+
+		actions = append(actions, model.PostProcessAction{Action: "remove", Field: "res." + field})
+	}
+	return actions
+}
+
+// excludePolicyFields adds a stage excluding the fields of the policy actions at the start of the aggregation pipeline
+func excludePolicyFields(ctx context.Context, req *model.AggregateRequest, actions []model.PostProcessAction) error {
+	pipeline, ok := req.Pipeline.([]interface{})
+	if !ok {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid pipeline of type (%T) provided for a collection with field policies, it must be an array of stages", req.Pipeline), nil, nil)
+	}
+
+	exclusions := make(map[string]interface{}, len(actions))
+	for _, action := range actions {
+		exclusions[strings.TrimPrefix(action.Field, "res.")] = 0
+	}
+	req.Pipeline = append([]interface{}{map[string]interface{}{"$project": exclusions}}, pipeline...)
+	return nil
+}
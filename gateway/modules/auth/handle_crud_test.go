@@ -518,3 +518,238 @@ func TestModule_getCrudRule(t *testing.T) {
 		})
 	}
 }
+
+func TestIsReadOpAuthorised_FieldPolicies(t *testing.T) {
+	isAdmin := &config.Rule{Rule: "match", Eval: "==", Type: "string", F1: "args.auth.role", F2: "admin"}
+	isSupport := &config.Rule{Rule: "match", Eval: "==", Type: "string", F1: "args.auth.role", F2: "support"}
+	dbRules := config.DatabaseRules{
+		config.GenerateResourceID("chicago", "project", config.ResourceDatabaseRule, "mongo", "users", "rule"): &config.DatabaseRule{
+			Rules: map[string]*config.Rule{"read": {Rule: "allow"}},
+			FieldPolicies: config.FieldPolicies{
+				"card":     {Return: isAdmin, Mask: isSupport, Format: &config.FieldMask{ShowLast: 4}},
+				"password": {},
+				"ssn":      {Return: isAdmin},
+			},
+		},
+		config.GenerateResourceID("chicago", "project", config.ResourceDatabaseRule, "mongo", "posts", "rule"): &config.DatabaseRule{
+			Rules: map[string]*config.Rule{"read": {Rule: "allow"}},
+		},
+	}
+
+	m := Init("chicago", "1", &crud.Module{}, nil, nil)
+	if err := m.SetConfig(context.TODO(), "local", &config.ProjectConfig{ID: "project", Secrets: []*config.Secret{{IsPrimary: true, Secret: "mySecretkey"}}}, dbRules, config.DatabasePreparedQueries{}, config.FileStoreRules{}, config.Services{}, config.EventingRules{}); err != nil {
+		t.Fatalf("error setting config of auth module - %s", err.Error())
+	}
+	createToken := func(role string) string {
+		token, err := m.CreateToken(context.TODO(), map[string]interface{}{"id": "1", "role": role})
+		if err != nil {
+			t.Fatalf("error creating token - %s", err.Error())
+		}
+		return token
+	}
+
+	tests := []struct {
+		name  string
+		col   string
+		token string
+		want  *model.PostProcess
+	}{
+		{
+			name:  "admin gets fields which are returned",
+			col:   "users",
+			token: createToken("admin"),
+			want:  &model.PostProcess{PostProcessAction: []model.PostProcessAction{{Action: "remove", Field: "res.password"}}},
+		},
+		{
+			name:  "support gets masked fields",
+			col:   "users",
+			token: createToken("support"),
+			want: &model.PostProcess{PostProcessAction: []model.PostProcessAction{
+				{Action: "mask", Field: "res.card", Value: &config.FieldMask{ShowLast: 4}},
+				{Action: "remove", Field: "res.password"},
+				{Action: "remove", Field: "res.ssn"},
+			}},
+		},
+		{
+			name: "anonymous user gets fields omitted",
+			col:  "users",
+			want: &model.PostProcess{PostProcessAction: []model.PostProcessAction{
+				{Action: "remove", Field: "res.card"},
+				{Action: "remove", Field: "res.password"},
+				{Action: "remove", Field: "res.ssn"},
+			}},
+		},
+		{
+			name:  "table without field policies",
+			col:   "posts",
+			token: createToken("support"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := m.IsReadOpAuthorised(context.Background(), "project", "mongo", tt.col, tt.token, &model.ReadRequest{Operation: "all"}, model.ReturnWhereStub{})
+			if err != nil {
+				t.Errorf("IsReadOpAuthorised() unexpected error - %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IsReadOpAuthorised() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsAggregateOpAuthorised_FieldPolicies(t *testing.T) {
+	isAdmin := &config.Rule{Rule: "match", Eval: "==", Type: "string", F1: "args.auth.role", F2: "admin"}
+	isSupport := &config.Rule{Rule: "match", Eval: "==", Type: "string", F1: "args.auth.role", F2: "support"}
+	dbRules := config.DatabaseRules{
+		config.GenerateResourceID("chicago", "project", config.ResourceDatabaseRule, "mongo", "users", "rule"): &config.DatabaseRule{
+			Rules: map[string]*config.Rule{"aggr": {Rule: "allow"}},
+			FieldPolicies: config.FieldPolicies{
+				"card": {Return: isAdmin, Mask: isSupport, Format: &config.FieldMask{ShowLast: 4}},
+				"ssn":  {Return: isAdmin},
+			},
+		},
+		config.GenerateResourceID("chicago", "project", config.ResourceDatabaseRule, "mongo", "posts", "rule"): &config.DatabaseRule{
+			Rules: map[string]*config.Rule{"aggr": {Rule: "allow"}},
+		},
+	}
+
+	m := Init("chicago", "1", &crud.Module{}, nil, nil)
+	if err := m.SetConfig(context.TODO(), "local", &config.ProjectConfig{ID: "project", Secrets: []*config.Secret{{IsPrimary: true, Secret: "mySecretkey"}}}, dbRules, config.DatabasePreparedQueries{}, config.FileStoreRules{}, config.Services{}, config.EventingRules{}); err != nil {
+		t.Fatalf("error setting config of auth module - %s", err.Error())
+	}
+	createToken := func(role string) string {
+		token, err := m.CreateToken(context.TODO(), map[string]interface{}{"id": "1", "role": role})
+		if err != nil {
+			t.Fatalf("error creating token - %s", err.Error())
+		}
+		return token
+	}
+
+	group := map[string]interface{}{"$group": map[string]interface{}{"_id": "$card"}}
+	tests := []struct {
+		name     string
+		col      string
+		token    string
+		pipeline interface{}
+		want     interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "admin gets all the fields",
+			col:      "users",
+			token:    createToken("admin"),
+			pipeline: []interface{}{group},
+			want:     []interface{}{group},
+		},
+		{
+			name:     "masked and omitted fields are excluded before the pipeline",
+			col:      "users",
+			token:    createToken("support"),
+			pipeline: []interface{}{group},
+			want:     []interface{}{map[string]interface{}{"$project": map[string]interface{}{"card": 0, "ssn": 0}}, group},
+		},
+		{
+			name:     "anonymous user gets fields excluded",
+			col:      "users",
+			pipeline: []interface{}{group},
+			want:     []interface{}{map[string]interface{}{"$project": map[string]interface{}{"card": 0, "ssn": 0}}, group},
+		},
+		{
+			name:     "pipeline which isn't an array of stages",
+			col:      "users",
+			pipeline: group,
+			wantErr:  true,
+		},
+		{
+			name:     "table without field policies",
+			col:      "posts",
+			pipeline: []interface{}{group},
+			want:     []interface{}{group},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &model.AggregateRequest{Operation: "all", Pipeline: tt.pipeline}
+			_, err := m.IsAggregateOpAuthorised(context.Background(), "project", "mongo", tt.col, tt.token, req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IsAggregateOpAuthorised() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(req.Pipeline, tt.want) {
+				t.Errorf("IsAggregateOpAuthorised() pipeline = %v, want %v", req.Pipeline, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPreparedQueryAuthorised_FieldPolicies(t *testing.T) {
+	isAdmin := &config.Rule{Rule: "match", Eval: "==", Type: "string", F1: "args.auth.role", F2: "admin"}
+	isSupport := &config.Rule{Rule: "match", Eval: "==", Type: "string", F1: "args.auth.role", F2: "support"}
+	dbRules := config.DatabaseRules{
+		config.GenerateResourceID("chicago", "project", config.ResourceDatabaseRule, "db", "users", "rule"): &config.DatabaseRule{
+			FieldPolicies: config.FieldPolicies{
+				"card": {Return: isAdmin, Mask: isSupport, Format: &config.FieldMask{ShowLast: 4}},
+				"ssn":  {Return: isAdmin},
+			},
+		},
+		config.GenerateResourceID("chicago", "project", config.ResourceDatabaseRule, "db", "payments", "rule"): &config.DatabaseRule{
+			FieldPolicies: config.FieldPolicies{"card": {Return: isAdmin}},
+		},
+		config.GenerateResourceID("chicago", "project", config.ResourceDatabaseRule, "other", "users", "rule"): &config.DatabaseRule{
+			FieldPolicies: config.FieldPolicies{"name": {}},
+		},
+	}
+	preparedQueries := config.DatabasePreparedQueries{
+		config.GenerateResourceID("chicago", "project", config.ResourceDatabasePreparedQuery, "db", "getUsers"): &config.DatbasePreparedQuery{ID: "getUsers", DbAlias: "db", Rule: &config.Rule{Rule: "allow"}},
+	}
+
+	m := Init("chicago", "1", &crud.Module{}, nil, nil)
+	if err := m.SetConfig(context.TODO(), "local", &config.ProjectConfig{ID: "project", Secrets: []*config.Secret{{IsPrimary: true, Secret: "mySecretkey"}}}, dbRules, preparedQueries, config.FileStoreRules{}, config.Services{}, config.EventingRules{}); err != nil {
+		t.Fatalf("error setting config of auth module - %s", err.Error())
+	}
+	createToken := func(role string) string {
+		token, err := m.CreateToken(context.TODO(), map[string]interface{}{"id": "1", "role": role})
+		if err != nil {
+			t.Fatalf("error creating token - %s", err.Error())
+		}
+		return token
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  *model.PostProcess
+	}{
+		{
+			name:  "admin gets all the fields",
+			token: createToken("admin"),
+		},
+		{
+			name:  "field masked by a table is removed if another table omits it",
+			token: createToken("support"),
+			want: &model.PostProcess{PostProcessAction: []model.PostProcessAction{
+				{Action: "remove", Field: "res.card"},
+				{Action: "remove", Field: "res.ssn"},
+			}},
+		},
+		{
+			name: "anonymous user gets fields omitted",
+			want: &model.PostProcess{PostProcessAction: []model.PostProcessAction{
+				{Action: "remove", Field: "res.card"},
+				{Action: "remove", Field: "res.ssn"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := m.IsPreparedQueryAuthorised(context.Background(), "project", "db", "getUsers", tt.token, &model.PreparedQueryRequest{})
+			if err != nil {
+				t.Fatalf("IsPreparedQueryAuthorised() unexpected error - %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IsPreparedQueryAuthorised() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -28,7 +28,7 @@ func (m *Module) IsEventingOpAuthorised(ctx context.Context, project, token stri
 
 	var auth map[string]interface{}
 	if rule.Rule != "allow" {
-		auth, err = m.jwt.ParseToken(ctx, token)
+		auth, err = m.ParseToken(ctx, token)
 		if err != nil {
 			return model.RequestParams{}, err
 		}
@@ -50,6 +50,7 @@ func (m *Module) IsEventingOpAuthorised(ctx context.Context, project, token stri
 		}
 	}
 
+	ctx = withRBACTarget(ctx, eventingRBACResource(event.Type), "queue")
 	if _, err = m.matchRule(ctx, project, rule, map[string]interface{}{
 		"args": map[string]interface{}{"auth": auth, "params": event.Payload, "token": token},
 	}, auth, model.ReturnWhereStub{}); err != nil {
@@ -31,7 +31,7 @@ func (m *Module) IsFileOpAuthorised(ctx context.Context, project, token, path st
 	}
 
 	var auth map[string]interface{}
-	auth, err = m.jwt.ParseToken(ctx, token)
+	auth, err = m.ParseToken(ctx, token)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +57,7 @@ func (m *Module) IsFileOpAuthorised(ctx context.Context, project, token, path st
 	args["token"] = token
 
 	// Match the rule
+	ctx = withRBACTarget(ctx, fileRBACResource(rules.ID), string(op))
 	return m.matchRule(ctx, project, rule, map[string]interface{}{"args": args}, auth, model.ReturnWhereStub{})
 }
 
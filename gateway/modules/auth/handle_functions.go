@@ -28,7 +28,7 @@ func (m *Module) IsFuncCallAuthorised(ctx context.Context, project, service, fun
 
 	var auth map[string]interface{}
 	if rule.Rule != "allow" {
-		auth, err = m.jwt.ParseToken(ctx, token)
+		auth, err = m.ParseToken(ctx, token)
 		if err != nil {
 			return nil, model.RequestParams{}, err
 		}
@@ -50,6 +50,7 @@ func (m *Module) IsFuncCallAuthorised(ctx context.Context, project, service, fun
 		}
 	}
 
+	ctx = withRBACTarget(ctx, functionRBACResource(service, function), "access")
 	actions, err := m.matchRule(ctx, project, rule, map[string]interface{}{
 		"args": map[string]interface{}{"auth": auth, "params": params, "token": token},
 	}, auth, model.ReturnWhereStub{})
@@ -3,6 +3,10 @@ package helpers
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"fmt"
+	"strings"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
 )
 
 // DecryptAESCFB decrypts aes cfb string
@@ -15,3 +19,31 @@ func DecryptAESCFB(dst, src, key, iv []byte) error {
 	aesDecrypter.XORKeyStream(dst, src)
 	return nil
 }
+
+// MaskValue replaces all the characters of the value other than the ones the format asks to show. The
+// whole value is masked if no format is provided
+func MaskValue(value interface{}, format *config.FieldMask) string {
+	if format == nil {
+		format = &config.FieldMask{}
+	}
+	char := format.Char
+	if char == "" {
+		char = "*"
+	}
+
+	first, last := format.ShowFirst, format.ShowLast
+	if first < 0 {
+		first = 0
+	}
+	if last < 0 {
+		last = 0
+	}
+
+	// Mask the entire value if nothing would be hidden otherwise
+	runes := []rune(fmt.Sprintf("%v", value))
+	if first+last >= len(runes) {
+		return strings.Repeat(char, len(runes))
+	}
+
+	return string(runes[:first]) + strings.Repeat(char, len(runes)-first-last) + string(runes[len(runes)-last:])
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/base64"
 	"reflect"
 	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
 )
 
 func base64DecodeString(key string) []byte {
@@ -46,3 +48,26 @@ func Test_decryptAESCFB(t *testing.T) {
 		})
 	}
 }
+
+func TestMaskValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  interface{}
+		format *config.FieldMask
+		want   string
+	}{
+		{name: "mask everything by default", value: "secret", want: "******"},
+		{name: "show last characters", value: "4111111111111111", format: &config.FieldMask{ShowLast: 4}, want: "************1111"},
+		{name: "show first and last characters", value: "john@example.com", format: &config.FieldMask{ShowFirst: 2, ShowLast: 4, Char: "#"}, want: "jo##########.com"},
+		{name: "mask numbers", value: 987654, format: &config.FieldMask{ShowLast: 2}, want: "****54"},
+		{name: "value shorter than the visible characters", value: "123", format: &config.FieldMask{ShowLast: 4}, want: "***"},
+		{name: "multi byte characters", value: "héllo", format: &config.FieldMask{ShowFirst: 2}, want: "hé***"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaskValue(tt.value, tt.format); got != tt.want {
+				t.Errorf("MaskValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/spaceuptech/helpers"
 
+	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
@@ -106,6 +107,17 @@ func PostProcessMethod(ctx context.Context, aesKey []byte, postProcess *model.Po
 
 				}
 
+			case "mask":
+				loadedValue, err := utils.LoadValue(field.Field, map[string]interface{}{"res": doc})
+				if err != nil || loadedValue == nil {
+					// Nothing to mask if the field wasn't returned
+					continue
+				}
+				format, _ := field.Value.(*config.FieldMask)
+				if err := utils.StoreValue(ctx, field.Field, MaskValue(loadedValue, format), map[string]interface{}{"res": doc}); err != nil {
+					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to store value in post process", err, map[string]interface{}{"mask": true})
+				}
+
 			default:
 				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid action (%s) received in post processing read op", field.Action), nil, nil)
 			}
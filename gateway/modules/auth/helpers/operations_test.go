@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 )
 
@@ -184,6 +185,18 @@ func TestPostProcessMethod(t *testing.T) {
 			result:      map[string]interface{}{"password": "password"},
 			finalResult: map[string]interface{}{"password": hash("password")},
 		},
+		{
+			testName:    "mask in array",
+			postProcess: &model.PostProcess{PostProcessAction: []model.PostProcessAction{{Action: "mask", Field: "res.card", Value: &config.FieldMask{ShowLast: 4}}}},
+			result:      []interface{}{map[string]interface{}{"card": "4111111111111111"}, map[string]interface{}{"card": 12345678}},
+			finalResult: []interface{}{map[string]interface{}{"card": "************1111"}, map[string]interface{}{"card": "****5678"}},
+		},
+		{
+			testName:    "mask skips missing fields",
+			postProcess: &model.PostProcess{PostProcessAction: []model.PostProcessAction{{Action: "mask", Field: "res.card"}, {Action: "remove", Field: "res.ssn"}}},
+			result:      map[string]interface{}{"ssn": "123-45-6789"},
+			finalResult: map[string]interface{}{},
+		},
 	}
 
 	for _, test := range authMatchQuery {
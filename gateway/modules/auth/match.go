@@ -26,6 +26,14 @@ func (m *Module) MatchRule(ctx context.Context, project string, rule *config.Rul
 }
 
 func (m *Module) matchRule(ctx context.Context, project string, rule *config.Rule, args, auth map[string]interface{}, returnWhere model.ReturnWhereStub) (*model.PostProcess, error) {
+	// Record the evaluation of the rule if it is being explained
+	if trace, ok := getRuleTrace(ctx); ok {
+		return m.traceRule(ctx, trace, project, rule, args, auth, returnWhere)
+	}
+	return m.evaluateRule(ctx, project, rule, args, auth, returnWhere)
+}
+
+func (m *Module) evaluateRule(ctx context.Context, project string, rule *config.Rule, args, auth map[string]interface{}, returnWhere model.ReturnWhereStub) (*model.PostProcess, error) {
 	if project != m.project {
 		return nil, formatError(ctx, rule, errors.New("invalid project details provided"))
 	}
@@ -53,6 +61,9 @@ func (m *Module) matchRule(ctx context.Context, project string, rule *config.Rul
 	case "or":
 		return m.matchOr(ctx, project, rule, args, auth, returnWhere)
 
+	case "rbac":
+		return nil, m.matchRBAC(ctx, rule, args)
+
 	case "webhook":
 		return nil, m.matchFunc(ctx, rule, m.makeHTTPRequest, args)
 
@@ -116,6 +127,7 @@ func (m *Module) matchFunc(ctx context.Context, rule *config.Rule, MakeHTTPReque
 	if err := MakeHTTPRequest(ctx, http.MethodPost, rule.URL, token, scToken, obj, &result); err != nil {
 		return formatError(ctx, rule, err)
 	}
+	recordRuleResult(ctx, result)
 
 	if rule.Store == "" {
 		rule.Store = "args.result"
@@ -142,6 +154,7 @@ func (m *Module) matchQuery(ctx context.Context, project string, rule *config.Ru
 	if err != nil {
 		return nil, formatError(ctx, rule, err)
 	}
+	recordRuleResult(ctx, data)
 
 	if rule.Store == "" {
 		rule.Store = "args.result"
@@ -15,9 +15,16 @@ func (m *Module) Encrypt(value string) (string, error) {
 	return utils.Encrypt(m.aesKey, value)
 }
 
-// ParseToken simply parses and returns the claims of a provided token
+// ParseToken parses and returns the claims of a provided token. Tokens belonging to a revoked session are rejected
 func (m *Module) ParseToken(ctx context.Context, token string) (map[string]interface{}, error) {
-	return m.jwt.ParseToken(ctx, token)
+	claims, err := m.jwt.ParseToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.checkRevocation(ctx, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
 }
 
 // GetAESKey gets aes key
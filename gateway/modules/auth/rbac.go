@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// defaultRBACRoleField is the field used to get the roles of the caller when the rbac rule doesn't specify one
+const defaultRBACRoleField = "args.auth.role"
+
+type rbacTargetKey struct{}
+
+// rbacTarget is the resource and the operation being authorised by the rbac rule
+type rbacTarget struct {
+	resource string
+	op       string
+}
+
+// withRBACTarget stores the resource and the operation being authorised in the context
+func withRBACTarget(ctx context.Context, resource, op string) context.Context {
+	return context.WithValue(ctx, rbacTargetKey{}, rbacTarget{resource: resource, op: op})
+}
+
+func getRBACTarget(ctx context.Context) (rbacTarget, bool) {
+	target, ok := ctx.Value(rbacTargetKey{}).(rbacTarget)
+	return target, ok
+}
+
+func crudRBACResource(dbAlias, col string) string {
+	return fmt.Sprintf("db:%s:%s", dbAlias, col)
+}
+
+func preparedQueryRBACResource(dbAlias, id string) string {
+	return fmt.Sprintf("db-prepared-query:%s:%s", dbAlias, id)
+}
+
+func fileRBACResource(ruleID string) string {
+	return fmt.Sprintf("file:%s", ruleID)
+}
+
+func functionRBACResource(service, endpoint string) string {
+	return fmt.Sprintf("remote-service:%s:%s", service, endpoint)
+}
+
+func eventingRBACResource(eventType string) string {
+	return fmt.Sprintf("eventing:%s", eventType)
+}
+
+// maxRBACDecisions is the number of decisions cached. Resources, like the collection of a request, are provided by
+// the client, hence the cache is cleared once it is full instead of growing without bound
+const maxRBACDecisions = 10000
+
+// rbacPermissions stores the flattened permissions of every role along with a cache of the decisions made
+type rbacPermissions struct {
+	lock      sync.RWMutex
+	roles     map[string][]*config.SecurityPermission
+	decisions map[string]bool
+}
+
+func newRBACPermissions() *rbacPermissions {
+	return &rbacPermissions{roles: map[string][]*config.SecurityPermission{}, decisions: map[string]bool{}}
+}
+
+// isAllowed checks if any of the roles has been granted the operation on the resource
+func (p *rbacPermissions) isAllowed(roles []string, resource, op string) bool {
+	for _, role := range roles {
+		if p.isRoleAllowed(role, resource, op) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *rbacPermissions) isRoleAllowed(role, resource, op string) bool {
+	key := role + "|" + resource + "|" + op
+
+	p.lock.RLock()
+	permissions, isConfigured := p.roles[role]
+	allowed, ok := p.decisions[key]
+	p.lock.RUnlock()
+
+	// Roles come from the claims of the token. Only the configured ones are worth caching decisions for
+	if !isConfigured {
+		return false
+	}
+	if ok {
+		return allowed
+	}
+
+	allowed = false
+	for _, permission := range permissions {
+		if matchRBACResource(permission.Resource, resource) && matchRBACOp(permission.Ops, op) {
+			allowed = true
+			break
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.decisions) >= maxRBACDecisions {
+		p.decisions = make(map[string]bool, maxRBACDecisions)
+	}
+	p.decisions[key] = allowed
+	return allowed
+}
+
+// matchRBACResource matches the resource segment by segment. A `*` in the last segment of the pattern matches all the remaining ones
+func matchRBACResource(pattern, resource string) bool {
+	patternSegments := strings.Split(pattern, ":")
+	resourceSegments := strings.Split(resource, ":")
+	for i, segment := range patternSegments {
+		if i >= len(resourceSegments) {
+			return false
+		}
+		if segment == "*" {
+			if i == len(patternSegments)-1 {
+				return true
+			}
+			continue
+		}
+		if segment != resourceSegments[i] {
+			return false
+		}
+	}
+	return len(patternSegments) == len(resourceSegments)
+}
+
+func matchRBACOp(ops []string, op string) bool {
+	for _, o := range ops {
+		if o == "*" || o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenSecurityRoles returns the permissions of every role including the ones it inherits
+func flattenSecurityRoles(roles config.SecurityRoles) (map[string][]*config.SecurityPermission, error) {
+	rolesByID := make(map[string]*config.SecurityRole, len(roles))
+	for _, role := range roles {
+		rolesByID[role.ID] = role
+	}
+
+	flattened := make(map[string][]*config.SecurityPermission, len(rolesByID))
+	var visit func(id string, path []string) ([]*config.SecurityPermission, error)
+	visit = func(id string, path []string) ([]*config.SecurityPermission, error) {
+		if permissions, p := flattened[id]; p {
+			return permissions, nil
+		}
+		for _, parent := range path {
+			if parent == id {
+				return nil, fmt.Errorf("cyclic inheritance detected in security roles (%s)", strings.Join(append(path, id), " -> "))
+			}
+		}
+
+		role, p := rolesByID[id]
+		if !p {
+			return nil, fmt.Errorf("security role (%s) inherited by role (%s) does not exist", id, path[len(path)-1])
+		}
+
+		permissions := append([]*config.SecurityPermission{}, role.Permissions...)
+		for _, inherited := range role.Inherits {
+			inheritedPermissions, err := visit(inherited, append(path, id))
+			if err != nil {
+				return nil, err
+			}
+			permissions = append(permissions, inheritedPermissions...)
+		}
+		flattened[id] = permissions
+		return permissions, nil
+	}
+
+	for id := range rolesByID {
+		if _, err := visit(id, []string{}); err != nil {
+			return nil, err
+		}
+	}
+	return flattened, nil
+}
+
+func (m *Module) matchRBAC(ctx context.Context, rule *config.Rule, args map[string]interface{}) error {
+	target, ok := getRBACTarget(ctx)
+	if !ok {
+		return formatError(ctx, rule, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Rule (rbac) cannot be used for this resource", nil, nil))
+	}
+
+	field := rule.Field
+	if field == "" {
+		field = defaultRBACRoleField
+	}
+	value, err := utils.LoadValue(field, args)
+	if err != nil {
+		return formatError(ctx, rule, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to load the roles from field (%s) in rule (rbac)", field), err, nil))
+	}
+
+	var roles []string
+	switch v := value.(type) {
+	case string:
+		roles = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			if role, ok := item.(string); ok {
+				roles = append(roles, role)
+			}
+		}
+	case []string:
+		roles = v
+	default:
+		return formatError(ctx, rule, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid type (%T) provided for roles in rule (rbac)", value), nil, nil))
+	}
+
+	if !m.rbac.isAllowed(roles, target.resource, target.op) {
+		return formatError(ctx, rule, errors.New("the operation being performed is not permitted for the role"))
+	}
+	return nil
+}
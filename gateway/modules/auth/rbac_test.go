@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+func TestModule_SetSecurityRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		roles   config.SecurityRoles
+		wantErr bool
+	}{
+		{
+			name: "valid inheritance",
+			roles: config.SecurityRoles{
+				"admin":  &config.SecurityRole{ID: "admin", Inherits: []string{"editor"}},
+				"editor": &config.SecurityRole{ID: "editor", Inherits: []string{"viewer"}},
+				"viewer": &config.SecurityRole{ID: "viewer"},
+			},
+		},
+		{
+			name: "inherited role does not exist",
+			roles: config.SecurityRoles{
+				"admin": &config.SecurityRole{ID: "admin", Inherits: []string{"editor"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cyclic inheritance",
+			roles: config.SecurityRoles{
+				"admin":  &config.SecurityRole{ID: "admin", Inherits: []string{"editor"}},
+				"editor": &config.SecurityRole{ID: "editor", Inherits: []string{"viewer"}},
+				"viewer": &config.SecurityRole{ID: "viewer", Inherits: []string{"admin"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Module{rbac: newRBACPermissions()}
+			if err := m.SetSecurityRoles(tt.roles); (err != nil) != tt.wantErr {
+				t.Errorf("SetSecurityRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModule_matchRBAC(t *testing.T) {
+	roles := config.SecurityRoles{
+		"viewer": &config.SecurityRole{ID: "viewer", Permissions: []*config.SecurityPermission{
+			{Resource: "db:mydb:*", Ops: []string{"read"}},
+			{Resource: "remote-service:*", Ops: []string{"access"}},
+		}},
+		"editor": &config.SecurityRole{ID: "editor", Inherits: []string{"viewer"}, Permissions: []*config.SecurityPermission{
+			{Resource: "db:mydb:posts", Ops: []string{"create", "update"}},
+		}},
+		"admin": &config.SecurityRole{ID: "admin", Inherits: []string{"editor"}, Permissions: []*config.SecurityPermission{
+			{Resource: "*", Ops: []string{"*"}},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		rule     *config.Rule
+		resource string
+		op       string
+		noTarget bool
+		auth     map[string]interface{}
+		wantErr  bool
+	}{
+		{name: "role has permission", rule: &config.Rule{Rule: "rbac"}, resource: crudRBACResource("mydb", "users"), op: "read", auth: map[string]interface{}{"role": "viewer"}},
+		{name: "role does not have permission", rule: &config.Rule{Rule: "rbac"}, resource: crudRBACResource("mydb", "users"), op: "delete", auth: map[string]interface{}{"role": "viewer"}, wantErr: true},
+		{name: "permission of another database", rule: &config.Rule{Rule: "rbac"}, resource: crudRBACResource("otherdb", "users"), op: "read", auth: map[string]interface{}{"role": "viewer"}, wantErr: true},
+		{name: "wildcard in the last segment matches nested resources", rule: &config.Rule{Rule: "rbac"}, resource: functionRBACResource("payments", "charge"), op: "access", auth: map[string]interface{}{"role": "viewer"}},
+		{name: "permission of the role itself", rule: &config.Rule{Rule: "rbac"}, resource: crudRBACResource("mydb", "posts"), op: "create", auth: map[string]interface{}{"role": "editor"}},
+		{name: "inherited permission", rule: &config.Rule{Rule: "rbac"}, resource: crudRBACResource("mydb", "users"), op: "read", auth: map[string]interface{}{"role": "editor"}},
+		{name: "permission is not granted to a sibling table", rule: &config.Rule{Rule: "rbac"}, resource: crudRBACResource("mydb", "users"), op: "create", auth: map[string]interface{}{"role": "editor"}, wantErr: true},
+		{name: "wildcard permission", rule: &config.Rule{Rule: "rbac"}, resource: eventingRBACResource("order-placed"), op: "queue", auth: map[string]interface{}{"role": "admin"}},
+		{name: "any of multiple roles", rule: &config.Rule{Rule: "rbac"}, resource: crudRBACResource("mydb", "posts"), op: "update", auth: map[string]interface{}{"role": []interface{}{"viewer", "editor"}}},
+		{name: "custom role field", rule: &config.Rule{Rule: "rbac", Field: "args.auth.roles"}, resource: crudRBACResource("mydb", "users"), op: "read", auth: map[string]interface{}{"roles": []interface{}{"viewer"}}},
+		{name: "unknown role", rule: &config.Rule{Rule: "rbac"}, resource: crudRBACResource("mydb", "users"), op: "read", auth: map[string]interface{}{"role": "guest"}, wantErr: true},
+		{name: "role not present in token", rule: &config.Rule{Rule: "rbac"}, resource: crudRBACResource("mydb", "users"), op: "read", auth: map[string]interface{}{}, wantErr: true},
+		{name: "resource not provided", rule: &config.Rule{Rule: "rbac"}, noTarget: true, auth: map[string]interface{}{"role": "admin"}, wantErr: true},
+	}
+
+	m := &Module{project: "project", rbac: newRBACPermissions()}
+	if err := m.SetSecurityRoles(roles); err != nil {
+		t.Fatalf("SetSecurityRoles() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if !tt.noTarget {
+				ctx = withRBACTarget(ctx, tt.resource, tt.op)
+			}
+			args := map[string]interface{}{"args": map[string]interface{}{"auth": tt.auth}}
+
+			// Run it twice to make sure the cached decision is the same
+			for i := 0; i < 2; i++ {
+				if _, err := m.matchRule(ctx, "project", tt.rule, args, tt.auth, model.ReturnWhereStub{}); (err != nil) != tt.wantErr {
+					t.Errorf("matchRule() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func Test_rbacPermissions_decisions(t *testing.T) {
+	p := newRBACPermissions()
+	p.roles["viewer"] = []*config.SecurityPermission{{Resource: "db:mydb:*", Ops: []string{"read"}}}
+
+	if p.isRoleAllowed("guest", crudRBACResource("mydb", "users"), "read") {
+		t.Errorf("isRoleAllowed() = true for a role which is not configured")
+	}
+	if len(p.decisions) != 0 {
+		t.Errorf("isRoleAllowed() cached %d decisions for a role which is not configured", len(p.decisions))
+	}
+
+	for i := 0; i < maxRBACDecisions+10; i++ {
+		if !p.isRoleAllowed("viewer", crudRBACResource("mydb", fmt.Sprintf("col%d", i)), "read") {
+			t.Fatalf("isRoleAllowed() = false for a permitted resource")
+		}
+	}
+	if len(p.decisions) > maxRBACDecisions {
+		t.Errorf("isRoleAllowed() cached %d decisions, want at most %d", len(p.decisions), maxRBACDecisions)
+	}
+}
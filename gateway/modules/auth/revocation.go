@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+	jwtUtils "github.com/spaceuptech/space-cloud/gateway/utils/jwt"
+	"github.com/spaceuptech/space-cloud/gateway/utils/pubsub"
+)
+
+// revocationTopic is the pubsub topic on which the revoked sessions are broadcast to all gateways
+const revocationTopic = "auth-revocations"
+
+// revocationMessage is the payload sent to the other gateways when sessions get revoked
+type revocationMessage struct {
+	Sessions []string `json:"sessions" mapstructure:"sessions"`
+}
+
+// sessionCheckInterval is the duration for which a session found to be active in the sessions table is trusted. It
+// bounds the time a gateway which missed a revocation message keeps accepting the tokens of the session
+const sessionCheckInterval = 30 * time.Second
+
+// revocationList stores the sessions which have been revoked. A session needs to be remembered only until the
+// last access token issued for it expires. It also stores the sessions which have recently been checked against the
+// sessions table
+type revocationList struct {
+	lock     sync.RWMutex
+	sessions map[string]time.Time
+	checked  map[string]time.Time
+}
+
+func newRevocationList() *revocationList {
+	return &revocationList{sessions: map[string]time.Time{}, checked: map[string]time.Time{}}
+}
+
+func (r *revocationList) add(sessions []string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now()
+	for id, expiry := range r.sessions {
+		if now.After(expiry) {
+			delete(r.sessions, id)
+		}
+	}
+
+	expiry := now.Add(jwtUtils.TokenExpiry)
+	for _, id := range sessions {
+		r.sessions[id] = expiry
+		delete(r.checked, id)
+	}
+}
+
+func (r *revocationList) markChecked(session string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now()
+	for id, expiry := range r.checked {
+		if now.After(expiry) {
+			delete(r.checked, id)
+		}
+	}
+	r.checked[session] = now.Add(sessionCheckInterval)
+}
+
+func (r *revocationList) isChecked(session string) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	expiry, p := r.checked[session]
+	return p && time.Now().Before(expiry)
+}
+
+func (r *revocationList) isRevoked(session string) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	expiry, p := r.sessions[session]
+	return p && time.Now().Before(expiry)
+}
+
+// StartRevocationSync syncs the revoked sessions with the other gateways of the cluster
+func (m *Module) StartRevocationSync(projectID string) error {
+	pubsubClient, err := pubsub.New(projectID, os.Getenv("REDIS_CONN"))
+	if err != nil {
+		return err
+	}
+
+	ch, err := pubsubClient.Subscribe(context.Background(), revocationTopic)
+	if err != nil {
+		pubsubClient.Close()
+		return err
+	}
+
+	m.Lock()
+	m.pubsubClient = pubsubClient
+	m.Unlock()
+
+	go func() {
+		for msg := range ch {
+			pubsubMsg := new(model.PubSubMessage)
+			if err := json.Unmarshal([]byte(msg.Payload), pubsubMsg); err != nil {
+				_ = helpers.Logger.LogError("auth-revocation", "Unable to unmarshal incoming revocation message", err, map[string]interface{}{"payload": msg.Payload})
+				continue
+			}
+
+			revocation := new(revocationMessage)
+			if err := pubsubMsg.Unmarshal(revocation); err != nil {
+				_ = helpers.Logger.LogError("auth-revocation", "Unable to extract the sessions from incoming revocation message", err, map[string]interface{}{"payload": msg.Payload})
+				continue
+			}
+			m.revocations.add(revocation.Sessions)
+		}
+	}()
+	return nil
+}
+
+// RevokeSessions revokes the sessions on all the gateways. Access tokens issued for a revoked session are rejected
+// even if they haven't expired yet
+func (m *Module) RevokeSessions(ctx context.Context, sessions []string) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	m.revocations.add(sessions)
+
+	m.RLock()
+	pubsubClient := m.pubsubClient
+	m.RUnlock()
+
+	if pubsubClient == nil {
+		return nil
+	}
+	if err := pubsubClient.Publish(ctx, revocationTopic, revocationMessage{Sessions: sessions}); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to broadcast the revoked sessions to other gateways", err, nil)
+	}
+	return nil
+}
+
+// checkRevocation rejects the tokens of revoked sessions. Revocations are broadcast to the other gateways, but a
+// gateway can miss them. Hence the session is also checked against the sessions table once in a while
+func (m *Module) checkRevocation(ctx context.Context, claims map[string]interface{}) error {
+	session, ok := claims[model.SessionClaim].(string)
+	if !ok {
+		return nil
+	}
+	if m.revocations.isRevoked(session) {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Token belongs to a session which has been revoked", errors.New("session revoked"), nil)
+	}
+
+	dbAlias, ok := claims[model.SessionDBClaim].(string)
+	if !ok || m.revocations.isChecked(session) {
+		return nil
+	}
+
+	active, err := m.isSessionActive(ctx, dbAlias, session)
+	if err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to check if the session of the token has been revoked", err, map[string]interface{}{"session": session})
+	}
+	if !active {
+		m.revocations.add([]string{session})
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Token belongs to a session which has been revoked", errors.New("session revoked"), nil)
+	}
+	m.revocations.markChecked(session)
+	return nil
+}
+
+func (m *Module) isSessionActive(ctx context.Context, dbAlias, session string) (bool, error) {
+	dbType, err := m.crud.GetDBType(dbAlias)
+	if err != nil {
+		return false, err
+	}
+	idField := "id"
+	if dbType == string(model.Mongo) || dbType == string(model.EmbeddedDB) {
+		idField = "_id"
+	}
+
+	attr := map[string]string{"db": dbAlias, "col": model.SessionsCol}
+	readReq := &model.ReadRequest{Find: map[string]interface{}{idField: session}, Operation: utils.One, Options: &model.ReadOptions{}}
+	result, _, err := m.crud.Read(ctx, dbAlias, model.SessionsCol, readReq, model.RequestParams{Resource: "db-read", Op: "access", Attributes: attr})
+	if err != nil {
+		return false, err
+	}
+
+	doc, ok := result.(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	switch revoked := doc["revoked"].(type) {
+	case bool:
+		return !revoked, nil
+	case int64:
+		return revoked == 0, nil
+	case float64:
+		return revoked == 0, nil
+	}
+	return true, nil
+}
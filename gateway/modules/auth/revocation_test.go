@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/modules/crud"
+)
+
+func TestModule_RevokeSessions(t *testing.T) {
+	authModule := Init("chicago", "1", &crud.Module{}, nil, nil)
+	_ = authModule.SetConfig(context.TODO(), "local", &config.ProjectConfig{Secrets: []*config.Secret{{IsPrimary: true, Secret: "mySecretkey"}}}, nil, nil, nil, nil, config.EventingRules{})
+
+	tokens := map[string]string{}
+	for _, session := range []string{"session1", "session2", ""} {
+		claims := map[string]interface{}{"id": "user1"}
+		if session != "" {
+			claims[model.SessionClaim] = session
+		}
+		token, err := authModule.CreateToken(context.Background(), claims)
+		if err != nil {
+			t.Fatalf("CreateToken() error = %v", err)
+		}
+		tokens[session] = token
+	}
+
+	if err := authModule.RevokeSessions(context.Background(), []string{"session1"}); err != nil {
+		t.Fatalf("RevokeSessions() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		session string
+		wantErr bool
+	}{
+		{name: "token of revoked session is rejected", session: "session1", wantErr: true},
+		{name: "token of other session is accepted", session: "session2", wantErr: false},
+		{name: "token without session is accepted", session: "", wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := authModule.ParseToken(context.Background(), tokens[tt.session]); (err != nil) != tt.wantErr {
+				t.Errorf("ParseToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type mockSessionsCrud struct {
+	sessions map[string]interface{}
+	reads    int
+}
+
+func (c *mockSessionsCrud) Read(ctx context.Context, dbAlias, col string, req *model.ReadRequest, params model.RequestParams) (interface{}, *model.SQLMetaData, error) {
+	c.reads++
+	session, ok := c.sessions[req.Find["id"].(string)]
+	if !ok {
+		return nil, nil, errors.New("session not found")
+	}
+	return session, nil, nil
+}
+
+func (c *mockSessionsCrud) GetDBType(dbAlias string) (string, error) {
+	return string(model.Postgres), nil
+}
+
+func TestModule_ParseToken_sessionsTable(t *testing.T) {
+	crud := &mockSessionsCrud{sessions: map[string]interface{}{
+		"active":  map[string]interface{}{"id": "active", "revoked": false},
+		"revoked": map[string]interface{}{"id": "revoked", "revoked": true},
+	}}
+	authModule := Init("chicago", "1", crud, nil, nil)
+	_ = authModule.SetConfig(context.TODO(), "local", &config.ProjectConfig{Secrets: []*config.Secret{{IsPrimary: true, Secret: "mySecretkey"}}}, nil, nil, nil, nil, config.EventingRules{})
+
+	tests := []struct {
+		name    string
+		session string
+		wantErr bool
+	}{
+		{name: "token of active session is accepted", session: "active"},
+		{name: "token of session revoked on another gateway is rejected", session: "revoked", wantErr: true},
+		{name: "token of deleted session is rejected", session: "deleted", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := authModule.CreateToken(context.Background(), map[string]interface{}{"id": "user1", model.SessionClaim: tt.session, model.SessionDBClaim: "db"})
+			if err != nil {
+				t.Fatalf("CreateToken() error = %v", err)
+			}
+			if _, err := authModule.ParseToken(context.Background(), token); (err != nil) != tt.wantErr {
+				t.Errorf("ParseToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// The sessions which have been checked recently aren't read again
+	reads := crud.reads
+	token, _ := authModule.CreateToken(context.Background(), map[string]interface{}{"id": "user1", model.SessionClaim: "active", model.SessionDBClaim: "db"})
+	if _, err := authModule.ParseToken(context.Background(), token); err != nil || crud.reads != reads {
+		t.Errorf("ParseToken() of checked session error = %v, reads = %v, want %v", err, crud.reads, reads)
+	}
+}
@@ -56,6 +56,26 @@ func (m *Module) CloseConfig() {
 	m.eventingRules = map[string]*config.Rule{}
 	m.fileRules = []*config.FileRule{}
 	m.dbRules = map[string]*config.DatabaseRule{}
+	m.rbac = newRBACPermissions()
+	if m.pubsubClient != nil {
+		m.pubsubClient.Close()
+		m.pubsubClient = nil
+	}
+}
+
+// SetSecurityRoles sets the roles used by the rbac rule. The permissions of inherited roles are resolved
+// upfront and the cached decisions are discarded
+func (m *Module) SetSecurityRoles(roles config.SecurityRoles) error {
+	flattened, err := flattenSecurityRoles(roles)
+	if err != nil {
+		return err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	m.rbac = newRBACPermissions()
+	m.rbac.roles = flattened
+	return nil
 }
 
 // SetRemoteServiceConfig sets the service module config
@@ -9,6 +9,6 @@ import (
 )
 
 // Batch performs the provided operations in a single Batch
-func (b *Bolt) Batch(ctx context.Context, req *model.BatchRequest) ([]int64, error) {
-	return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Batch operation not supported for selected database", nil, nil)
+func (b *Bolt) Batch(ctx context.Context, req *model.BatchRequest) ([]int64, []interface{}, error) {
+	return nil, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Batch operation not supported for selected database", nil, nil)
 }
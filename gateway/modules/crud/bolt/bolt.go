@@ -18,6 +18,7 @@ type Bolt struct {
 	connection      string
 	bucketName      string
 	client          *bbolt.DB
+	aesKey          []byte
 }
 
 // Init initialises a new bolt instance
@@ -86,4 +87,5 @@ func (b *Bolt) SetQueryFetchLimit(limit int64) {
 
 // SetProjectAESKey sets aes key
 func (b *Bolt) SetProjectAESKey(aesKey []byte) {
+	b.aesKey = aesKey
 }
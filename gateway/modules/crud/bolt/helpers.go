@@ -0,0 +1,130 @@
+package bolt
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// generateCursorWhereClause generates the where clause for the cursor in the form the documents are stored in.
+// A json round trip converts dates to strings and numbers to floats just like the unmarshalled documents
+func generateCursorWhereClause(key []byte, sortFields []string, cursor string) (map[string]interface{}, error) {
+	where, err := utils.GenerateCursorWhereClause(key, sortFields, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(where)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// sortResults sorts the documents in the order of the provided sort fields. Fields prefixed with "-" are sorted in descending order
+func sortResults(results []interface{}, sortFields []string) {
+	sort.SliceStable(results, func(i, j int) bool {
+		for _, field := range sortFields {
+			key := strings.TrimPrefix(field, "-")
+			c := compareValues(getField(results[i], key), getField(results[j], key))
+			if c == 0 {
+				continue
+			}
+			if strings.HasPrefix(field, "-") {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+// rankResults sorts the documents in the descending order of their relevance to the full text searches
+func rankResults(results []interface{}, clauses []utils.SearchClause) {
+	type rankedResult struct {
+		result interface{}
+		score  int
+	}
+
+	ranked := make([]rankedResult, len(results))
+	for i, result := range results {
+		ranked[i].result = result
+		for _, clause := range clauses {
+			value, _ := getField(result, clause.Field).(string)
+			ranked[i].score += utils.TextSearchScore(value, clause.Text)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+
+	for i, r := range ranked {
+		results[i] = r.result
+	}
+}
+
+func paginateResults(results []interface{}, skip, limit *int64) []interface{} {
+	if skip != nil {
+		if *skip >= int64(len(results)) {
+			return []interface{}{}
+		}
+		results = results[*skip:]
+	}
+	if limit != nil && *limit < int64(len(results)) {
+		results = results[:*limit]
+	}
+	return results
+}
+
+func getField(doc interface{}, key string) interface{} {
+	value, err := utils.LoadValue("doc."+key, map[string]interface{}{"doc": doc})
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+// compareValues compares two values of an unmarshalled document. Missing values come first
+func compareValues(a, b interface{}) int {
+	switch v1 := a.(type) {
+	case float64:
+		if v2, ok := b.(float64); ok {
+			switch {
+			case v1 < v2:
+				return -1
+			case v1 > v2:
+				return 1
+			}
+			return 0
+		}
+	case string:
+		if v2, ok := b.(string); ok {
+			return strings.Compare(v1, v2)
+		}
+	case bool:
+		if v2, ok := b.(bool); ok {
+			switch {
+			case v1 == v2:
+				return 0
+			case v2:
+				return -1
+			}
+			return 1
+		}
+	case nil:
+		if b == nil {
+			return 0
+		}
+		return -1
+	}
+
+	if b == nil {
+		return 1
+	}
+	return 0
+}
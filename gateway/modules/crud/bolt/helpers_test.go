@@ -0,0 +1,30 @@
+package bolt
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func Test_rankResults(t *testing.T) {
+	results := []interface{}{
+		map[string]interface{}{"id": "1", "title": "space"},
+		map[string]interface{}{"id": "2", "title": "space cloud", "body": "cloud native"},
+		map[string]interface{}{"id": "3", "title": "space space", "body": "cloud"},
+		map[string]interface{}{"id": "4"},
+	}
+	clauses := []utils.SearchClause{{Field: "body", Text: "cloud"}, {Field: "title", Text: "space"}}
+
+	rankResults(results, clauses)
+
+	want := []interface{}{
+		map[string]interface{}{"id": "3", "title": "space space", "body": "cloud"},
+		map[string]interface{}{"id": "2", "title": "space cloud", "body": "cloud native"},
+		map[string]interface{}{"id": "1", "title": "space"},
+		map[string]interface{}{"id": "4"},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("rankResults() got = %v, want %v", results, want)
+	}
+}
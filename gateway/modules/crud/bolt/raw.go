@@ -19,6 +19,11 @@ func (b *Bolt) CreateDatabaseIfNotExist(ctx context.Context, project string) err
 	return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create database operation cannot be performed over selected database", nil, nil)
 }
 
+// CreateTextIndex creates the text index of a collection
+func (b *Bolt) CreateTextIndex(ctx context.Context, col string, fields []string) error {
+	return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create text index operation cannot be performed over selected database", nil, nil)
+}
+
 // RawBatch performs a batch operation for schema creation
 // NOTE: not to be exposed externally
 func (b *Bolt) RawBatch(ctx context.Context, batchedQueries []string) error {
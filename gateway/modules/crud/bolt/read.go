@@ -24,6 +24,22 @@ func (b *Bolt) Read(ctx context.Context, col string, req *model.ReadRequest) (in
 	}
 	switch req.Operation {
 	case utils.All, utils.One:
+		var cursorWhere map[string]interface{}
+		if req.Options.Cursor != "" {
+			var err error
+			cursorWhere, err = generateCursorWhereClause(b.aesKey, req.Options.Sort, req.Options.Cursor)
+			if err != nil {
+				return 0, nil, nil, nil, err
+			}
+		}
+
+		// Documents can only be returned as soon as they match when they don't have to be sorted first
+		isSorted := len(req.Options.Sort) > 0
+
+		// Full text search results are ranked by their relevance unless an explicit sort is provided
+		searchClauses := utils.GetSearchClauses(req.Find)
+		isRanked := !isSorted && len(searchClauses) > 0
+
 		var count int64
 		results := []interface{}{}
 		if err := b.client.View(func(tx *bbolt.Tx) error {
@@ -40,14 +56,14 @@ func (b *Bolt) Read(ctx context.Context, col string, req *model.ReadRequest) (in
 				if err := json.Unmarshal(v, &result); err != nil {
 					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to unmarshal while reading from bbolt db", err, nil)
 				}
-				if utils.Validate(string(model.EmbeddedDB), req.Find, result) {
+				if utils.Validate(string(model.EmbeddedDB), req.Find, result) && (cursorWhere == nil || utils.Validate(string(model.EmbeddedDB), cursorWhere, result)) {
 					if req.Options.Debug {
 						result["_dbFetchTs"] = time.Now().Format(time.RFC3339Nano)
 					}
 
 					results = append(results, result)
 					count++
-					if req.Operation == utils.One {
+					if req.Operation == utils.One && !isSorted && !isRanked {
 						break
 					}
 				}
@@ -56,13 +72,19 @@ func (b *Bolt) Read(ctx context.Context, col string, req *model.ReadRequest) (in
 		}); err != nil {
 			return 0, nil, nil, nil, err
 		}
+
+		if isSorted {
+			sortResults(results, req.Options.Sort)
+		} else if isRanked {
+			rankResults(results, searchClauses)
+		}
+		results = paginateResults(results, req.Options.Skip, req.Options.Limit)
+		count = int64(len(results))
 		if req.Operation == utils.One {
 			if count == 0 {
 				return 0, nil, nil, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "No match found for specified find clause", nil, nil)
 			}
-			if count == 1 {
-				return count, results[0], nil, nil, nil
-			}
+			return 1, results[0], nil, nil, nil
 		}
 
 		return count, results, nil, nil, nil
@@ -14,6 +14,9 @@ import (
 )
 
 func TestBolt_Read(t *testing.T) {
+	limit := int64(2)
+	cursor, _ := utils.EncodeCursor(nil, []string{"-project_count"}, map[string]interface{}{"project_count": float64(52)})
+
 	type fields struct {
 		enabled    bool
 		connection string
@@ -106,6 +109,120 @@ func TestBolt_Read(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "read documents sorted in descending order with limit",
+			want: 2,
+			want1: []interface{}{
+				map[string]interface{}{
+					"_id":           "4",
+					"name":          "ali",
+					"team":          "admin",
+					"project_count": float64(100),
+					"isPrimary":     true,
+					"project_details": map[string]interface{}{
+						"project_name": "project1",
+					},
+				}, map[string]interface{}{
+					"_id":           "3",
+					"name":          "noorain",
+					"team":          "admin",
+					"project_count": float64(52),
+					"isPrimary":     true,
+					"project_details": map[string]interface{}{
+						"project_name": "project1",
+					},
+				}},
+			fields: fields{
+				enabled:    true,
+				connection: "read.db",
+			},
+			args: args{
+				ctx: context.Background(),
+				col: "project_details",
+				req: &model.ReadRequest{
+					Find: map[string]interface{}{
+						"isPrimary": true,
+					},
+					Operation: utils.All,
+					Options:   &model.ReadOptions{Sort: []string{"-project_count"}, Limit: &limit},
+				},
+			},
+		},
+		{
+			name: "read documents after a cursor",
+			want: 1,
+			want1: []interface{}{
+				map[string]interface{}{
+					"_id":           "2",
+					"name":          "jayesh",
+					"team":          "admin",
+					"project_count": float64(10),
+					"isPrimary":     true,
+					"project_details": map[string]interface{}{
+						"project_name": "project1",
+					},
+				}},
+			fields: fields{
+				enabled:    true,
+				connection: "read.db",
+			},
+			args: args{
+				ctx: context.Background(),
+				col: "project_details",
+				req: &model.ReadRequest{
+					Find: map[string]interface{}{
+						"isPrimary": true,
+					},
+					Operation: utils.All,
+					Options:   &model.ReadOptions{Sort: []string{"-project_count"}, Limit: &limit, Cursor: cursor},
+				},
+			},
+		},
+		{
+			name: "read documents matching a full text search",
+			want: 1,
+			want1: []interface{}{
+				map[string]interface{}{
+					"_id":           "3",
+					"name":          "noorain",
+					"team":          "admin",
+					"project_count": float64(52),
+					"isPrimary":     true,
+					"project_details": map[string]interface{}{
+						"project_name": "project1",
+					},
+				}},
+			fields: fields{
+				enabled:    true,
+				connection: "read.db",
+			},
+			args: args{
+				ctx: context.Background(),
+				col: "project_details",
+				req: &model.ReadRequest{
+					Find: map[string]interface{}{
+						"name": map[string]interface{}{"$search": "Noorain"},
+					},
+					Operation: utils.All,
+				},
+			},
+		},
+		{
+			name: "read documents with a cursor of a different sort order",
+			fields: fields{
+				enabled:    true,
+				connection: "read.db",
+			},
+			args: args{
+				ctx: context.Background(),
+				col: "project_details",
+				req: &model.ReadRequest{
+					Operation: utils.All,
+					Options:   &model.ReadOptions{Sort: []string{"project_count"}, Cursor: cursor},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	b, err := Init(true, "read.db", "bucketName")
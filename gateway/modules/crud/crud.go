@@ -44,6 +44,9 @@ type Module struct {
 
 	// Schema module
 	schemaDoc model.Type
+
+	// Key of the project used by the blocks and to seal the cursors
+	aesKey []byte
 }
 
 type loader struct {
@@ -58,13 +61,14 @@ type Crud interface {
 	Update(ctx context.Context, col string, req *model.UpdateRequest) (int64, error)
 	Delete(ctx context.Context, col string, req *model.DeleteRequest) (int64, error)
 	Aggregate(ctx context.Context, col string, req *model.AggregateRequest) (interface{}, error)
-	Batch(ctx context.Context, req *model.BatchRequest) ([]int64, error)
+	Batch(ctx context.Context, req *model.BatchRequest) ([]int64, []interface{}, error)
 	DescribeTable(ctc context.Context, col string) ([]model.InspectorFieldType, []model.IndexType, error)
 	RawQuery(ctx context.Context, query string, isDebug bool, args []interface{}) (int64, interface{}, *model.SQLMetaData, error)
 	GetCollections(ctx context.Context) ([]utils.DatabaseCollections, error)
 	DeleteCollection(ctx context.Context, col string) error
 	CreateDatabaseIfNotExist(ctx context.Context, name string) error
 	RawBatch(ctx context.Context, batchedQueries []string) error
+	CreateTextIndex(ctx context.Context, col string, fields []string) error
 	GetDBType() model.DBType
 	IsClientSafe(ctx context.Context) error
 	IsSame(conn, dbName string, driverConf config.DriverConfig) bool
@@ -85,7 +89,7 @@ func (m *Module) initBlock(dbType model.DBType, enabled bool, connection, dbName
 		return mgo.Init(enabled, connection, dbName, driverConf)
 	case model.EmbeddedDB:
 		return bolt.Init(enabled, connection, dbName)
-	case model.MySQL, model.Postgres, model.SQLServer:
+	case model.MySQL, model.Postgres, model.SQLServer, model.SQLite:
 		c, err := sql.Init(dbType, enabled, connection, dbName, driverConf)
 		if err == nil && enabled {
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -8,6 +8,8 @@ import (
 	"github.com/spaceuptech/helpers"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
 func (m *Module) createBatch(ctx context.Context, project, dbAlias, col string, doc interface{}) (int64, error) {
@@ -80,3 +82,40 @@ func (m *Module) getDBType(dbAlias string) (string, error) {
 
 	return string(block.GetDBType()), nil
 }
+
+// validateBatchRequest checks the step ids and savepoints of a batch request. Savepoint names end up in the
+// sql queries as is, hence they must be plain identifiers
+func validateBatchRequest(ctx context.Context, req *model.BatchRequest) error {
+	stepIDs := map[string]struct{}{}
+	savepoints := map[string]struct{}{}
+	for i, r := range req.Requests {
+		switch r.Type {
+		case string(model.Create), string(model.Read), string(model.Update), string(model.Delete):
+		case model.BatchSavepoint:
+			if !utils.IsValidBatchIdentifier(r.Savepoint) {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid savepoint name (%s) provided at index (%d) of batch request", r.Savepoint, i), nil, nil)
+			}
+			savepoints[r.Savepoint] = struct{}{}
+			continue
+		default:
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid type (%s) provided at index (%d) of batch request", r.Type, i), nil, nil)
+		}
+
+		if r.ID != "" {
+			if !utils.IsValidBatchIdentifier(r.ID) {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid id (%s) provided at index (%d) of batch request", r.ID, i), nil, nil)
+			}
+			if _, p := stepIDs[r.ID]; p {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Duplicate id (%s) provided in batch request", r.ID), nil, nil)
+			}
+			stepIDs[r.ID] = struct{}{}
+		}
+
+		if r.RollbackTo != "" {
+			if _, p := savepoints[r.RollbackTo]; !p {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Savepoint (%s) must be created before the step at index (%d) of batch request", r.RollbackTo, i), nil, nil)
+			}
+		}
+	}
+	return nil
+}
@@ -2,52 +2,50 @@ package mgo
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/spaceuptech/helpers"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
-// Batch performs the provided operations in a single Batch
-func (m *Mongo) Batch(ctx context.Context, req *model.BatchRequest) ([]int64, error) {
+// Batch performs the provided operations in a single session transaction. The result of each step is returned
+// and made available to the later steps which reference it by its id
+func (m *Mongo) Batch(ctx context.Context, req *model.BatchRequest) ([]int64, []interface{}, error) {
 	counts := make([]int64, len(req.Requests))
+	results := make([]interface{}, len(req.Requests))
+
+	// Mongo transactions do not have savepoints
+	for _, r := range req.Requests {
+		if r.Type == model.BatchSavepoint || r.RollbackTo != "" {
+			return counts, results, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Savepoints are not supported in batch requests for mongo", nil, nil)
+		}
+	}
+
 	err := m.getClient().UseSession(ctx, func(session mongo.SessionContext) error {
 		err := session.StartTransaction()
 		if err != nil {
 			return err
 		}
-		for i, req := range req.Requests {
-			col := req.Col
 
-			switch req.Type {
-			case string(model.Create):
-				doc := req.Document
-				op := req.Operation
-
-				counts[i], err = m.Create(session, col, &model.CreateRequest{Document: doc, Operation: op})
-				if err != nil {
-					_ = session.AbortTransaction(session)
-					return err
-				}
-			case string(model.Update):
-				find := req.Find
-				op := req.Operation
-				update := req.Update
-
-				counts[i], err = m.Update(session, col, &model.UpdateRequest{Find: find, Operation: op, Update: update})
-				if err != nil {
-					_ = session.AbortTransaction(session)
-					return err
-				}
-			case string(model.Delete):
-				find := req.Find
-				op := req.Operation
-
-				counts[i], err = m.Delete(session, col, &model.DeleteRequest{Find: find, Operation: op})
-				if err != nil {
-					_ = session.AbortTransaction(session)
-					return err
-				}
+		stepResults := map[string]interface{}{}
+		for i, r := range req.Requests {
+			step, err := utils.ResolveBatchReferences(session, r, stepResults)
+			if err == nil && step.Authorise != nil {
+				err = step.Authorise(session, step)
+			}
+			if err == nil {
+				counts[i], results[i], err = m.batchStep(session, step)
+			}
+			if err != nil {
+				_ = session.AbortTransaction(session)
+				return err
+			}
+			if r.ID != "" {
+				stepResults[r.ID] = results[i]
 			}
 		}
 		err = session.CommitTransaction(session)
@@ -58,5 +56,65 @@ func (m *Mongo) Batch(ctx context.Context, req *model.BatchRequest) ([]int64, er
 		return nil
 	})
 
-	return counts, err
+	return counts, results, err
+}
+
+func (m *Mongo) batchStep(session mongo.SessionContext, req *model.AllRequest) (int64, interface{}, error) {
+	col := req.Col
+	switch req.Type {
+	case string(model.Create):
+		docs, ok := req.Document.([]interface{})
+		if req.Operation == utils.One {
+			docs, ok = []interface{}{req.Document}, true
+		}
+		if !ok {
+			return 0, nil, utils.ErrInvalidParams
+		}
+
+		// Generate the object ids here instead of leaving it to mongo so that the later steps can reference them
+		for _, doc := range docs {
+			if obj, ok := doc.(map[string]interface{}); ok {
+				if _, p := obj["_id"]; !p {
+					obj["_id"] = primitive.NewObjectID()
+				}
+			}
+		}
+
+		count, err := m.Create(session, col, &model.CreateRequest{Document: req.Document, Operation: req.Operation})
+		if err != nil {
+			return 0, nil, err
+		}
+		return count, map[string]interface{}{"count": count, "docs": docs}, nil
+
+	case string(model.Read):
+		count, result, _, _, err := m.Read(session, col, &model.ReadRequest{Find: req.Find, Operation: req.Operation, Options: req.Options, MatchWhere: req.MatchWhere, PostProcess: req.PostProcess})
+		if err != nil {
+			return 0, nil, err
+		}
+
+		// The later steps may only reference the fields the security rules allow to be read
+		if req.ProcessResult != nil {
+			if err := req.ProcessResult(session, req, result); err != nil {
+				return 0, nil, err
+			}
+		}
+		return count, result, nil
+
+	case string(model.Update):
+		count, err := m.Update(session, col, &model.UpdateRequest{Find: req.Find, Operation: req.Operation, Update: req.Update})
+		if err != nil {
+			return 0, nil, err
+		}
+		return count, map[string]interface{}{"count": count}, nil
+
+	case string(model.Delete):
+		count, err := m.Delete(session, col, &model.DeleteRequest{Find: req.Find, Operation: req.Operation})
+		if err != nil {
+			return 0, nil, err
+		}
+		return count, map[string]interface{}{"count": count}, nil
+
+	default:
+		return 0, nil, fmt.Errorf("invalid batch request type (%s) provided", req.Type)
+	}
 }
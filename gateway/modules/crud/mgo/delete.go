@@ -14,6 +14,11 @@ import (
 func (m *Mongo) Delete(ctx context.Context, col string, req *model.DeleteRequest) (int64, error) {
 	collection := m.getClient().Database(m.dbName).Collection(col)
 	req.Find = sanitizeWhereClause(ctx, col, req.Find)
+	find, _, err := generateTextSearch(ctx, req.Find)
+	if err != nil {
+		return 0, err
+	}
+	req.Find = find
 
 	switch req.Operation {
 	case utils.One:
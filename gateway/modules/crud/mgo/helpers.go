@@ -2,7 +2,12 @@ package mgo
 
 import (
 	"context"
+	"fmt"
 	"strings"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
 func sanitizeWhereClause(ctx context.Context, col string, find map[string]interface{}) map[string]interface{} {
@@ -32,3 +37,63 @@ func sanitizeWhereClause(ctx context.Context, col string, find map[string]interf
 	}
 	return find
 }
+
+// generateTextSearch replaces the $search operator at the top level of the where clause with a $text query. Mongo
+// searches all the fields of the text index of a collection at once, hence a single $search is allowed per query and it
+// cannot be nested inside logical operators. It returns true if a text search is present
+func generateTextSearch(ctx context.Context, find map[string]interface{}) (map[string]interface{}, bool, error) {
+	for key, value := range find {
+		if strings.HasPrefix(key, "$") && hasNestedTextSearch(value) {
+			return nil, false, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Operator $search cannot be used inside (%s) in mongo, it can only be used at the top level of the where clause", key), nil, nil)
+		}
+	}
+
+	clauses := utils.GetSearchClauses(find)
+	switch len(clauses) {
+	case 0:
+		return find, false, nil
+	case 1:
+	default:
+		return nil, false, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Only a single $search operator can be used in a query in mongo, since it searches all the fulltext fields of a collection at once", nil, nil)
+	}
+	clause := clauses[0]
+
+	newFind := make(map[string]interface{}, len(find))
+	for key, value := range find {
+		newFind[key] = value
+	}
+
+	cond := make(map[string]interface{})
+	for operator, value := range find[clause.Field].(map[string]interface{}) {
+		if operator != "$search" {
+			cond[operator] = value
+		}
+	}
+	if len(cond) == 0 {
+		delete(newFind, clause.Field)
+	} else {
+		newFind[clause.Field] = cond
+	}
+
+	newFind["$text"] = map[string]interface{}{"$search": clause.Text}
+	return newFind, true, nil
+}
+
+// hasNestedTextSearch checks if a $search operator is present anywhere in the value of a logical operator
+func hasNestedTextSearch(value interface{}) bool {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if hasNestedTextSearch(item) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			if key == "$search" || hasNestedTextSearch(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -85,3 +85,68 @@ func Test_sanitizeWhereClause(t *testing.T) {
 		})
 	}
 }
+
+func Test_generateTextSearch(t *testing.T) {
+	tests := []struct {
+		name         string
+		find         map[string]interface{}
+		want         map[string]interface{}
+		isTextSearch bool
+		wantErr      bool
+	}{
+		{
+			name:         "where clause without a search",
+			find:         map[string]interface{}{"age": map[string]interface{}{"$gt": 10}},
+			want:         map[string]interface{}{"age": map[string]interface{}{"$gt": 10}},
+			isTextSearch: false,
+		},
+		{
+			name: "search is replaced by a text query",
+			find: map[string]interface{}{
+				"body": map[string]interface{}{"$search": "cloud", "$ne": "draft"},
+				"age":  10,
+			},
+			want: map[string]interface{}{
+				"$text": map[string]interface{}{"$search": "cloud"},
+				"body":  map[string]interface{}{"$ne": "draft"},
+				"age":   10,
+			},
+			isTextSearch: true,
+		},
+		{
+			name: "searches on multiple fields",
+			find: map[string]interface{}{
+				"title": map[string]interface{}{"$search": "space"},
+				"body":  map[string]interface{}{"$search": "cloud"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "search inside or",
+			find:    map[string]interface{}{"$or": []interface{}{map[string]interface{}{"title": map[string]interface{}{"$search": "space"}}, map[string]interface{}{"age": 10}}},
+			wantErr: true,
+		},
+		{
+			name:    "search nested inside and",
+			find:    map[string]interface{}{"$and": []interface{}{map[string]interface{}{"$or": []interface{}{map[string]interface{}{"title": map[string]interface{}{"$search": "space"}}}}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isTextSearch, err := generateTextSearch(context.Background(), tt.find)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generateTextSearch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("generateTextSearch() got = %v, want %v", got, tt.want)
+			}
+			if isTextSearch != tt.isTextSearch {
+				t.Errorf("generateTextSearch() isTextSearch = %v, want %v", isTextSearch, tt.isTextSearch)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package mgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spaceuptech/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// textIndexName is the name of the text index created for the fulltext fields of a collection
+const textIndexName = "space_cloud_fulltext"
+
+// CreateTextIndex creates the text index used by the $search operator on the provided fields. Mongo allows a single text
+// index per collection, hence an existing text index on other fields gets dropped. The text index created earlier is
+// dropped if no fields are provided
+func (m *Mongo) CreateTextIndex(ctx context.Context, col string, fields []string) error {
+	indexes := m.getClient().Database(m.dbName).Collection(col).Indexes()
+
+	cursor, err := indexes.List(ctx)
+	if err != nil && !isNamespaceNotFound(err) {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to list indexes of collection (%s)", col), err, nil)
+	}
+	if err == nil {
+		defer func() { _ = cursor.Close(ctx) }()
+		for cursor.Next(ctx) {
+			// Only text indexes have weights
+			weights, ok := cursor.Current.Lookup("weights").DocumentOK()
+			if !ok {
+				continue
+			}
+			elements, err := weights.Elements()
+			if err != nil {
+				return err
+			}
+			if isSameTextIndex(elements, fields) {
+				return nil
+			}
+
+			// Text indexes which weren't created for the fulltext fields are left as is if there are no fulltext fields
+			name := cursor.Current.Lookup("name").StringValue()
+			if len(fields) == 0 && name != textIndexName {
+				continue
+			}
+			if _, err := indexes.DropOne(ctx, name); err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to drop text index (%s) of collection (%s)", name, col), err, nil)
+			}
+		}
+		if err := cursor.Err(); err != nil {
+			return err
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	keys := make(bson.D, len(fields))
+	for i, field := range fields {
+		keys[i] = bson.E{Key: field, Value: "text"}
+	}
+	if _, err := indexes.CreateOne(ctx, mongo.IndexModel{Keys: keys, Options: options.Index().SetName(textIndexName)}); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to create text index on collection (%s)", col), err, nil)
+	}
+	return nil
+}
+
+// isSameTextIndex checks if the weights of an existing text index cover exactly the provided fields
+func isSameTextIndex(weights []bson.RawElement, fields []string) bool {
+	if len(weights) != len(fields) {
+		return false
+	}
+	existing := make(map[string]struct{}, len(weights))
+	for _, weight := range weights {
+		existing[weight.Key()] = struct{}{}
+	}
+	for _, field := range fields {
+		if _, p := existing[field]; !p {
+			return false
+		}
+	}
+	return true
+}
+
+// isNamespaceNotFound checks if the error was returned because the collection does not exist yet
+func isNamespaceNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Code == 26
+}
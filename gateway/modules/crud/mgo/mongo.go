@@ -26,6 +26,7 @@ type Mongo struct {
 	client              *mongo.Client
 	driverConf          config.DriverConfig
 	connRetryCloserChan chan struct{}
+	aesKey              []byte
 }
 
 // Init initialises a new mongo instance
@@ -156,6 +157,7 @@ func (m *Mongo) SetQueryFetchLimit(limit int64) {
 
 // SetProjectAESKey sets aes key
 func (m *Mongo) SetProjectAESKey(aesKey []byte) {
+	m.aesKey = aesKey
 }
 
 func (m *Mongo) setClient(c *mongo.Client) {
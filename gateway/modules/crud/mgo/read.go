@@ -26,9 +26,27 @@ func (m *Mongo) Read(ctx context.Context, col string, req *model.ReadRequest) (i
 
 	req.Find = sanitizeWhereClause(ctx, col, req.Find)
 
+	find, isTextSearch, err := generateTextSearch(ctx, req.Find)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	req.Find = find
+
 	if req.Options == nil {
 		req.Options = &model.ReadOptions{}
 	}
+
+	// Documents after the cursor are selected using the sort fields instead of skipping
+	if req.Options.Cursor != "" {
+		cursorWhere, err := utils.GenerateCursorWhereClause(m.aesKey, req.Options.Sort, req.Options.Cursor)
+		if err != nil {
+			return 0, nil, nil, nil, err
+		}
+		if len(req.Find) > 0 {
+			cursorWhere = map[string]interface{}{"$and": []interface{}{req.Find, cursorWhere}}
+		}
+		req.Find = cursorWhere
+	}
 	if req.Options.Limit == nil {
 		req.Options.Limit = m.queryFetchLimit
 		req.Options.HasOptions = true
@@ -84,6 +102,9 @@ func (m *Mongo) Read(ctx context.Context, col string, req *model.ReadRequest) (i
 
 			if req.Options.Sort != nil {
 				findOptions = findOptions.SetSort(generateSortOptions(req.Options.Sort))
+			} else if isTextSearch {
+				// Rank the documents by their relevance when performing a full text search without an explicit sort
+				findOptions = findOptions.SetSort(textScoreSort)
 			}
 		}
 
@@ -189,6 +210,8 @@ func (m *Mongo) Read(ctx context.Context, col string, req *model.ReadRequest) (i
 
 			if req.Options.Sort != nil {
 				findOneOptions = findOneOptions.SetSort(generateSortOptions(req.Options.Sort))
+			} else if isTextSearch {
+				findOneOptions = findOneOptions.SetSort(textScoreSort)
 			}
 		}
 
@@ -209,6 +232,9 @@ func (m *Mongo) Read(ctx context.Context, col string, req *model.ReadRequest) (i
 	}
 }
 
+// textScoreSort sorts the documents by the relevance computed by a $text query
+var textScoreSort = bson.D{primitive.E{Key: "_textScore", Value: bson.M{"$meta": "textScore"}}}
+
 func generateSortFields(sortColumn, currentColumn, newColumnName string) string {
 	isDescending := false
 	if strings.HasPrefix(sortColumn, "-") {
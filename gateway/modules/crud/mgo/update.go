@@ -13,6 +13,11 @@ import (
 func (m *Mongo) Update(ctx context.Context, col string, req *model.UpdateRequest) (int64, error) {
 	collection := m.getClient().Database(m.dbName).Collection(col)
 	req.Find = sanitizeWhereClause(ctx, col, req.Find)
+	find, _, err := generateTextSearch(ctx, req.Find)
+	if err != nil {
+		return 0, err
+	}
+	req.Find = find
 
 	switch req.Operation {
 	case utils.One:
@@ -3,6 +3,8 @@ package crud
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spaceuptech/helpers"
 
@@ -164,6 +166,51 @@ func (m *Module) Read(ctx context.Context, dbAlias, col string, req *model.ReadR
 	return result, metaData, err
 }
 
+// GenerateCursor generates the cursor pointing to the last document in the result of a read request.
+// It is provided in the read options of the next request to fetch the documents after it. The cursor needs the
+// values of the sort fields, hence it must be generated before the result is post processed
+func (m *Module) GenerateCursor(dbAlias, col string, req *model.ReadRequest, result interface{}) string {
+	m.RLock()
+	defer m.RUnlock()
+
+	if req.Options == nil || len(req.Options.Sort) == 0 || req.Operation != utils.All || len(req.Aggregate) > 0 {
+		return ""
+	}
+
+	docs, ok := result.([]interface{})
+	if !ok || len(docs) == 0 {
+		return ""
+	}
+	lastDoc, ok := docs[len(docs)-1].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	// The sql databases return dates as strings. Parse them back so that the cursor keeps them as dates
+	if fields, p := m.schemaDoc[dbAlias][col]; p {
+		doc := make(map[string]interface{}, len(lastDoc))
+		for k, v := range lastDoc {
+			doc[k] = v
+		}
+		for _, field := range req.Options.Sort {
+			key := strings.TrimPrefix(field, "-")
+			fieldType, p := fields[key]
+			if !p || (fieldType.Kind != model.TypeDateTime && fieldType.Kind != model.TypeDateTimeWithZone) {
+				continue
+			}
+			if value, ok := doc[key].(string); ok {
+				if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+					doc[key] = t
+				}
+			}
+		}
+		lastDoc = doc
+	}
+
+	cursor, _ := utils.EncodeCursor(m.aesKey, req.Options.Sort, lastDoc)
+	return cursor
+}
+
 // Update updates the documents(s) which match a query from the database based on dbType
 func (m *Module) Update(ctx context.Context, dbAlias, col string, req *model.UpdateRequest, params model.RequestParams) error {
 	m.RLock()
@@ -340,32 +387,46 @@ func (m *Module) Aggregate(ctx context.Context, dbAlias, col string, req *model.
 	return crud.Aggregate(ctx, col, req)
 }
 
-// Batch performs a batch operation on the database
-func (m *Module) Batch(ctx context.Context, dbAlias string, req *model.BatchRequest, params model.RequestParams) error {
+// Batch performs a batch operation on the database. It returns the result of each step of the batch
+func (m *Module) Batch(ctx context.Context, dbAlias string, req *model.BatchRequest, params model.RequestParams) ([]interface{}, error) {
 	m.RLock()
 	defer m.RUnlock()
 
 	crud, err := m.getCrudBlock(dbAlias)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	dbType, err := m.getDBType(dbAlias)
 	if err != nil {
-		return err
+		return nil, err
 	}
+
+	if err := validateBatchRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	// Only the steps of a batch request may reference the results of earlier steps
+	batchCtx := utils.WithBatchReferences(ctx)
 	for _, r := range req.Requests {
 		switch r.Type {
 		case string(model.Create):
 			v := &model.CreateRequest{Document: r.Document, Operation: r.Operation}
-			if err := schemaHelpers.ValidateCreateOperation(ctx, dbAlias, dbType, r.Col, m.schemaDoc, v); err != nil {
-				return err
+			if err := schemaHelpers.ValidateCreateOperation(batchCtx, dbAlias, dbType, r.Col, m.schemaDoc, v); err != nil {
+				return nil, err
 			}
 			r.Document = v.Document
 			r.Operation = v.Operation
+		case string(model.Read):
+			if r.Options == nil {
+				r.Options = new(model.ReadOptions)
+			}
+			if err := schemaHelpers.AdjustWhereClause(batchCtx, dbAlias, model.DBType(dbType), r.Col, m.schemaDoc, r.Find); err != nil {
+				return nil, err
+			}
 		case string(model.Update):
-			if err := schemaHelpers.ValidateUpdateOperation(ctx, dbAlias, dbType, r.Col, r.Operation, r.Update, r.Find, m.schemaDoc); err != nil {
-				return err
+			if err := schemaHelpers.ValidateUpdateOperation(batchCtx, dbAlias, dbType, r.Col, r.Operation, r.Update, r.Find, m.schemaDoc); err != nil {
+				return nil, err
 			}
 		}
 	}
@@ -375,28 +436,38 @@ func (m *Module) Batch(ctx context.Context, dbAlias string, req *model.BatchRequ
 	if hookResponse.CheckResponse() {
 		// Check if an error occurred
 		if err := hookResponse.Error(); err != nil {
-			return err
+			return nil, err
 		}
 
 		// Gracefully return
-		return nil
+		return nil, nil
 	}
 
 	if err := crud.IsClientSafe(ctx); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Perform the batch operation
-	counts, err := crud.Batch(ctx, req)
+	counts, results, err := crud.Batch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 
-	// Invoke the metric hook if the operation was successful
-	if err == nil {
-		for i, r := range req.Requests {
-			m.metricHook(m.project, dbAlias, r.Col, counts[i], model.OperationType(r.Type))
+	// Invoke the metric hook & process the results of the read steps
+	for i, r := range req.Requests {
+		if r.Type == model.BatchSavepoint {
+			continue
+		}
+		m.metricHook(m.project, dbAlias, r.Col, counts[i], model.OperationType(r.Type))
+
+		if r.Type == string(model.Read) {
+			if err := schemaHelpers.CrudPostProcess(ctx, dbAlias, dbType, r.Col, m.schemaDoc, results[i]); err != nil {
+				return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("error executing batch request in crud module unable to perform schema post process for un marshalling json for project (%s) col (%s)", m.project, r.Col), err, nil)
+			}
 		}
 	}
 
-	return err
+	return results, nil
 }
 
 // DescribeTable performs a db operation for describing a table
@@ -433,6 +504,23 @@ func (m *Module) RawBatch(ctx context.Context, dbAlias string, batchedQueries []
 	return crud.RawBatch(ctx, batchedQueries)
 }
 
+// CreateTextIndex creates the text index used by the $search operator on the provided fields of a mongo collection
+func (m *Module) CreateTextIndex(ctx context.Context, dbAlias, col string, fields []string) error {
+	m.RLock()
+	defer m.RUnlock()
+
+	crud, err := m.getCrudBlock(dbAlias)
+	if err != nil {
+		return err
+	}
+
+	if err := crud.IsClientSafe(ctx); err != nil {
+		return err
+	}
+
+	return crud.CreateTextIndex(ctx, col, fields)
+}
+
 // GetCollections returns collection / tables name of specified database
 func (m *Module) GetCollections(ctx context.Context, dbAlias string) ([]utils.DatabaseCollections, error) {
 	m.RLock()
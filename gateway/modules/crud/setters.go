@@ -105,6 +105,7 @@ func (m *Module) SetConfig(project string, crud config.DatabaseConfigs) error {
 		m.databaseConfigs[blockKey] = v
 		m.blocks[blockKey] = c
 		c.SetQueryFetchLimit(v.Limit)
+		c.SetProjectAESKey(m.aesKey)
 	}
 
 	return nil
@@ -162,14 +163,16 @@ func (m *Module) SetHooks(metricHook model.MetricCrudHook) {
 
 // SetProjectAESKey set aes config for sql databases
 func (m *Module) SetProjectAESKey(aesKey string) error {
-	m.RLock()
-	defer m.RUnlock()
+	m.Lock()
+	defer m.Unlock()
 
 	decodedAESKey, err := base64.StdEncoding.DecodeString(aesKey)
 	if err != nil {
 		return err
 	}
 
+	m.aesKey = decodedAESKey
+
 	for _, block := range m.blocks {
 		block.SetProjectAESKey(decodedAESKey)
 	}
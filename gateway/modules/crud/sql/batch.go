@@ -2,54 +2,157 @@ package sql
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
-// Batch performs the provided operations in a single Batch
-func (s *SQL) Batch(ctx context.Context, req *model.BatchRequest) ([]int64, error) {
+// Batch performs the provided operations in a single transaction. The result of each step is returned
+// and made available to the later steps which reference it by its id
+func (s *SQL) Batch(ctx context.Context, req *model.BatchRequest) ([]int64, []interface{}, error) {
 
-	// Create an array to hold the counts
+	// Create an array to hold the counts and results
 	counts := make([]int64, len(req.Requests))
+	results := make([]interface{}, len(req.Requests))
 
 	// Create a transaction object
 	tx, err := s.getClient().BeginTxx(ctx, nil) // TODO - Write *sqlx.TxOption instead of nil
 	if err != nil {
-		return counts, err
+		return counts, results, err
 	}
+	// Rolling back is a no-op once the transaction is committed
+	defer func() { _ = tx.Rollback() }()
 
-	for i, req := range req.Requests {
-		switch req.Type {
-		case string(model.Create):
-			sqlQuery, args, err := s.generateCreateQuery(req.Col, &model.CreateRequest{Document: req.Document, Operation: req.Operation})
-			if err != nil {
-				return counts, err
+	stepResults := map[string]interface{}{}
+	for i, r := range req.Requests {
+		if r.Type == model.BatchSavepoint {
+			if _, err := tx.ExecContext(ctx, s.generateSavepointQuery(r.Savepoint)); err != nil {
+				return counts, results, err
 			}
-			res, err := doExecContext(ctx, sqlQuery, args, tx)
-			if err != nil {
-				return counts, err
+			continue
+		}
+
+		step, err := utils.ResolveBatchReferences(ctx, r, stepResults)
+		if err == nil && step.Authorise != nil {
+			// A step denied by the security rules fails the entire batch even if it has a savepoint to roll back to
+			if err := step.Authorise(ctx, step); err != nil {
+				return counts, results, err
 			}
-			counts[i], _ = res.RowsAffected()
+		}
 
-		case string(model.Delete):
-			sqlQuery, args, err := s.generateDeleteQuery(ctx, &model.DeleteRequest{Find: req.Find, Operation: req.Operation}, req.Col)
-			if err != nil {
-				return counts, err
+		var count int64
+		var result interface{}
+		if err == nil {
+			count, result, err = s.batchStep(ctx, tx, step)
+		}
+		if err != nil {
+			if r.RollbackTo == "" {
+				return counts, results, err
 			}
-			res, err := doExecContext(ctx, sqlQuery, args, tx)
-			if err != nil {
-				return counts, err
+
+			// Undo everything done after the savepoint and carry on with the rest of the batch
+			if _, rollbackErr := tx.ExecContext(ctx, s.generateRollbackToSavepointQuery(r.RollbackTo)); rollbackErr != nil {
+				return counts, results, rollbackErr
 			}
-			counts[i], _ = res.RowsAffected()
+			count, result = 0, map[string]interface{}{"error": err.Error(), "rolledBackTo": r.RollbackTo}
+		}
+
+		counts[i], results[i] = count, result
+		if r.ID != "" {
+			stepResults[r.ID] = result
+		}
+	}
+	return counts, results, tx.Commit() // commit the Batch
+}
 
-		case string(model.Update):
-			n, err := s.update(ctx, req.Col, &model.UpdateRequest{Find: req.Find, Operation: req.Operation, Update: req.Update}, tx)
+func (s *SQL) batchStep(ctx context.Context, tx *sqlx.Tx, req *model.AllRequest) (int64, interface{}, error) {
+	switch req.Type {
+	case string(model.Create):
+		sqlQuery, args, err := s.generateCreateQuery(req.Col, &model.CreateRequest{Document: req.Document, Operation: req.Operation})
+		if err != nil {
+			return 0, nil, err
+		}
+
+		// Postgres doesn't support LastInsertId. We return the inserted rows instead so that generated values can be referenced
+		if s.GetDBType() == model.Postgres {
+			count, docs, _, _, err := s.readExec(ctx, req.Col, sqlQuery+" RETURNING *", args, tx, &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{}})
 			if err != nil {
-				return counts, err
+				return 0, nil, err
 			}
-			counts[i] = n
+			return count, map[string]interface{}{"count": count, "docs": docs}, nil
+		}
+
+		res, err := doExecContext(ctx, sqlQuery, args, tx)
+		if err != nil {
+			return 0, nil, err
+		}
+		count, _ := res.RowsAffected()
 
+		docs := req.Document
+		if req.Operation == utils.One {
+			docs = []interface{}{req.Document}
 		}
+		result := map[string]interface{}{"count": count, "docs": docs}
+		switch s.GetDBType() {
+		case model.MySQL, model.SQLite:
+			if id, err := res.LastInsertId(); err == nil {
+				result["id"] = id
+			}
+		}
+		return count, result, nil
+
+	case string(model.Read):
+		count, result, _, _, err := s.read(ctx, req.Col, &model.ReadRequest{Find: req.Find, Operation: req.Operation, Options: req.Options, MatchWhere: req.MatchWhere, PostProcess: req.PostProcess}, tx)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		// The later steps may only reference the fields the security rules allow to be read
+		if req.ProcessResult != nil {
+			if err := req.ProcessResult(ctx, req, result); err != nil {
+				return 0, nil, err
+			}
+		}
+		return count, result, nil
+
+	case string(model.Delete):
+		sqlQuery, args, err := s.generateDeleteQuery(ctx, &model.DeleteRequest{Find: req.Find, Operation: req.Operation}, req.Col)
+		if err != nil {
+			return 0, nil, err
+		}
+		res, err := doExecContext(ctx, sqlQuery, args, tx)
+		if err != nil {
+			return 0, nil, err
+		}
+		count, _ := res.RowsAffected()
+		return count, map[string]interface{}{"count": count}, nil
+
+	case string(model.Update):
+		count, err := s.update(ctx, req.Col, &model.UpdateRequest{Find: req.Find, Operation: req.Operation, Update: req.Update}, tx)
+		if err != nil {
+			return 0, nil, err
+		}
+		return count, map[string]interface{}{"count": count}, nil
+
+	default:
+		return 0, nil, fmt.Errorf("invalid batch request type (%s) provided", req.Type)
+	}
+}
+
+// The savepoint names are validated by the crud module before the batch reaches the driver
+func (s *SQL) generateSavepointQuery(name string) string {
+	if s.GetDBType() == model.SQLServer {
+		return "SAVE TRANSACTION " + name
+	}
+	return "SAVEPOINT " + name
+}
+
+func (s *SQL) generateRollbackToSavepointQuery(name string) string {
+	if s.GetDBType() == model.SQLServer {
+		return "ROLLBACK TRANSACTION " + name
 	}
-	return counts, tx.Commit() // commit the Batch
+	return "ROLLBACK TO SAVEPOINT " + name
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/doug-martin/goqu/v8"
 	"github.com/spaceuptech/helpers"
 
+	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
@@ -16,6 +17,11 @@ func (s *SQL) GetCollections(ctx context.Context) ([]utils.DatabaseCollections,
 	dialect := goqu.Dialect(s.dbType)
 	query := dialect.From("information_schema.tables").Prepared(true).Select("table_name").Where(goqu.Ex{"table_schema": s.name})
 
+	// Sqlite doesn't have an information schema. The tables are listed in the sqlite_master table instead
+	if s.dbType == string(model.SQLite) {
+		query = dialect.From("sqlite_master").Prepared(true).Select("name").Where(goqu.Ex{"type": "table"}, goqu.I("name").NotLike("sqlite_%"))
+	}
+
 	sqlString, args, err := query.ToSQL()
 	if err != nil {
 		return nil, err
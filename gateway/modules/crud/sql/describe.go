@@ -3,6 +3,8 @@ package sql
 import (
 	"context"
 	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
 )
@@ -170,6 +172,36 @@ where c.table_name = @p1 and c.table_schema = @p2
 order by c.ordinal_position;
 `
 		args = append(args, col, project)
+	case model.SQLite:
+		// Sqlite doesn't have an information schema. We use the table valued pragma functions to describe the table instead.
+		// The foreign keys of sqlite are unnamed, hence the constraint name is derived the same way the schema module does
+		queryString = `
+select ''                                                                                        AS "TABLE_SCHEMA",
+       m.name                                                                                    AS "TABLE_NAME",
+
+       p.name                                                                                    AS "COLUMN_NAME",
+       lower(p.type)                                                                             AS "DATA_TYPE",
+       case when p."notnull" = 1 or p.pk > 0 then 'NO' else 'YES' end                            AS "IS_NULLABLE",
+       p.cid + 1                                                                                 AS "ORDINAL_POSITION",
+       trim(coalesce(p.dflt_value, ''), char(39))                                                AS "DEFAULT",
+       case when p.pk > 0 and upper(m.sql) like '%AUTOINCREMENT%' then 'true' else 'false' end   AS "AUTO_INCREMENT",
+       0                                                                                         AS "CHARACTER_MAXIMUM_LENGTH",
+       0                                                                                         AS "NUMERIC_PRECISION",
+       0                                                                                         AS "NUMERIC_SCALE",
+       0                                                                                         AS "DATETIME_PRECISION",
+
+       case when f.id is null then '' else 'c_' || m.name || '_' || p.name end                   AS "CONSTRAINT_NAME",
+       coalesce(f.on_delete, '')                                                                 AS "DELETE_RULE",
+       ''                                                                                        AS "REFERENCED_TABLE_SCHEMA",
+       coalesce(f."table", '')                                                                   AS "REFERENCED_TABLE_NAME",
+       coalesce(f."to", '')                                                                      AS "REFERENCED_COLUMN_NAME"
+from sqlite_master m
+         join pragma_table_info(m.name) p
+         left join pragma_foreign_key_list(m.name) f on f."from" = p.name
+where m.type = 'table' and m.name = ?
+order by p.cid;
+`
+		args = append(args, col)
 	}
 	rows, err := s.getClient().QueryxContext(ctx, queryString, args...)
 	if err != nil {
@@ -187,6 +219,10 @@ order by c.ordinal_position;
 			return nil, err
 		}
 
+		if model.DBType(s.dbType) == model.SQLite {
+			parseSQLiteDataType(fieldType)
+		}
+
 		result = append(result, *fieldType)
 	}
 	if count == 0 {
@@ -197,6 +233,7 @@ order by c.ordinal_position;
 
 func (s *SQL) getIndexDetails(ctx context.Context, project, col string) ([]model.IndexType, error) {
 	queryString := ""
+	args := []interface{}{project, col}
 	switch model.DBType(s.dbType) {
 
 	case model.MySQL:
@@ -208,7 +245,8 @@ select b.table_schema AS 'TABLE_SCHEMA',
        b.seq_in_index AS 'SEQ_IN_INDEX',
 	   case when b.collation = "A" then "asc" else "desc" end as SORT,
        case when b.non_unique=0 then true else false end 'IS_UNIQUE',
-       case when upper(b.index_name)='PRIMARY' then 1 else 0 end 'IS_PRIMARY'
+       case when upper(b.index_name)='PRIMARY' then 1 else 0 end 'IS_PRIMARY',
+       case when b.index_type='FULLTEXT' then true else false end 'IS_FULLTEXT'
 from INFORMATION_SCHEMA.STATISTICS  b
 where b.table_schema= ? and b.table_name= ?;`
 
@@ -221,13 +259,33 @@ where b.table_schema= ? and b.table_name= ?;`
     array_position(i.indkey, b.attnum)+1 "SEQ_IN_INDEX",
 	case when i.indoption[array_position(i.indkey, b.attnum)] = 0 then 'asc' else 'desc' END AS "SORT",
     i.indisunique AS "IS_UNIQUE",
-    i.indisprimary "IS_PRIMARY"
+    i.indisprimary "IS_PRIMARY",
+    false AS "IS_FULLTEXT"
 from pg_class a
          left join pg_namespace n on n.oid = a.relnamespace
          left join pg_index i on a.oid = i.indexrelid and a.relkind='i' and i.indisvalid = true
          left join pg_class t on t.oid = i.indrelid
          left join pg_attribute b on b.attrelid = t.oid and b.attnum = ANY(i.indkey)
-where n.nspname= $1 and t.relname= $2;`
+where n.nspname= $1 and t.relname= $2 and b.attname is not null
+union all
+select
+    n.nspname,
+    t.relname,
+    substring(pg_get_indexdef(i.indexrelid, k, true) from '^to_tsvector\(''\w+''::regconfig, "?(\w+)"?\)$'),
+    a.relname,
+    k,
+    'asc',
+    false,
+    false,
+    true
+from pg_class a
+         join pg_namespace n on n.oid = a.relnamespace
+         join pg_am am on am.oid = a.relam and am.amname = 'gin'
+         join pg_index i on a.oid = i.indexrelid and i.indisvalid = true
+         join pg_class t on t.oid = i.indrelid
+         cross join generate_series(1, i.indnatts) k
+where n.nspname= $1 and t.relname= $2 and i.indkey[k-1] = 0
+  and pg_get_indexdef(i.indexrelid, k, true) like 'to_tsvector(%';`
 	case model.SQLServer:
 		queryString = `
 select
@@ -238,7 +296,8 @@ select
     d.index_key AS 'SEQ_IN_INDEX',
     lower(d.index_sort_order) AS 'SORT',
     case when i.is_unique = 1 then 'true' else 'false' end AS 'IS_UNIQUE',
-    case when i.is_primary_key = 1 then 'true' else 'false' end AS 'IS_PRIMARY'
+    case when i.is_primary_key = 1 then 'true' else 'false' end AS 'IS_PRIMARY',
+    'false' AS 'IS_FULLTEXT'
 from sys.objects t
          inner join sys.indexes i
                     on t.object_id = i.object_id
@@ -254,8 +313,31 @@ where t.is_ms_shipped <> 1
   and schema_name(t.schema_id) = @p1
   and t.[name] = @p2
 order by i.index_id;`
+	case model.SQLite:
+		// An integer primary key is an alias for the row id in sqlite and doesn't have an index of its own.
+		// Hence the primary key is read from the table info instead of the index list
+		queryString = `
+select ''                                                  AS "TABLE_SCHEMA",
+       m.name                                              AS "TABLE_NAME",
+       x.name                                              AS "COLUMN_NAME",
+       l.name                                              AS "INDEX_NAME",
+       x.seqno + 1                                         AS "SEQ_IN_INDEX",
+       case when x."desc" = 1 then 'desc' else 'asc' end   AS "SORT",
+       l."unique"                                          AS "IS_UNIQUE",
+       0                                                   AS "IS_PRIMARY",
+       0                                                   AS "IS_FULLTEXT"
+from sqlite_master m
+         join pragma_index_list(m.name) l
+         join pragma_index_xinfo(l.name) x on x.key = 1
+where m.type = 'table' and m.name = ? and l.origin <> 'pk'
+union all
+select '', m.name, p.name, 'PRIMARY', p.pk, 'asc', 1, 1, 0
+from sqlite_master m
+         join pragma_table_info(m.name) p on p.pk > 0
+where m.type = 'table' and m.name = ?;`
+		args = []interface{}{col, col}
 	}
-	rows, err := s.getClient().QueryxContext(ctx, queryString, []interface{}{project, col}...)
+	rows, err := s.getClient().QueryxContext(ctx, queryString, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -273,3 +355,24 @@ order by i.index_id;`
 	}
 	return result, nil
 }
+
+// parseSQLiteDataType extracts the size, precision & scale of a column from its declared type,
+// since sqlite doesn't store them separately like the other databases
+func parseSQLiteDataType(field *model.InspectorFieldType) {
+	start, end := strings.Index(field.FieldType, "("), strings.Index(field.FieldType, ")")
+	if start == -1 || end < start {
+		return
+	}
+
+	arr := strings.Split(field.FieldType[start+1:end], ",")
+	first, _ := strconv.Atoi(strings.TrimSpace(arr[0]))
+	switch strings.TrimSpace(field.FieldType[:start]) {
+	case "varchar", "char":
+		field.VarcharSize = first
+	case "decimal", "numeric":
+		field.NumericPrecision = first
+		if len(arr) > 1 {
+			field.NumericScale, _ = strconv.Atoi(strings.TrimSpace(arr[1]))
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/doug-martin/goqu/v8"
+	"github.com/doug-martin/goqu/v8/exp"
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
@@ -55,10 +56,20 @@ func (s *SQL) generator(ctx context.Context, find map[string]interface{}, isJoin
 					switch s.dbType {
 					case "postgres":
 						array = append(array, goqu.L(fmt.Sprintf("(%s ~ ?)", k), v2))
-					case "mysql":
+					case "mysql", "sqlite":
 						array = append(array, goqu.L(fmt.Sprintf("(%s REGEXP ?)", k), v2))
 					}
 
+				case "$search":
+					switch s.dbType {
+					case string(model.Postgres):
+						array = append(array, goqu.L(fmt.Sprintf("(to_tsvector('%s', %s) @@ plainto_tsquery('%s', ?))", model.FullTextSearchLanguage, k, model.FullTextSearchLanguage), v2))
+					case string(model.MySQL):
+						array = append(array, goqu.L(fmt.Sprintf("(MATCH (%s) AGAINST (? IN NATURAL LANGUAGE MODE))", k), v2))
+					default:
+						array = append(array, generateSearchFallback(k, v2))
+					}
+
 				case "$like":
 					array = append(array, goqu.I(k).Like(v2))
 				case "$eq":
@@ -109,6 +120,36 @@ func (s *SQL) generator(ctx context.Context, find map[string]interface{}, isJoin
 	return goqu.And(array...)
 }
 
+// generateSearchFallback matches the rows containing every word of the search text for the databases which do not
+// support full text search, like plainto_tsquery does for postgres. Texts without any words do not match any row
+func generateSearchFallback(field string, text interface{}) goqu.Expression {
+	textString, _ := text.(string)
+	words := utils.TokenizeText(textString)
+	if len(words) == 0 {
+		return goqu.L("(1 = 0)")
+	}
+
+	likes := make([]goqu.Expression, len(words))
+	for i, word := range words {
+		likes[i] = goqu.L(fmt.Sprintf("(LOWER(%s) LIKE ?)", field), "%"+word+"%")
+	}
+	return goqu.And(likes...)
+}
+
+// generateSearchRanking orders the rows by their relevance to the full text searches of the where clause
+func (s *SQL) generateSearchRanking(find map[string]interface{}) []exp.OrderedExpression {
+	orderBys := make([]exp.OrderedExpression, 0)
+	for _, clause := range utils.GetSearchClauses(find) {
+		switch s.dbType {
+		case string(model.Postgres):
+			orderBys = append(orderBys, goqu.L(fmt.Sprintf("ts_rank(to_tsvector('%s', %s), plainto_tsquery('%s', ?))", model.FullTextSearchLanguage, clause.Field, model.FullTextSearchLanguage), clause.Text).Desc())
+		case string(model.MySQL):
+			orderBys = append(orderBys, goqu.L(fmt.Sprintf("MATCH (%s) AGAINST (? IN NATURAL LANGUAGE MODE)", clause.Field), clause.Text).Desc())
+		}
+	}
+	return orderBys
+}
+
 func (s *SQL) generateWhereClause(ctx context.Context, q *goqu.SelectDataset, find map[string]interface{}, matchWhere []map[string]interface{}) (query *goqu.SelectDataset) {
 	query = q
 
@@ -250,6 +291,38 @@ func mysqlTypeCheck(ctx context.Context, dbType model.DBType, types []*sql.Colum
 	}
 }
 
+// sqliteTypeCheck converts the values returned by sqlite into the types expected by space cloud. Sqlite stores
+// everything in a handful of storage classes, hence we rely on the type the column was declared with
+func sqliteTypeCheck(types []*sql.ColumnType, mapping map[string]interface{}) {
+	for _, colType := range types {
+		typeName := strings.ToUpper(strings.Split(colType.DatabaseTypeName(), "(")[0])
+		switch v := mapping[colType.Name()].(type) {
+		case string:
+			if typeName == "JSON" {
+				var val interface{}
+				if err := json.Unmarshal([]byte(v), &val); err == nil {
+					mapping[colType.Name()] = val
+				}
+			}
+		case []byte:
+			if typeName == "JSON" {
+				var val interface{}
+				if err := json.Unmarshal(v, &val); err == nil {
+					mapping[colType.Name()] = val
+				}
+				continue
+			}
+			mapping[colType.Name()] = string(v)
+		case time.Time:
+			if typeName == "DATE" {
+				mapping[colType.Name()] = v.Format("2006-01-02")
+				continue
+			}
+			mapping[colType.Name()] = v.UTC().Format(time.RFC3339Nano)
+		}
+	}
+}
+
 func (s *SQL) processJoins(ctx context.Context, query *goqu.SelectDataset, join []*model.JoinOption, sel map[string]int32, isAggregate bool) (*goqu.SelectDataset, error) {
 	for _, j := range join {
 		on := s.generator(ctx, j.On, true)
@@ -11,6 +11,12 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
+// CreateTextIndex creates the text index of a collection. Fulltext indexes of sql databases are created with queries
+// generated by the schema module instead
+func (s *SQL) CreateTextIndex(ctx context.Context, col string, fields []string) error {
+	return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Text indexes of sql databases are created with raw batch queries", nil, nil)
+}
+
 // RawBatch performs a batch operation for schema creation
 // NOTE: not to be exposed externally
 func (s *SQL) RawBatch(ctx context.Context, queries []string) error {
@@ -71,6 +77,9 @@ func (s *SQL) CreateDatabaseIfNotExist(ctx context.Context, name string) error {
 		sql = fmt.Sprintf("create database if not exists `%s`", name)
 	case model.Postgres:
 		sql = "create schema if not exists " + name
+	case model.SQLite:
+		// Sqlite has a single database per file. There is nothing to create
+		return nil
 	case model.SQLServer:
 		sql = `IF (NOT EXISTS (SELECT * FROM sys.schemas WHERE name = '` + name + `')) 
 					BEGIN
@@ -40,8 +40,18 @@ func (s *SQL) generateReadQuery(ctx context.Context, col string, req *model.Read
 	dialect := goqu.Dialect(dbType)
 	query := dialect.From(s.getColName(col)).Prepared(true)
 
+	// Rows after the cursor are selected using the sort fields instead of an offset
+	matchWhere := req.MatchWhere
+	if req.Options.Cursor != "" {
+		cursorWhere, err := utils.GenerateCursorWhereClause(s.aesKey, req.Options.Sort, req.Options.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		matchWhere = append(append(make([]map[string]interface{}, 0, len(matchWhere)+1), matchWhere...), cursorWhere)
+	}
+
 	// Get the where clause from query object
-	query = s.generateWhereClause(ctx, query, req.Find, req.MatchWhere)
+	query = s.generateWhereClause(ctx, query, req.Find, matchWhere)
 
 	selArray := make([]interface{}, 0)
 	if req.Options != nil {
@@ -79,6 +89,11 @@ func (s *SQL) generateReadQuery(ctx context.Context, col string, req *model.Read
 				orderBys[i] = e
 			}
 			query = query.Order(orderBys...)
+		} else if req.Operation == utils.All || req.Operation == utils.One {
+			// Rank the rows by their relevance when performing a full text search without an explicit sort
+			if orderBys := s.generateSearchRanking(req.Find); len(orderBys) > 0 {
+				query = query.Order(orderBys...)
+			}
 		}
 
 		q, err := s.processJoins(ctx, query, req.Options.Join, req.Options.Select, len(req.Aggregate) > 0)
@@ -238,7 +253,7 @@ func (s *SQL) readExec(ctx context.Context, col, sqlString string, args []interf
 	var rowTypes []*sql.ColumnType
 
 	switch s.GetDBType() {
-	case model.MySQL, model.Postgres, model.SQLServer:
+	case model.MySQL, model.Postgres, model.SQLServer, model.SQLite:
 		rowTypes, _ = rows.ColumnTypes()
 	}
 
@@ -257,6 +272,8 @@ func (s *SQL) readExec(ctx context.Context, col, sqlString string, args []interf
 		switch s.GetDBType() {
 		case model.MySQL, model.Postgres, model.SQLServer:
 			mysqlTypeCheck(ctx, s.GetDBType(), rowTypes, mapping)
+		case model.SQLite:
+			sqliteTypeCheck(rowTypes, mapping)
 		}
 
 		for _, v := range mapping {
@@ -284,6 +301,8 @@ func (s *SQL) readExec(ctx context.Context, col, sqlString string, args []interf
 			switch s.GetDBType() {
 			case model.MySQL, model.Postgres, model.SQLServer:
 				mysqlTypeCheck(ctx, s.GetDBType(), rowTypes, row)
+			case model.SQLite:
+				sqliteTypeCheck(rowTypes, row)
 			}
 
 			if req.Options == nil || req.Options.ReturnType == "table" || len(req.Options.Join) == 0 {
@@ -118,6 +118,25 @@ func TestSQL_generateReadQuery(t *testing.T) {
 			want1:   []interface{}{"ss"},
 			wantErr: false,
 		},
+		{
+			name:    "search ranked by relevance",
+			fields:  fields{dbType: "mysql"},
+			args:    args{project: "test", col: "table", req: &model.ReadRequest{Find: map[string]interface{}{"title": map[string]interface{}{"$search": "space cloud"}}, Operation: "all"}},
+			want:    []string{"SELECT * FROM table WHERE (MATCH (title) AGAINST (? IN NATURAL LANGUAGE MODE)) ORDER BY MATCH (title) AGAINST (? IN NATURAL LANGUAGE MODE) DESC"},
+			want1:   []interface{}{"space cloud", "space cloud"},
+			wantErr: false,
+		},
+		{
+			name:   "search with an explicit sort",
+			fields: fields{dbType: "mysql"},
+			args: args{project: "test", col: "table", req: &model.ReadRequest{
+				Find:      map[string]interface{}{"title": map[string]interface{}{"$search": "space cloud"}},
+				Options:   &model.ReadOptions{Sort: []string{"-id"}},
+				Operation: "all"}},
+			want:    []string{"SELECT * FROM table WHERE (MATCH (title) AGAINST (? IN NATURAL LANGUAGE MODE)) ORDER BY id DESC"},
+			want1:   []interface{}{"space cloud"},
+			wantErr: false,
+		},
 		{
 			name:   "Column1 = ? and select Column1 from one doc",
 			fields: fields{dbType: "mysql"},
@@ -330,6 +349,22 @@ func TestSQL_generateReadQuery(t *testing.T) {
 			want1:   []interface{}{"ss"},
 			wantErr: false,
 		},
+		{
+			name:    "search ranked by relevance",
+			fields:  fields{dbType: "postgres"},
+			args:    args{project: "test", col: "table", req: &model.ReadRequest{Find: map[string]interface{}{"title": map[string]interface{}{"$search": "space cloud"}}, Operation: "all"}},
+			want:    []string{"SELECT * FROM test.table WHERE (to_tsvector('english', title) @@ plainto_tsquery('english', $1)) ORDER BY ts_rank(to_tsvector('english', title), plainto_tsquery('english', $2)) DESC"},
+			want1:   []interface{}{"space cloud", "space cloud"},
+			wantErr: false,
+		},
+		{
+			name:    "count with search is not ranked",
+			fields:  fields{dbType: "postgres"},
+			args:    args{project: "test", col: "table", req: &model.ReadRequest{Find: map[string]interface{}{"title": map[string]interface{}{"$search": "space cloud"}}, Operation: "count"}},
+			want:    []string{"SELECT COUNT(*) FROM test.table WHERE (to_tsvector('english', title) @@ plainto_tsquery('english', $1))"},
+			want1:   []interface{}{"space cloud"},
+			wantErr: false,
+		},
 		{
 			name:   "Column1 = ? and select Column1 from one doc",
 			fields: fields{dbType: "postgres"},
@@ -434,6 +469,22 @@ func TestSQL_generateReadQuery(t *testing.T) {
 			want1:   []interface{}{int64(1)},
 			wantErr: false,
 		},
+		{
+			name:    "search matches every word",
+			fields:  fields{dbType: "sqlserver"},
+			args:    args{project: "test", col: "table", req: &model.ReadRequest{Find: map[string]interface{}{"title": map[string]interface{}{"$search": "Space cloud!"}}, Operation: "all"}},
+			want:    []string{"SELECT * FROM test.table WHERE ((LOWER(title) LIKE @p1) AND (LOWER(title) LIKE @p2))"},
+			want1:   []interface{}{"%space%", "%cloud%"},
+			wantErr: false,
+		},
+		{
+			name:    "search without words",
+			fields:  fields{dbType: "sqlserver"},
+			args:    args{project: "test", col: "table", req: &model.ReadRequest{Find: map[string]interface{}{"title": map[string]interface{}{"$search": "?!"}}, Operation: "all"}},
+			want:    []string{"SELECT * FROM test.table WHERE (1 = 0)"},
+			want1:   []interface{}{},
+			wantErr: false,
+		},
 		{
 			name:    "String1 != ?",
 			fields:  fields{dbType: "sqlserver"},
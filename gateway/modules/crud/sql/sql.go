@@ -50,6 +50,9 @@ func Init(dbType model.DBType, enabled bool, connection string, dbName string, d
 	case model.SQLServer:
 		s.dbType = "sqlserver"
 
+	case model.SQLite:
+		s.dbType = "sqlite"
+
 	default:
 		err = utils.ErrUnsupportedDatabase
 		return
@@ -113,6 +116,8 @@ func (s *SQL) GetDBType() model.DBType {
 		return model.MySQL
 	case "sqlserver":
 		return model.SQLServer
+	case "sqlite":
+		return model.SQLite
 	}
 
 	return model.MySQL
@@ -139,7 +144,12 @@ func (s *SQL) connect() error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
 	defer cancel()
 
-	sql, err := sqlx.Open(s.dbType, s.connection)
+	driverName := s.dbType
+	if driverName == string(model.SQLite) {
+		driverName = sqliteDriver
+	}
+
+	sql, err := sqlx.Open(driverName, s.connection)
 	if err != nil {
 		return err
 	}
@@ -149,6 +159,12 @@ func (s *SQL) connect() error {
 	maxConn := s.driverConf.MaxConn
 	if maxConn == 0 {
 		maxConn = 100
+
+		// Sqlite serialises all writes to a database file. Besides, every connection to an in-memory database
+		// gets a database of its own. Hence we use a single connection unless configured otherwise
+		if model.DBType(s.dbType) == model.SQLite {
+			maxConn = 1
+		}
 	}
 
 	maxIdleConn := s.driverConf.MaxIdleConn
@@ -156,8 +172,9 @@ func (s *SQL) connect() error {
 		maxIdleConn = 50
 	}
 
+	// Idle sqlite connections are never closed by default, since closing the last connection to an in-memory database drops it
 	maxIdleTimeout := s.driverConf.MaxIdleTimeout
-	if maxIdleTimeout == 0 {
+	if maxIdleTimeout == 0 && model.DBType(s.dbType) != model.SQLite {
 		maxIdleTimeout = 60 * 5 * 1000
 	}
 
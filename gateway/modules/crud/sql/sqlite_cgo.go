@@ -0,0 +1,29 @@
+//go:build cgo
+// +build cgo
+
+package sql
+
+import (
+	"database/sql"
+	"regexp"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteDriver is the name under which the sqlite driver is registered. We register our own driver
+// instead of using the default one to provide the regexp function and enforce foreign keys on every connection
+const sqliteDriver = "sqlite3_space_cloud"
+
+func init() {
+	sql.Register(sqliteDriver, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			// Foreign key constraints are disabled by default in sqlite
+			if _, err := conn.Exec("PRAGMA foreign_keys = ON", nil); err != nil {
+				return err
+			}
+
+			// The REGEXP operator of sqlite calls a user defined function named regexp
+			return conn.RegisterFunc("regexp", regexp.MatchString, true)
+		},
+	})
+}
@@ -0,0 +1,24 @@
+//go:build !cgo
+// +build !cgo
+
+package sql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+// sqliteDriver is the name under which the sqlite driver is registered. The sqlite driver is a wrapper around the
+// c library, hence gateways built without cgo register a driver which fails to open connections instead
+const sqliteDriver = "sqlite3_space_cloud"
+
+func init() {
+	sql.Register(sqliteDriver, sqliteUnsupportedDriver{})
+}
+
+type sqliteUnsupportedDriver struct{}
+
+func (sqliteUnsupportedDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("sqlite is not supported by this build of space cloud as it has been built without cgo")
+}
@@ -0,0 +1,277 @@
+//go:build cgo
+// +build cgo
+
+package sql
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func initSQLite(t *testing.T) *SQL {
+	s, err := Init(model.SQLite, true, filepath.Join(t.TempDir(), "test.db"), "test", config.DriverConfig{})
+	if err != nil {
+		t.Fatalf("Unable to initialise sqlite - %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	queries := []string{
+		"CREATE TABLE customers (id varchar(50) NOT NULL , name text NOT NULL ,age integer ,is_prime boolean ,birth_date datetime ,address json ,PRIMARY KEY (id));",
+		"CREATE TABLE orders (id integer NOT NULL PRIMARY KEY AUTOINCREMENT, customer_id varchar(50) REFERENCES customers (id) ON DELETE CASCADE ,amount decimal(10,2));",
+		"CREATE UNIQUE INDEX index__customers__name ON customers (name asc)",
+	}
+	if err := s.RawBatch(context.Background(), queries); err != nil {
+		t.Fatalf("Unable to create tables - %v", err)
+	}
+	return s
+}
+
+func TestSQLite_CRUD(t *testing.T) {
+	ctx := context.Background()
+	s := initSQLite(t)
+
+	birthDate := time.Date(1990, 1, 2, 3, 4, 5, 0, time.UTC)
+	docs := []interface{}{
+		map[string]interface{}{"id": "1", "name": "Alice", "age": 30, "is_prime": true, "birth_date": birthDate, "address": `{"city":"Mumbai"}`},
+		map[string]interface{}{"id": "2", "name": "Bob", "age": 25, "is_prime": false, "birth_date": birthDate, "address": `{"city":"Pune"}`},
+	}
+	if count, err := s.Create(ctx, "customers", &model.CreateRequest{Operation: utils.All, Document: docs}); err != nil || count != 2 {
+		t.Fatalf("Create() count = %v, error = %v", count, err)
+	}
+
+	_, result, _, _, err := s.Read(ctx, "customers", &model.ReadRequest{Operation: utils.One, Find: map[string]interface{}{"name": map[string]interface{}{"$regex": "^Ali"}}, Options: &model.ReadOptions{}})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := map[string]interface{}{"id": "1", "name": "Alice", "age": int64(30), "is_prime": true, "birth_date": birthDate.Format(time.RFC3339Nano), "address": map[string]interface{}{"city": "Mumbai"}}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Read() got = %v, want %v", result, want)
+	}
+
+	if _, err := s.Update(ctx, "customers", &model.UpdateRequest{Operation: utils.All, Find: map[string]interface{}{"id": "2"}, Update: map[string]interface{}{"$inc": map[string]interface{}{"age": 5}}}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, err := s.Update(ctx, "customers", &model.UpdateRequest{Operation: utils.Upsert, Find: map[string]interface{}{"id": "3"}, Update: map[string]interface{}{"$set": map[string]interface{}{"name": "Carol", "age": 40}}}); err != nil {
+		t.Fatalf("Update() upsert error = %v", err)
+	}
+
+	count, _, _, _, err := s.Read(ctx, "customers", &model.ReadRequest{Operation: utils.Count, Find: map[string]interface{}{"age": map[string]interface{}{"$gte": 30}}, Options: &model.ReadOptions{}})
+	if err != nil || count != 3 {
+		t.Errorf("Read() count = %v, error = %v", count, err)
+	}
+
+	counts, _, err := s.Batch(ctx, &model.BatchRequest{Requests: []*model.AllRequest{
+		{Type: string(model.Create), Col: "orders", Operation: utils.One, Document: map[string]interface{}{"customer_id": "1", "amount": 10.5}},
+		{Type: string(model.Create), Col: "orders", Operation: utils.One, Document: map[string]interface{}{"customer_id": "2", "amount": 20}},
+	}})
+	if err != nil || !reflect.DeepEqual(counts, []int64{1, 1}) {
+		t.Fatalf("Batch() counts = %v, error = %v", counts, err)
+	}
+
+	// Deleting a customer must cascade to the orders since foreign keys are enforced
+	if _, err := s.Delete(ctx, "customers", &model.DeleteRequest{Operation: utils.All, Find: map[string]interface{}{"id": "1"}}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	_, result, _, err = s.RawQuery(ctx, "SELECT id, customer_id FROM orders", false, nil)
+	if err != nil {
+		t.Fatalf("RawQuery() error = %v", err)
+	}
+	if wantOrders := []interface{}{map[string]interface{}{"id": int64(2), "customer_id": "2"}}; !reflect.DeepEqual(result, wantOrders) {
+		t.Errorf("RawQuery() got = %v, want %v", result, wantOrders)
+	}
+}
+
+func TestSQLite_CursorPagination(t *testing.T) {
+	ctx := context.Background()
+	s := initSQLite(t)
+
+	docs := []interface{}{
+		map[string]interface{}{"id": "1", "name": "Alice", "age": 30},
+		map[string]interface{}{"id": "2", "name": "Bob", "age": 25},
+		map[string]interface{}{"id": "3", "name": "Carol", "age": 30},
+		map[string]interface{}{"id": "4", "name": "Dave", "age": 40},
+	}
+	if _, err := s.Create(ctx, "customers", &model.CreateRequest{Operation: utils.All, Document: docs}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	limit := int64(2)
+	sort := []string{"-age", "id"}
+	var ids []interface{}
+	var cursor string
+	for page := 0; page < 3; page++ {
+		_, result, _, _, err := s.Read(ctx, "customers", &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Select: map[string]int32{"id": 1, "age": 1}, Sort: sort, Limit: &limit, Cursor: cursor}})
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		rows := result.([]interface{})
+		if len(rows) == 0 {
+			break
+		}
+		for _, row := range rows {
+			ids = append(ids, row.(map[string]interface{})["id"])
+		}
+		cursor, _ = utils.EncodeCursor(nil, sort, rows[len(rows)-1])
+	}
+
+	if want := []interface{}{"4", "1", "3", "2"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Read() with cursor got ids = %v, want %v", ids, want)
+	}
+}
+
+func TestSQLite_Batch(t *testing.T) {
+	ctx := context.Background()
+	s := initSQLite(t)
+
+	counts, results, err := s.Batch(ctx, &model.BatchRequest{Requests: []*model.AllRequest{
+		{ID: "customer", Type: string(model.Create), Col: "customers", Operation: utils.One, Document: map[string]interface{}{"id": "1", "name": "Alice", "age": 30}},
+		{ID: "order", Type: string(model.Create), Col: "orders", Operation: utils.One, Document: map[string]interface{}{"customer_id": map[string]interface{}{"$ref": "customer.docs.0.id"}, "amount": 10}},
+		{Type: model.BatchSavepoint, Savepoint: "before_bob"},
+		{Type: string(model.Create), Col: "customers", Operation: utils.One, Document: map[string]interface{}{"id": "2", "name": "Bob"}},
+		// Fails on the unique index on name and rolls back the insert of Bob
+		{Type: string(model.Create), Col: "customers", Operation: utils.One, Document: map[string]interface{}{"id": "3", "name": "Alice"}, RollbackTo: "before_bob"},
+		{ID: "read", Type: string(model.Read), Col: "orders", Operation: utils.One, Find: map[string]interface{}{"id": map[string]interface{}{"$ref": "order.id"}}, Options: &model.ReadOptions{}},
+		{Type: string(model.Update), Col: "customers", Operation: utils.All, Find: map[string]interface{}{"id": map[string]interface{}{"$ref": "read.customer_id"}}, Update: map[string]interface{}{"$set": map[string]interface{}{"age": map[string]interface{}{"$ref": "order.id"}}}},
+	}})
+	if err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	if want := []int64{1, 1, 0, 1, 0, 1, 1}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("Batch() counts = %v, want %v", counts, want)
+	}
+	if result, ok := results[4].(map[string]interface{}); !ok || result["rolledBackTo"] != "before_bob" {
+		t.Errorf("Batch() rolled back step result = %v", results[4])
+	}
+	if want := map[string]interface{}{"id": int64(1), "customer_id": "1", "amount": int64(10)}; !reflect.DeepEqual(results[5], want) {
+		t.Errorf("Batch() read step result = %v, want %v", results[5], want)
+	}
+
+	_, result, _, err := s.RawQuery(ctx, "SELECT id, age FROM customers", false, nil)
+	if err != nil {
+		t.Fatalf("RawQuery() error = %v", err)
+	}
+	if want := []interface{}{map[string]interface{}{"id": "1", "age": int64(1)}}; !reflect.DeepEqual(result, want) {
+		t.Errorf("RawQuery() got = %v, want %v", result, want)
+	}
+
+	// A failing step without a savepoint must roll back the entire batch
+	_, _, err = s.Batch(ctx, &model.BatchRequest{Requests: []*model.AllRequest{
+		{Type: string(model.Create), Col: "customers", Operation: utils.One, Document: map[string]interface{}{"id": "4", "name": "Dave"}},
+		{Type: string(model.Create), Col: "customers", Operation: utils.One, Document: map[string]interface{}{"id": "5", "name": "Dave"}},
+	}})
+	if err == nil {
+		t.Fatalf("Batch() expected an error")
+	}
+	count, _, _, _, err := s.Read(ctx, "customers", &model.ReadRequest{Operation: utils.Count, Find: map[string]interface{}{"name": "Dave"}, Options: &model.ReadOptions{}})
+	if err != nil || count != 0 {
+		t.Errorf("Read() count = %v, error = %v", count, err)
+	}
+}
+
+func TestSQLite_Batch_security(t *testing.T) {
+	ctx := context.Background()
+	s := initSQLite(t)
+
+	if _, err := s.Create(ctx, "customers", &model.CreateRequest{Operation: utils.One, Document: map[string]interface{}{"id": "1", "name": "Alice", "age": 30}}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// The age of the customer is hidden by the read rules, hence it cannot be copied by the later steps
+	var authorised []interface{}
+	_, _, err := s.Batch(ctx, &model.BatchRequest{Requests: []*model.AllRequest{
+		{
+			ID: "read", Type: string(model.Read), Col: "customers", Operation: utils.One, Find: map[string]interface{}{"id": "1"}, Options: &model.ReadOptions{},
+			ProcessResult: func(ctx context.Context, req *model.AllRequest, result interface{}) error {
+				delete(result.(map[string]interface{}), "age")
+				return nil
+			},
+		},
+		{
+			Type: string(model.Create), Col: "customers", Operation: utils.One, Document: map[string]interface{}{"id": "2", "name": "Bob", "age": map[string]interface{}{"$ref": "read.age"}},
+			Authorise: func(ctx context.Context, req *model.AllRequest) error {
+				authorised = append(authorised, req.Document)
+				return nil
+			},
+		},
+	}})
+	if err == nil {
+		t.Errorf("Batch() expected an error for a reference to a removed field")
+	}
+	if len(authorised) != 0 {
+		t.Errorf("Batch() authorised steps = %v, want none", authorised)
+	}
+
+	// The rules of a step are checked with the resolved references. A denied step fails the batch even with a savepoint
+	denied := errors.New("denied")
+	_, _, err = s.Batch(ctx, &model.BatchRequest{Requests: []*model.AllRequest{
+		{ID: "read", Type: string(model.Read), Col: "customers", Operation: utils.One, Find: map[string]interface{}{"id": "1"}, Options: &model.ReadOptions{}},
+		{Type: model.BatchSavepoint, Savepoint: "before_bob"},
+		{
+			Type: string(model.Create), Col: "customers", Operation: utils.One, Document: map[string]interface{}{"id": "2", "name": "Bob", "age": map[string]interface{}{"$ref": "read.age"}}, RollbackTo: "before_bob",
+			Authorise: func(ctx context.Context, req *model.AllRequest) error {
+				authorised = append(authorised, req.Document)
+				return denied
+			},
+		},
+	}})
+	if err != denied {
+		t.Errorf("Batch() error = %v, want %v", err, denied)
+	}
+	if want := []interface{}{map[string]interface{}{"id": "2", "name": "Bob", "age": int64(30)}}; !reflect.DeepEqual(authorised, want) {
+		t.Errorf("Batch() authorised steps = %v, want %v", authorised, want)
+	}
+	count, _, _, _, err := s.Read(ctx, "customers", &model.ReadRequest{Operation: utils.Count, Find: map[string]interface{}{}, Options: &model.ReadOptions{}})
+	if err != nil || count != 1 {
+		t.Errorf("Read() count = %v, error = %v", count, err)
+	}
+}
+
+func TestSQLite_Inspection(t *testing.T) {
+	ctx := context.Background()
+	s := initSQLite(t)
+
+	collections, err := s.GetCollections(ctx)
+	if err != nil {
+		t.Fatalf("GetCollections() error = %v", err)
+	}
+	if want := []utils.DatabaseCollections{{TableName: "customers"}, {TableName: "orders"}}; !reflect.DeepEqual(collections, want) {
+		t.Errorf("GetCollections() got = %v, want %v", collections, want)
+	}
+
+	fields, indexes, err := s.DescribeTable(ctx, "orders")
+	if err != nil {
+		t.Fatalf("DescribeTable() error = %v", err)
+	}
+	wantFields := []model.InspectorFieldType{
+		{TableName: "orders", ColumnName: "id", FieldType: "integer", FieldNull: "NO", OrdinalPosition: "1", AutoIncrement: "true"},
+		{TableName: "orders", ColumnName: "customer_id", FieldType: "varchar(50)", FieldNull: "YES", OrdinalPosition: "2", AutoIncrement: "false", VarcharSize: 50, ConstraintName: "c_orders_customer_id", DeleteRule: "CASCADE", RefTableName: "customers", RefColumnName: "id"},
+		{TableName: "orders", ColumnName: "amount", FieldType: "decimal(10,2)", FieldNull: "YES", OrdinalPosition: "3", AutoIncrement: "false", NumericPrecision: 10, NumericScale: 2},
+	}
+	if !reflect.DeepEqual(fields, wantFields) {
+		t.Errorf("DescribeTable() fields got = %v, want %v", fields, wantFields)
+	}
+	wantIndexes := []model.IndexType{{TableName: "orders", ColumnName: "id", IndexName: "PRIMARY", Order: 1, Sort: "asc", IsUnique: true, IsPrimary: true}}
+	if !reflect.DeepEqual(indexes, wantIndexes) {
+		t.Errorf("DescribeTable() indexes got = %v, want %v", indexes, wantIndexes)
+	}
+
+	_, indexes, err = s.DescribeTable(ctx, "customers")
+	if err != nil {
+		t.Fatalf("DescribeTable() error = %v", err)
+	}
+	wantIndexes = []model.IndexType{
+		{TableName: "customers", ColumnName: "name", IndexName: "index__customers__name", Order: 1, Sort: "asc", IsUnique: true},
+		{TableName: "customers", ColumnName: "id", IndexName: "PRIMARY", Order: 1, Sort: "asc", IsUnique: true, IsPrimary: true},
+	}
+	if !reflect.DeepEqual(indexes, wantIndexes) {
+		t.Errorf("DescribeTable() indexes got = %v, want %v", indexes, wantIndexes)
+	}
+}
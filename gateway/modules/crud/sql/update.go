@@ -193,7 +193,7 @@ func (s *SQL) generateUpdateQuery(ctx context.Context, col string, req *model.Up
 			if err != nil {
 				return "", nil, err
 			}
-			if s.dbType == string(model.MySQL) {
+			if s.dbType == string(model.MySQL) || s.dbType == string(model.SQLite) {
 				sqlString = strings.Replace(sqlString, k+"=?", k+"="+k+"+?", -1)
 			}
 			if dbType == string(model.Postgres) {
@@ -208,7 +208,7 @@ func (s *SQL) generateUpdateQuery(ctx context.Context, col string, req *model.Up
 			if err != nil {
 				return "", nil, err
 			}
-			if dbType == string(model.MySQL) {
+			if dbType == string(model.MySQL) || dbType == string(model.SQLite) {
 				sqlString = strings.Replace(sqlString, k+"=?", k+"="+k+"*?", -1)
 			}
 			if dbType == string(model.Postgres) {
@@ -226,6 +226,10 @@ func (s *SQL) generateUpdateQuery(ctx context.Context, col string, req *model.Up
 			if s.dbType == string(model.MySQL) {
 				sqlString = strings.Replace(sqlString, k+"=?", k+"=GREATEST("+k+","+"?"+")", -1)
 			}
+			if s.dbType == string(model.SQLite) {
+				// Sqlite doesn't have GREATEST. The multi argument MAX function does the same
+				sqlString = strings.Replace(sqlString, k+"=?", k+"=MAX("+k+","+"?"+")", -1)
+			}
 			if dbType == string(model.Postgres) {
 				sqlString = strings.Replace(sqlString, k+"=$", k+"=GREATEST("+k+","+"$"+"", -1)
 			}
@@ -241,6 +245,9 @@ func (s *SQL) generateUpdateQuery(ctx context.Context, col string, req *model.Up
 			if dbType == string(model.MySQL) {
 				sqlString = strings.Replace(sqlString, k+"=?", k+"=LEAST("+k+","+"?"+")", -1)
 			}
+			if dbType == string(model.SQLite) {
+				sqlString = strings.Replace(sqlString, k+"=?", k+"=MIN("+k+","+"?"+")", -1)
+			}
 			if dbType == string(model.Postgres) {
 				sqlString = strings.Replace(sqlString, k+"=$", k+"=LEAST("+k+","+"$", -1)
 			}
@@ -253,7 +260,7 @@ func (s *SQL) generateUpdateQuery(ctx context.Context, col string, req *model.Up
 			if !ok {
 				return "", nil, utils.ErrInvalidParams
 			}
-			if dbType == string(model.MySQL) {
+			if dbType == string(model.MySQL) || dbType == string(model.SQLite) {
 				sqlString = strings.Replace(sqlString, k+"=?", k+"="+val, -1)
 			}
 			if dbType == string(model.Postgres) {
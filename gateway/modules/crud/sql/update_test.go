@@ -1313,6 +1313,77 @@ func TestSQL_generateUpdateQuery(t *testing.T) {
 			want:    "",
 			wantErr: true,
 		},
+		// #######################################################################################
+		// ###################################  SQLite  ##########################################
+		// #######################################################################################
+		{
+			name:   "sqlite: valid $inc",
+			fields: fields{dbType: "sqlite"},
+			args: args{
+				ctx:     context.TODO(),
+				project: "project",
+				col:     "col",
+				op:      "$inc",
+				req: model.UpdateRequest{
+					Update: map[string]interface{}{"$inc": map[string]interface{}{"Int1": 2}},
+					Find:   map[string]interface{}{"id": "1"},
+				},
+			},
+			want:    "UPDATE col SET Int1=Int1+? WHERE (id = ?)",
+			want1:   []interface{}{int64(2), "1"},
+			wantErr: false,
+		},
+		{
+			name:   "sqlite: valid $max",
+			fields: fields{dbType: "sqlite"},
+			args: args{
+				ctx:     context.TODO(),
+				project: "project",
+				col:     "col",
+				op:      "$max",
+				req: model.UpdateRequest{
+					Update: map[string]interface{}{"$max": map[string]interface{}{"Int1": 2}},
+					Find:   map[string]interface{}{"id": "1"},
+				},
+			},
+			want:    "UPDATE col SET Int1=MAX(Int1,?) WHERE (id = ?)",
+			want1:   []interface{}{int64(2), "1"},
+			wantErr: false,
+		},
+		{
+			name:   "sqlite: valid $min",
+			fields: fields{dbType: "sqlite"},
+			args: args{
+				ctx:     context.TODO(),
+				project: "project",
+				col:     "col",
+				op:      "$min",
+				req: model.UpdateRequest{
+					Update: map[string]interface{}{"$min": map[string]interface{}{"Int1": 2}},
+					Find:   map[string]interface{}{"id": "1"},
+				},
+			},
+			want:    "UPDATE col SET Int1=MIN(Int1,?) WHERE (id = ?)",
+			want1:   []interface{}{int64(2), "1"},
+			wantErr: false,
+		},
+		{
+			name:   "sqlite: valid $currentDate",
+			fields: fields{dbType: "sqlite"},
+			args: args{
+				ctx:     context.TODO(),
+				project: "project",
+				col:     "col",
+				op:      "$currentDate",
+				req: model.UpdateRequest{
+					Update: map[string]interface{}{"$currentDate": map[string]interface{}{"Date1": map[string]interface{}{"$type": "timestamp"}}},
+					Find:   map[string]interface{}{"id": "1"},
+				},
+			},
+			want:    "UPDATE col SET Date1=CURRENT_TIMESTAMP WHERE (id = ?)",
+			want1:   []interface{}{"1"},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
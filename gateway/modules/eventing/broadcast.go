@@ -28,6 +28,11 @@ func (m *Module) ProcessTransmittedEvents(eventDocs []*model.EventDocument) {
 			}
 
 			if currentTimestamp.After(timestamp) || currentTimestamp.Equal(timestamp) {
+				// Events of a partition are delivered one after the other
+				if eventDoc.PartitionKey != "" {
+					m.processPartition(eventDoc)
+					continue
+				}
 				go m.processStagedEvent(eventDoc)
 			}
 		}
@@ -0,0 +1,175 @@
+package eventing
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/cron"
+)
+
+// cronCatchUp is how far back a gateway looks for missed invocations once it becomes the leader. Invocations which
+// were already materialised by the previous leader are skipped since their ids are deterministic
+const cronCatchUp = 30 * time.Second
+
+// maxCronInvocations is the maximum number of invocations of a trigger materialised in a single run
+const maxCronInvocations = 100
+
+type cronSchedule struct {
+	schedule *cron.Schedule
+	location *time.Location
+}
+
+func newCronSchedule(trigger *config.EventingTrigger) (*cronSchedule, error) {
+	schedule, err := cron.Parse(trigger.Cron)
+	if err != nil {
+		return nil, err
+	}
+
+	location := time.UTC
+	if trigger.Timezone != "" {
+		if location, err = time.LoadLocation(trigger.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone (%s) provided: %v", trigger.Timezone, err)
+		}
+	}
+	return &cronSchedule{schedule: schedule, location: location}, nil
+}
+
+// processCronTriggers materialises the invocations of CRON triggers which are due into the event logs. Only the leader
+// gateway does this. The invocations are then processed like any other staged event
+func (m *Module) processCronTriggers(t time.Time) {
+	// Return if module is not enabled
+	if !m.IsEnabled() {
+		return
+	}
+
+	m.cronLock.Lock()
+	defer m.cronLock.Unlock()
+
+	isLeader, err := m.syncMan.CheckIfLeaderGateway(m.nodeID)
+	if err != nil || !isLeader {
+		// Forget the progress so that we catch up with the new leader if we get elected again
+		m.cronLastRun = map[string]time.Time{}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if m.cronLastRun == nil {
+		m.cronLastRun = map[string]time.Time{}
+	}
+
+	// Remove the progress of triggers which no longer exist
+	for id := range m.cronLastRun {
+		if _, p := m.schedules[id]; !p {
+			delete(m.cronLastRun, id)
+		}
+	}
+
+	for id, schedule := range m.schedules {
+		rule, p := m.config.Rules[id]
+		if !p {
+			continue
+		}
+
+		from, p := m.cronLastRun[id]
+		if !p {
+			from = t.Add(-cronCatchUp)
+		}
+
+		if err := m.materialiseCronTrigger(ctx, rule, schedule, from, t); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to materialise invocations of cron trigger (%s)", id), err, nil)
+			continue
+		}
+		m.cronLastRun[id] = t
+	}
+}
+
+// materialiseCronTrigger creates the staged events for the invocations of the trigger in the interval (from, to]
+func (m *Module) materialiseCronTrigger(ctx context.Context, rule *config.EventingTrigger, schedule *cronSchedule, from, to time.Time) error {
+	token := rand.Intn(utils.MaxEventTokens)
+	batchID := m.generateBatchID()
+
+	var eventDocs []*model.EventDocument
+	ids := make([]interface{}, 0)
+	next := schedule.schedule.Next(from.In(schedule.location))
+	for !next.IsZero() && !next.After(to) && len(eventDocs) < maxCronInvocations {
+		event := &model.QueueEventRequest{
+			Type:      utils.EventCron,
+			Timestamp: next.UTC().Format(time.RFC3339Nano),
+			Payload:   map[string]interface{}{"scheduledAt": next.Format(time.RFC3339)},
+		}
+
+		// Skip the invocation if the filter does not match
+		if rule.Filter != nil {
+			if _, err := m.auth.MatchRule(ctx, m.project, rule.Filter, map[string]interface{}{"args": map[string]interface{}{"data": event.Payload}}, map[string]interface{}{}, model.ReturnWhereStub{}); err != nil {
+				next = schedule.schedule.Next(next)
+				continue
+			}
+		}
+
+		eventDoc := m.generateQueueEventRequestRaw(ctx, token, rule, m.getCronEventID(rule.ID, next), batchID, utils.EventStatusStaged, event)
+		eventDoc.TriggerType = "external"
+		eventDocs = append(eventDocs, eventDoc)
+		ids = append(ids, eventDoc.ID)
+		next = schedule.schedule.Next(next)
+	}
+
+	if len(eventDocs) == 0 {
+		return nil
+	}
+
+	// Skip the invocations which have already been materialised
+	readRequest := &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{"_id": map[string]interface{}{"$in": ids}}}
+	attr := map[string]string{"project": m.project, "db": m.config.DBAlias, "col": utils.TableEventingLogs}
+	results, _, err := m.crud.Read(ctx, m.config.DBAlias, utils.TableEventingLogs, readRequest, model.RequestParams{Resource: "db-read", Op: "access", Attributes: attr})
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]bool{}
+	if docs, ok := results.([]interface{}); ok {
+		for _, doc := range docs {
+			if obj, ok := doc.(map[string]interface{}); ok {
+				existing[fmt.Sprintf("%v", obj["_id"])] = true
+			}
+		}
+	}
+
+	newDocs := make([]*model.EventDocument, 0, len(eventDocs))
+	for _, eventDoc := range eventDocs {
+		if !existing[eventDoc.ID] {
+			newDocs = append(newDocs, eventDoc)
+		}
+	}
+	if len(newDocs) == 0 {
+		return nil
+	}
+
+	if err := m.persistEvents(ctx, newDocs); err != nil {
+		return err
+	}
+	for range newDocs {
+		m.metricHook(m.project, utils.EventCron)
+	}
+	return nil
+}
+
+// getCronEventID returns the id of the invocation of a trigger at the provided time. It is the same across gateways
+func (m *Module) getCronEventID(triggerName string, t time.Time) string {
+	hash := sha256.Sum256([]byte(m.project + "/" + triggerName))
+	id, _ := ksuid.FromParts(t, hash[:16])
+	return id.String()
+}
@@ -0,0 +1,164 @@
+package eventing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func TestModule_SetTriggerConfig_cron(t *testing.T) {
+	tests := []struct {
+		name    string
+		trigger *config.EventingTrigger
+		wantErr bool
+	}{
+		{name: "valid schedule", trigger: &config.EventingTrigger{ID: "cron", Type: utils.EventCron, Cron: "*/5 * * * *"}},
+		{name: "valid schedule with timezone", trigger: &config.EventingTrigger{ID: "cron", Type: utils.EventCron, Cron: "@daily", Timezone: "UTC"}},
+		{name: "invalid schedule", trigger: &config.EventingTrigger{ID: "cron", Type: utils.EventCron, Cron: "* * *"}, wantErr: true},
+		{name: "invalid timezone", trigger: &config.EventingTrigger{ID: "cron", Type: utils.EventCron, Cron: "@daily", Timezone: "Mars/Olympus"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Module{config: &config.Eventing{}}
+			err := m.SetTriggerConfig(config.EventingTriggers{"resource": tt.trigger})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetTriggerConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, p := m.schedules["cron"]; p == tt.wantErr {
+				t.Errorf("SetTriggerConfig() schedule present = %v, want %v", p, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModule_processCronTriggers(t *testing.T) {
+	now := time.Date(2020, 1, 1, 10, 2, 20, 0, time.UTC)
+	trigger := &config.EventingTrigger{ID: "cron", Type: utils.EventCron, Cron: "* * * * *"}
+
+	type mockArgs struct {
+		method         string
+		args           []interface{}
+		paramsReturned []interface{}
+	}
+	tests := []struct {
+		name            string
+		lastRun         map[string]time.Time
+		syncmanMockArgs []mockArgs
+		crudMockArgs    []mockArgs
+		wantInvocations []string
+		wantLastRun     time.Time
+	}{
+		{
+			name:            "not the leader",
+			lastRun:         map[string]time.Time{"cron": now.Add(-time.Hour)},
+			syncmanMockArgs: []mockArgs{{method: "CheckIfLeaderGateway", args: []interface{}{"node"}, paramsReturned: []interface{}{false, nil}}},
+		},
+		{
+			name: "leader catches up with recent invocations",
+			syncmanMockArgs: []mockArgs{
+				{method: "CheckIfLeaderGateway", args: []interface{}{"node"}, paramsReturned: []interface{}{true, nil}},
+				{method: "GetAssignedSpaceCloudID", args: []interface{}{mock.Anything, "project", mock.Anything}, paramsReturned: []interface{}{"node", nil}},
+			},
+			crudMockArgs: []mockArgs{
+				{method: "Read", args: []interface{}{mock.Anything, "db", utils.TableEventingLogs, mock.Anything}, paramsReturned: []interface{}{[]interface{}{}, new(model.SQLMetaData), nil}},
+				{method: "InternalCreate", args: []interface{}{mock.Anything, "db", "project", utils.TableEventingLogs, mock.Anything, false}, paramsReturned: []interface{}{nil}},
+			},
+			wantInvocations: []string{"2020-01-01T10:02:00Z"},
+			wantLastRun:     now,
+		},
+		{
+			name:    "leader continues from the last run",
+			lastRun: map[string]time.Time{"cron": now.Add(-150 * time.Second)},
+			syncmanMockArgs: []mockArgs{
+				{method: "CheckIfLeaderGateway", args: []interface{}{"node"}, paramsReturned: []interface{}{true, nil}},
+				{method: "GetAssignedSpaceCloudID", args: []interface{}{mock.Anything, "project", mock.Anything}, paramsReturned: []interface{}{"node", nil}},
+			},
+			crudMockArgs: []mockArgs{
+				{method: "Read", args: []interface{}{mock.Anything, "db", utils.TableEventingLogs, mock.Anything}, paramsReturned: []interface{}{[]interface{}{}, new(model.SQLMetaData), nil}},
+				{method: "InternalCreate", args: []interface{}{mock.Anything, "db", "project", utils.TableEventingLogs, mock.Anything, false}, paramsReturned: []interface{}{nil}},
+			},
+			wantInvocations: []string{"2020-01-01T10:00:00Z", "2020-01-01T10:01:00Z", "2020-01-01T10:02:00Z"},
+			wantLastRun:     now,
+		},
+		{
+			name: "invocations materialised by the previous leader are skipped",
+			syncmanMockArgs: []mockArgs{
+				{method: "CheckIfLeaderGateway", args: []interface{}{"node"}, paramsReturned: []interface{}{true, nil}},
+			},
+			crudMockArgs: []mockArgs{
+				{method: "Read", args: []interface{}{mock.Anything, "db", utils.TableEventingLogs, mock.Anything}, paramsReturned: []interface{}{[]interface{}{map[string]interface{}{"_id": (&Module{project: "project"}).getCronEventID("cron", time.Date(2020, 1, 1, 10, 2, 0, 0, time.UTC))}}, new(model.SQLMetaData), nil}},
+			},
+			wantLastRun: now,
+		},
+		{
+			name:    "progress is kept if the events could not be logged",
+			lastRun: map[string]time.Time{"cron": now.Add(-time.Minute)},
+			syncmanMockArgs: []mockArgs{
+				{method: "CheckIfLeaderGateway", args: []interface{}{"node"}, paramsReturned: []interface{}{true, nil}},
+			},
+			crudMockArgs: []mockArgs{
+				{method: "Read", args: []interface{}{mock.Anything, "db", utils.TableEventingLogs, mock.Anything}, paramsReturned: []interface{}{[]interface{}{}, new(model.SQLMetaData), nil}},
+				{method: "InternalCreate", args: []interface{}{mock.Anything, "db", "project", utils.TableEventingLogs, mock.Anything, false}, paramsReturned: []interface{}{errors.New("some error")}},
+			},
+			wantInvocations: []string{"2020-01-01T10:02:00Z"},
+			wantLastRun:     now.Add(-time.Minute),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Module{
+				nodeID:      "node",
+				project:     "project",
+				config:      &config.Eventing{Enabled: true, DBAlias: "db"},
+				metricHook:  func(project, eventingType string) {},
+				cronLastRun: tt.lastRun,
+			}
+			if err := m.SetTriggerConfig(config.EventingTriggers{"resource": trigger}); err != nil {
+				t.Fatalf("SetTriggerConfig() error = %v", err)
+			}
+
+			mockSyncman := mockSyncmanEventingInterface{}
+			mockCrud := mockCrudInterface{}
+			for _, a := range tt.syncmanMockArgs {
+				mockSyncman.On(a.method, a.args...).Return(a.paramsReturned...)
+			}
+			for _, a := range tt.crudMockArgs {
+				mockCrud.On(a.method, a.args...).Return(a.paramsReturned...)
+			}
+			m.syncMan = &mockSyncman
+			m.crud = &mockCrud
+
+			m.processCronTriggers(now)
+
+			mockSyncman.AssertExpectations(t)
+			mockCrud.AssertExpectations(t)
+
+			if got := m.cronLastRun["cron"]; !got.Equal(tt.wantLastRun) {
+				t.Errorf("processCronTriggers() last run = %v, want %v", got, tt.wantLastRun)
+			}
+
+			var docs []interface{}
+			for _, call := range mockCrud.Calls {
+				if call.Method == "InternalCreate" {
+					docs = call.Arguments.Get(4).(*model.CreateRequest).Document.([]interface{})
+				}
+			}
+			if len(docs) != len(tt.wantInvocations) {
+				t.Fatalf("processCronTriggers() created (%d) events, want (%d)", len(docs), len(tt.wantInvocations))
+			}
+			for i, doc := range docs {
+				obj := doc.(map[string]interface{})
+				ts, _ := time.Parse(time.RFC3339, tt.wantInvocations[i])
+				if obj["_id"] != m.getCronEventID("cron", ts) || obj["ts"] != ts.Format(time.RFC3339Nano) || obj["type"] != utils.EventCron || obj["status"] != utils.EventStatusStaged {
+					t.Errorf("processCronTriggers() event = %v, want invocation at %v", obj, ts)
+				}
+			}
+		})
+	}
+}
@@ -16,7 +16,9 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/managers/syncman"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	schemaHelpers "github.com/spaceuptech/space-cloud/gateway/modules/schema/helpers"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
 	"github.com/spaceuptech/space-cloud/gateway/utils/pubsub"
+	"github.com/spaceuptech/space-cloud/gateway/utils/sink"
 )
 
 // Module is responsible for managing the eventing system
@@ -30,7 +32,8 @@ type Module struct {
 	config    *config.Eventing
 
 	// Atomic maps to handle events being processed
-	processingEvents sync.Map
+	processingEvents     sync.Map
+	processingPartitions sync.Map
 
 	// Variables defined during initialisation
 	auth model.AuthEventingInterface
@@ -38,6 +41,7 @@ type Module struct {
 
 	syncMan   model.SyncmanEventingInterface
 	fileStore model.FilestoreEventingInterface
+	functions model.FunctionsEventingInterface
 
 	schemas    map[string]model.Fields
 	metricHook model.MetricEventingHook
@@ -45,10 +49,27 @@ type Module struct {
 	eventChanMap sync.Map // key here is batchID
 	tickerIntent *time.Ticker
 	tickerStaged *time.Ticker
+	tickerCron   *time.Ticker
+
+	// Parsed schedules of CRON triggers along with the time till which they have been materialised. The progress is
+	// written while only the read lock of the module is held, hence it has a lock of its own
+	schedules   map[string]*cronSchedule
+	cronLock    sync.Mutex
+	cronLastRun map[string]time.Time
 
 	// Templates for body transformation
 	templates map[string]*template.Template
 
+	// Message brokers the events of triggers with a target get published to. The sinks have a lock of their own so that
+	// they can be looked up without relying on the caller holding the lock of the module
+	sinksLock sync.RWMutex
+	sinks     map[string]sink.Sink
+
+	// Message brokers events are consumed from along with the consumers running on the leader gateway
+	sources       map[string]*config.EventingSource
+	consumers     map[string]context.CancelFunc
+	tickerSources *time.Ticker
+
 	// Pub sub network
 	pubsubClient *pubsub.Module
 
@@ -82,12 +103,19 @@ func New(clusterID, projectID, nodeID string, auth model.AuthEventingInterface,
 		metricHook:   hook,
 		config:       &config.Eventing{Enabled: false, InternalRules: make(config.EventingTriggers)},
 		templates:    map[string]*template.Template{},
+		sinks:        map[string]sink.Sink{},
+		sources:      map[string]*config.EventingSource{},
+		consumers:    map[string]context.CancelFunc{},
+		schedules:    map[string]*cronSchedule{},
+		cronLastRun:  map[string]time.Time{},
 		pubsubClient: pubsubClient,
 	}
 
 	// Start the internal processes
 	go m.routineProcessIntents()
 	go m.routineProcessStaged()
+	go m.routineProcessCronTriggers()
+	go m.routineProcessSources()
 	go m.routineHandleMessages()
 	go m.routineHandleEventResponseMessages()
 	m.createProcessUpdateEventsRoutine()
@@ -121,6 +149,13 @@ func (m *Module) SetConfig(projectID string, eventing *config.EventingConfig) er
 	return nil
 }
 
+// SetFunctionsModule sets the functions module used to send failed events to remote services
+func (m *Module) SetFunctionsModule(functions model.FunctionsEventingInterface) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.functions = functions
+}
+
 // SetSchemaConfig sets schema config of eventing module
 func (m *Module) SetSchemaConfig(evSchemas config.EventingSchemas) error {
 	m.lock.Lock()
@@ -160,9 +195,39 @@ func (m *Module) SetTriggerConfig(triggers config.EventingTriggers) error {
 	}
 
 	m.templates = map[string]*template.Template{}
+	m.schedules = map[string]*cronSchedule{}
+	m.closeSinks()
+	if err := validateDLQTriggers(m.config.Rules); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Invalid dlq provided for triggers", err, nil)
+	}
 	for name, trigger := range m.config.Rules {
 		trigger.ID = name
 
+		if err := validateBackoff(trigger.Backoff); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid backoff provided for trigger (%s)", trigger.ID), err, nil)
+		}
+		if err := validateDLQ(trigger.DLQ); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid dlq provided for trigger (%s)", trigger.ID), err, nil)
+		}
+
+		if trigger.Type == utils.EventCron {
+			schedule, err := newCronSchedule(trigger)
+			if err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid schedule provided for trigger (%s)", trigger.ID), err, nil)
+			}
+			m.schedules[trigger.ID] = schedule
+		}
+
+		if trigger.Target != nil {
+			s, err := sink.New(trigger.Target)
+			if err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid target provided for trigger (%s)", trigger.ID), err, nil)
+			}
+			m.sinksLock.Lock()
+			m.sinks[trigger.ID] = s
+			m.sinksLock.Unlock()
+		}
+
 		// Set default templating engine
 		if trigger.Tmpl == "" {
 			trigger.Tmpl = config.TemplatingEngineGo
@@ -185,6 +250,11 @@ func (m *Module) SetTriggerConfig(triggers config.EventingTriggers) error {
 					return err
 				}
 			}
+			if trigger.PartitionKey != "" {
+				if err := m.createGoTemplate("partition", trigger.ID, trigger.PartitionKey); err != nil {
+					return err
+				}
+			}
 		default:
 			return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid templating engine (%s) provided", trigger.Tmpl), nil, map[string]interface{}{})
 		}
@@ -234,7 +304,11 @@ func (m *Module) CloseConfig() error {
 	for k := range m.config.Schemas {
 		delete(m.config.Schemas, k)
 	}
+	m.closeSinks()
+	m.stopConsumers()
 	m.tickerIntent.Stop()
 	m.tickerStaged.Stop()
+	m.tickerCron.Stop()
+	m.tickerSources.Stop()
 	return nil
 }
@@ -137,6 +137,18 @@ func (m *Module) HookStage(ctx context.Context, intent *model.EventIntent, err e
 
 	// Broadcast the event so the concerned worker can process it immediately
 	if !intent.Invalid {
-		m.transmitEvents(intent.Token, intent.Docs)
+		// Events of partitions have a token of their own
+		var unordered, ordered []*model.EventDocument
+		for _, doc := range intent.Docs {
+			if doc.PartitionKey != "" {
+				ordered = append(ordered, doc)
+				continue
+			}
+			unordered = append(unordered, doc)
+		}
+		if len(unordered) > 0 {
+			m.transmitEvents(intent.Token, unordered)
+		}
+		m.transmitEventsByToken(ordered)
 	}
 }
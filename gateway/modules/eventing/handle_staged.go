@@ -66,6 +66,11 @@ func (m *Module) processStagedEvents(t *time.Time) {
 		timestamp = timestamp.Add(15 * time.Second)
 
 		if t.After(timestamp) || t.Equal(timestamp) {
+			// Events of a partition are delivered one after the other
+			if eventDoc.PartitionKey != "" {
+				m.processPartition(eventDoc)
+				continue
+			}
 			go m.processStagedEvent(eventDoc)
 		}
 	}
@@ -156,7 +161,13 @@ func (m *Module) processStagedEvent(eventDoc *model.EventDocument) {
 	}
 
 	for {
-		if err := m.invokeWebhook(ctx, token, &http.Client{}, rule, eventDoc, newDoc); err != nil {
+		// Triggers with a target publish the event to a message broker instead of invoking a webhook
+		if rule.Target != nil {
+			err = m.invokeSink(ctx, rule, eventDoc, newDoc)
+		} else {
+			err = m.invokeWebhook(ctx, token, &http.Client{}, rule, eventDoc, newDoc)
+		}
+		if err != nil {
 			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Eventing staged event handler could not get response from service", err, nil)
 
 			// Increment the retries. Exit the loop if max retries reached.
@@ -166,8 +177,8 @@ func (m *Module) processStagedEvent(eventDoc *model.EventDocument) {
 				break
 			}
 
-			// Sleep for 5 seconds
-			time.Sleep(5 * time.Second)
+			// Wait as per the backoff of the trigger
+			time.Sleep(retryInterval(rule.Backoff, retries))
 			continue
 		}
 
@@ -175,7 +186,7 @@ func (m *Module) processStagedEvent(eventDoc *model.EventDocument) {
 		return
 	}
 
-	if err := m.triggerDLQEvent(ctx, eventDoc); err != nil {
+	if err := m.triggerDLQEvent(ctx, rule, eventDoc); err != nil {
 		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Couldn't create DLQ event for event id %v", eventDoc.ID), err, nil)
 	}
 
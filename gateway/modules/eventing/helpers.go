@@ -41,6 +41,23 @@ func (m *Module) transmitEvents(eventToken int, eventDocs []*model.EventDocument
 	}
 }
 
+// transmitEventsByToken broadcasts the events to the workers of their tokens. Events of a batch have different tokens
+// when they belong to partitions
+func (m *Module) transmitEventsByToken(eventDocs []*model.EventDocument) {
+	tokens := make([]int, 0)
+	docsByToken := map[int][]*model.EventDocument{}
+	for _, eventDoc := range eventDocs {
+		if _, p := docsByToken[eventDoc.Token]; !p {
+			tokens = append(tokens, eventDoc.Token)
+		}
+		docsByToken[eventDoc.Token] = append(docsByToken[eventDoc.Token], eventDoc)
+	}
+
+	for _, token := range tokens {
+		m.transmitEvents(token, docsByToken[token])
+	}
+}
+
 func (m *Module) getSpaceCloudIDFromBatchID(batchID string) string {
 	return strings.Split(batchID, "--")[1]
 }
@@ -77,14 +94,17 @@ func (m *Module) batchRequestsRaw(ctx context.Context, token int, requests []*mo
 		return nil
 	}
 
-	// Persist the events
+	return m.persistEvents(ctx, eventDocs)
+}
+
+// persistEvents logs the events and broadcasts them so the concerned worker can process them immediately
+func (m *Module) persistEvents(ctx context.Context, eventDocs []*model.EventDocument) error {
 	createRequest := &model.CreateRequest{Document: convertToArray(eventDocs), Operation: utils.All, IsBatch: true}
 	if err := m.crud.InternalCreate(ctx, m.config.DBAlias, m.project, utils.TableEventingLogs, createRequest, false); err != nil {
 		return errors.New("eventing module couldn't log the request -" + err.Error())
 	}
 
-	// Broadcast the event so the concerned worker can process it immediately
-	m.transmitEvents(token, eventDocs)
+	m.transmitEventsByToken(eventDocs)
 	return nil
 }
 
@@ -116,16 +136,23 @@ func (m *Module) generateQueueEventRequestRaw(ctx context.Context, token int, ru
 
 	data, _ := json.Marshal(event.Payload)
 
+	// Events of a partition share the same token so that they are processed by the same worker
+	partitionKey := m.getPartitionKey(ctx, rule, event.Payload)
+	if partitionKey != "" {
+		token = getPartitionToken(rule.ID, partitionKey)
+	}
+
 	return &model.EventDocument{
-		ID:          eventDocID,
-		BatchID:     batchID,
-		Type:        event.Type,
-		RuleName:    rule.ID,
-		Token:       token,
-		Timestamp:   eventTs.Format(time.RFC3339Nano),
-		Payload:     string(data),
-		Status:      status,
-		TriggerType: rule.TriggerType,
+		ID:           eventDocID,
+		BatchID:      batchID,
+		Type:         event.Type,
+		RuleName:     rule.ID,
+		Token:        token,
+		Timestamp:    eventTs.Format(time.RFC3339Nano),
+		Payload:      string(data),
+		Status:       status,
+		TriggerType:  rule.TriggerType,
+		PartitionKey: partitionKey,
 	}
 }
 
@@ -169,24 +196,120 @@ func (m *Module) generateProcessedEventRequest(eventID string) *model.UpdateRequ
 	}
 }
 
-func (m *Module) triggerDLQEvent(ctx context.Context, eventDoc *model.EventDocument) error {
-	req := &model.QueueEventRequest{
-		Type: fmt.Sprintf("%s%s", utils.DLQEventTriggerPrefix, eventDoc.RuleName),
-		Payload: map[string]interface{}{
-			"event_id":        eventDoc.ID,
-			"event_type":      eventDoc.Type,
-			"event_payload":   eventDoc.Payload,
-			"event_timestamp": eventDoc.Timestamp,
-			"event_name":      eventDoc.RuleName,
-		},
+func (m *Module) triggerDLQEvent(ctx context.Context, rule *config.EventingTrigger, eventDoc *model.EventDocument) error {
+	payload := map[string]interface{}{
+		"event_id":        eventDoc.ID,
+		"event_type":      eventDoc.Type,
+		"event_payload":   eventDoc.Payload,
+		"event_timestamp": eventDoc.Timestamp,
+		"event_name":      eventDoc.RuleName,
+	}
+
+	// Fallback to the generic dlq event if no destination has been configured
+	if rule.DLQ == nil {
+		req := &model.QueueEventRequest{Type: fmt.Sprintf("%s%s", utils.DLQEventTriggerPrefix, eventDoc.RuleName), Payload: payload}
+		if err := m.batchRequests(ctx, []*model.QueueEventRequest{req}, m.generateBatchID()); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Eventing was unable to queue dlq event to batch requests", err, map[string]interface{}{})
+			return err
+		}
+
+		m.metricHook(m.project, req.Type)
+		return nil
 	}
 
-	if err := m.batchRequests(ctx, []*model.QueueEventRequest{req}, m.generateBatchID()); err != nil {
-		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Eventing was unable to queue dlq event to batch requests", err, map[string]interface{}{})
-		return err
+	dlq := rule.DLQ
+	switch dlq.Type {
+	case config.DLQTypeTrigger:
+		target, err := m.selectRule(dlq.Trigger)
+		if err != nil {
+			return err
+		}
+
+		token := rand.Intn(utils.MaxEventTokens)
+		req := &model.QueueEventRequest{Type: target.Type, Payload: payload}
+		dlqEventDoc := m.generateQueueEventRequest(ctx, token, target, m.generateBatchID(), utils.EventStatusStaged, req)
+		if err := m.persistEvents(ctx, []*model.EventDocument{dlqEventDoc}); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Eventing was unable to queue dlq event for trigger (%s)", dlq.Trigger), err, nil)
+		}
+		m.metricHook(m.project, target.Type)
+		return nil
+
+	case config.DLQTypeDatabase:
+		// The payload is stored as a string so that it can be inserted in tables without a json column
+		data, _ := json.Marshal(eventDoc.Payload)
+		doc := map[string]interface{}{"_id": ksuid.New().String(), "failed_at": time.Now().UTC().Format(time.RFC3339Nano)}
+		for k, v := range payload {
+			doc[k] = v
+		}
+		doc["event_payload"] = string(data)
+
+		createRequest := &model.CreateRequest{Document: doc, Operation: utils.One}
+		if err := m.crud.InternalCreate(ctx, dlq.DBAlias, m.project, dlq.Table, createRequest, false); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Eventing was unable to insert dlq event in table (%s) of database (%s)", dlq.Table, dlq.DBAlias), err, nil)
+		}
+		return nil
+
+	case config.DLQTypeService:
+		if m.functions == nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Remote services are not available to receive the dlq event", nil, nil)
+		}
+		token, err := m.auth.GetInternalAccessToken(ctx)
+		if err != nil {
+			return err
+		}
+		attr := map[string]string{"project": m.project, "service": dlq.Service, "endpoint": dlq.Endpoint}
+		reqParams := model.RequestParams{Resource: "service-call", Op: "access", Attributes: attr}
+		if _, _, err := m.functions.CallWithContext(ctx, dlq.Service, dlq.Endpoint, token, reqParams, &model.FunctionsRequest{Params: payload}); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Eventing was unable to send dlq event to remote service", err, map[string]interface{}{"service": dlq.Service, "endpoint": dlq.Endpoint})
+		}
+		return nil
+
+	default:
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid dlq type (%s) provided", dlq.Type), nil, nil)
 	}
+}
 
-	m.metricHook(m.project, req.Type)
+func validateDLQ(dlq *config.EventingDLQ) error {
+	if dlq == nil {
+		return nil
+	}
+	switch dlq.Type {
+	case config.DLQTypeTrigger:
+		if dlq.Trigger == "" {
+			return errors.New("trigger of the dlq has not been provided")
+		}
+	case config.DLQTypeDatabase:
+		if dlq.DBAlias == "" || dlq.Table == "" {
+			return errors.New("db and table of the dlq have not been provided")
+		}
+	case config.DLQTypeService:
+		if dlq.Service == "" || dlq.Endpoint == "" {
+			return errors.New("service and endpoint of the dlq have not been provided")
+		}
+	default:
+		return fmt.Errorf("invalid dlq type (%s) provided", dlq.Type)
+	}
+	return nil
+}
+
+// validateDLQTriggers checks that the triggers used as dlqs exist and that events which keep failing can't be passed
+// around in a loop of dlq triggers
+func validateDLQTriggers(triggers config.EventingTriggers) error {
+	for id, trigger := range triggers {
+		visited := map[string]bool{id: true}
+		for next := trigger; next.DLQ != nil && next.DLQ.Type == config.DLQTypeTrigger; {
+			name := next.DLQ.Trigger
+			target, p := triggers[name]
+			if !p {
+				return fmt.Errorf("dlq trigger (%s) of trigger (%s) does not exist", name, next.ID)
+			}
+			if visited[name] {
+				return fmt.Errorf("dlq of trigger (%s) leads back to trigger (%s)", id, name)
+			}
+			visited[name] = true
+			next = target
+		}
+	}
 	return nil
 }
 
@@ -286,7 +409,7 @@ func (m *Module) selectRule(name string) (*config.EventingTrigger, error) {
 }
 
 func (m *Module) validate(ctx context.Context, project, token string, event *model.QueueEventRequest) error {
-	if event.Type == utils.EventDBCreate || event.Type == utils.EventDBDelete || event.Type == utils.EventDBUpdate || event.Type == utils.EventFileCreate || event.Type == utils.EventFileDelete {
+	if event.Type == utils.EventDBCreate || event.Type == utils.EventDBDelete || event.Type == utils.EventDBUpdate || event.Type == utils.EventFileCreate || event.Type == utils.EventFileDelete || event.Type == utils.EventCron {
 		return fmt.Errorf("cannot create internal event (%s) with project token", event.Type)
 	}
 
@@ -735,3 +735,185 @@ func TestModule_batchRequestsRaw(t *testing.T) {
 		})
 	}
 }
+
+func TestModule_triggerDLQEvent(t *testing.T) {
+	eventDoc := &model.EventDocument{ID: "event", Type: "DB_INSERT", RuleName: "rule", Timestamp: "2020-01-01T00:00:00Z", Payload: map[string]interface{}{"id": "1"}}
+	payload := map[string]interface{}{"event_id": "event", "event_type": "DB_INSERT", "event_payload": map[string]interface{}{"id": "1"}, "event_timestamp": "2020-01-01T00:00:00Z", "event_name": "rule"}
+
+	type mockArgs struct {
+		method         string
+		args           []interface{}
+		paramsReturned []interface{}
+	}
+	tests := []struct {
+		name              string
+		dlq               *config.EventingDLQ
+		crudMockArgs      []mockArgs
+		authMockArgs      []mockArgs
+		functionsMockArgs []mockArgs
+		wantErr           bool
+	}{
+		{
+			name: "dlq event is queued for another trigger",
+			dlq:  &config.EventingDLQ{Type: config.DLQTypeTrigger, Trigger: "failures"},
+			crudMockArgs: []mockArgs{
+				{
+					method: "InternalCreate",
+					args: []interface{}{mock.Anything, "db", "project", utils.TableEventingLogs, mock.MatchedBy(func(req *model.CreateRequest) bool {
+						docs := req.Document.([]interface{})
+						doc := docs[0].(map[string]interface{})
+						return len(docs) == 1 && doc["rule_name"] == "failures" && doc["type"] == "failure-type" && doc["status"] == utils.EventStatusStaged
+					}), false},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+		},
+		{
+			name:    "unknown trigger",
+			dlq:     &config.EventingDLQ{Type: config.DLQTypeTrigger, Trigger: "unknown"},
+			wantErr: true,
+		},
+		{
+			name: "dlq event is inserted in a table",
+			dlq:  &config.EventingDLQ{Type: config.DLQTypeDatabase, DBAlias: "failures-db", Table: "failures"},
+			crudMockArgs: []mockArgs{
+				{
+					method: "InternalCreate",
+					args: []interface{}{mock.Anything, "failures-db", "project", "failures", mock.MatchedBy(func(req *model.CreateRequest) bool {
+						doc := req.Document.(map[string]interface{})
+						return req.Operation == utils.One && doc["event_id"] == "event" && doc["event_payload"] == `{"id":"1"}` && doc["_id"] != "" && doc["failed_at"] != ""
+					}), false},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+		},
+		{
+			name: "table is not available",
+			dlq:  &config.EventingDLQ{Type: config.DLQTypeDatabase, DBAlias: "failures-db", Table: "failures"},
+			crudMockArgs: []mockArgs{
+				{method: "InternalCreate", args: []interface{}{mock.Anything, "failures-db", "project", "failures", mock.Anything, false}, paramsReturned: []interface{}{errors.New("some error")}},
+			},
+			wantErr: true,
+		},
+		{
+			name:              "dlq event is sent to a remote service",
+			dlq:               &config.EventingDLQ{Type: config.DLQTypeService, Service: "alerts", Endpoint: "failed"},
+			authMockArgs:      []mockArgs{{method: "GetInternalAccessToken", paramsReturned: []interface{}{"token", nil}}},
+			functionsMockArgs: []mockArgs{{method: "CallWithContext", args: []interface{}{"alerts", "failed", "token", &model.FunctionsRequest{Params: payload}}, paramsReturned: []interface{}{200, nil, nil}}},
+		},
+		{
+			name:              "remote service fails",
+			dlq:               &config.EventingDLQ{Type: config.DLQTypeService, Service: "alerts", Endpoint: "failed"},
+			authMockArgs:      []mockArgs{{method: "GetInternalAccessToken", paramsReturned: []interface{}{"token", nil}}},
+			functionsMockArgs: []mockArgs{{method: "CallWithContext", args: []interface{}{"alerts", "failed", "token", &model.FunctionsRequest{Params: payload}}, paramsReturned: []interface{}{500, nil, errors.New("some error")}}},
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCrud := mockCrudInterface{}
+			mockAuth := mockAuthEventingInterface{}
+			mockFunctions := mockFunctionsEventingInterface{}
+			mockSyncman := mockSyncmanEventingInterface{}
+			for _, a := range tt.crudMockArgs {
+				mockCrud.On(a.method, a.args...).Return(a.paramsReturned...)
+			}
+			for _, a := range tt.authMockArgs {
+				mockAuth.On(a.method, a.args...).Return(a.paramsReturned...)
+			}
+			for _, a := range tt.functionsMockArgs {
+				mockFunctions.On(a.method, a.args...).Return(a.paramsReturned...)
+			}
+			mockSyncman.On("GetAssignedSpaceCloudID", mock.Anything, "project", mock.Anything).Return("node", nil)
+
+			m := &Module{
+				project:    "project",
+				nodeID:     "node",
+				config:     &config.Eventing{DBAlias: "db", Rules: config.EventingTriggers{"failures": {ID: "failures", Type: "failure-type"}}},
+				crud:       &mockCrud,
+				auth:       &mockAuth,
+				functions:  &mockFunctions,
+				syncMan:    &mockSyncman,
+				metricHook: func(project, eventingType string) {},
+			}
+			rule := &config.EventingTrigger{ID: "rule", DLQ: tt.dlq}
+			if err := m.triggerDLQEvent(context.Background(), rule, eventDoc); (err != nil) != tt.wantErr {
+				t.Errorf("triggerDLQEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			mockCrud.AssertExpectations(t)
+			mockAuth.AssertExpectations(t)
+			mockFunctions.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_validateDLQTriggers(t *testing.T) {
+	dlq := func(trigger string) *config.EventingDLQ {
+		return &config.EventingDLQ{Type: config.DLQTypeTrigger, Trigger: trigger}
+	}
+	tests := []struct {
+		name     string
+		triggers config.EventingTriggers
+		wantErr  bool
+	}{
+		{
+			name: "chain of dlq triggers",
+			triggers: config.EventingTriggers{
+				"orders":   {ID: "orders", DLQ: dlq("failures")},
+				"payments": {ID: "payments", DLQ: dlq("failures")},
+				"failures": {ID: "failures", DLQ: &config.EventingDLQ{Type: config.DLQTypeDatabase, DBAlias: "db", Table: "failures"}},
+			},
+		},
+		{
+			name:     "unknown dlq trigger",
+			triggers: config.EventingTriggers{"orders": {ID: "orders", DLQ: dlq("failures")}},
+			wantErr:  true,
+		},
+		{
+			name:     "trigger is its own dlq",
+			triggers: config.EventingTriggers{"orders": {ID: "orders", DLQ: dlq("orders")}},
+			wantErr:  true,
+		},
+		{
+			name: "dlq triggers forming a cycle",
+			triggers: config.EventingTriggers{
+				"orders":   {ID: "orders", DLQ: dlq("failures")},
+				"failures": {ID: "failures", DLQ: dlq("alerts")},
+				"alerts":   {ID: "alerts", DLQ: dlq("failures")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateDLQTriggers(tt.triggers); (err != nil) != tt.wantErr {
+				t.Errorf("validateDLQTriggers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateDLQ(t *testing.T) {
+	tests := []struct {
+		name    string
+		dlq     *config.EventingDLQ
+		wantErr bool
+	}{
+		{name: "no dlq"},
+		{name: "trigger", dlq: &config.EventingDLQ{Type: config.DLQTypeTrigger, Trigger: "failures"}},
+		{name: "trigger not provided", dlq: &config.EventingDLQ{Type: config.DLQTypeTrigger}, wantErr: true},
+		{name: "database", dlq: &config.EventingDLQ{Type: config.DLQTypeDatabase, DBAlias: "db", Table: "failures"}},
+		{name: "table not provided", dlq: &config.EventingDLQ{Type: config.DLQTypeDatabase, DBAlias: "db"}, wantErr: true},
+		{name: "service", dlq: &config.EventingDLQ{Type: config.DLQTypeService, Service: "alerts", Endpoint: "failed"}},
+		{name: "endpoint not provided", dlq: &config.EventingDLQ{Type: config.DLQTypeService, Service: "alerts"}, wantErr: true},
+		{name: "invalid type", dlq: &config.EventingDLQ{Type: "queue"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateDLQ(tt.dlq); (err != nil) != tt.wantErr {
+				t.Errorf("validateDLQ() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
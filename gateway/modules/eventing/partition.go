@@ -0,0 +1,145 @@
+package eventing
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+	tmpl2 "github.com/spaceuptech/space-cloud/gateway/utils/tmpl"
+)
+
+// partitionUpdateRetries is the number of times the worker of a partition waits for the status of the last processed
+// event to get updated before giving up. The partition gets picked up again by the staged events routine
+const partitionUpdateRetries = 50
+
+// getPartitionKey evaluates the partition key template of the trigger. The payload is available as `args.data` just
+// like in filters. An empty key is returned for triggers which don't need ordered delivery
+func (m *Module) getPartitionKey(ctx context.Context, rule *config.EventingTrigger, payload interface{}) string {
+	if rule.PartitionKey == "" {
+		return ""
+	}
+
+	tmpl, p := m.templates[getGoTemplateKey("partition", rule.ID)]
+	if !p {
+		return ""
+	}
+
+	key, err := tmpl2.ExecTemplate(ctx, tmpl, map[string]interface{}{"args": map[string]interface{}{"data": payload}})
+	key = strings.TrimSpace(key)
+	if err != nil || key == "" || key == "<no value>" {
+		helpers.Logger.LogWarn(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to evaluate partition key of trigger (%s). Event will be delivered out of order", rule.ID), map[string]interface{}{"error": err})
+		return ""
+	}
+	return key
+}
+
+// getPartitionToken returns the token of the partition. Since tokens are assigned to gateways, every partition is
+// owned by a single gateway at a time
+func getPartitionToken(ruleName, key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(ruleName + "/" + key))
+	return int(h.Sum32() % uint32(utils.MaxEventTokens))
+}
+
+// processPartition starts the worker of the partition of the event if it isn't running already. The worker delivers
+// the events of the partition one after the other in the order of their timestamps
+func (m *Module) processPartition(eventDoc *model.EventDocument) {
+	partition := eventDoc.RuleName + "/" + eventDoc.PartitionKey
+	if _, loaded := m.processingPartitions.LoadOrStore(partition, true); loaded {
+		return
+	}
+
+	go func() {
+		defer m.processingPartitions.Delete(partition)
+
+		// The status of processed events gets updated asynchronously. Keep track of them so they aren't delivered twice
+		done := map[string]bool{}
+		for retries := 0; retries < partitionUpdateRetries; {
+			next, pending, err := m.nextPartitionEvent(eventDoc.RuleName, eventDoc.PartitionKey, done)
+			if err != nil {
+				_ = helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Unable to read events of partition (%s)", partition), err, nil)
+				return
+			}
+			if next == nil {
+				if !pending {
+					return
+				}
+				retries++
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+
+			// Process the event and wait till it either succeeds or gets dead lettered
+			retries = 0
+			m.processStagedEvent(next)
+			done[next.ID] = true
+		}
+	}()
+}
+
+// nextPartitionEvent returns the oldest event of the partition which is yet to be processed. Nothing is returned if the
+// oldest event is still an intent or isn't due yet since later events need to wait for it. Pending is true if only
+// events whose status is yet to be updated were found
+func (m *Module) nextPartitionEvent(ruleName, key string, done map[string]bool) (*model.EventDocument, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	m.lock.RLock()
+	dbAlias, col := m.config.DBAlias, utils.TableEventingLogs
+	m.lock.RUnlock()
+
+	partitionLimit := int64(len(done) + 1)
+	readRequest := model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"ts"}, Limit: &partitionLimit}, Find: map[string]interface{}{
+		"rule_name":     ruleName,
+		"partition_key": key,
+		"status":        map[string]interface{}{"$in": []interface{}{utils.EventStatusIntent, utils.EventStatusStaged}},
+	}}
+	attr := map[string]string{"project": m.project, "db": dbAlias, "col": col}
+	results, _, err := m.crud.Read(ctx, dbAlias, col, &readRequest, model.RequestParams{Resource: "db-read", Op: "access", Attributes: attr})
+	if err != nil {
+		return nil, false, err
+	}
+
+	docs, _ := results.([]interface{})
+	seen := map[string]bool{}
+	var next *model.EventDocument
+	for _, doc := range docs {
+		eventDoc := new(model.EventDocument)
+		if err := mapstructure.Decode(doc, eventDoc); err != nil {
+			return nil, false, err
+		}
+		if done[eventDoc.ID] {
+			seen[eventDoc.ID] = true
+			continue
+		}
+		next = eventDoc
+		break
+	}
+
+	// Forget the events whose status has been updated
+	for id := range done {
+		if !seen[id] {
+			delete(done, id)
+		}
+	}
+
+	if next == nil {
+		return nil, len(seen) > 0, nil
+	}
+	if next.Status != utils.EventStatusStaged {
+		return nil, false, nil
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, next.Timestamp)
+	if err != nil || timestamp.After(time.Now()) {
+		return nil, false, nil
+	}
+	return next, false, nil
+}
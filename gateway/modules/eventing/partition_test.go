@@ -0,0 +1,119 @@
+package eventing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func TestModule_generateQueueEventRequest_partition(t *testing.T) {
+	m := &Module{config: &config.Eventing{}}
+	rules := config.EventingTriggers{
+		"ordered":   {ID: "ordered", Type: utils.EventDBUpdate, PartitionKey: "{{.args.data.doc.id}}"},
+		"unordered": {ID: "unordered", Type: utils.EventDBUpdate},
+	}
+	if err := m.SetTriggerConfig(rules); err != nil {
+		t.Fatalf("SetTriggerConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		rule      string
+		payload   interface{}
+		wantKey   string
+		wantToken int
+	}{
+		{name: "key of the row", rule: "ordered", payload: map[string]interface{}{"doc": map[string]interface{}{"id": "1"}}, wantKey: "1", wantToken: getPartitionToken("ordered", "1")},
+		{name: "key is missing", rule: "ordered", payload: map[string]interface{}{"doc": map[string]interface{}{}}, wantToken: 7},
+		{name: "trigger without partition key", rule: "unordered", payload: map[string]interface{}{"doc": map[string]interface{}{"id": "1"}}, wantToken: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := m.generateQueueEventRequest(context.Background(), 7, m.config.Rules[tt.rule], "batch", utils.EventStatusStaged, &model.QueueEventRequest{Type: utils.EventDBUpdate, Payload: tt.payload})
+			if doc.PartitionKey != tt.wantKey || doc.Token != tt.wantToken {
+				t.Errorf("generateQueueEventRequest() partition = (%v, %v), want (%v, %v)", doc.PartitionKey, doc.Token, tt.wantKey, tt.wantToken)
+			}
+		})
+	}
+}
+
+func Test_getPartitionToken(t *testing.T) {
+	if getPartitionToken("rule", "1") != getPartitionToken("rule", "1") {
+		t.Errorf("getPartitionToken() returned different tokens for the same partition")
+	}
+	for _, key := range []string{"1", "2", "abc", "some-long-key"} {
+		if token := getPartitionToken("rule", key); token < 0 || token >= utils.MaxEventTokens {
+			t.Errorf("getPartitionToken() = %v, want token in [0, %v)", token, utils.MaxEventTokens)
+		}
+	}
+}
+
+func TestModule_nextPartitionEvent(t *testing.T) {
+	past := time.Now().Add(-time.Minute).Format(time.RFC3339Nano)
+	future := time.Now().Add(time.Minute).Format(time.RFC3339Nano)
+	event := func(id, status, ts string) map[string]interface{} {
+		return map[string]interface{}{"_id": id, "rule_name": "rule", "partition_key": "key", "status": status, "ts": ts}
+	}
+
+	tests := []struct {
+		name        string
+		done        map[string]bool
+		docs        []interface{}
+		wantID      string
+		wantPending bool
+		wantDone    map[string]bool
+	}{
+		{name: "oldest staged event", done: map[string]bool{}, docs: []interface{}{event("1", utils.EventStatusStaged, past)}, wantID: "1", wantDone: map[string]bool{}},
+		{name: "no events", done: map[string]bool{}, docs: []interface{}{}, wantDone: map[string]bool{}},
+		{name: "processed events are skipped", done: map[string]bool{"1": true}, docs: []interface{}{event("1", utils.EventStatusStaged, past), event("2", utils.EventStatusStaged, past)}, wantID: "2", wantDone: map[string]bool{"1": true}},
+		{name: "waiting for status of processed events", done: map[string]bool{"1": true}, docs: []interface{}{event("1", utils.EventStatusStaged, past)}, wantPending: true, wantDone: map[string]bool{"1": true}},
+		{name: "updated events are forgotten", done: map[string]bool{"1": true}, docs: []interface{}{event("2", utils.EventStatusStaged, past)}, wantID: "2", wantDone: map[string]bool{}},
+		{name: "later events wait for the intent", done: map[string]bool{}, docs: []interface{}{event("1", utils.EventStatusIntent, past)}, wantDone: map[string]bool{}},
+		{name: "later events wait for events which are not due", done: map[string]bool{}, docs: []interface{}{event("1", utils.EventStatusStaged, future)}, wantDone: map[string]bool{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := int64(len(tt.done) + 1)
+			mockCrud := mockCrudInterface{}
+			mockCrud.On("Read", mock.Anything, "db", utils.TableEventingLogs, &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"ts"}, Limit: &limit}, Find: map[string]interface{}{
+				"rule_name":     "rule",
+				"partition_key": "key",
+				"status":        map[string]interface{}{"$in": []interface{}{utils.EventStatusIntent, utils.EventStatusStaged}},
+			}}).Return(tt.docs, new(model.SQLMetaData), nil)
+
+			m := &Module{project: "project", config: &config.Eventing{DBAlias: "db"}, crud: &mockCrud}
+			got, pending, err := m.nextPartitionEvent("rule", "key", tt.done)
+			if err != nil {
+				t.Fatalf("nextPartitionEvent() error = %v", err)
+			}
+
+			var gotID string
+			if got != nil {
+				gotID = got.ID
+			}
+			if gotID != tt.wantID || pending != tt.wantPending {
+				t.Errorf("nextPartitionEvent() = (%v, %v), want (%v, %v)", gotID, pending, tt.wantID, tt.wantPending)
+			}
+			if len(tt.done) != len(tt.wantDone) {
+				t.Errorf("nextPartitionEvent() done = %v, want %v", tt.done, tt.wantDone)
+			}
+			mockCrud.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModule_processPartition(t *testing.T) {
+	// A partition which is already being processed must not get a second worker
+	m := &Module{}
+	m.processingPartitions.Store("rule/key", true)
+	m.processPartition(&model.EventDocument{RuleName: "rule", PartitionKey: "key"})
+	if _, p := m.processingPartitions.Load("rule/key"); !p {
+		t.Errorf("processPartition() removed the running partition")
+	}
+}
@@ -0,0 +1,98 @@
+package eventing
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/segmentio/ksuid"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// ReplayEvents queues the logged events matching the request again with their original payloads. The events are
+// queued as new events so that the logs of the original ones are kept intact. It returns the number of events queued
+func (m *Module) ReplayEvents(ctx context.Context, req *model.ReplayEventsRequest) (int, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	status := req.Status
+	if status == "" {
+		status = utils.EventStatusFailed
+	}
+	replayLimit := req.Limit
+	if replayLimit <= 0 {
+		replayLimit = limit
+	}
+
+	find := map[string]interface{}{"status": status}
+	if len(req.IDs) > 0 {
+		ids := make([]interface{}, len(req.IDs))
+		for i, id := range req.IDs {
+			ids[i] = id
+		}
+		find["_id"] = map[string]interface{}{"$in": ids}
+	}
+	if req.Trigger != "" {
+		find["rule_name"] = req.Trigger
+	}
+
+	dbAlias, col := m.config.DBAlias, utils.TableEventingLogs
+	readRequest := &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"ts"}, Limit: &replayLimit}, Find: find}
+	attr := map[string]string{"project": m.project, "db": dbAlias, "col": col}
+	results, _, err := m.crud.Read(ctx, dbAlias, col, readRequest, model.RequestParams{Resource: "db-read", Op: "access", Attributes: attr})
+	if err != nil {
+		return 0, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to read the events to be replayed", err, nil)
+	}
+
+	docs, _ := results.([]interface{})
+	token := rand.Intn(utils.MaxEventTokens)
+	batchID := m.generateBatchID()
+	timestamp := time.Now().Format(time.RFC3339Nano)
+
+	eventDocs := make([]*model.EventDocument, 0, len(docs))
+	for _, doc := range docs {
+		eventDoc := new(model.EventDocument)
+		if err := mapstructure.Decode(doc, eventDoc); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Could not covert object (%v) as event doc", doc), err, nil)
+			continue
+		}
+
+		// Skip the events of triggers which no longer exist
+		_, isRule := m.config.Rules[eventDoc.RuleName]
+		_, isInternalRule := m.config.InternalRules[eventDoc.RuleName]
+		if !isRule && !isInternalRule {
+			helpers.Logger.LogWarn(helpers.GetRequestID(ctx), fmt.Sprintf("Skipping replay of event (%s) since trigger (%s) does not exist", eventDoc.ID, eventDoc.RuleName), nil)
+			continue
+		}
+
+		eventDoc.ID = ksuid.New().String()
+		eventDoc.BatchID = batchID
+		// Events of a partition keep their token so that they are processed by the worker of the partition
+		if eventDoc.PartitionKey == "" {
+			eventDoc.Token = token
+		}
+		eventDoc.Timestamp = timestamp
+		eventDoc.EventTimestamp = ""
+		eventDoc.Status = utils.EventStatusStaged
+		eventDoc.Remark = ""
+		eventDocs = append(eventDocs, eventDoc)
+	}
+
+	if len(eventDocs) == 0 {
+		return 0, nil
+	}
+
+	if err := m.persistEvents(ctx, eventDocs); err != nil {
+		return 0, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to queue the replayed events", err, nil)
+	}
+
+	for _, eventDoc := range eventDocs {
+		m.metricHook(m.project, eventDoc.Type)
+	}
+	return len(eventDocs), nil
+}
@@ -0,0 +1,113 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func TestModule_ReplayEvents(t *testing.T) {
+	failedEvent := map[string]interface{}{"_id": "1", "batchid": "batch--node", "type": "DB_INSERT", "rule_name": "rule", "token": 10, "ts": "2020-01-01T00:00:00Z", "payload": `{"id":"1"}`, "status": utils.EventStatusFailed, "remark": "Max retires limit reached", "trigger_type": "external"}
+	deletedRuleEvent := map[string]interface{}{"_id": "2", "type": "DB_INSERT", "rule_name": "deleted", "ts": "2020-01-01T00:00:00Z", "payload": `{}`, "status": utils.EventStatusFailed}
+	customLimit := int64(10)
+
+	type mockArgs struct {
+		method         string
+		args           []interface{}
+		paramsReturned []interface{}
+	}
+	tests := []struct {
+		name         string
+		req          *model.ReplayEventsRequest
+		crudMockArgs []mockArgs
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "failed events of a trigger are replayed",
+			req:  &model.ReplayEventsRequest{Trigger: "rule"},
+			crudMockArgs: []mockArgs{
+				{
+					method:         "Read",
+					args:           []interface{}{mock.Anything, "db", utils.TableEventingLogs, &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"ts"}, Limit: &limit}, Find: map[string]interface{}{"status": utils.EventStatusFailed, "rule_name": "rule"}}},
+					paramsReturned: []interface{}{[]interface{}{failedEvent, deletedRuleEvent}, new(model.SQLMetaData), nil},
+				},
+				{
+					method: "InternalCreate",
+					args: []interface{}{mock.Anything, "db", "project", utils.TableEventingLogs, mock.MatchedBy(func(req *model.CreateRequest) bool {
+						docs := req.Document.([]interface{})
+						if len(docs) != 1 {
+							return false
+						}
+						doc := docs[0].(map[string]interface{})
+						return doc["_id"] != "1" && doc["rule_name"] == "rule" && doc["type"] == "DB_INSERT" && doc["payload"] == `{"id":"1"}` && doc["status"] == utils.EventStatusStaged && doc["remark"] == "" && doc["trigger_type"] == "external"
+					}), false},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "specific events are replayed",
+			req:  &model.ReplayEventsRequest{IDs: []string{"1", "3"}, Status: utils.EventStatusProcessed, Limit: customLimit},
+			crudMockArgs: []mockArgs{
+				{
+					method:         "Read",
+					args:           []interface{}{mock.Anything, "db", utils.TableEventingLogs, &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"ts"}, Limit: &customLimit}, Find: map[string]interface{}{"status": utils.EventStatusProcessed, "_id": map[string]interface{}{"$in": []interface{}{"1", "3"}}}}},
+					paramsReturned: []interface{}{[]interface{}{}, new(model.SQLMetaData), nil},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "events could not be read",
+			req:  &model.ReplayEventsRequest{},
+			crudMockArgs: []mockArgs{
+				{method: "Read", args: []interface{}{mock.Anything, "db", utils.TableEventingLogs, mock.Anything}, paramsReturned: []interface{}{[]interface{}{}, new(model.SQLMetaData), errors.New("some error")}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "events could not be queued",
+			req:  &model.ReplayEventsRequest{},
+			crudMockArgs: []mockArgs{
+				{method: "Read", args: []interface{}{mock.Anything, "db", utils.TableEventingLogs, mock.Anything}, paramsReturned: []interface{}{[]interface{}{failedEvent}, new(model.SQLMetaData), nil}},
+				{method: "InternalCreate", args: []interface{}{mock.Anything, "db", "project", utils.TableEventingLogs, mock.Anything, false}, paramsReturned: []interface{}{errors.New("some error")}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCrud := mockCrudInterface{}
+			mockSyncman := mockSyncmanEventingInterface{}
+			for _, a := range tt.crudMockArgs {
+				mockCrud.On(a.method, a.args...).Return(a.paramsReturned...)
+			}
+			mockSyncman.On("GetAssignedSpaceCloudID", mock.Anything, "project", mock.Anything).Return("node", nil)
+
+			m := &Module{
+				project:    "project",
+				nodeID:     "node",
+				config:     &config.Eventing{DBAlias: "db", Rules: config.EventingTriggers{"rule": {ID: "rule", Type: "DB_INSERT"}}},
+				crud:       &mockCrud,
+				syncMan:    &mockSyncman,
+				metricHook: func(project, eventingType string) {},
+			}
+			got, err := m.ReplayEvents(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReplayEvents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReplayEvents() = %v, want %v", got, tt.want)
+			}
+			mockCrud.AssertExpectations(t)
+		})
+	}
+}
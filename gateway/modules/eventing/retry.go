@@ -0,0 +1,63 @@
+package eventing
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+// defaultRetryInterval is the interval between retries of triggers which don't have a backoff configured
+const defaultRetryInterval = 5 * time.Second
+
+// retryInterval returns the time to wait before the provided retry (starting from 1) of an event
+func retryInterval(backoff *config.EventingBackoff, retry int) time.Duration {
+	if backoff == nil {
+		return defaultRetryInterval
+	}
+
+	interval := defaultRetryInterval
+	if backoff.Interval > 0 {
+		interval = time.Duration(backoff.Interval) * time.Millisecond
+	}
+
+	if backoff.Strategy == config.BackoffStrategyExponential || backoff.Strategy == config.BackoffStrategyJitter {
+		multiplier := backoff.Multiplier
+		if multiplier <= 1 {
+			multiplier = 2
+		}
+		// Guard against overflows for large retry counts
+		factor := math.Pow(multiplier, float64(retry-1))
+		if f := float64(interval) * factor; f < float64(math.MaxInt64) {
+			interval = time.Duration(f)
+		} else {
+			interval = time.Duration(math.MaxInt64)
+		}
+	}
+
+	if backoff.MaxInterval > 0 && interval > time.Duration(backoff.MaxInterval)*time.Millisecond {
+		interval = time.Duration(backoff.MaxInterval) * time.Millisecond
+	}
+
+	if backoff.Strategy == config.BackoffStrategyJitter && interval > 0 {
+		interval = time.Duration(rand.Int63n(int64(interval) + 1))
+	}
+	return interval
+}
+
+func validateBackoff(backoff *config.EventingBackoff) error {
+	if backoff == nil {
+		return nil
+	}
+	switch backoff.Strategy {
+	case config.BackoffStrategyFixed, config.BackoffStrategyExponential, config.BackoffStrategyJitter:
+	default:
+		return fmt.Errorf("invalid backoff strategy (%s) provided", backoff.Strategy)
+	}
+	if backoff.Interval < 0 || backoff.MaxInterval < 0 {
+		return fmt.Errorf("backoff intervals cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package eventing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+func Test_retryInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff *config.EventingBackoff
+		retry   int
+		want    time.Duration
+	}{
+		{name: "no backoff", retry: 3, want: defaultRetryInterval},
+		{name: "fixed", backoff: &config.EventingBackoff{Strategy: config.BackoffStrategyFixed, Interval: 1000}, retry: 3, want: time.Second},
+		{name: "fixed with default interval", backoff: &config.EventingBackoff{Strategy: config.BackoffStrategyFixed}, retry: 1, want: defaultRetryInterval},
+		{name: "exponential first retry", backoff: &config.EventingBackoff{Strategy: config.BackoffStrategyExponential, Interval: 100}, retry: 1, want: 100 * time.Millisecond},
+		{name: "exponential", backoff: &config.EventingBackoff{Strategy: config.BackoffStrategyExponential, Interval: 100}, retry: 4, want: 800 * time.Millisecond},
+		{name: "exponential with multiplier", backoff: &config.EventingBackoff{Strategy: config.BackoffStrategyExponential, Interval: 100, Multiplier: 3}, retry: 3, want: 900 * time.Millisecond},
+		{name: "exponential with max interval", backoff: &config.EventingBackoff{Strategy: config.BackoffStrategyExponential, Interval: 100, MaxInterval: 500}, retry: 10, want: 500 * time.Millisecond},
+		{name: "exponential does not overflow", backoff: &config.EventingBackoff{Strategy: config.BackoffStrategyExponential, Interval: 100, MaxInterval: 60000}, retry: 200, want: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryInterval(tt.backoff, tt.retry); got != tt.want {
+				t.Errorf("retryInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_retryInterval_jitter(t *testing.T) {
+	backoff := &config.EventingBackoff{Strategy: config.BackoffStrategyJitter, Interval: 100, MaxInterval: 1000}
+	for retry := 1; retry <= 10; retry++ {
+		max := 100 * time.Millisecond << uint(retry-1)
+		if max > time.Second {
+			max = time.Second
+		}
+		for i := 0; i < 20; i++ {
+			if got := retryInterval(backoff, retry); got < 0 || got > max {
+				t.Errorf("retryInterval() = %v for retry %d, want value in [0, %v]", got, retry, max)
+			}
+		}
+	}
+}
+
+func Test_validateBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff *config.EventingBackoff
+		wantErr bool
+	}{
+		{name: "no backoff"},
+		{name: "valid", backoff: &config.EventingBackoff{Strategy: config.BackoffStrategyJitter, Interval: 100, MaxInterval: 1000}},
+		{name: "invalid strategy", backoff: &config.EventingBackoff{Strategy: "linear"}, wantErr: true},
+		{name: "negative interval", backoff: &config.EventingBackoff{Strategy: config.BackoffStrategyFixed, Interval: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateBackoff(tt.backoff); (err != nil) != tt.wantErr {
+				t.Errorf("validateBackoff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -37,6 +37,20 @@ func (m *Module) routineProcessStaged() {
 	}
 }
 
+func (m *Module) routineProcessCronTriggers() {
+	m.tickerCron = time.NewTicker(5 * time.Second)
+	for t := range m.tickerCron.C {
+		m.processCronTriggers(t)
+	}
+}
+
+func (m *Module) routineProcessSources() {
+	m.tickerSources = time.NewTicker(5 * time.Second)
+	for range m.tickerSources.C {
+		m.processSources()
+	}
+}
+
 func (m *Module) routineHandleMessages() {
 	ch, err := m.pubsubClient.Subscribe(context.Background(), getEventingTopic(m.nodeID))
 	if err != nil {
@@ -0,0 +1,76 @@
+package eventing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/sink"
+)
+
+// invokeSink publishes the cloud event to the message broker of the trigger. The event is marked as processed once the
+// broker acknowledges it. Events are keyed by their partition key so that brokers preserve their order
+func (m *Module) invokeSink(ctx context.Context, rule *config.EventingTrigger, eventDoc *model.EventDocument, params interface{}) error {
+	m.sinksLock.RLock()
+	s, p := m.sinks[rule.ID]
+	m.sinksLock.RUnlock()
+	if !p {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Target of trigger (%s) has not been initialised", rule.ID), nil, nil)
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		if err := m.logInvocation(ctx, eventDoc.ID, data, 0, "", err.Error()); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to log invocation request", err, nil)
+		}
+		return err
+	}
+
+	key := eventDoc.PartitionKey
+	if key == "" {
+		key = eventDoc.ID
+	}
+
+	ctxLocal, cancel := context.WithTimeout(ctx, time.Duration(rule.Timeout)*time.Millisecond)
+	defer cancel()
+
+	if err := s.Publish(ctxLocal, key, data); err != nil {
+		if err := m.logInvocation(ctx, eventDoc.ID, data, 0, "", err.Error()); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to log invocation request", err, nil)
+		}
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("error publishing event to %s target of trigger (%s)", rule.Target.Type, rule.ID), err, nil)
+	}
+
+	if err := m.logInvocation(ctx, eventDoc.ID, data, http.StatusOK, "", ""); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to log invocation request", err, nil)
+	}
+
+	m.updateEventC <- &queueUpdateEvent{
+		project: m.project,
+		db:      m.config.DBAlias,
+		col:     utils.TableEventingLogs,
+		req:     m.generateProcessedEventRequest(eventDoc.ID),
+		err:     "Eventing: Couldn't update staged event to processed",
+	}
+	return nil
+}
+
+// closeSinks closes the connections to the message brokers of all triggers
+func (m *Module) closeSinks() {
+	m.sinksLock.Lock()
+	defer m.sinksLock.Unlock()
+
+	for name, s := range m.sinks {
+		if err := s.Close(); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Unable to close target of trigger (%s)", name), err, nil)
+		}
+	}
+	m.sinks = map[string]sink.Sink{}
+}
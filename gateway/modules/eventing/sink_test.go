@@ -0,0 +1,105 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/sink"
+)
+
+func TestModule_invokeSink(t *testing.T) {
+	type mockArgs struct {
+		method         string
+		args           []interface{}
+		paramsReturned []interface{}
+	}
+	rule := &config.EventingTrigger{ID: "orders", Timeout: 100, Target: &config.EventingTarget{Type: config.EventingTargetKafka, Brokers: []string{"localhost:9092"}, Topic: "orders"}}
+	payload := "{\"specversion\":\"1.0\",\"type\":\"DB_INSERT\",\"source\":\"\",\"id\":\"id\",\"time\":\"\",\"data\":{\"id\":\"1\"}}"
+	cloudEvent := &model.CloudEventPayload{SpecVersion: "1.0", Type: "DB_INSERT", ID: "id", Data: map[string]interface{}{"id": "1"}}
+	invocation := func(statusCode int, errorMsg string) *model.CreateRequest {
+		return &model.CreateRequest{Document: map[string]interface{}{"error_msg": errorMsg, "event_id": "id", "request_payload": payload, "response_body": "", "response_status_code": statusCode}, Operation: utils.One, IsBatch: true}
+	}
+	tests := []struct {
+		name          string
+		eventDoc      *model.EventDocument
+		noSink        bool
+		sinkMockArgs  []mockArgs
+		crudMockArgs  []mockArgs
+		wantProcessed bool
+		wantErr       bool
+	}{
+		{
+			name:     "event is keyed by its id",
+			eventDoc: &model.EventDocument{ID: "id"},
+			sinkMockArgs: []mockArgs{
+				{method: "Publish", args: []interface{}{"id", payload}, paramsReturned: []interface{}{nil}},
+			},
+			crudMockArgs: []mockArgs{
+				{method: "InternalCreate", args: []interface{}{mock.Anything, "db", "abc", utils.TableInvocationLogs, invocation(200, ""), false}, paramsReturned: []interface{}{nil}},
+			},
+			wantProcessed: true,
+		},
+		{
+			name:     "event is keyed by its partition key",
+			eventDoc: &model.EventDocument{ID: "id", PartitionKey: "user-1"},
+			sinkMockArgs: []mockArgs{
+				{method: "Publish", args: []interface{}{"user-1", payload}, paramsReturned: []interface{}{nil}},
+			},
+			crudMockArgs: []mockArgs{
+				{method: "InternalCreate", args: []interface{}{mock.Anything, "db", "abc", utils.TableInvocationLogs, invocation(200, ""), false}, paramsReturned: []interface{}{nil}},
+			},
+			wantProcessed: true,
+		},
+		{
+			name:     "broker does not acknowledge the event",
+			eventDoc: &model.EventDocument{ID: "id"},
+			sinkMockArgs: []mockArgs{
+				{method: "Publish", args: []interface{}{"id", payload}, paramsReturned: []interface{}{errors.New("not enough replicas")}},
+			},
+			crudMockArgs: []mockArgs{
+				{method: "InternalCreate", args: []interface{}{mock.Anything, "db", "abc", utils.TableInvocationLogs, invocation(0, "not enough replicas"), false}, paramsReturned: []interface{}{nil}},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "target has not been initialised",
+			eventDoc: &model.EventDocument{ID: "id"},
+			noSink:   true,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCrud := mockCrudInterface{}
+			mockSink := mockSink{}
+			for _, m := range tt.crudMockArgs {
+				mockCrud.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+			for _, m := range tt.sinkMockArgs {
+				mockSink.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+
+			m := &Module{project: "abc", config: &config.Eventing{DBAlias: "db"}, crud: &mockCrud, sinks: map[string]sink.Sink{}, updateEventC: make(chan *queueUpdateEvent, 5)}
+			if !tt.noSink {
+				m.sinks[rule.ID] = &mockSink
+			}
+
+			if err := m.invokeSink(context.Background(), rule, tt.eventDoc, cloudEvent); (err != nil) != tt.wantErr {
+				t.Errorf("Module.invokeSink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(m.updateEventC) == 1; got != tt.wantProcessed {
+				t.Errorf("Module.invokeSink() marked event as processed = %v, want %v", got, tt.wantProcessed)
+			}
+
+			mockCrud.AssertExpectations(t)
+			mockSink.AssertExpectations(t)
+		})
+	}
+}
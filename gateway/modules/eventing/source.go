@@ -0,0 +1,148 @@
+package eventing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils/sink"
+)
+
+// sourceRetryInterval is the time a consumer waits before connecting to its message broker again
+const sourceRetryInterval = 5 * time.Second
+
+// SetSourceConfig sets the message brokers events are consumed from. The running consumers are stopped and the leader
+// gateway starts them again with the new config
+func (m *Module) SetSourceConfig(sources config.EventingSources) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	newSources := make(map[string]*config.EventingSource, len(sources))
+	for _, source := range sources {
+		if source.EventType == "" {
+			return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Event type of source (%s) has not been provided", source.ID), nil, nil)
+		}
+		if err := sink.ValidateSource(source); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid source (%s) provided", source.ID), err, nil)
+		}
+		newSources[source.ID] = source
+	}
+
+	m.sources = newSources
+	m.stopConsumers()
+	return nil
+}
+
+// processSources starts the consumers of the sources which aren't running yet. Only the leader gateway consumes from the
+// sources so that every message is queued once
+func (m *Module) processSources() {
+	isLeader := false
+	if m.IsEnabled() {
+		var err error
+		if isLeader, err = m.syncMan.CheckIfLeaderGateway(m.nodeID); err != nil {
+			isLeader = false
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !isLeader {
+		m.stopConsumers()
+		return
+	}
+
+	for id, source := range m.sources {
+		if _, p := m.consumers[id]; p {
+			continue
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		m.consumers[id] = cancel
+		go m.consume(ctx, source)
+	}
+}
+
+// consume queues the messages of the source till the context is cancelled. The consumer connects to the message broker
+// again if the connection fails
+func (m *Module) consume(ctx context.Context, source *config.EventingSource) {
+	s := *source
+	if s.Group == "" {
+		s.Group = fmt.Sprintf("%s-%s", m.project, s.ID)
+	}
+
+	// Creating the consumer is retried as well so that the source doesn't stay registered as consumed without a consumer
+	var consumer sink.Consumer
+	defer func() {
+		if consumer != nil {
+			_ = consumer.Close()
+		}
+	}()
+
+	for {
+		if consumer == nil {
+			c, err := sink.NewConsumer(&s)
+			if err != nil {
+				_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to create consumer of source (%s)", s.ID), err, nil)
+			} else {
+				consumer = c
+			}
+		}
+
+		if consumer != nil {
+			if err := consumer.Consume(ctx, func(ctx context.Context, payload []byte) error {
+				return m.queueSourceEvent(ctx, &s, payload)
+			}); err != nil {
+				_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to consume events from source (%s)", s.ID), err, nil)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(sourceRetryInterval):
+		}
+	}
+}
+
+// queueSourceEvent queues a message of the source as an event of its event type. Messages which aren't valid events
+// are dropped since consuming them again wouldn't help
+func (m *Module) queueSourceEvent(ctx context.Context, source *config.EventingSource, payload []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(payload, &doc); err != nil {
+		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Dropping message of source (%s) since it isn't a json object", source.ID), err, nil)
+		return nil
+	}
+	req := &model.QueueEventRequest{Type: source.EventType, Payload: doc, Options: map[string]string{}}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if err := m.validate(ctx, m.project, source.Token, req); err != nil {
+		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Dropping message of source (%s) since it isn't a valid event", source.ID), err, nil)
+		return nil
+	}
+
+	if err := m.batchRequests(ctx, []*model.QueueEventRequest{req}, m.generateBatchID()); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to queue message of source (%s)", source.ID), err, nil)
+	}
+
+	m.metricHook(m.project, req.Type)
+	return nil
+}
+
+// stopConsumers stops all the running consumers
+func (m *Module) stopConsumers() {
+	for id, cancel := range m.consumers {
+		cancel()
+		delete(m.consumers, id)
+	}
+}
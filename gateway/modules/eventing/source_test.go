@@ -0,0 +1,198 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func TestModule_SetSourceConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources config.EventingSources
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "valid sources",
+			sources: config.EventingSources{
+				"orders":   {ID: "orders", Type: config.EventingSourceRedis, EventType: "order-created", URL: "redis://localhost:6379", Stream: "orders"},
+				"payments": {ID: "payments", Type: config.EventingSourceKafka, EventType: "payment-received", Brokers: []string{"localhost:9092"}, Topic: "payments"},
+			},
+			want: 2,
+		},
+		{
+			name:    "source without event type",
+			sources: config.EventingSources{"orders": {ID: "orders", Type: config.EventingSourceNATS, URL: "nats://localhost", Subject: "orders"}},
+			wantErr: true,
+		},
+		{
+			name:    "source without subject",
+			sources: config.EventingSources{"orders": {ID: "orders", Type: config.EventingSourceNATS, EventType: "order-created", URL: "nats://localhost"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stopped := false
+			m := &Module{config: &config.Eventing{}, sources: map[string]*config.EventingSource{}, consumers: map[string]context.CancelFunc{"old": func() { stopped = true }}}
+
+			if err := m.SetSourceConfig(tt.sources); (err != nil) != tt.wantErr {
+				t.Fatalf("Module.SetSourceConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(m.sources) != tt.want {
+				t.Errorf("Module.SetSourceConfig() sources = %v, want %d", m.sources, tt.want)
+			}
+			if !stopped || len(m.consumers) != 0 {
+				t.Errorf("Module.SetSourceConfig() didn't stop the running consumers")
+			}
+		})
+	}
+}
+
+func TestModule_consume(t *testing.T) {
+	// The queue group is invalid so the consumer can't be created
+	source := &config.EventingSource{ID: "orders", Type: config.EventingSourceNATS, EventType: "order-created", URL: "nats://localhost", Subject: "orders", Group: "invalid group"}
+	m := &Module{project: "project"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.consume(ctx, source)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatalf("Module.consume() returned before being stopped although creating the consumer is retried")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("Module.consume() didn't return once stopped")
+	}
+}
+
+func TestModule_queueSourceEvent(t *testing.T) {
+	type mockArgs struct {
+		method         string
+		args           []interface{}
+		paramsReturned []interface{}
+	}
+	source := &config.EventingSource{ID: "orders", Type: config.EventingSourceRedis, EventType: "order-created", Token: "token"}
+	tests := []struct {
+		name            string
+		payload         string
+		authMockArgs    []mockArgs
+		crudMockArgs    []mockArgs
+		syncmanMockArgs []mockArgs
+		wantErr         bool
+	}{
+		{
+			name:    "message which isn't a json object is dropped",
+			payload: "order",
+		},
+		{
+			name:    "message rejected by the security rules is dropped",
+			payload: `{"id":"1"}`,
+			authMockArgs: []mockArgs{
+				{
+					method:         "IsEventingOpAuthorised",
+					args:           []interface{}{mock.Anything, "project", "token", &model.QueueEventRequest{Type: "order-created", Payload: map[string]interface{}{"id": "1"}, Options: map[string]string{}}},
+					paramsReturned: []interface{}{model.RequestParams{}, errors.New("unauthorized")},
+				},
+			},
+		},
+		{
+			name:    "message is queued",
+			payload: `{"id":"1"}`,
+			authMockArgs: []mockArgs{
+				{
+					method:         "IsEventingOpAuthorised",
+					args:           []interface{}{mock.Anything, "project", "token", mock.Anything},
+					paramsReturned: []interface{}{model.RequestParams{}, nil},
+				},
+			},
+			crudMockArgs: []mockArgs{
+				{
+					method:         "InternalCreate",
+					args:           []interface{}{mock.Anything, "db", "project", utils.TableEventingLogs, mock.Anything, false},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+			syncmanMockArgs: []mockArgs{
+				{
+					method:         "GetAssignedSpaceCloudID",
+					args:           []interface{}{mock.Anything, mock.Anything, mock.Anything},
+					paramsReturned: []interface{}{"node", nil},
+				},
+			},
+		},
+		{
+			name:    "message is consumed again if it can't be queued",
+			payload: `{"id":"1"}`,
+			authMockArgs: []mockArgs{
+				{
+					method:         "IsEventingOpAuthorised",
+					args:           []interface{}{mock.Anything, "project", "token", mock.Anything},
+					paramsReturned: []interface{}{model.RequestParams{}, nil},
+				},
+			},
+			crudMockArgs: []mockArgs{
+				{
+					method:         "InternalCreate",
+					args:           []interface{}{mock.Anything, "db", "project", utils.TableEventingLogs, mock.Anything, false},
+					paramsReturned: []interface{}{errors.New("some error")},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuth := mockAuthEventingInterface{}
+			mockCrud := mockCrudInterface{}
+			mockSyncman := mockSyncmanEventingInterface{}
+			for _, m := range tt.authMockArgs {
+				mockAuth.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+			for _, m := range tt.crudMockArgs {
+				mockCrud.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+			for _, m := range tt.syncmanMockArgs {
+				mockSyncman.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+
+			m := &Module{
+				project:    "project",
+				config:     &config.Eventing{DBAlias: "db", Rules: map[string]*config.EventingTrigger{"notify": {ID: "notify", Type: "order-created", Options: map[string]string{}}}},
+				schemas:    map[string]model.Fields{},
+				auth:       &mockAuth,
+				crud:       &mockCrud,
+				syncMan:    &mockSyncman,
+				metricHook: func(project, eventingType string) {},
+			}
+
+			if err := m.queueSourceEvent(context.Background(), source, []byte(tt.payload)); (err != nil) != tt.wantErr {
+				t.Errorf("Module.queueSourceEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			mockAuth.AssertExpectations(t)
+			mockCrud.AssertExpectations(t)
+			mockSyncman.AssertExpectations(t)
+		})
+	}
+}
@@ -92,6 +92,11 @@ func (m *mockSyncmanEventingInterface) GetNodeID() string {
 	return c.String(0)
 }
 
+func (m *mockSyncmanEventingInterface) CheckIfLeaderGateway(nodeID string) (bool, error) {
+	c := m.Called(nodeID)
+	return c.Bool(0), c.Error(1)
+}
+
 func (m *mockSyncmanEventingInterface) GetSpaceCloudURLFromID(ctx context.Context, nodeID string) (string, error) {
 	c := m.Called(nodeID)
 	if len(c) > 1 {
@@ -141,3 +146,26 @@ func (m *mockFileStoreEventingInterface) DoesExists(ctx context.Context, project
 	c := m.Called(ctx, project, token, path)
 	return c.Error(0)
 }
+
+type mockFunctionsEventingInterface struct {
+	mock.Mock
+}
+
+func (m *mockFunctionsEventingInterface) CallWithContext(ctx context.Context, service, function, token string, reqParams model.RequestParams, req *model.FunctionsRequest) (int, interface{}, error) {
+	c := m.Called(service, function, token, req)
+	return c.Int(0), c.Get(1), c.Error(2)
+}
+
+type mockSink struct {
+	mock.Mock
+}
+
+func (m *mockSink) Publish(ctx context.Context, key string, payload []byte) error {
+	c := m.Called(key, string(payload))
+	return c.Error(0)
+}
+
+func (m *mockSink) Close() error {
+	c := m.Called()
+	return c.Error(0)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/managers/syncman"
 	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils/ratelimit"
 )
 
 // Module is responsible for functions
@@ -22,6 +23,7 @@ type Module struct {
 	manager        *syncman.Manager
 	integrationMan integrationManagerInterface
 	caching        cachingInterface
+	rateLimiter    ratelimit.Counter
 
 	// Variable configuration
 	project    string
@@ -69,6 +71,10 @@ func (m *Module) SetConfig(project string, c config.Services) error {
 				endpoint.Timeout = 60
 			}
 
+			if err := ratelimit.Validate(context.TODO(), endpoint.RateLimits); err != nil {
+				return err
+			}
+
 			switch endpoint.Tmpl {
 			case config.TemplatingEngineGo:
 				if endpoint.ReqTmpl != "" {
@@ -103,3 +109,8 @@ func (m *Module) SetConfig(project string, c config.Services) error {
 func (m *Module) SetCachingModule(c cachingInterface) {
 	m.caching = c
 }
+
+// SetRateLimiter sets the counters used to rate limit the calls made to endpoints
+func (m *Module) SetRateLimiter(rateLimiter ratelimit.Counter) {
+	m.rateLimiter = rateLimiter
+}
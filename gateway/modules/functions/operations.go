@@ -8,6 +8,7 @@ import (
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils/ratelimit"
 )
 
 // CallWithContext invokes function on a service. The response from the function is returned back along with
@@ -52,3 +53,23 @@ func (m *Module) GetEndpointContextTimeout(ctx context.Context, projectID, servi
 	}
 	return 0, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Could not find endpoint (%s) for service (%s)", function, service), nil, nil)
 }
+
+// ApplyRateLimits counts a call made by a client against the rate limits of the endpoint. It returns nil if the
+// endpoint isn't rate limited
+func (m *Module) ApplyRateLimits(ctx context.Context, service, function string, args map[string]interface{}) *model.RateLimitStatus {
+	m.lock.RLock()
+	var rateLimits []*config.RateLimit
+	resourceID := config.GenerateResourceID(m.clusterID, m.project, config.ResourceRemoteService, service)
+	if serviceVal, ok := m.config[resourceID]; ok {
+		if endpoint, ok := serviceVal.Endpoints[function]; ok {
+			rateLimits = endpoint.RateLimits
+		}
+	}
+	project, rateLimiter := m.project, m.rateLimiter
+	m.lock.RUnlock()
+
+	if len(rateLimits) == 0 {
+		return nil
+	}
+	return ratelimit.Apply(ctx, rateLimiter, fmt.Sprintf("function-%s-%s-%s", project, service, function), rateLimits, args)
+}
@@ -1,11 +1,14 @@
 package global
 
 import (
+	"os"
+
 	"github.com/spaceuptech/space-cloud/gateway/managers"
 	"github.com/spaceuptech/space-cloud/gateway/modules/global/caching"
 	"github.com/spaceuptech/space-cloud/gateway/modules/global/letsencrypt"
 	"github.com/spaceuptech/space-cloud/gateway/modules/global/metrics"
 	"github.com/spaceuptech/space-cloud/gateway/modules/global/routing"
+	"github.com/spaceuptech/space-cloud/gateway/utils/pubsub"
 )
 
 // Global holds global modules
@@ -38,6 +41,13 @@ func New(clusterID, nodeID string, isDev bool, managers *managers.Managers) (*Gl
 	c.SetAdminModule(managers.Admin())
 	r.SetCachingModule(c)
 
+	// The rate limits of the routes are counted in redis so that they are shared by all gateways
+	rateLimiter, err := pubsub.New(clusterID, os.Getenv("REDIS_CONN"))
+	if err != nil {
+		return nil, err
+	}
+	r.SetRateLimiter(rateLimiter)
+
 	return &Global{letsencrypt: le, metrics: m, routing: r, caching: c}, nil
 }
 
@@ -0,0 +1,339 @@
+package routing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+const (
+	defaultHealthCheckInterval = 10
+	defaultHealthCheckTimeout  = 2
+	defaultHealthyThreshold    = 2
+	defaultUnhealthyThreshold  = 3
+	defaultConsecutiveErrors   = 5
+	defaultEjectionTime        = 30
+
+	// maxEjectionTimeMultiplier caps the ejection time of a target which keeps failing its probes
+	maxEjectionTimeMultiplier = 10
+)
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// routeHealth tracks the health of the targets of a route
+type routeHealth struct {
+	lock sync.Mutex
+
+	healthCheck      *config.RouteHealthCheck
+	outlierDetection *config.RouteOutlierDetection
+	targets          map[string]*targetHealth
+}
+
+// targetHealth combines the result of the active health checks of a target with a circuit breaker driven by the
+// responses of the requests routed to it
+type targetHealth struct {
+	// Consecutive results of the active health checks
+	unhealthy      bool
+	checkSuccesses int
+	checkFailures  int
+
+	// Circuit breaker
+	circuit         circuitState
+	errors          int
+	ejections       int
+	retryAt         time.Time
+	probeInProgress bool
+}
+
+func newRouteHealth(route *config.Route) *routeHealth {
+	return &routeHealth{healthCheck: route.HealthCheck, outlierDetection: route.OutlierDetection, targets: map[string]*targetHealth{}}
+}
+
+func getTargetKey(target config.RouteTarget) string {
+	scheme := target.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, target.Host, target.Port)
+}
+
+func (h *routeHealth) getTarget(target config.RouteTarget) *targetHealth {
+	key := getTargetKey(target)
+	t, ok := h.targets[key]
+	if !ok {
+		t = &targetHealth{}
+		h.targets[key] = t
+	}
+	return t
+}
+
+// selectTarget selects one of the targets which are healthy and whose circuit isn't open. A target whose ejection
+// time has elapsed is selected for a single probe request
+func (h *routeHealth) selectTarget(ctx context.Context, route *config.Route, exclude []config.RouteTarget, now time.Time) (config.RouteTarget, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	target, err := route.SelectAvailableTarget(ctx, func(target config.RouteTarget) bool {
+		return !containsTarget(exclude, target) && h.getTarget(target).isAvailable(now)
+	})
+	if err != nil {
+		return config.RouteTarget{}, err
+	}
+
+	// The probe is given as long as the last ejection to complete. Another probe is sent if its result is never
+	// reported, for instance because the response was served from the cache
+	if t := h.getTarget(target); t.circuit != circuitClosed {
+		t.circuit, t.probeInProgress = circuitHalfOpen, true
+		t.retryAt = now.Add(h.ejectionTime(t.ejections))
+	}
+	return target, nil
+}
+
+func (t *targetHealth) isAvailable(now time.Time) bool {
+	if t.unhealthy {
+		return false
+	}
+
+	switch t.circuit {
+	case circuitOpen:
+		return !now.Before(t.retryAt)
+	case circuitHalfOpen:
+		return !t.probeInProgress || !now.Before(t.retryAt)
+	default:
+		return true
+	}
+}
+
+// reportResult updates the circuit of a target with the outcome of a request routed to it. A request fails if the
+// target couldn't be reached or it responded with a 5xx status code
+func (h *routeHealth) reportResult(target config.RouteTarget, failed bool, now time.Time) {
+	if h.outlierDetection == nil {
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	t := h.getTarget(target)
+	if !failed {
+		t.circuit, t.errors, t.ejections, t.probeInProgress = circuitClosed, 0, 0, false
+		return
+	}
+
+	t.errors++
+	threshold := h.outlierDetection.ConsecutiveErrors
+	if threshold <= 0 {
+		threshold = defaultConsecutiveErrors
+	}
+
+	// A failed probe ejects the target right away
+	if t.circuit == circuitHalfOpen || t.errors >= threshold {
+		// The ejection time grows with every consecutive ejection
+		if t.ejections < maxEjectionTimeMultiplier {
+			t.ejections++
+		}
+		t.circuit, t.errors, t.probeInProgress = circuitOpen, 0, false
+		t.retryAt = now.Add(h.ejectionTime(t.ejections))
+	}
+}
+
+func (h *routeHealth) ejectionTime(ejections int) time.Duration {
+	ejectionTime := defaultEjectionTime
+	if h.outlierDetection != nil && h.outlierDetection.EjectionTime > 0 {
+		ejectionTime = h.outlierDetection.EjectionTime
+	}
+	return time.Duration(ejectionTime*ejections) * time.Second
+}
+
+// reportHealthCheck updates the health of a target with the outcome of an active health check
+func (h *routeHealth) reportHealthCheck(target config.RouteTarget, healthy bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	t := h.getTarget(target)
+	if healthy {
+		t.checkSuccesses, t.checkFailures = t.checkSuccesses+1, 0
+		threshold := h.healthCheck.HealthyThreshold
+		if threshold <= 0 {
+			threshold = defaultHealthyThreshold
+		}
+		if t.unhealthy && t.checkSuccesses >= threshold {
+			t.unhealthy = false
+		}
+		return
+	}
+
+	t.checkSuccesses, t.checkFailures = 0, t.checkFailures+1
+	threshold := h.healthCheck.UnhealthyThreshold
+	if threshold <= 0 {
+		threshold = defaultUnhealthyThreshold
+	}
+	if !t.unhealthy && t.checkFailures >= threshold {
+		t.unhealthy = true
+	}
+}
+
+// routineHealthCheck checks the health of a target periodically till the context is cancelled
+func (h *routeHealth) routineHealthCheck(ctx context.Context, target config.RouteTarget) {
+	interval := h.healthCheck.Interval
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	timeout := h.healthCheck.Timeout
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+
+	client := &http.Client{Timeout: time.Duration(timeout) * time.Second}
+	if target.Scheme == config.RouteTargetSchemeH2C {
+		client.Transport = h2cTransport
+	}
+	url := fmt.Sprintf("%s://%s:%d%s", getTargetScheme(target), target.Host, target.Port, h.healthCheck.Path)
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.reportHealthCheck(target, checkHealth(ctx, client, url))
+		}
+	}
+}
+
+func checkHealth(ctx context.Context, client *http.Client, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), fmt.Sprintf("Health check of (%s) failed", url), map[string]interface{}{"error": err.Error()})
+		return false
+	}
+	utils.CloseTheCloser(res.Body)
+
+	return res.StatusCode >= 200 && res.StatusCode < 400
+}
+
+// setRouteHealth resets the health of the routes of a project and starts the active health checks of their targets
+func (r *Routing) setRouteHealth(project string, routes config.Routes) {
+	r.deleteRouteHealth(project)
+
+	if r.health == nil {
+		r.health = map[string]*routeHealth{}
+	}
+	if r.healthCheckers == nil {
+		r.healthCheckers = map[string]context.CancelFunc{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.healthCheckers[project] = cancel
+
+	for _, route := range routes {
+		if route.HealthCheck == nil && route.OutlierDetection == nil {
+			continue
+		}
+
+		health := newRouteHealth(route)
+		r.health[getRouteHealthKey(project, route.ID)] = health
+
+		if route.HealthCheck != nil {
+			for _, target := range route.Targets {
+				go health.routineHealthCheck(ctx, target)
+			}
+		}
+	}
+}
+
+// deleteRouteHealth stops the active health checks of the routes of a project
+func (r *Routing) deleteRouteHealth(project string) {
+	if cancel, ok := r.healthCheckers[project]; ok {
+		cancel()
+		delete(r.healthCheckers, project)
+	}
+
+	for key := range r.health {
+		if strings.HasPrefix(key, getRouteHealthKey(project, "")) {
+			delete(r.health, key)
+		}
+	}
+}
+
+func (r *Routing) getRouteHealth(route *config.Route) *routeHealth {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.health[getRouteHealthKey(route.Project, route.ID)]
+}
+
+func getRouteHealthKey(project, routeID string) string {
+	return fmt.Sprintf("%s---%s", project, routeID)
+}
+
+// selectTarget selects the target of a route. Unhealthy targets are skipped if the route tracks their health. The
+// targets to exclude are only selected if no other target is available
+func (r *Routing) selectTarget(ctx context.Context, route *config.Route, exclude []config.RouteTarget) (config.RouteTarget, error) {
+	health := r.getRouteHealth(route)
+	if health == nil {
+		if !hasOtherTargets(route, exclude) {
+			return route.SelectTarget(ctx, -1) // pass a -ve weight to randomly generate
+		}
+		return route.SelectAvailableTarget(ctx, func(target config.RouteTarget) bool {
+			return !containsTarget(exclude, target)
+		})
+	}
+
+	if hasOtherTargets(route, exclude) {
+		if target, err := health.selectTarget(ctx, route, exclude, time.Now()); err == nil {
+			return target, nil
+		}
+	}
+	return health.selectTarget(ctx, route, nil, time.Now())
+}
+
+// hasOtherTargets checks if the route has a target with a weight which isn't excluded
+func hasOtherTargets(route *config.Route, exclude []config.RouteTarget) bool {
+	if len(exclude) == 0 {
+		return false
+	}
+	for _, target := range route.Targets {
+		if target.Weight > 0 && !containsTarget(exclude, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsTarget(targets []config.RouteTarget, target config.RouteTarget) bool {
+	for _, t := range targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+// reportResult records the outcome of a request routed to a target
+func (r *Routing) reportResult(route *config.Route, target config.RouteTarget, res *http.Response, err error) {
+	if health := r.getRouteHealth(route); health != nil {
+		health.reportResult(target, err != nil || res.StatusCode >= http.StatusInternalServerError, time.Now())
+	}
+}
@@ -0,0 +1,199 @@
+package routing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+func Test_routeHealth_reportResult(t *testing.T) {
+	now := time.Now()
+	target := config.RouteTarget{Host: "1", Port: 8080, Weight: 100}
+
+	tests := []struct {
+		name             string
+		outlierDetection *config.RouteOutlierDetection
+		state            *targetHealth
+		failed           bool
+		want             *targetHealth
+	}{
+		{
+			name:   "ignore results if outlier detection is disabled",
+			state:  &targetHealth{errors: 4},
+			failed: true,
+			want:   &targetHealth{errors: 4},
+		},
+		{
+			name:             "count consecutive errors",
+			outlierDetection: &config.RouteOutlierDetection{ConsecutiveErrors: 3},
+			state:            &targetHealth{errors: 1},
+			failed:           true,
+			want:             &targetHealth{errors: 2},
+		},
+		{
+			name:             "reset errors on success",
+			outlierDetection: &config.RouteOutlierDetection{ConsecutiveErrors: 3},
+			state:            &targetHealth{errors: 2},
+			want:             &targetHealth{},
+		},
+		{
+			name:             "eject target once errors reach the threshold",
+			outlierDetection: &config.RouteOutlierDetection{ConsecutiveErrors: 3, EjectionTime: 10},
+			state:            &targetHealth{errors: 2},
+			failed:           true,
+			want:             &targetHealth{circuit: circuitOpen, ejections: 1, retryAt: now.Add(10 * time.Second)},
+		},
+		{
+			name:             "eject target for longer if probe fails",
+			outlierDetection: &config.RouteOutlierDetection{ConsecutiveErrors: 3, EjectionTime: 10},
+			state:            &targetHealth{circuit: circuitHalfOpen, ejections: 1, probeInProgress: true},
+			failed:           true,
+			want:             &targetHealth{circuit: circuitOpen, ejections: 2, retryAt: now.Add(20 * time.Second)},
+		},
+		{
+			name:             "cap ejection time",
+			outlierDetection: &config.RouteOutlierDetection{ConsecutiveErrors: 3, EjectionTime: 10},
+			state:            &targetHealth{circuit: circuitHalfOpen, ejections: maxEjectionTimeMultiplier, probeInProgress: true},
+			failed:           true,
+			want:             &targetHealth{circuit: circuitOpen, ejections: maxEjectionTimeMultiplier, retryAt: now.Add(100 * time.Second)},
+		},
+		{
+			name:             "close circuit if probe succeeds",
+			outlierDetection: &config.RouteOutlierDetection{},
+			state:            &targetHealth{circuit: circuitHalfOpen, ejections: 2, probeInProgress: true},
+			want:             &targetHealth{},
+		},
+		{
+			name:             "use default threshold",
+			outlierDetection: &config.RouteOutlierDetection{},
+			state:            &targetHealth{errors: defaultConsecutiveErrors - 1},
+			failed:           true,
+			want:             &targetHealth{circuit: circuitOpen, ejections: 1, retryAt: now.Add(defaultEjectionTime * time.Second)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &routeHealth{outlierDetection: tt.outlierDetection, targets: map[string]*targetHealth{getTargetKey(target): tt.state}}
+			h.reportResult(target, tt.failed, now)
+			if got := h.targets[getTargetKey(target)]; *got != *tt.want {
+				t.Errorf("reportResult() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_routeHealth_selectTarget(t *testing.T) {
+	now := time.Now()
+	target1 := config.RouteTarget{Host: "1", Port: 8080, Weight: 50}
+	target2 := config.RouteTarget{Host: "2", Port: 8080, Weight: 50}
+
+	tests := []struct {
+		name      string
+		states    map[string]*targetHealth
+		want      config.RouteTarget
+		wantState *targetHealth
+		wantErr   bool
+	}{
+		{
+			name:      "skip unhealthy target",
+			states:    map[string]*targetHealth{getTargetKey(target1): {unhealthy: true}},
+			want:      target2,
+			wantState: &targetHealth{},
+		},
+		{
+			name:      "skip ejected target",
+			states:    map[string]*targetHealth{getTargetKey(target1): {circuit: circuitOpen, ejections: 1, retryAt: now.Add(time.Second)}},
+			want:      target2,
+			wantState: &targetHealth{},
+		},
+		{
+			name: "probe target once ejection time elapses",
+			states: map[string]*targetHealth{
+				getTargetKey(target1): {circuit: circuitOpen, ejections: 1, retryAt: now},
+				getTargetKey(target2): {unhealthy: true},
+			},
+			want:      target1,
+			wantState: &targetHealth{circuit: circuitHalfOpen, ejections: 1, probeInProgress: true, retryAt: now.Add(defaultEjectionTime * time.Second)},
+		},
+		{
+			name: "skip target being probed",
+			states: map[string]*targetHealth{
+				getTargetKey(target1): {circuit: circuitHalfOpen, ejections: 1, probeInProgress: true, retryAt: now.Add(time.Second)},
+			},
+			want:      target2,
+			wantState: &targetHealth{},
+		},
+		{
+			name: "no target available",
+			states: map[string]*targetHealth{
+				getTargetKey(target1): {unhealthy: true},
+				getTargetKey(target2): {circuit: circuitOpen, ejections: 1, retryAt: now.Add(time.Second)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &routeHealth{outlierDetection: &config.RouteOutlierDetection{}, targets: tt.states}
+			got, err := h.selectTarget(context.Background(), &config.Route{Targets: []config.RouteTarget{target1, target2}}, nil, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("selectTarget() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("selectTarget() got = %v, want %v", got, tt.want)
+			}
+			if state := h.targets[getTargetKey(got)]; *state != *tt.wantState {
+				t.Errorf("selectTarget() state = %+v, want %+v", state, tt.wantState)
+			}
+		})
+	}
+}
+
+func Test_routeHealth_reportHealthCheck(t *testing.T) {
+	target := config.RouteTarget{Host: "1", Port: 8080, Weight: 100}
+
+	tests := []struct {
+		name    string
+		state   *targetHealth
+		healthy bool
+		want    *targetHealth
+	}{
+		{
+			name:  "count consecutive failures",
+			state: &targetHealth{checkSuccesses: 3},
+			want:  &targetHealth{checkFailures: 1},
+		},
+		{
+			name:  "mark target unhealthy once failures reach the threshold",
+			state: &targetHealth{checkFailures: 2},
+			want:  &targetHealth{unhealthy: true, checkFailures: 3},
+		},
+		{
+			name:    "keep target unhealthy till successes reach the threshold",
+			state:   &targetHealth{unhealthy: true, checkFailures: 5},
+			healthy: true,
+			want:    &targetHealth{unhealthy: true, checkSuccesses: 1},
+		},
+		{
+			name:    "mark target healthy once successes reach the threshold",
+			state:   &targetHealth{unhealthy: true, checkSuccesses: 1},
+			healthy: true,
+			want:    &targetHealth{checkSuccesses: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &routeHealth{healthCheck: &config.RouteHealthCheck{HealthyThreshold: 2, UnhealthyThreshold: 3}, targets: map[string]*targetHealth{getTargetKey(target): tt.state}}
+			h.reportHealthCheck(target, tt.healthy)
+			if got := h.targets[getTargetKey(target)]; *got != *tt.want {
+				t.Errorf("reportHealthCheck() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
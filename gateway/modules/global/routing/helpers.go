@@ -11,7 +11,8 @@ import (
 	tmpl2 "github.com/spaceuptech/space-cloud/gateway/utils/tmpl"
 )
 
-func (r *Routing) createGoTemplate(kind, project, id, tmpl string) error {
+// createGoTemplate parses the template and adds it to the templates provided
+func createGoTemplate(templates map[string]*template.Template, kind, project, id, tmpl string) error {
 	key := getGoTemplateKey(kind, project, id)
 
 	// Create a new template object
@@ -22,7 +23,7 @@ func (r *Routing) createGoTemplate(kind, project, id, tmpl string) error {
 		return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Invalid golang template provided", err, nil)
 	}
 
-	r.goTemplates[key] = val
+	templates[key] = val
 	return nil
 }
 
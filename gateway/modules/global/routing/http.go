@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -17,6 +18,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/modules/auth"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/ratelimit"
 )
 
 type modulesInterface interface {
@@ -35,17 +37,31 @@ func (r *Routing) HandleRoutes(modules modulesInterface) http.HandlerFunc {
 		// Close the body of the request
 		defer utils.CloseTheCloser(request.Body)
 
-		// Extract the host and url to select route
-		host, url := getHostAndURL(request)
+		if err := validateUpgradeRequest(request); err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(writer).Encode(map[string]string{"error": err.Error()})
+			return
+		}
 
-		// Select a route based on host and url
-		route, err := r.selectRoute(request.Context(), host, request.Method, url)
+		// Select a route based on host, url and the other attributes of the request
+		loadClaims := newClaimsLoader(request.Context(), modules, utils.GetTokenFromHeader(request))
+		route, err := r.selectRoute(request.Context(), request, loadClaims)
 		if err != nil {
 			writer.WriteHeader(http.StatusBadRequest)
 			_ = json.NewEncoder(writer).Encode(map[string]string{"error": err.Error()})
 			return
 		}
 
+		// Rate limit the request before doing anything else for it
+		if status := r.applyRateLimits(request.Context(), route, request, loadClaims); status != nil {
+			ratelimit.SetHeaders(writer.Header(), status)
+			if !status.Allowed {
+				writer.WriteHeader(http.StatusTooManyRequests)
+				_ = json.NewEncoder(writer).Encode(map[string]string{"error": fmt.Sprintf("rate limit exceeded: retry after %v", status.RetryAfter)})
+				return
+			}
+		}
+
 		token, claims, status, err := r.modifyRequest(request.Context(), modules, route, request)
 		if err != nil {
 			writer.WriteHeader(status)
@@ -57,17 +73,24 @@ func (r *Routing) HandleRoutes(modules modulesInterface) http.HandlerFunc {
 
 		// Apply the rewrite url if provided. It is the users responsibility to make sure both url
 		// and rewrite url starts with a '/'
-		url = rewriteURL(url, route)
-
-		// Proxy the request
+		_, url := getHostAndURL(request)
+		if route.Source.Type == config.RouteRegex {
+			url = r.rewriteRegexURL(url, route)
+		} else {
+			url = rewriteURL(url, route)
+		}
 
-		if err := setRequest(request.Context(), request, route, url); err != nil {
-			writer.WriteHeader(http.StatusInternalServerError)
+		// Select a target skipping the unhealthy ones
+		target, err := r.selectTarget(request.Context(), route, nil)
+		if err != nil {
+			writer.WriteHeader(http.StatusServiceUnavailable)
 			_ = json.NewEncoder(writer).Encode(map[string]string{"error": err.Error()})
-			_ = helpers.Logger.LogError(helpers.GetRequestID(request.Context()), fmt.Sprintf("Failed set request for route (%v)", route), err, nil)
 			return
 		}
 
+		// Proxy the request
+		setRequest(request, target, url)
+
 		var redisKey string
 		if route.IsRouteCacheable && request.Method == http.MethodGet {
 			cacheOptionsArray := make([]interface{}, 0)
@@ -100,16 +123,32 @@ func (r *Routing) HandleRoutes(modules modulesInterface) http.HandlerFunc {
 			redisKey = key
 		}
 
-		// TODO: Use http2 client if that was the incoming request protocol
-		response, err := httpClient.Do(request)
+		response, err := r.proxyRequest(request.Context(), route, request, target, url)
 		if err != nil {
-			writer.WriteHeader(http.StatusInternalServerError)
+			status := http.StatusBadGateway
+			if errors.Is(err, context.DeadlineExceeded) {
+				status = http.StatusGatewayTimeout
+			}
+			writer.WriteHeader(status)
 			_ = json.NewEncoder(writer).Encode(map[string]string{"error": err.Error()})
 			_ = helpers.Logger.LogError(helpers.GetRequestID(request.Context()), fmt.Sprintf("Failed to make request for route (%v)", route), err, nil)
 			return
 		}
 		defer utils.CloseTheCloser(response.Body)
 
+		// Tunnel the connection to the target if it switched protocols. Targets may only do so if the client asked for it
+		if response.StatusCode == http.StatusSwitchingProtocols {
+			if !isUpgradeRequest(request) {
+				writer.WriteHeader(http.StatusBadGateway)
+				_ = json.NewEncoder(writer).Encode(map[string]string{"error": "target switched protocols without being asked to"})
+				return
+			}
+			if err := tunnelUpgradedConnection(writer, response); err != nil {
+				_ = helpers.Logger.LogError(helpers.GetRequestID(request.Context()), fmt.Sprintf("Failed to tunnel upgraded connection for route (%s)", route.ID), err, nil)
+			}
+			return
+		}
+
 		if err := r.modifyResponse(request.Context(), response, route, token, claims); err != nil {
 			writer.WriteHeader(http.StatusInternalServerError)
 			_ = json.NewEncoder(writer).Encode(map[string]string{"error": err.Error()})
@@ -149,14 +188,8 @@ func (r *Routing) HandleRoutes(modules modulesInterface) http.HandlerFunc {
 			}
 		}
 
-		// Copy headers and status code
-		for k, v := range response.Header {
-			writer.Header()[k] = v
-		}
-		writer.WriteHeader(response.StatusCode)
-
-		// Copy the body
-		n, err := io.Copy(writer, response.Body)
+		// Copy headers, status code and body
+		n, err := writeResponse(writer, response)
 		if err != nil {
 			_ = helpers.Logger.LogError(helpers.GetRequestID(request.Context()), fmt.Sprintf("Failed to copy upstream (%s) response to downstream", request.URL.String()), err, nil)
 		}
@@ -165,6 +198,17 @@ func (r *Routing) HandleRoutes(modules modulesInterface) http.HandlerFunc {
 	}
 }
 
+// applyRateLimits counts the request against the rate limits of the route. The counters of a route are shared by all
+// gateways of the cluster
+func (r *Routing) applyRateLimits(ctx context.Context, route *config.Route, request *http.Request, loadClaims claimsLoader) *model.RateLimitStatus {
+	if len(route.RateLimits) == 0 {
+		return nil
+	}
+
+	args := ratelimit.NewArgs(request, loadClaims(route.Project), utils.GetTokenFromHeader(request))
+	return ratelimit.Apply(ctx, r.rateLimiter, fmt.Sprintf("route-%s-%s", route.Project, route.ID), route.RateLimits, args)
+}
+
 func getHostAndURL(request *http.Request) (string, string) {
 	return strings.Split(request.Host, ":")[0], request.URL.Path
 }
@@ -180,27 +224,18 @@ func rewriteURL(url string, route *config.Route) string {
 	return url
 }
 
-func setRequest(ctx context.Context, request *http.Request, route *config.Route, url string) error {
+func setRequest(request *http.Request, target config.RouteTarget, url string) {
 	// http: Request.RequestURI can't be set in client requests.
 	// http://golang.org/src/pkg/net/http/client.go
 	request.RequestURI = ""
 
 	// Change the request with the destination host, port and url
-	target, err := route.SelectTarget(ctx, -1) // pass a -ve weight to randomly generate
-	if err != nil {
-		return err
-	}
-
 	request.Host = target.Host
 	request.URL.Host = fmt.Sprintf("%s:%d", target.Host, target.Port)
 	request.URL.Path = url
 
 	// Set the url scheme to http
-	if target.Scheme == "" {
-		target.Scheme = "http"
-	}
-	request.URL.Scheme = target.Scheme
-	return nil
+	request.URL.Scheme = getTargetScheme(target)
 }
 
 func prepareHeaders(headers config.Headers, state map[string]interface{}) config.Headers {
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
 )
 
 func Test_getHostAndURL(t *testing.T) {
@@ -123,7 +124,7 @@ func Test_setRequest(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_ = setRequest(context.Background(), tt.args.request, tt.args.route, tt.args.url)
+			setRequest(tt.args.request, tt.args.route.Targets[0], tt.args.url)
 			if !reflect.DeepEqual(tt.args.request, tt.want) {
 				t.Errorf("Routing.addProjectRoutes(): wanted - %v; got - %v", tt.want, tt.args.request)
 
@@ -136,3 +137,38 @@ func Test_setRequest(t *testing.T) {
 		})
 	}
 }
+
+type fakeRateLimiter struct {
+	keys []string
+}
+
+func (f *fakeRateLimiter) CountRequest(ctx context.Context, key string, rateLimit *config.RateLimit) (*model.RateLimitStatus, error) {
+	f.keys = append(f.keys, key)
+	return &model.RateLimitStatus{Allowed: len(f.keys) <= rateLimit.Limit, Limit: rateLimit.Limit}, nil
+}
+
+func TestRouting_applyRateLimits(t *testing.T) {
+	route := &config.Route{ID: "route1", Project: "project1", RateLimits: []*config.RateLimit{{Key: "args.auth.id", Limit: 1, Window: 60}}}
+	loadClaims := func(project string) map[string]interface{} {
+		return map[string]interface{}{"id": "user1"}
+	}
+	request, _ := http.NewRequest(http.MethodGet, "http://localhost/v1/api", nil)
+
+	limiter := &fakeRateLimiter{}
+	r := &Routing{rateLimiter: limiter}
+
+	if status := r.applyRateLimits(context.Background(), &config.Route{ID: "route2"}, request, loadClaims); status != nil {
+		t.Errorf("applyRateLimits() = %v for route without rate limits, want nil", status)
+	}
+	if status := r.applyRateLimits(context.Background(), route, request, loadClaims); status == nil || !status.Allowed {
+		t.Errorf("applyRateLimits() = %v for first request, want allowed", status)
+	}
+	if status := r.applyRateLimits(context.Background(), route, request, loadClaims); status == nil || status.Allowed {
+		t.Errorf("applyRateLimits() = %v for second request, want denied", status)
+	}
+
+	want := []string{"route-project1-route1-0-args.auth.id-user1", "route-project1-route1-0-args.auth.id-user1"}
+	if !reflect.DeepEqual(limiter.keys, want) {
+		t.Errorf("applyRateLimits() counted keys = %v, want %v", limiter.keys, want)
+	}
+}
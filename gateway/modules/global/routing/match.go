@@ -0,0 +1,195 @@
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// claimsLoader returns the claims of the token of a request for a project. It returns nil if the token isn't valid
+type claimsLoader func(project string) map[string]interface{}
+
+// newClaimsLoader parses the token of a request only if a route needs its claims. The claims are parsed once per project
+// since every project verifies tokens with its own secrets
+func newClaimsLoader(ctx context.Context, modules modulesInterface, token string) claimsLoader {
+	cache := map[string]map[string]interface{}{}
+	return func(project string) map[string]interface{} {
+		if claims, ok := cache[project]; ok {
+			return claims
+		}
+
+		var claims map[string]interface{}
+		if token != "" {
+			if a, err := modules.Auth(project); err == nil {
+				claims, _ = a.ParseToken(ctx, token)
+			}
+		}
+		cache[project] = claims
+		return claims
+	}
+}
+
+// createRouteRegexps compiles the regular expressions used to match the requests of a route and adds them to the
+// regexps provided
+func createRouteRegexps(regexps map[string]*regexp.Regexp, route *config.Route) error {
+	if route.Source.Type == config.RouteRegex {
+		if err := createRegexp(regexps, "url", route.Project, route.ID, route.Source.URL, false); err != nil {
+			return err
+		}
+	}
+
+	matchers := map[string][]*config.RouteMatcher{"headers": route.Source.Headers, "cookies": route.Source.Cookies, "query": route.Source.Query, "claims": route.Source.Claims}
+	for kind, list := range matchers {
+		for i, m := range list {
+			switch m.Type {
+			case "", config.RouteMatcherExact, config.RouteMatcherPrefix, config.RouteMatcherPresent:
+			case config.RouteMatcherRegex:
+				if err := createRegexp(regexps, getMatcherKind(kind, i), route.Project, route.ID, m.Value, m.IgnoreCase); err != nil {
+					return err
+				}
+			default:
+				return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid type (%s) provided for matching %s of route (%s)", m.Type, kind, route.ID), nil, nil)
+			}
+		}
+	}
+	return nil
+}
+
+// createRegexp compiles a regular expression which needs to match the entire value
+func createRegexp(regexps map[string]*regexp.Regexp, kind, project, id, pattern string, ignoreCase bool) error {
+	expr := "^(?:" + pattern + ")$"
+	if ignoreCase {
+		expr = "(?i)" + expr
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid regular expression (%s) provided for route (%s)", pattern, id), err, nil)
+	}
+
+	regexps[getRegexpKey(kind, project, id)] = re
+	return nil
+}
+
+func getRegexpKey(kind, project, id string) string {
+	return fmt.Sprintf("%s---%s---%s", project, id, kind)
+}
+
+func getMatcherKind(kind string, index int) string {
+	return fmt.Sprintf("%s-%d", kind, index)
+}
+
+// matchURL checks if the url matches the source of the route
+func (r *Routing) matchURL(ctx context.Context, route *config.Route, url string) (bool, error) {
+	switch route.Source.Type {
+	case config.RoutePrefix:
+		return strings.HasPrefix(url, route.Source.URL), nil
+	case config.RouteExact:
+		return url == route.Source.URL, nil
+	case config.RouteRegex:
+		re, ok := r.regexps[getRegexpKey("url", route.Project, route.ID)]
+		return ok && re.MatchString(url), nil
+	default:
+		return false, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid type (%s) provided for url matching", route.Source.Type), nil, nil)
+	}
+}
+
+// matchAttributes checks if the headers, cookies, query params and claims of the request match the route
+func (r *Routing) matchAttributes(route *config.Route, request *http.Request, loadClaims claimsLoader) bool {
+	for i, m := range route.Source.Headers {
+		if !r.matchValues(route, getMatcherKind("headers", i), m, request.Header.Values(m.Key)) {
+			return false
+		}
+	}
+
+	for i, m := range route.Source.Cookies {
+		var values []string
+		if cookie, err := request.Cookie(m.Key); err == nil {
+			values = []string{cookie.Value}
+		}
+		if !r.matchValues(route, getMatcherKind("cookies", i), m, values) {
+			return false
+		}
+	}
+
+	var query url.Values
+	for i, m := range route.Source.Query {
+		if query == nil {
+			query = request.URL.Query()
+		}
+		if !r.matchValues(route, getMatcherKind("query", i), m, query[m.Key]) {
+			return false
+		}
+	}
+
+	for i, m := range route.Source.Claims {
+		var values []string
+		if value, err := utils.LoadValue("auth."+m.Key, map[string]interface{}{"auth": loadClaims(route.Project)}); err == nil {
+			values = []string{stringifyValue(value)}
+		}
+		if !r.matchValues(route, getMatcherKind("claims", i), m, values) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchValues checks if any of the values of an attribute of the request match
+func (r *Routing) matchValues(route *config.Route, kind string, m *config.RouteMatcher, values []string) bool {
+	if m.Type == config.RouteMatcherPresent {
+		return len(values) > 0
+	}
+
+	for _, value := range values {
+		switch m.Type {
+		case config.RouteMatcherRegex:
+			if re, ok := r.regexps[getRegexpKey(kind, route.Project, route.ID)]; ok && re.MatchString(value) {
+				return true
+			}
+		case config.RouteMatcherPrefix:
+			if m.IgnoreCase && strings.HasPrefix(strings.ToLower(value), strings.ToLower(m.Value)) || strings.HasPrefix(value, m.Value) {
+				return true
+			}
+		default:
+			if m.IgnoreCase && strings.EqualFold(value, m.Value) || value == m.Value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func stringifyValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// rewriteRegexURL replaces the groups captured from the url in the rewrite url of a route
+func (r *Routing) rewriteRegexURL(url string, route *config.Route) string {
+	r.lock.RLock()
+	re, ok := r.regexps[getRegexpKey("url", route.Project, route.ID)]
+	r.lock.RUnlock()
+
+	if !ok || route.Source.RewriteURL == "" {
+		return url
+	}
+
+	match := re.FindStringSubmatchIndex(url)
+	if match == nil {
+		return url
+	}
+	return string(re.ExpandString(nil, route.Source.RewriteURL, url, match))
+}
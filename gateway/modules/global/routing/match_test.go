@@ -0,0 +1,169 @@
+package routing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+func TestRouting_selectRoute_matchers(t *testing.T) {
+	routes := config.IngressRoutes{
+		"canary": &config.Route{
+			ID: "canary",
+			Source: config.RouteSource{
+				Hosts:  []string{"*"},
+				URL:    "/v1/users/(?P<id>[0-9]+)",
+				Type:   config.RouteRegex,
+				Claims: []*config.RouteMatcher{{Key: "meta.cohort", Value: "beta"}},
+			},
+		},
+		"versioned": &config.Route{
+			ID: "versioned",
+			Source: config.RouteSource{
+				Hosts:   []string{"*"},
+				URL:     "/v1/users/(?P<id>[0-9]+)",
+				Type:    config.RouteRegex,
+				Headers: []*config.RouteMatcher{{Key: "X-Api-Version", Value: "v2", IgnoreCase: true}},
+			},
+		},
+		"cookie": &config.Route{
+			ID: "cookie",
+			Source: config.RouteSource{
+				Hosts:   []string{"*"},
+				URL:     "/v1/users/(?P<id>[0-9]+)",
+				Type:    config.RouteRegex,
+				Cookies: []*config.RouteMatcher{{Key: "session", Type: config.RouteMatcherPresent}},
+				Query:   []*config.RouteMatcher{{Key: "region", Value: "eu-.*", Type: config.RouteMatcherRegex}},
+			},
+		},
+		"default": &config.Route{
+			ID:     "default",
+			Source: config.RouteSource{Hosts: []string{"*"}, URL: "/v1", Type: config.RoutePrefix},
+		},
+	}
+
+	r := New()
+	if err := r.SetProjectRoutes("project", routes); err != nil {
+		t.Fatalf("SetProjectRoutes() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		url     string
+		headers map[string]string
+		cookies map[string]string
+		claims  map[string]interface{}
+		want    string
+	}{
+		{
+			name:   "match claims",
+			url:    "/v1/users/12",
+			claims: map[string]interface{}{"meta": map[string]interface{}{"cohort": "beta"}},
+			want:   "canary",
+		},
+		{
+			name:    "match header ignoring case",
+			url:     "/v1/users/12",
+			headers: map[string]string{"X-Api-Version": "V2"},
+			claims:  map[string]interface{}{"meta": map[string]interface{}{"cohort": "stable"}},
+			want:    "versioned",
+		},
+		{
+			name:    "match cookie presence and query regex",
+			url:     "/v1/users/12?region=eu-west",
+			cookies: map[string]string{"session": "abc"},
+			want:    "cookie",
+		},
+		{
+			name:    "query regex doesn't match",
+			url:     "/v1/users/12?region=us-east",
+			cookies: map[string]string{"session": "abc"},
+			want:    "default",
+		},
+		{
+			name:    "regex url must match entirely",
+			url:     "/v1/users/12/posts",
+			headers: map[string]string{"X-Api-Version": "v2"},
+			want:    "default",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			for k, v := range tt.headers {
+				request.Header.Set(k, v)
+			}
+			for k, v := range tt.cookies {
+				request.AddCookie(&http.Cookie{Name: k, Value: v})
+			}
+
+			got, err := r.selectRoute(context.Background(), request, func(string) map[string]interface{} { return tt.claims })
+			if err != nil {
+				t.Fatalf("selectRoute() error = %v", err)
+			}
+			if got.ID != tt.want {
+				t.Errorf("selectRoute() got = %s, want %s", got.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouting_rewriteRegexURL(t *testing.T) {
+	route := &config.Route{
+		ID: "1",
+		Source: config.RouteSource{
+			Hosts:      []string{"*"},
+			URL:        "/api/(?P<version>v[0-9]+)/users/([0-9]+)",
+			RewriteURL: "/users/$2?version=${version}",
+			Type:       config.RouteRegex,
+		},
+	}
+
+	r := New()
+	if err := r.SetProjectRoutes("project", config.IngressRoutes{"1": route}); err != nil {
+		t.Fatalf("SetProjectRoutes() error = %v", err)
+	}
+
+	if got := r.rewriteRegexURL("/api/v2/users/12", route); got != "/users/12?version=v2" {
+		t.Errorf("rewriteRegexURL() got = %s, want %s", got, "/users/12?version=v2")
+	}
+}
+
+func TestRouting_createRouteRegexps(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  config.RouteSource
+		wantErr bool
+	}{
+		{
+			name:   "valid expressions",
+			source: config.RouteSource{URL: "/users/(?P<id>[0-9]+)", Type: config.RouteRegex, Headers: []*config.RouteMatcher{{Key: "X-Version", Value: "v[0-9]", Type: config.RouteMatcherRegex}}},
+		},
+		{
+			name:    "invalid url expression",
+			source:  config.RouteSource{URL: "/users/([0-9]+", Type: config.RouteRegex},
+			wantErr: true,
+		},
+		{
+			name:    "invalid matcher expression",
+			source:  config.RouteSource{URL: "/users", Type: config.RoutePrefix, Query: []*config.RouteMatcher{{Key: "id", Value: "[", Type: config.RouteMatcherRegex}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid matcher type",
+			source:  config.RouteSource{URL: "/users", Type: config.RoutePrefix, Cookies: []*config.RouteMatcher{{Key: "id", Type: "suffix"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := createRouteRegexps(map[string]*regexp.Regexp{}, &config.Route{ID: "1", Project: "project", Source: tt.source}); (err != nil) != tt.wantErr {
+				t.Errorf("createRouteRegexps() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -106,7 +106,7 @@ func TestRouting_modifyResponse(t *testing.T) {
 			// Make an instance of the routing module
 			r := &Routing{goTemplates: make(map[string]*template.Template), globalConfig: &config.GlobalRoutesConfig{ResponseHeaders: tt.args.globalHeaders}}
 			if tt.args.resTmpl != "" {
-				err := r.createGoTemplate("response", "p", "id", tt.args.resTmpl)
+				err := createGoTemplate(r.goTemplates, "response", "p", "id", tt.args.resTmpl)
 				if err != nil {
 					t.Error("Unable to parse template", err)
 					return
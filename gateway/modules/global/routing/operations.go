@@ -1,9 +1,13 @@
 package routing
 
 import (
+	"context"
+	"regexp"
 	"strings"
+	"text/template"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/utils/ratelimit"
 )
 
 // SetProjectRoutes adds a project's routes to the global list of routes
@@ -15,12 +19,10 @@ func (r *Routing) SetProjectRoutes(project string, routesConfig config.IngressRo
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	// Delete all templates for this project
-	for k := range r.goTemplates {
-		if strings.HasPrefix(k, project) {
-			delete(r.goTemplates, k)
-		}
-	}
+	// The templates and regular expressions are compiled separately so that the ones currently in use are kept if any
+	// of the routes is invalid
+	templates := map[string]*template.Template{}
+	regexps := map[string]*regexp.Regexp{}
 
 	// Add projects to the routes object and generate go templates
 	for _, route := range routes {
@@ -29,20 +31,50 @@ func (r *Routing) SetProjectRoutes(project string, routesConfig config.IngressRo
 
 		// Parse request template
 		if route.Modify.ReqTmpl != "" {
-			if err := r.createGoTemplate("request", project, route.ID, route.Modify.ReqTmpl); err != nil {
+			if err := createGoTemplate(templates, "request", project, route.ID, route.Modify.ReqTmpl); err != nil {
 				return err
 			}
 		}
 
 		// Parse response template
 		if route.Modify.ResTmpl != "" {
-			if err := r.createGoTemplate("response", project, route.ID, route.Modify.ResTmpl); err != nil {
+			if err := createGoTemplate(templates, "response", project, route.ID, route.Modify.ResTmpl); err != nil {
 				return err
 			}
 		}
+
+		// Compile the regular expressions used for matching requests
+		if err := createRouteRegexps(regexps, route); err != nil {
+			return err
+		}
+
+		if err := ratelimit.Validate(context.TODO(), route.RateLimits); err != nil {
+			return err
+		}
+	}
+
+	// Replace the templates and regular expressions of the project. The keys of other projects whose name starts with
+	// the name of this project must be left alone
+	prefix := project + "---"
+	for k := range r.goTemplates {
+		if strings.HasPrefix(k, prefix) {
+			delete(r.goTemplates, k)
+		}
+	}
+	for k, v := range templates {
+		r.goTemplates[k] = v
+	}
+	for k := range r.regexps {
+		if strings.HasPrefix(k, prefix) {
+			delete(r.regexps, k)
+		}
+	}
+	for k, v := range regexps {
+		r.regexps[k] = v
 	}
 
 	r.addProjectRoutes(project, routes)
+	r.setRouteHealth(project, routes)
 	return nil
 }
 
@@ -52,6 +84,7 @@ func (r *Routing) DeleteProjectRoutes(project string) {
 	defer r.lock.Unlock()
 
 	r.deleteProjectRoutes(project)
+	r.deleteRouteHealth(project)
 }
 
 // SetGlobalConfig sets the project level config of the routing module
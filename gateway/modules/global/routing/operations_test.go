@@ -128,3 +128,58 @@ func TestRouting_SetProjectRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestRouting_SetProjectRoutes_regexps(t *testing.T) {
+	regexRoute := func(id, url string) *config.Route {
+		return &config.Route{ID: id, Source: config.RouteSource{Type: config.RouteRegex, URL: url}, Targets: []config.RouteTarget{{Host: "localhost", Port: 8080}}}
+	}
+
+	r := New()
+	if err := r.SetProjectRoutes("a", config.IngressRoutes{"1": regexRoute("1", "/v1/.*")}); err != nil {
+		t.Fatalf("Routing.SetProjectRoutes() unexpected error = %v", err)
+	}
+	if err := r.SetProjectRoutes("ab", config.IngressRoutes{"2": regexRoute("2", "/v2/.*")}); err != nil {
+		t.Fatalf("Routing.SetProjectRoutes() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		project  string
+		routes   config.IngressRoutes
+		wantErr  bool
+		wantKeys []string
+	}{
+		{
+			name:     "invalid routes keep the regular expressions in use",
+			project:  "a",
+			routes:   config.IngressRoutes{"3": regexRoute("3", "/v3/(")},
+			wantErr:  true,
+			wantKeys: []string{getRegexpKey("url", "a", "1"), getRegexpKey("url", "ab", "2")},
+		},
+		{
+			name:     "regular expressions of projects sharing a prefix are kept",
+			project:  "a",
+			routes:   config.IngressRoutes{"3": regexRoute("3", "/v3/.*")},
+			wantKeys: []string{getRegexpKey("url", "a", "3"), getRegexpKey("url", "ab", "2")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.SetProjectRoutes(tt.project, tt.routes); (err != nil) != tt.wantErr {
+				t.Errorf("Routing.SetProjectRoutes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			keys := map[string]bool{}
+			for k := range r.regexps {
+				keys[k] = true
+			}
+			want := map[string]bool{}
+			for _, k := range tt.wantKeys {
+				want[k] = true
+			}
+			if !reflect.DeepEqual(keys, want) {
+				t.Errorf("Routing.SetProjectRoutes() regexps = %v, want %v", keys, want)
+			}
+		})
+	}
+}
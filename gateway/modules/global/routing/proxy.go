@@ -0,0 +1,295 @@
+package routing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+const defaultHedgingDelay = 100
+
+// proxyRequest sends the request to the target selected for it. The timeout of the route only covers receiving the
+// response headers, so that streamed responses and tunnelled connections aren't cut while their body is being read
+func (r *Routing) proxyRequest(ctx context.Context, route *config.Route, request *http.Request, target config.RouteTarget, url string) (*http.Response, error) {
+	if route.Timeout <= 0 || isUpgradeRequest(request) {
+		return r.sendRequestWithRetries(ctx, route, request, target, url)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	timer := time.AfterFunc(time.Duration(route.Timeout)*time.Millisecond, cancel)
+	res, err := r.sendRequestWithRetries(ctx, route, request, target, url)
+
+	// The request was cancelled if the timer has already fired
+	if !timer.Stop() {
+		if err == nil {
+			utils.CloseTheCloser(res.Body)
+		}
+		cancel()
+		return nil, fmt.Errorf("no response received from the targets of route (%s) within %d ms: %w", route.ID, route.Timeout, context.DeadlineExceeded)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// sendRequestWithRetries sends the request to the target. Failed requests are retried on other targets and slow GET
+// requests are hedged as configured for the route
+func (r *Routing) sendRequestWithRetries(ctx context.Context, route *config.Route, request *http.Request, target config.RouteTarget, url string) (*http.Response, error) {
+	attempts := 0
+	if route.Retries != nil && isIdempotent(request.Method) {
+		attempts = route.Retries.Attempts
+	}
+	hedge := route.Hedging != nil && request.Method == http.MethodGet && !isUpgradeRequest(request)
+
+	// Buffer the body so that it can be sent more than once
+	if attempts > 0 || hedge {
+		if err := bufferBody(request); err != nil {
+			return nil, err
+		}
+	}
+
+	tried := []config.RouteTarget{}
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			var err error
+			target, err = r.selectTarget(ctx, route, tried)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var res *http.Response
+		var err error
+		if hedge {
+			res, err = r.sendHedgedRequest(ctx, route, request, target, url, &tried)
+		} else {
+			res, err = r.sendRequest(ctx, route, request, target, url)
+			tried = append(tried, target)
+		}
+
+		if attempt >= attempts || ctx.Err() != nil || !shouldRetry(route.Retries, res, err) {
+			return res, err
+		}
+
+		if err != nil {
+			helpers.Logger.LogDebug(helpers.GetRequestID(ctx), fmt.Sprintf("Retrying request for route (%s) which failed", route.ID), map[string]interface{}{"error": err.Error()})
+		} else {
+			helpers.Logger.LogDebug(helpers.GetRequestID(ctx), fmt.Sprintf("Retrying request for route (%s) which failed with status (%d)", route.ID, res.StatusCode), nil)
+			utils.CloseTheCloser(res.Body)
+		}
+	}
+}
+
+// sendRequest sends a copy of the request to the target
+func (r *Routing) sendRequest(ctx context.Context, route *config.Route, request *http.Request, target config.RouteTarget, url string) (*http.Response, error) {
+	req := request.Clone(ctx)
+	if request.GetBody != nil {
+		req.Body, _ = request.GetBody()
+	}
+	setRequest(req, target, url)
+
+	res, err := getHTTPClient(request, target).Do(req)
+	r.reportResult(route, target, res, err)
+	return res, err
+}
+
+type hedgedResult struct {
+	res    *http.Response
+	err    error
+	index  int
+	cancel context.CancelFunc
+}
+
+// sendHedgedRequest sends the request to the target and another one if it doesn't respond within the hedging
+// delay. The first successful response is returned and the other request is cancelled
+func (r *Routing) sendHedgedRequest(ctx context.Context, route *config.Route, request *http.Request, target config.RouteTarget, url string, tried *[]config.RouteTarget) (*http.Response, error) {
+	results := make(chan *hedgedResult, 2)
+
+	// The requests still in flight are cancelled as soon as a response is picked
+	cancels := make([]context.CancelFunc, 0, 2)
+	send := func(target config.RouteTarget) {
+		*tried = append(*tried, target)
+
+		ctx, cancel := context.WithCancel(ctx)
+		index := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			res, err := r.sendRequest(ctx, route, request, target, url)
+			results <- &hedgedResult{res: res, err: err, index: index, cancel: cancel}
+		}()
+	}
+
+	delay := route.Hedging.Delay
+	if delay <= 0 {
+		delay = defaultHedgingDelay
+	}
+	timer := time.NewTimer(time.Duration(delay) * time.Millisecond)
+	defer timer.Stop()
+
+	send(target)
+	pending, hedged := 1, false
+
+	var failed *hedgedResult
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if hedgeTarget, ok := r.selectHedgeTarget(ctx, route, *tried); ok {
+				send(hedgeTarget)
+				pending++
+			}
+			hedged = true
+
+		case result := <-results:
+			pending--
+			if result.err == nil && result.res.StatusCode < http.StatusInternalServerError {
+				for i, cancel := range cancels {
+					if i != result.index {
+						cancel()
+					}
+				}
+				go discardResults(results, pending)
+				return wrapHedgedResult(result)
+			}
+
+			// Keep waiting for the other request if this one failed
+			if failed != nil {
+				discardResult(failed)
+			}
+			failed = result
+
+			// Hedge the failed request right away if it hasn't been hedged yet
+			if pending == 0 && !hedged && timer.Stop() {
+				hedged = true
+				if hedgeTarget, ok := r.selectHedgeTarget(ctx, route, *tried); ok {
+					discardResult(failed)
+					failed = nil
+					send(hedgeTarget)
+					pending++
+				}
+			}
+		}
+	}
+	return wrapHedgedResult(failed)
+}
+
+// selectHedgeTarget selects a target which hasn't been tried yet. Requests are only hedged if such a target is available
+func (r *Routing) selectHedgeTarget(ctx context.Context, route *config.Route, tried []config.RouteTarget) (config.RouteTarget, bool) {
+	if !hasOtherTargets(route, tried) {
+		return config.RouteTarget{}, false
+	}
+	target, err := r.selectTarget(ctx, route, tried)
+	if err != nil || containsTarget(tried, target) {
+		return config.RouteTarget{}, false
+	}
+	return target, true
+}
+
+// wrapHedgedResult makes sure the request of a result is cancelled once its response has been read
+func wrapHedgedResult(result *hedgedResult) (*http.Response, error) {
+	if result.err != nil {
+		result.cancel()
+		return nil, result.err
+	}
+	result.res.Body = &cancelOnClose{ReadCloser: result.res.Body, cancel: result.cancel}
+	return result.res, nil
+}
+
+func discardResult(result *hedgedResult) {
+	if result.err == nil {
+		utils.CloseTheCloser(result.res.Body)
+	}
+	result.cancel()
+}
+
+func discardResults(results chan *hedgedResult, pending int) {
+	for i := 0; i < pending; i++ {
+		result := <-results
+		discardResult(result)
+	}
+}
+
+// cancelOnClose cancels the context of a request once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// bufferBody reads the body of the request in memory so that copies of the request can be sent
+func bufferBody(request *http.Request) error {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil
+	}
+
+	data, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return err
+	}
+	request.Body = ioutil.NopCloser(bytes.NewReader(data))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	}
+	return nil
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry checks if the outcome of a request is one of the failures to retry on
+func shouldRetry(retries *config.RouteRetries, res *http.Response, err error) bool {
+	var failure config.RouteRetryOn
+	switch {
+	case err != nil:
+		if !isConnectFailure(err) {
+			return false
+		}
+		failure = config.RouteRetryOnConnectFailure
+	case res.StatusCode == http.StatusBadGateway:
+		failure = config.RouteRetryOnBadGateway
+	case res.StatusCode == http.StatusServiceUnavailable:
+		failure = config.RouteRetryOnServiceUnavailable
+	case res.StatusCode == http.StatusGatewayTimeout:
+		failure = config.RouteRetryOnGatewayTimeout
+	default:
+		return false
+	}
+
+	if len(retries.RetryOn) == 0 {
+		return true
+	}
+	for _, retryOn := range retries.RetryOn {
+		if retryOn == failure {
+			return true
+		}
+	}
+	return false
+}
+
+// isConnectFailure checks if the request failed since the connection to the target couldn't be established
+func isConnectFailure(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,265 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+func newTestTarget(t *testing.T, handler http.HandlerFunc) config.RouteTarget {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(u.Port())
+	return config.RouteTarget{Host: u.Hostname(), Port: int32(port), Weight: 50}
+}
+
+func TestRouting_proxyRequest(t *testing.T) {
+	respond := func(status int, body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			data, _ := ioutil.ReadAll(r.Body)
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body + string(data)))
+		}
+	}
+	slow := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+				return
+			}
+			_, _ = w.Write([]byte(body))
+		}
+	}
+
+	tests := []struct {
+		name       string
+		route      *config.Route
+		handlers   []http.HandlerFunc
+		method     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "retry on another target",
+			route:      &config.Route{Retries: &config.RouteRetries{Attempts: 1}},
+			handlers:   []http.HandlerFunc{respond(http.StatusServiceUnavailable, "first"), respond(http.StatusOK, "second")},
+			method:     http.MethodPut,
+			body:       "-body",
+			wantStatus: http.StatusOK,
+			wantBody:   "second-body",
+		},
+		{
+			name:       "don't retry failures which aren't configured",
+			route:      &config.Route{Retries: &config.RouteRetries{Attempts: 1, RetryOn: []config.RouteRetryOn{config.RouteRetryOnBadGateway}}},
+			handlers:   []http.HandlerFunc{respond(http.StatusServiceUnavailable, "first"), respond(http.StatusOK, "second")},
+			method:     http.MethodGet,
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "first",
+		},
+		{
+			name:       "don't retry methods which aren't idempotent",
+			route:      &config.Route{Retries: &config.RouteRetries{Attempts: 1}},
+			handlers:   []http.HandlerFunc{respond(http.StatusServiceUnavailable, "first"), respond(http.StatusOK, "second")},
+			method:     http.MethodPost,
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "first",
+		},
+		{
+			name:       "hedge slow request",
+			route:      &config.Route{Hedging: &config.RouteHedging{Delay: 10}},
+			handlers:   []http.HandlerFunc{slow("first"), respond(http.StatusOK, "second")},
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantBody:   "second",
+		},
+		{
+			name:       "hedge failed request right away",
+			route:      &config.Route{Hedging: &config.RouteHedging{Delay: 5000}},
+			handlers:   []http.HandlerFunc{respond(http.StatusInternalServerError, "first"), respond(http.StatusOK, "second")},
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantBody:   "second",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, handler := range tt.handlers {
+				tt.route.Targets = append(tt.route.Targets, newTestTarget(t, handler))
+			}
+
+			// Give the first target all the weight so that it always gets the request first
+			tt.route.Targets[0].Weight = 100
+			request := httptest.NewRequest(tt.method, "/", strings.NewReader(tt.body))
+
+			r := New()
+			res, err := r.proxyRequest(context.Background(), tt.route, request, tt.route.Targets[0], "/")
+			if err != nil {
+				t.Fatalf("proxyRequest() unexpected error = %v", err)
+			}
+			defer res.Body.Close()
+
+			data, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.wantStatus || string(data) != tt.wantBody {
+				t.Errorf("proxyRequest() got = (%d, %s), want (%d, %s)", res.StatusCode, string(data), tt.wantStatus, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRouting_proxyRequest_hedgingCancelsSlowRequest(t *testing.T) {
+	cancelled := make(chan struct{})
+	slow := newTestTarget(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(5 * time.Second):
+		case <-r.Context().Done():
+			close(cancelled)
+		}
+	})
+	fast := newTestTarget(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("fast"))
+	})
+	slow.Weight = 100
+	route := &config.Route{Targets: []config.RouteTarget{slow, fast}, Hedging: &config.RouteHedging{Delay: 10}}
+
+	res, err := New().proxyRequest(context.Background(), route, httptest.NewRequest(http.MethodGet, "/", nil), slow, "/")
+	if err != nil {
+		t.Fatalf("proxyRequest() unexpected error = %v", err)
+	}
+	defer res.Body.Close()
+
+	// The slow request must be cancelled once the response of the hedged request is picked, not after it completes
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Errorf("proxyRequest() did not cancel the slow request after picking the response of the hedged request")
+	}
+}
+
+func TestRouting_proxyRequest_timeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		wantErr  bool
+		wantBody string
+	}{
+		{
+			name: "headers not received in time",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(time.Second):
+				case <-r.Context().Done():
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "body streamed for longer than the timeout",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("first "))
+				w.(http.Flusher).Flush()
+				time.Sleep(200 * time.Millisecond)
+				_, _ = w.Write([]byte("second"))
+			},
+			wantBody: "first second",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := newTestTarget(t, tt.handler)
+			route := &config.Route{ID: "route", Targets: []config.RouteTarget{target}, Timeout: 50}
+
+			res, err := New().proxyRequest(context.Background(), route, httptest.NewRequest(http.MethodGet, "/", nil), target, "/")
+			if tt.wantErr {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Fatalf("proxyRequest() error = %v, want %v", err, context.DeadlineExceeded)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("proxyRequest() unexpected error = %v", err)
+			}
+			defer res.Body.Close()
+
+			data, err := ioutil.ReadAll(res.Body)
+			if err != nil || string(data) != tt.wantBody {
+				t.Errorf("proxyRequest() body = (%s, %v), want %s", string(data), err, tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_shouldRetry(t *testing.T) {
+	connectErr := &url.Error{Op: "Get", URL: "http://localhost", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+
+	tests := []struct {
+		name    string
+		retries *config.RouteRetries
+		res     *http.Response
+		err     error
+		want    bool
+	}{
+		{
+			name:    "retry connect failure",
+			retries: &config.RouteRetries{},
+			err:     connectErr,
+			want:    true,
+		},
+		{
+			name:    "don't retry other errors",
+			retries: &config.RouteRetries{},
+			err:     context.DeadlineExceeded,
+			want:    false,
+		},
+		{
+			name:    "retry gateway timeout",
+			retries: &config.RouteRetries{},
+			res:     &http.Response{StatusCode: http.StatusGatewayTimeout},
+			want:    true,
+		},
+		{
+			name:    "don't retry internal server error",
+			retries: &config.RouteRetries{},
+			res:     &http.Response{StatusCode: http.StatusInternalServerError},
+			want:    false,
+		},
+		{
+			name:    "don't retry success",
+			retries: &config.RouteRetries{},
+			res:     &http.Response{StatusCode: http.StatusOK},
+			want:    false,
+		},
+		{
+			name:    "retry configured failure",
+			retries: &config.RouteRetries{RetryOn: []config.RouteRetryOn{config.RouteRetryOnConnectFailure, config.RouteRetryOnBadGateway}},
+			res:     &http.Response{StatusCode: http.StatusBadGateway},
+			want:    true,
+		},
+		{
+			name:    "don't retry failure which isn't configured",
+			retries: &config.RouteRetries{RetryOn: []config.RouteRetryOn{config.RouteRetryOnBadGateway}},
+			err:     connectErr,
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldRetry(tt.retries, tt.res, tt.err); got != tt.want {
+				t.Errorf("shouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,8 +3,8 @@ package routing
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sort"
-	"strings"
 
 	"github.com/spaceuptech/helpers"
 
@@ -28,10 +28,13 @@ func (r *Routing) deleteProjectRoutes(project string) {
 	r.routes = newRoutes
 }
 
-func (r *Routing) selectRoute(ctx context.Context, host, method, url string) (*config.Route, error) {
+func (r *Routing) selectRoute(ctx context.Context, request *http.Request, loadClaims claimsLoader) (*config.Route, error) {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
 
+	host, url := getHostAndURL(request)
+	method := request.Method
+
 	// Iterate over each route
 	for _, route := range r.routes {
 		// Skip if the hosts isn't present in the rule and hosts doesn't contain `*`
@@ -44,18 +47,18 @@ func (r *Routing) selectRoute(ctx context.Context, host, method, url string) (*c
 			continue
 		}
 
-		// TODO: add support for path parameters in routes
-		switch route.Source.Type {
-		case config.RoutePrefix:
-			if strings.HasPrefix(url, route.Source.URL) {
-				return route, nil
-			}
-		case config.RouteExact:
-			if url == route.Source.URL {
-				return route, nil
-			}
-		default:
-			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid type (%s) provided for url matching", route.Source.Type), nil, nil)
+		// Skip if the url doesn't match
+		matched, err := r.matchURL(ctx, route, url)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
+
+		// Skip if the headers, cookies, query params or claims don't match
+		if r.matchAttributes(route, request, loadClaims) {
+			return route, nil
 		}
 	}
 
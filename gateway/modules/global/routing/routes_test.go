@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"net/url"
 	"reflect"
 	"testing"
 
@@ -350,7 +351,8 @@ func Test_routeMapping_selectRoute(t *testing.T) {
 	for _, tt := range tests {
 		routeObj.routes = tt.r
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := routeObj.selectRoute(context.Background(), tt.args.host, tt.args.method, tt.args.url)
+			request := &http.Request{Host: tt.args.host, Method: tt.args.method, URL: &url.URL{Path: tt.args.url}, Header: http.Header{}}
+			got, err := routeObj.selectRoute(context.Background(), request, func(string) map[string]interface{} { return nil })
 			if (err != nil) != tt.wantErr {
 				t.Errorf("routeMapping.selectRoute() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -480,6 +482,27 @@ func Test_routeMapping_addProjectRoutes(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "routes with more matchers come first",
+			r:    config.Routes{},
+			args: args{
+				project: "test",
+				routes: config.Routes{
+					&config.Route{ID: "b", Project: "test", Source: config.RouteSource{URL: "/api"}},
+					&config.Route{ID: "c", Project: "test", Source: config.RouteSource{URL: "/api", Headers: []*config.RouteMatcher{{Key: "x-beta"}}}},
+					&config.Route{ID: "d", Project: "test", Source: config.RouteSource{URL: "/api", Headers: []*config.RouteMatcher{{Key: "x-beta"}}, Claims: []*config.RouteMatcher{{Key: "role", Value: "admin"}}}},
+					&config.Route{ID: "a", Project: "test", Source: config.RouteSource{URL: "/api"}},
+					&config.Route{ID: "e", Project: "test", Source: config.RouteSource{URL: "/api/v2"}},
+				},
+			},
+			want: config.Routes{
+				&config.Route{ID: "e", Project: "test", Source: config.RouteSource{URL: "/api/v2"}},
+				&config.Route{ID: "d", Project: "test", Source: config.RouteSource{URL: "/api", Headers: []*config.RouteMatcher{{Key: "x-beta"}}, Claims: []*config.RouteMatcher{{Key: "role", Value: "admin"}}}},
+				&config.Route{ID: "c", Project: "test", Source: config.RouteSource{URL: "/api", Headers: []*config.RouteMatcher{{Key: "x-beta"}}}},
+				&config.Route{ID: "a", Project: "test", Source: config.RouteSource{URL: "/api"}},
+				&config.Route{ID: "b", Project: "test", Source: config.RouteSource{URL: "/api"}},
+			},
+		},
 	}
 	routeObj := New()
 
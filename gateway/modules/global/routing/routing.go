@@ -2,11 +2,13 @@ package routing
 
 import (
 	"context"
+	"regexp"
 	"sync"
 	"text/template"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils/ratelimit"
 )
 
 // Routing manages the routing functionality of space cloud
@@ -17,11 +19,18 @@ type Routing struct {
 	globalConfig *config.GlobalRoutesConfig
 	caching      cachingInterface
 	goTemplates  map[string]*template.Template
+	regexps      map[string]*regexp.Regexp
+	rateLimiter  ratelimit.Counter
+
+	// Health of the targets of the routes
+	health         map[string]*routeHealth
+	healthCheckers map[string]context.CancelFunc
 }
 
 // New creates a new instance of the routing module
 func New() *Routing {
-	return &Routing{routes: make(config.Routes, 0), goTemplates: map[string]*template.Template{}, globalConfig: new(config.GlobalRoutesConfig)}
+	return &Routing{routes: make(config.Routes, 0), goTemplates: map[string]*template.Template{}, regexps: map[string]*regexp.Regexp{}, globalConfig: new(config.GlobalRoutesConfig),
+		health: map[string]*routeHealth{}, healthCheckers: map[string]context.CancelFunc{}}
 }
 
 // SetCachingModule sets caching module
@@ -29,6 +38,11 @@ func (r *Routing) SetCachingModule(c cachingInterface) {
 	r.caching = c
 }
 
+// SetRateLimiter sets the counters used to rate limit the requests of the routes
+func (r *Routing) SetRateLimiter(rateLimiter ratelimit.Counter) {
+	r.rateLimiter = rateLimiter
+}
+
 type cachingInterface interface {
 	SetIngressRouteKey(ctx context.Context, redisKey string, cache *config.ReadCacheOptions, result *model.CacheIngressRoute) error
 	GetIngressRoute(ctx context.Context, routeID string, cacheOptions []interface{}) (string, bool, *model.CacheIngressRoute, error)
@@ -1,7 +1,9 @@
 package routing
 
 import (
+	"context"
 	"reflect"
+	"regexp"
 	"sync"
 	"testing"
 	"text/template"
@@ -18,10 +20,13 @@ func TestNew(t *testing.T) {
 		{
 			name: "New Routing instance",
 			want: &Routing{
-				lock:         sync.RWMutex{},
-				routes:       make(config.Routes, 0),
-				goTemplates:  map[string]*template.Template{},
-				globalConfig: new(config.GlobalRoutesConfig),
+				lock:           sync.RWMutex{},
+				routes:         make(config.Routes, 0),
+				goTemplates:    map[string]*template.Template{},
+				regexps:        map[string]*regexp.Regexp{},
+				globalConfig:   new(config.GlobalRoutesConfig),
+				health:         map[string]*routeHealth{},
+				healthCheckers: map[string]context.CancelFunc{},
 			},
 		},
 	}
@@ -0,0 +1,207 @@
+package routing
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http2"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// h2cTransport sends HTTP/2 requests to targets without TLS
+var h2cTransport = &http2.Transport{
+	AllowHTTP: true,
+	DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+		return net.Dial(network, addr)
+	},
+}
+
+var h2cClient = http.Client{
+	Transport: h2cTransport,
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// getHTTPClient returns the client to send the request to the target with. gRPC requires HTTP/2, hence gRPC
+// requests to targets without TLS are sent over h2c as well
+func getHTTPClient(request *http.Request, target config.RouteTarget) *http.Client {
+	if target.Scheme == config.RouteTargetSchemeH2C || (isGRPCRequest(request) && getTargetScheme(target) == "http") {
+		return &h2cClient
+	}
+	return &httpClient
+}
+
+func getTargetScheme(target config.RouteTarget) string {
+	if target.Scheme == "" || target.Scheme == config.RouteTargetSchemeH2C {
+		return "http"
+	}
+	return target.Scheme
+}
+
+func isGRPCRequest(request *http.Request) bool {
+	return request.ProtoMajor == 2 && strings.HasPrefix(request.Header.Get("Content-Type"), "application/grpc")
+}
+
+// isUpgradeRequest checks if the client wants to switch protocols, for instance to a websocket
+func isUpgradeRequest(request *http.Request) bool {
+	if request.Header.Get("Upgrade") == "" {
+		return false
+	}
+	for _, value := range request.Header.Values("Connection") {
+		for _, token := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateUpgradeRequest checks if the protocol the client wants to switch to can be proxied. Only websockets are
+// allowed. Tunnelling other protocols, like h2c, would let the client send requests straight to the target
+func validateUpgradeRequest(request *http.Request) error {
+	if !isUpgradeRequest(request) {
+		return nil
+	}
+	for _, value := range request.Header.Values("Upgrade") {
+		for _, token := range strings.Split(value, ",") {
+			if protocol := strings.TrimSpace(token); !strings.EqualFold(protocol, "websocket") {
+				return fmt.Errorf("upgrading the connection to protocol (%s) is not supported", protocol)
+			}
+		}
+	}
+	return nil
+}
+
+// tunnelUpgradedConnection forwards the response of a target which switched protocols to the client. The connection
+// of the client is then tunnelled to the target till either of them closes it
+func tunnelUpgradedConnection(writer http.ResponseWriter, response *http.Response) error {
+	backConn, ok := response.Body.(io.ReadWriteCloser)
+	if !ok {
+		return errors.New("target switched protocols with a response body which isn't writable")
+	}
+	defer utils.CloseTheCloser(backConn)
+
+	hijacker, ok := writer.(http.Hijacker)
+	if !ok {
+		return errors.New("connection of the client can't be hijacked to switch protocols")
+	}
+	conn, brw, err := hijacker.Hijack()
+	if err != nil {
+		return err
+	}
+	defer utils.CloseTheCloser(conn)
+
+	// Only write the status line and the headers since the body is the tunnel itself
+	response.Body = nil
+	if err := response.Write(brw); err != nil {
+		return err
+	}
+	if err := brw.Flush(); err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(conn, backConn)
+		errCh <- err
+	}()
+	go func() {
+		// The client might have sent data which has already been buffered
+		_, err := io.Copy(backConn, brw)
+		errCh <- err
+	}()
+	return <-errCh
+}
+
+// isStreamingResponse checks if the response needs to be sent to the client as soon as it arrives
+func isStreamingResponse(response *http.Response) bool {
+	contentType := response.Header.Get("Content-Type")
+	return response.ContentLength == -1 || strings.HasPrefix(contentType, "text/event-stream") || strings.HasPrefix(contentType, "application/grpc")
+}
+
+// writeResponse copies the headers, status code, body and trailers of the response to the client
+func writeResponse(writer http.ResponseWriter, response *http.Response) (int64, error) {
+	for k, v := range response.Header {
+		writer.Header()[k] = v
+	}
+	announced := announceTrailers(writer, response)
+	writer.WriteHeader(response.StatusCode)
+
+	n, err := copyResponse(writer, response)
+	if err != nil {
+		return n, err
+	}
+
+	copyTrailers(writer, response, announced)
+	return n, nil
+}
+
+// copyResponse copies the body of the response to the client. Streamed responses are flushed after every write
+// so that events aren't held back in the buffer of the response writer
+func copyResponse(writer http.ResponseWriter, response *http.Response) (int64, error) {
+	flusher, ok := writer.(http.Flusher)
+	if !ok || !isStreamingResponse(response) {
+		return io.Copy(writer, response.Body)
+	}
+
+	// Send the headers right away since the first event might take a while
+	flusher.Flush()
+	return io.Copy(&flushWriter{writer: writer, flusher: flusher}, response.Body)
+}
+
+type flushWriter struct {
+	writer  io.Writer
+	flusher http.Flusher
+}
+
+func (w *flushWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	if n > 0 {
+		w.flusher.Flush()
+	}
+	return n, err
+}
+
+// announceTrailers declares the trailers of the response, like the status of a gRPC call, before the headers are
+// written. It returns the number of trailers announced
+func announceTrailers(writer http.ResponseWriter, response *http.Response) int {
+	if len(response.Trailer) == 0 {
+		return 0
+	}
+
+	keys := make([]string, 0, len(response.Trailer))
+	for k := range response.Trailer {
+		keys = append(keys, k)
+	}
+	writer.Header().Add("Trailer", strings.Join(keys, ", "))
+
+	// Trailers can only be sent with a chunked response
+	writer.Header().Del("Content-Length")
+	return len(keys)
+}
+
+// copyTrailers copies the trailers of the response once its body has been read. Trailers which weren't announced
+// are sent with the trailer prefix
+func copyTrailers(writer http.ResponseWriter, response *http.Response, announced int) {
+	if len(response.Trailer) == announced {
+		for k, v := range response.Trailer {
+			writer.Header()[k] = v
+		}
+		return
+	}
+
+	for k, v := range response.Trailer {
+		for _, value := range v {
+			writer.Header().Add(http.TrailerPrefix+k, value)
+		}
+	}
+}
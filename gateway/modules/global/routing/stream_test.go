@@ -0,0 +1,237 @@
+package routing
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+// newTestProxy proxies every request to the target the same way the routing handler does
+func newTestProxy(t *testing.T, route *config.Route) *httptest.Server {
+	r := New()
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		response, err := r.proxyRequest(request.Context(), route, request, route.Targets[0], request.URL.Path)
+		if err != nil {
+			writer.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode == http.StatusSwitchingProtocols {
+			if err := tunnelUpgradedConnection(writer, response); err != nil {
+				t.Logf("tunnelUpgradedConnection() error = %v", err)
+			}
+			return
+		}
+		if _, err := writeResponse(writer, response); err != nil {
+			t.Logf("writeResponse() error = %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func Test_isUpgradeRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{
+			name:    "websocket upgrade",
+			headers: map[string]string{"Connection": "Upgrade", "Upgrade": "websocket"},
+			want:    true,
+		},
+		{
+			name:    "upgrade along with other connection options",
+			headers: map[string]string{"Connection": "keep-alive, upgrade", "Upgrade": "websocket"},
+			want:    true,
+		},
+		{
+			name:    "upgrade without connection option",
+			headers: map[string]string{"Upgrade": "websocket"},
+			want:    false,
+		},
+		{
+			name:    "plain request",
+			headers: map[string]string{"Connection": "keep-alive"},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				request.Header.Set(k, v)
+			}
+			if got := isUpgradeRequest(request); got != tt.want {
+				t.Errorf("isUpgradeRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateUpgradeRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "websocket upgrade",
+			headers: map[string]string{"Connection": "Upgrade", "Upgrade": "WebSocket"},
+		},
+		{
+			name:    "h2c upgrade",
+			headers: map[string]string{"Connection": "Upgrade, HTTP2-Settings", "Upgrade": "h2c"},
+			wantErr: true,
+		},
+		{
+			name:    "websocket along with another protocol",
+			headers: map[string]string{"Connection": "Upgrade", "Upgrade": "websocket, h2c"},
+			wantErr: true,
+		},
+		{
+			name:    "upgrade header without connection option",
+			headers: map[string]string{"Upgrade": "h2c"},
+		},
+		{
+			name:    "plain request",
+			headers: map[string]string{"Connection": "keep-alive"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				request.Header.Set(k, v)
+			}
+			if err := validateUpgradeRequest(request); (err != nil) != tt.wantErr {
+				t.Errorf("validateUpgradeRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRouting_proxyWebsocket(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	target := newTestTarget(t, func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(messageType, append([]byte("echo-"), data...)); err != nil {
+				return
+			}
+		}
+	})
+
+	// Hedging and timeouts mustn't interfere with tunnelled connections
+	proxy := newTestProxy(t, &config.Route{Targets: []config.RouteTarget{target}, Timeout: 1, Hedging: &config.RouteHedging{Delay: 1}})
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(proxy.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	for _, message := range []string{"first", "second"} {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+			t.Fatalf("WriteMessage() error = %v", err)
+		}
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("ReadMessage() error = %v", err)
+		}
+		if string(data) != "echo-"+message {
+			t.Errorf("ReadMessage() got = %s, want %s", string(data), "echo-"+message)
+		}
+	}
+}
+
+func TestRouting_proxyServerSentEvents(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+
+	target := newTestTarget(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: first\n\n"))
+		w.(http.Flusher).Flush()
+
+		// Keep the stream open till the test is done
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	})
+	proxy := newTestProxy(t, &config.Route{Targets: []config.RouteTarget{target}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, proxy.URL+"/events", nil)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	defer response.Body.Close()
+
+	// The event must arrive while the stream is still open
+	line, err := bufio.NewReader(response.Body).ReadString('\n')
+	if err != nil {
+		t.Fatalf("ReadString() error = %v", err)
+	}
+	if line != "data: first\n" {
+		t.Errorf("ReadString() got = %q, want %q", line, "data: first\n")
+	}
+}
+
+func TestRouting_proxyH2C(t *testing.T) {
+	target := newTestTarget(t, h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 {
+			w.WriteHeader(http.StatusHTTPVersionNotSupported)
+			return
+		}
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status")
+		_, _ = w.Write([]byte("message"))
+		w.Header().Set("Grpc-Status", "0")
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", "ok")
+	}), &http2.Server{}).ServeHTTP)
+	target.Scheme = config.RouteTargetSchemeH2C
+
+	proxy := newTestProxy(t, &config.Route{Targets: []config.RouteTarget{target}})
+
+	response, err := http.Post(proxy.URL+"/service/Method", "application/grpc", strings.NewReader("request"))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	defer response.Body.Close()
+
+	data, _ := ioutil.ReadAll(response.Body)
+	if response.StatusCode != http.StatusOK || string(data) != "message" {
+		t.Fatalf("Post() got = (%d, %s), want (%d, %s)", response.StatusCode, string(data), http.StatusOK, "message")
+	}
+	if got := response.Trailer.Get("Grpc-Status"); got != "0" {
+		t.Errorf("Post() trailer Grpc-Status = %s, want 0", got)
+	}
+	if got := response.Trailer.Get("Grpc-Message"); got != "ok" {
+		t.Errorf("Post() trailer Grpc-Message = %s, want ok", got)
+	}
+}
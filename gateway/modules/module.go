@@ -1,6 +1,8 @@
 package modules
 
 import (
+	"os"
+
 	"github.com/spaceuptech/space-cloud/gateway/managers"
 	"github.com/spaceuptech/space-cloud/gateway/modules/auth"
 	"github.com/spaceuptech/space-cloud/gateway/modules/crud"
@@ -12,6 +14,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/modules/schema"
 	"github.com/spaceuptech/space-cloud/gateway/modules/userman"
 	"github.com/spaceuptech/space-cloud/gateway/utils/graphql"
+	"github.com/spaceuptech/space-cloud/gateway/utils/pubsub"
 )
 
 // Module is an object that sets up the modules
@@ -52,6 +55,9 @@ func newModule(projectID, clusterID, nodeID string, managers *managers.Managers,
 
 	a := auth.Init(clusterID, nodeID, c, adminMan, integrationMan)
 	a.SetMakeHTTPRequest(syncMan.MakeHTTPRequest)
+	if err := a.StartRevocationSync(projectID); err != nil {
+		return nil, err
+	}
 
 	fn := functions.Init(clusterID, a, syncMan, integrationMan, metrics.AddFunctionOperation)
 	fn.SetCachingModule(globalMods.Caching())
@@ -64,6 +70,7 @@ func newModule(projectID, clusterID, nodeID string, managers *managers.Managers,
 	}
 
 	f.SetEventingModule(e)
+	e.SetFunctionsModule(fn)
 
 	c.SetHooks(metrics.AddDBOperation)
 
@@ -73,7 +80,16 @@ func newModule(projectID, clusterID, nodeID string, managers *managers.Managers,
 	}
 
 	u := userman.Init(c, a)
+	u.SetFunctionsModule(fn)
+	u.SetEventingModule(e)
 	graphqlMan := graphql.New(a, c, fn, s)
+	graphqlMan.SetAdminManager(adminMan)
+	rateLimiter, err := pubsub.New(projectID, os.Getenv("REDIS_CONN"))
+	if err != nil {
+		return nil, err
+	}
+	graphqlMan.SetRateLimiter(rateLimiter)
+	fn.SetRateLimiter(rateLimiter)
 
 	return &Module{auth: a, db: c, user: u, file: f, functions: fn, realtime: rt, eventing: e, graphql: graphqlMan, schema: s, Managers: managers, GlobalMods: globalMods}, nil
 }
@@ -151,6 +151,15 @@ func (m *Modules) SetEventingTriggerConfig(ctx context.Context, projectID string
 	return module.SetEventingTriggerConfig(ctx, eventingTriggers)
 }
 
+// SetEventingSourceConfig sets the message brokers the eventing module consumes events from
+func (m *Modules) SetEventingSourceConfig(ctx context.Context, projectID string, sources config.EventingSources) error {
+	module, err := m.loadModule(projectID)
+	if err != nil {
+		return err
+	}
+	return module.SetEventingSourceConfig(ctx, sources)
+}
+
 // SetEventingRuleConfig sets the config of eventing module
 func (m *Modules) SetEventingRuleConfig(ctx context.Context, projectID string, secureObj config.EventingRules) error {
 	module, err := m.loadModule(projectID)
@@ -169,6 +178,24 @@ func (m *Modules) SetUsermanConfig(ctx context.Context, projectID string, auth c
 	return module.SetUsermanConfig(ctx, projectID, auth)
 }
 
+// SetSecurityRolesConfig sets the roles used by the rbac security rule
+func (m *Modules) SetSecurityRolesConfig(ctx context.Context, projectID string, roles config.SecurityRoles) error {
+	module, err := m.loadModule(projectID)
+	if err != nil {
+		return err
+	}
+	return module.SetSecurityRolesConfig(ctx, roles)
+}
+
+// SetGraphQLQueriesConfig sets the queries registered for the graphql endpoint
+func (m *Modules) SetGraphQLQueriesConfig(ctx context.Context, projectID string, queries config.GraphQLQueries) error {
+	module, err := m.loadModule(projectID)
+	if err != nil {
+		return err
+	}
+	return module.SetGraphQLQueriesConfig(ctx, queries)
+}
+
 // SetLetsencryptConfig set the config of letsencrypt module
 func (m *Modules) SetLetsencryptConfig(ctx context.Context, projectID string, c *config.LetsEncrypt) error {
 	module, err := m.loadModule(projectID)
@@ -245,6 +272,9 @@ func (m *Modules) Delete(projectID string) {
 		if err := block.realtime.CloseConfig(); err != nil {
 			_ = helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Error closing realtime module config", err, map[string]interface{}{"project": projectID})
 		}
+
+		helpers.Logger.LogDebug(helpers.GetRequestID(context.TODO()), "Closing config of graphql module", nil)
+		block.graphql.CloseConfig()
 	}
 
 	delete(m.blocks, projectID)
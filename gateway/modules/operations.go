@@ -27,6 +27,7 @@ func (m *Module) SetInitialProjectConfig(ctx context.Context, projects config.Pr
 		if err := m.db.SetSchemaConfig(ctx, schemaDoc, project.DatabaseSchemas); err != nil {
 			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set schema db module config", err, nil)
 		}
+		m.graphql.SetDatabaseSchemas(schemaDoc)
 		if err := m.db.SetPreparedQueryConfig(ctx, project.DatabasePreparedQueries); err != nil {
 			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set db prepared query module config", err, nil)
 		}
@@ -40,6 +41,9 @@ func (m *Module) SetInitialProjectConfig(ctx context.Context, projects config.Pr
 		if err := m.auth.SetConfig(ctx, project.FileStoreConfig.StoreType, project.ProjectConfig, project.DatabaseRules, project.DatabasePreparedQueries, project.FileStoreRules, project.RemoteService, project.EventingRules); err != nil {
 			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set auth module config", err, nil)
 		}
+		if err := m.auth.SetSecurityRoles(project.SecurityRoles); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set security roles of auth module", err, nil)
+		}
 
 		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of functions module", nil)
 		if err := m.functions.SetConfig(projectID, project.RemoteService); err != nil {
@@ -67,6 +71,11 @@ func (m *Module) SetInitialProjectConfig(ctx context.Context, projects config.Pr
 			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set eventing module triggers", err, nil)
 		}
 
+		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting sources of eventing module", nil)
+		if err := m.eventing.SetSourceConfig(project.EventingSources); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set eventing module sources", err, nil)
+		}
+
 		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of realtime module", nil)
 		if err := m.realtime.SetConfig(project.DatabaseConfigs, project.DatabaseRules, project.DatabaseSchemas); err != nil {
 			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set realtime module config", err, nil)
@@ -77,6 +86,14 @@ func (m *Module) SetInitialProjectConfig(ctx context.Context, projects config.Pr
 
 		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of graphql module", nil)
 		m.graphql.SetConfig(projectID)
+		m.graphql.SetDatabaseConfig(project.DatabaseConfigs)
+		m.graphql.SetPreparedQueries(project.DatabasePreparedQueries)
+		m.graphql.SetRemoteServices(project.RemoteService)
+		m.graphql.SetQueryAllowList(project.ProjectConfig.GraphQLAllowList)
+		m.graphql.SetQueryLimits(project.ProjectConfig.GraphQLLimits)
+		if err := m.graphql.SetPersistedQueries(project.GraphQLQueries); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set persisted queries of graphql module", err, nil)
+		}
 		if err := m.graphql.SetProjectAESKey(project.ProjectConfig.AESKey); err != nil {
 			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set aes key for graphql module config", err, nil)
 		}
@@ -108,6 +125,8 @@ func (m *Module) SetProjectConfig(ctx context.Context, p *config.ProjectConfig)
 	_ = m.user.SetProjectAESKey(p.AESKey)
 	_ = m.graphql.SetProjectAESKey(p.AESKey)
 	m.graphql.SetConfig(p.ID)
+	m.graphql.SetQueryAllowList(p.GraphQLAllowList)
+	m.graphql.SetQueryLimits(p.GraphQLLimits)
 	return nil
 }
 
@@ -121,6 +140,9 @@ func (m *Module) SetDatabaseConfig(ctx context.Context, projectID string, databa
 	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of realtime module", nil)
 	m.realtime.SetDatabaseConfig(databaseConfigs)
 
+	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of graphql module", nil)
+	m.graphql.SetDatabaseConfig(databaseConfigs)
+
 	// Set the schema config as well
 	if err := m.SetDatabaseSchemaConfig(ctx, projectID, schemaConfigs); err != nil {
 		return err
@@ -154,6 +176,7 @@ func (m *Module) SetDatabaseSchemaConfig(ctx context.Context, projectID string,
 		return err
 	}
 	m.realtime.SetDatabaseSchemas(schemaConfigs)
+	m.graphql.SetDatabaseSchemas(schemaDoc)
 	return nil
 }
 
@@ -174,6 +197,7 @@ func (m *Module) SetDatabasePreparedQueryConfig(ctx context.Context, prepConfigs
 		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set db prepared query in db module", err, nil)
 	}
 	m.auth.SetDatabasePreparedQueryRules(prepConfigs)
+	m.graphql.SetPreparedQueries(prepConfigs)
 	return nil
 }
 
@@ -229,6 +253,12 @@ func (m *Module) SetEventingTriggerConfig(ctx context.Context, eventingTriggers
 	return m.eventing.SetTriggerConfig(eventingTriggers)
 }
 
+// SetEventingSourceConfig sets the message brokers the eventing module consumes events from
+func (m *Module) SetEventingSourceConfig(ctx context.Context, sources config.EventingSources) error {
+	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting source config of eventing module", nil)
+	return m.eventing.SetSourceConfig(sources)
+}
+
 // SetEventingRuleConfig sets the config of eventing module
 func (m *Module) SetEventingRuleConfig(ctx context.Context, secureObj config.EventingRules) error {
 	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting rules config of eventing module", nil)
@@ -246,6 +276,18 @@ func (m *Module) SetUsermanConfig(ctx context.Context, _ string, auth config.Aut
 	return nil
 }
 
+// SetSecurityRolesConfig sets the roles used by the rbac security rule
+func (m *Module) SetSecurityRolesConfig(ctx context.Context, roles config.SecurityRoles) error {
+	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting security roles of auth module", nil)
+	return m.auth.SetSecurityRoles(roles)
+}
+
+// SetGraphQLQueriesConfig sets the queries registered for the graphql endpoint
+func (m *Module) SetGraphQLQueriesConfig(ctx context.Context, queries config.GraphQLQueries) error {
+	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting persisted queries of graphql module", nil)
+	return m.graphql.SetPersistedQueries(queries)
+}
+
 // SetLetsencryptConfig set the config of letsencrypt module
 func (m *Module) SetLetsencryptConfig(ctx context.Context, projectID string, c *config.LetsEncrypt) error {
 	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting letsencrypt config of project", nil)
@@ -269,6 +311,7 @@ func (m *Module) SetIngressGlobalRouteConfig(ctx context.Context, _ string, c *c
 func (m *Module) SetRemoteServiceConfig(ctx context.Context, projectID string, services config.Services) error {
 	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of auth module", nil)
 	m.auth.SetRemoteServiceConfig(services)
+	m.graphql.SetRemoteServices(services)
 
 	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of remote service module", nil)
 	return m.functions.SetConfig(projectID, services)
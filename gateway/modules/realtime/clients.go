@@ -14,6 +14,9 @@ type queryStub struct {
 	sendFeed model.SendFeed
 	whereObj map[string]interface{}
 	actions  *model.PostProcess
+
+	// window is set for live queries with a sort, limit or join
+	window *liveQueryWindow
 }
 
 type clientsStub struct {
@@ -34,7 +37,26 @@ func (m *Module) AddLiveQuery(id, _, dbAlias, group, clientID string, whereObj m
 	queries = t.(*sync.Map)
 
 	// Add the query
-	queries.Store(id, &queryStub{sendFeed, whereObj, actions})
+	queries.Store(id, &queryStub{sendFeed: sendFeed, whereObj: whereObj, actions: actions})
+}
+
+// addWindowedLiveQuery tracks a client for a live query with a window. The query is tracked on the joined tables
+// as well so that it gets re-evaluated when either side of the join changes
+func (m *Module) addWindowedLiveQuery(id, dbAlias, clientID string, query *queryStub) {
+	for _, table := range query.window.tables() {
+		// Load clients in a particular group
+		clients := new(clientsStub)
+		t, _ := m.groups.LoadOrStore(createGroupKey(dbAlias, table), clients)
+		clients = t.(*clientsStub)
+
+		// Load the queries of a particular client
+		queries := new(sync.Map)
+		t, _ = clients.clients.LoadOrStore(clientID, queries)
+		queries = t.(*sync.Map)
+
+		// Add the query
+		queries.Store(id, query)
+	}
 }
 
 // RemoveLiveQuery removes a particular live query
@@ -54,6 +76,33 @@ func (m *Module) RemoveLiveQuery(ctx context.Context, dbAlias, group, clientID,
 	}
 	queries := queriesTemp.(*sync.Map)
 
+	// Stop tracking the query on the joined tables
+	if query, ok := queries.Load(queryID); ok && query.(*queryStub).window != nil {
+		for _, table := range query.(*queryStub).window.tables() {
+			if table != group {
+				m.removeQuery(dbAlias, table, clientID, queryID)
+			}
+		}
+	}
+
+	m.removeQuery(dbAlias, group, clientID, queryID)
+	return nil
+}
+
+// removeQuery removes a query from a group along with the client and group if they become empty
+func (m *Module) removeQuery(dbAlias, group, clientID, queryID string) {
+	clientsTemp, ok := m.groups.Load(createGroupKey(dbAlias, group))
+	if !ok {
+		return
+	}
+	clients := clientsTemp.(*clientsStub)
+
+	queriesTemp, ok := clients.clients.Load(clientID)
+	if !ok {
+		return
+	}
+	queries := queriesTemp.(*sync.Map)
+
 	// Remove the query
 	queries.Delete(queryID)
 
@@ -66,8 +115,6 @@ func (m *Module) RemoveLiveQuery(ctx context.Context, dbAlias, group, clientID,
 	if mapLen(&clients.clients) == 0 {
 		m.groups.Delete(createGroupKey(dbAlias, group))
 	}
-
-	return nil
 }
 
 // RemoveClient removes a client
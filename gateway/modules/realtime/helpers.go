@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
@@ -34,6 +35,19 @@ func isDBEnabled(dbAlias string, dbConfigs config.DatabaseConfigs) bool {
 	return false
 }
 
+// getDBType returns the type of the database with the alias provided
+func (m *Module) getDBType(dbAlias string) string {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, dbConfig := range m.dbConfigs {
+		if dbConfig.DbAlias == dbAlias {
+			return dbConfig.Type
+		}
+	}
+	return ""
+}
+
 func isRealTimeEnabled(dbAlias, table string, dbRules config.DatabaseRules) bool {
 	for _, dbRule := range dbRules {
 		if dbRule.DbAlias == dbAlias && dbRule.Table == table {
@@ -97,6 +111,10 @@ func generateEventRules(dbConfigs config.DatabaseConfigs, dbRules config.Databas
 	return eventingRules
 }
 
+func generateReadOptions(options model.LiveQueryOptions) *model.ReadOptions {
+	return &model.ReadOptions{Sort: options.Sort, Limit: options.Limit, Join: options.Join, HasOptions: len(options.Sort) > 0 || isWindowedLiveQuery(options)}
+}
+
 func createGroupKey(dbAlias, col string) string {
 	return dbAlias + "::" + col
 }
@@ -104,3 +122,24 @@ func createGroupKey(dbAlias, col string) string {
 func getSendTopic(nodeID string) string {
 	return fmt.Sprintf("realtime-%s", nodeID)
 }
+
+// copyPayload copies the maps and arrays of the payload so that the post process actions of one
+// query don't modify the payload sent to the others
+func copyPayload(payload interface{}) interface{} {
+	switch v := payload.(type) {
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			obj[key] = copyPayload(value)
+		}
+		return obj
+	case []interface{}:
+		arr := make([]interface{}, len(v))
+		for i, value := range v {
+			arr[i] = copyPayload(value)
+		}
+		return arr
+	default:
+		return v
+	}
+}
@@ -3,6 +3,7 @@ package realtime
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -23,20 +24,78 @@ func (m *Module) Subscribe(clientID string, data *model.RealtimeRequest, sendFee
 	if data.Group == "" || data.DBType == "" || data.Where == nil {
 		return nil, errors.New("invalid request parameters provided")
 	}
-	readReq := model.ReadRequest{Find: data.Where, Operation: utils.All}
+	readReq, actions, reqParams, err := m.authoriseSubscription(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.DoRealtimeSubscribe(ctx, clientID, data, readReq, actions, reqParams, sendFeed)
+}
+
+// authoriseSubscription checks if the user is authorised to read the table of the live query along with the tables
+// joined with it. The rules of the joined tables are added to the read request, so that their rows get filtered
+// and post processed by the crud module
+func (m *Module) authoriseSubscription(ctx context.Context, data *model.RealtimeRequest) (*model.ReadRequest, *model.PostProcess, model.RequestParams, error) {
+	readReq := &model.ReadRequest{Find: data.Where, Operation: utils.All, Options: generateReadOptions(data.Options)}
 
 	// Check if the user is authorised to make the request
-	actions, reqParams, err := m.auth.IsReadOpAuthorised(ctx, data.Project, data.DBType, data.Group, data.Token, &readReq, model.ReturnWhereStub{})
+	actions, reqParams, err := m.auth.IsReadOpAuthorised(ctx, data.Project, data.DBType, data.Group, data.Token, readReq, model.ReturnWhereStub{})
 	if err != nil {
-		return nil, err
+		return nil, nil, model.RequestParams{}, err
 	}
 
-	return m.DoRealtimeSubscribe(ctx, clientID, data, actions, reqParams, sendFeed)
+	// The user needs to be authorised to read the joined tables as well
+	if len(readReq.Options.Join) > 0 {
+		readReq.PostProcess = map[string]*model.PostProcess{data.Group: actions}
+		if err := m.auth.RunAuthForJoins(ctx, data.Project, m.getDBType(data.DBType), data.DBType, data.Token, readReq, readReq.Options.Join); err != nil {
+			return nil, nil, model.RequestParams{}, err
+		}
+	}
+
+	return readReq, actions, reqParams, nil
 }
 
 // DoRealtimeSubscribe makes the realtime query
-func (m *Module) DoRealtimeSubscribe(ctx context.Context, clientID string, data *model.RealtimeRequest, actions *model.PostProcess, reqParams model.RequestParams, sendFeed model.SendFeed) ([]*model.FeedData, error) {
-	readReq := &model.ReadRequest{Find: data.Where, Operation: utils.All}
+func (m *Module) DoRealtimeSubscribe(ctx context.Context, clientID string, data *model.RealtimeRequest, readReq *model.ReadRequest, actions *model.PostProcess, reqParams model.RequestParams, sendFeed model.SendFeed) ([]*model.FeedData, error) {
+	// Only replay the feeds missed by the client if the subscription is being resumed
+	if data.ResumeFrom != nil {
+		resumed, err := m.resumeLiveQuery(ctx, clientID, data, actions, sendFeed)
+		if err != nil {
+			return nil, err
+		}
+		if resumed {
+			return []*model.FeedData{}, nil
+		}
+	}
+
+	// Changes made after this offset might not be a part of the initial data
+	offset, err := m.pubsubClient.GetLogOffset(ctx, createGroupKey(data.DBType, data.Group))
+	if err != nil {
+		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to get offset of changes of (%s)", data.Group), err, nil)
+	}
+
+	feedData, err := m.subscribe(ctx, clientID, data, readReq, actions, reqParams, sendFeed)
+	if err != nil {
+		return nil, err
+	}
+	for _, feed := range feedData {
+		feed.Offset = offset
+	}
+
+	// Ask the client to drop its copy of the data if the subscription couldn't be resumed
+	if data.ResumeFrom != nil {
+		resync := &model.FeedData{QueryID: data.ID, Type: utils.RealtimeResync, Group: data.Group, DBType: data.DBType, Payload: map[string]interface{}{}, Find: map[string]interface{}{}, Offset: offset}
+		feedData = append([]*model.FeedData{resync}, feedData...)
+	}
+	return feedData, nil
+}
+
+func (m *Module) subscribe(ctx context.Context, clientID string, data *model.RealtimeRequest, readReq *model.ReadRequest, actions *model.PostProcess, reqParams model.RequestParams, sendFeed model.SendFeed) ([]*model.FeedData, error) {
+	// Live queries with a window need to track the rows sent to the client
+	if isWindowedLiveQuery(data.Options) {
+		return m.doWindowedSubscribe(ctx, clientID, data, readReq, actions, reqParams, sendFeed)
+	}
+
 	if data.Options.SkipInitial {
 		m.AddLiveQuery(data.ID, data.Project, data.DBType, data.Group, clientID, data.Where, actions, sendFeed)
 		return []*model.FeedData{}, nil
@@ -78,6 +137,44 @@ func (m *Module) DoRealtimeSubscribe(ctx context.Context, clientID string, data
 	return feedData, nil
 }
 
+func (m *Module) doWindowedSubscribe(ctx context.Context, clientID string, data *model.RealtimeRequest, readReq *model.ReadRequest, actions *model.PostProcess, reqParams model.RequestParams, sendFeed model.SendFeed) ([]*model.FeedData, error) {
+	// The crud module post processes the rows of each joined table with the actions added while authorising them
+	query := &queryStub{sendFeed: sendFeed, whereObj: data.Where, actions: actions, window: newLiveQueryWindow(data.DBType, data.Group, readReq, reqParams)}
+
+	// The window is read even if the initial rows are to be skipped, so that the changes can be diffed against it
+	rows, err := m.readWindow(ctx, query.window)
+	if err != nil {
+		return nil, err
+	}
+	query.window.rows = rows
+
+	feedData := make([]*model.FeedData, 0)
+	if !data.Options.SkipInitial {
+		for _, row := range rows {
+			payload := row.payload
+			if len(readReq.Options.Join) == 0 {
+				payload = copyPayload(payload)
+				_ = authHelpers.PostProcessMethod(ctx, m.aesKey, actions, payload)
+			}
+
+			feedData = append(feedData, &model.FeedData{
+				Group:     data.Group,
+				Type:      utils.RealtimeInitial,
+				TimeStamp: 1,
+				Find:      row.find,
+				DBType:    data.DBType,
+				Payload:   payload,
+				QueryID:   data.ID,
+			})
+		}
+	}
+
+	// Add the live query
+	m.addWindowedLiveQuery(data.ID, data.DBType, clientID, query)
+
+	return feedData, nil
+}
+
 // Unsubscribe performs the realtime unsubscribe operation.
 func (m *Module) Unsubscribe(ctx context.Context, data *model.RealtimeRequest, clientID string) error {
 	return m.RemoveLiveQuery(ctx, data.DBType, data.Group, clientID, data.ID)
@@ -99,6 +196,11 @@ func (m *Module) HandleRealtimeEvent(ctxRoot context.Context, eventDoc *model.Cl
 	ctx, cancel := context.WithTimeout(ctxRoot, 5*time.Second)
 	defer cancel()
 
+	// Give the change an offset in its group so that subscriptions can be resumed
+	if err := m.appendToChangeLog(ctx, eventDoc); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Realtime Module: Unable to log change", err, nil)
+	}
+
 	for _, i := range ids {
 		go func(id string) {
 			defer wg.Done()
@@ -135,17 +237,7 @@ func (m *Module) ProcessRealtimeRequests(ctx context.Context, eventDoc *model.Cl
 		return err
 	}
 
-	t, _ := time.Parse(time.RFC3339, eventDoc.Time)
-	feedData := &model.FeedData{
-		Type:      eventingToRealtimeEvent(eventDoc.Type),
-		Payload:   dbEvent.Doc,
-		TimeStamp: t.UnixNano() / int64(time.Millisecond),
-		Group:     dbEvent.Col,
-		DBType:    dbEvent.DBType,
-		Find:      dbEvent.Find,
-	}
-
-	m.helperSendFeed(ctx, feedData)
+	m.helperSendFeed(ctx, generateFeedData(eventDoc, dbEvent))
 
 	return nil
 }
@@ -0,0 +1,75 @@
+package realtime
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/modules/auth"
+	"github.com/spaceuptech/space-cloud/gateway/modules/crud"
+)
+
+func TestModule_authoriseSubscription(t *testing.T) {
+	dbRules := config.DatabaseRules{
+		config.GenerateResourceID("chicago", "project", config.ResourceDatabaseRule, "db", "players", "rule"): &config.DatabaseRule{
+			Rules: map[string]*config.Rule{"read": {Rule: "allow"}},
+		},
+		config.GenerateResourceID("chicago", "project", config.ResourceDatabaseRule, "db", "teams", "rule"): &config.DatabaseRule{
+			Rules: map[string]*config.Rule{"read": {Rule: "remove", Fields: []interface{}{"res.secret"}}},
+		},
+		config.GenerateResourceID("chicago", "project", config.ResourceDatabaseRule, "db", "accounts", "rule"): &config.DatabaseRule{
+			Rules: map[string]*config.Rule{"read": {Rule: "deny"}},
+		},
+	}
+
+	a := auth.Init("chicago", "1", &crud.Module{}, nil, nil)
+	if err := a.SetConfig(context.TODO(), "local", &config.ProjectConfig{ID: "project", Secrets: []*config.Secret{{IsPrimary: true, Secret: "mySecretkey"}}}, dbRules, config.DatabasePreparedQueries{}, config.FileStoreRules{}, config.Services{}, config.EventingRules{}); err != nil {
+		t.Fatalf("error setting config of auth module - %s", err.Error())
+	}
+	token, err := a.CreateToken(context.TODO(), map[string]interface{}{"id": "1"})
+	if err != nil {
+		t.Fatalf("error creating token - %s", err.Error())
+	}
+	m := &Module{auth: a, dbConfigs: config.DatabaseConfigs{"db": {DbAlias: "db", Type: string(model.Postgres)}}}
+
+	tests := []struct {
+		name            string
+		join            []*model.JoinOption
+		wantPostProcess map[string]*model.PostProcess
+		wantErr         bool
+	}{
+		{
+			name: "live query without joins",
+		},
+		{
+			name: "joined table with a remove rule",
+			join: []*model.JoinOption{{Table: "teams", On: map[string]interface{}{"players.team_id": "teams.id"}}},
+			wantPostProcess: map[string]*model.PostProcess{
+				"players": nil,
+				"teams":   {PostProcessAction: []model.PostProcessAction{{Action: "remove", Field: "res.secret"}}},
+			},
+		},
+		{
+			name:    "joined table which can't be read",
+			join:    []*model.JoinOption{{Table: "teams", Join: []*model.JoinOption{{Table: "accounts"}}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &model.RealtimeRequest{Project: "project", DBType: "db", Group: "players", Token: token, Where: map[string]interface{}{}, Options: model.LiveQueryOptions{Join: tt.join}}
+			readReq, _, _, err := m.authoriseSubscription(context.Background(), data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("authoriseSubscription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(readReq.PostProcess, tt.wantPostProcess) {
+				t.Errorf("authoriseSubscription() post process = %v, want %v", readReq.PostProcess, tt.wantPostProcess)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	authHelpers "github.com/spaceuptech/space-cloud/gateway/modules/auth/helpers"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+const (
+	// changeLogSize is the number of changes of a group retained to resume subscriptions
+	changeLogSize = 1000
+
+	// changeLogTTL is the duration after which the changes of an idle group are dropped
+	changeLogTTL = 24 * time.Hour
+)
+
+// appendToChangeLog stores the change in the change log of its group. The offset of the change is added to the
+// event so that the feeds sent by every gateway carry the same offset
+func (m *Module) appendToChangeLog(ctx context.Context, eventDoc *model.CloudEventPayload) error {
+	dbEvent := new(model.DatabaseEventMessage)
+	if err := mapstructure.Decode(eventDoc.Data, dbEvent); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(generateFeedData(eventDoc, dbEvent))
+	if err != nil {
+		return err
+	}
+
+	offset, err := m.pubsubClient.AppendToLog(ctx, createGroupKey(dbEvent.DBType, dbEvent.Col), string(data), changeLogSize, changeLogTTL)
+	if err != nil {
+		return err
+	}
+
+	dbEvent.Offset = offset
+	eventDoc.Data = dbEvent
+	return nil
+}
+
+func generateFeedData(eventDoc *model.CloudEventPayload, dbEvent *model.DatabaseEventMessage) *model.FeedData {
+	t, _ := time.Parse(time.RFC3339, eventDoc.Time)
+	return &model.FeedData{
+		Type:      eventingToRealtimeEvent(eventDoc.Type),
+		Payload:   dbEvent.Doc,
+		TimeStamp: t.UnixNano() / int64(time.Millisecond),
+		Group:     dbEvent.Col,
+		DBType:    dbEvent.DBType,
+		Find:      dbEvent.Find,
+		Offset:    dbEvent.Offset,
+	}
+}
+
+// resumeLiveQuery replays the feeds the client missed since the offset it resumes from. It returns false if the
+// subscription can't be resumed since some of those changes are no longer retained. Live queries with a window
+// can't be resumed since the rows which left the window aren't known
+func (m *Module) resumeLiveQuery(ctx context.Context, clientID string, data *model.RealtimeRequest, actions *model.PostProcess, sendFeed model.SendFeed) (bool, error) {
+	if isWindowedLiveQuery(data.Options) {
+		return false, nil
+	}
+
+	// Track the live query before reading the change log so that no change gets missed. The live feeds are held
+	// back till the missed ones have been sent
+	buffer := &feedBuffer{sendFeed: sendFeed}
+	m.AddLiveQuery(data.ID, data.Project, data.DBType, data.Group, clientID, data.Where, actions, buffer.send)
+
+	entries, ok, err := m.pubsubClient.ReadLog(ctx, createGroupKey(data.DBType, data.Group), *data.ResumeFrom)
+	if err != nil || !ok {
+		m.removeQuery(data.DBType, data.Group, clientID, data.ID)
+		return false, err
+	}
+
+	last := *data.ResumeFrom
+	feeds := make([]*model.FeedData, 0, len(entries))
+	for _, entry := range entries {
+		last = entry.Offset
+
+		feed := new(model.FeedData)
+		if err := json.Unmarshal([]byte(entry.Data), feed); err != nil {
+			m.removeQuery(data.DBType, data.Group, clientID, data.ID)
+			return false, err
+		}
+		feed.QueryID, feed.Offset = data.ID, entry.Offset
+
+		if feed.Type != utils.RealtimeDelete && !utils.Validate(model.DefaultValidate, data.Where, feed.Payload) {
+			continue
+		}
+		_ = authHelpers.PostProcessMethod(ctx, m.aesKey, actions, feed.Payload)
+		feeds = append(feeds, feed)
+	}
+
+	buffer.replay(feeds, last)
+	m.metrics.AddDBOperation(m.project, data.DBType, data.Group, int64(len(feeds)), model.Read)
+	return true, nil
+}
+
+// feedBuffer holds back the live feeds of a resumed live query till the feeds missed by the client have been sent
+type feedBuffer struct {
+	lock     sync.Mutex
+	sendFeed model.SendFeed
+	replayed bool
+	feeds    []*model.FeedData
+}
+
+func (b *feedBuffer) send(feed *model.FeedData) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !b.replayed {
+		b.feeds = append(b.feeds, feed)
+		return
+	}
+	b.sendFeed(feed)
+}
+
+// replay sends the missed feeds followed by the live feeds held back. Live feeds of the changes till the last
+// offset replayed are dropped
+func (b *feedBuffer) replay(missed []*model.FeedData, last int64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for _, feed := range missed {
+		b.sendFeed(feed)
+	}
+	for _, feed := range b.feeds {
+		if feed.Offset == 0 || feed.Offset > last {
+			b.sendFeed(feed)
+		}
+	}
+
+	b.feeds = nil
+	b.replayed = true
+}
@@ -0,0 +1,78 @@
+package realtime
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+func Test_feedBuffer(t *testing.T) {
+	tests := []struct {
+		name   string
+		live   []int64
+		missed []int64
+		last   int64
+		after  []int64
+		want   []int64
+	}{
+		{
+			name:   "missed feeds are sent before the live ones",
+			live:   []int64{5, 6},
+			missed: []int64{3, 4},
+			last:   4,
+			after:  []int64{7},
+			want:   []int64{3, 4, 5, 6, 7},
+		},
+		{
+			name:   "live feeds which were replayed are dropped",
+			live:   []int64{4, 5},
+			missed: []int64{3, 4},
+			last:   4,
+			want:   []int64{3, 4, 5},
+		},
+		{
+			name:  "live feeds of changes filtered out of the replay are dropped",
+			live:  []int64{4, 5},
+			last:  4,
+			after: []int64{6},
+			want:  []int64{5, 6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]int64, 0)
+			b := &feedBuffer{sendFeed: func(feed *model.FeedData) { got = append(got, feed.Offset) }}
+
+			for _, offset := range tt.live {
+				b.send(&model.FeedData{Offset: offset})
+			}
+			if len(got) != 0 {
+				t.Fatalf("feedBuffer.send() sent feeds %v before the replay", got)
+			}
+
+			missed := make([]*model.FeedData, len(tt.missed))
+			for i, offset := range tt.missed {
+				missed[i] = &model.FeedData{Offset: offset}
+			}
+			b.replay(missed, tt.last)
+
+			for _, offset := range tt.after {
+				b.send(&model.FeedData{Offset: offset})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("feedBuffer sent feeds %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_generateFeedData(t *testing.T) {
+	eventDoc := &model.CloudEventPayload{Type: "DB_UPDATE", Time: "2020-01-01T00:00:01Z"}
+	dbEvent := &model.DatabaseEventMessage{DBType: "db", Col: "players", Doc: map[string]interface{}{"id": "1"}, Find: map[string]interface{}{"id": "1"}, Offset: 7}
+
+	want := &model.FeedData{Type: "update", Payload: dbEvent.Doc, TimeStamp: 1577836801000, Group: "players", DBType: "db", Find: dbEvent.Find, Offset: 7}
+	if got := generateFeedData(eventDoc, dbEvent); !reflect.DeepEqual(got, want) {
+		t.Errorf("generateFeedData() = %v, want %v", got, want)
+	}
+}
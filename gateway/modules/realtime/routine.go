@@ -28,9 +28,18 @@ func (m *Module) helperSendFeed(ctx context.Context, data *model.FeedData) {
 		queries.Range(func(id interface{}, value interface{}) bool {
 			query := value.(*queryStub)
 
+			// Live queries with a window are re-evaluated as a whole
+			if query.window != nil {
+				m.processWindowFeed(ctx, id.(string), query, data)
+				return true
+			}
+
 			dataPoint := &model.FeedData{
 				QueryID: id.(string), Group: data.Group, Payload: data.Payload, Find: data.Find,
-				TimeStamp: data.TimeStamp, Type: data.Type, DBType: data.DBType,
+				TimeStamp: data.TimeStamp, Type: data.Type, DBType: data.DBType, Offset: data.Offset,
+			}
+			if query.actions != nil && len(query.actions.PostProcessAction) > 0 {
+				dataPoint.Payload = copyPayload(data.Payload)
 			}
 
 			switch data.Type {
@@ -0,0 +1,308 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	authHelpers "github.com/spaceuptech/space-cloud/gateway/modules/auth/helpers"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// liveQueryWindow holds the rows last sent to the client for a live query with a sort, limit or join. Such live
+// queries can't be evaluated against a single changed row. Instead, they are read again whenever a change may
+// affect them and the result is diffed against the rows the client already has
+type liveQueryWindow struct {
+	lock sync.Mutex
+
+	dbAlias   string
+	group     string
+	readReq   *model.ReadRequest
+	reqParams model.RequestParams
+
+	// rows are stored in the sort order of the live query
+	rows []*windowRow
+}
+
+type windowRow struct {
+	key     string
+	find    map[string]interface{}
+	payload interface{}
+}
+
+func newLiveQueryWindow(dbAlias, group string, readReq *model.ReadRequest, reqParams model.RequestParams) *liveQueryWindow {
+	return &liveQueryWindow{dbAlias: dbAlias, group: group, readReq: readReq, reqParams: reqParams}
+}
+
+func isWindowedLiveQuery(options model.LiveQueryOptions) bool {
+	return options.Limit != nil || len(options.Join) > 0
+}
+
+// tables returns the table of the live query along with all the tables joined with it
+func (w *liveQueryWindow) tables() []string {
+	tables := []string{w.group}
+	var addJoins func(join []*model.JoinOption)
+	addJoins = func(join []*model.JoinOption) {
+		for _, j := range join {
+			if !utils.StringExists(tables, j.Table) {
+				tables = append(tables, j.Table)
+			}
+			addJoins(j.Join)
+		}
+	}
+	addJoins(w.readReq.Options.Join)
+	return tables
+}
+
+// readWindow reads the rows of the window from the database
+func (m *Module) readWindow(ctx context.Context, w *liveQueryWindow) ([]*windowRow, error) {
+	// Use a copy of the request since the crud module may modify it
+	req := *w.readReq
+	options := *req.Options
+	req.Options = &options
+
+	ctx2, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, _, err := m.crud.Read(ctx2, w.dbAlias, w.group, &req, w.reqParams)
+	if err != nil {
+		return nil, err
+	}
+
+	array, _ := result.([]interface{})
+	rows := make([]*windowRow, 0, len(array))
+	for _, row := range array {
+		obj, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		find := m.prepareFindObject(w.dbAlias, w.group, obj)
+		rows = append(rows, &windowRow{key: windowKey(find, obj), find: find, payload: obj})
+	}
+	return rows, nil
+}
+
+// isAffectedBy checks if a change of a row of the table of the live query can modify the window. Changes of the
+// joined tables always affect the window
+func (m *Module) isAffectedBy(w *liveQueryWindow, data *model.FeedData) bool {
+	if data.Group != w.group || len(w.readReq.Options.Join) > 0 {
+		return true
+	}
+
+	var row map[string]interface{}
+	switch data.Type {
+	case utils.RealtimeDelete:
+		row, _ = data.Find.(map[string]interface{})
+	default:
+		row, _ = data.Payload.(map[string]interface{})
+	}
+	if row == nil {
+		return true
+	}
+
+	// We can't tell which row has changed if the table doesn't have primary keys
+	find := m.prepareFindObject(w.dbAlias, w.group, row)
+	if len(find) == 0 {
+		return true
+	}
+
+	// Rows which are already in the window are always affected
+	key := windowKey(find, row)
+	for _, r := range w.rows {
+		if r.key == key {
+			return true
+		}
+	}
+
+	// Rows which aren't in the window can only enter it if they match the where clause
+	if data.Type == utils.RealtimeDelete || !utils.Validate(model.DefaultValidate, w.readReq.Find, row) {
+		return false
+	}
+
+	// A row which comes after the last row of a full window stays out of it
+	limit := w.readReq.Options.Limit
+	if limit != nil && int64(len(w.rows)) >= *limit && len(w.rows) > 0 {
+		last, _ := w.rows[len(w.rows)-1].payload.(map[string]interface{})
+		if c, ok := compareSortOrder(w.readReq.Options.Sort, row, last); ok && c > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// processWindowFeed re-evaluates a live query with a window and sends the rows which entered, changed in or
+// left the window
+func (m *Module) processWindowFeed(ctx context.Context, queryID string, query *queryStub, data *model.FeedData) {
+	w := query.window
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if !m.isAffectedBy(w, data) {
+		return
+	}
+
+	rows, err := m.readWindow(ctx, w)
+	if err != nil {
+		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to re-evaluate live query (%s) on (%s)", queryID, w.group), err, nil)
+		return
+	}
+
+	feeds := diffWindow(w.rows, rows)
+	w.rows = rows
+
+	for _, feed := range feeds {
+		feed.QueryID, feed.Group, feed.DBType, feed.TimeStamp = queryID, w.group, w.dbAlias, data.TimeStamp
+		m.sendWindowFeed(ctx, query, feed)
+	}
+}
+
+// sendWindowFeed post processes the payload of a feed before sending it. The payload of a live query with joins is
+// post processed by the crud module itself
+func (m *Module) sendWindowFeed(ctx context.Context, query *queryStub, feed *model.FeedData) {
+	if len(query.window.readReq.Options.Join) == 0 && query.actions != nil && len(query.actions.PostProcessAction) > 0 {
+		feed.Payload = copyPayload(feed.Payload)
+		_ = authHelpers.PostProcessMethod(ctx, m.aesKey, query.actions, feed.Payload)
+	}
+	query.sendFeed(feed)
+	m.metrics.AddDBOperation(m.project, feed.DBType, feed.Group, 1, model.Read)
+}
+
+// diffWindow generates the feeds which take a client from the old rows of a window to the new ones. Rows leaving
+// the window are deleted first so that clients can maintain a window of a fixed size
+func diffWindow(oldRows, newRows []*windowRow) []*model.FeedData {
+	oldKeys := make(map[string]*windowRow, len(oldRows))
+	for _, row := range oldRows {
+		oldKeys[row.key] = row
+	}
+	newKeys := make(map[string]*windowRow, len(newRows))
+	for _, row := range newRows {
+		newKeys[row.key] = row
+	}
+
+	feeds := make([]*model.FeedData, 0)
+	for _, row := range oldRows {
+		if _, ok := newKeys[row.key]; !ok {
+			feeds = append(feeds, &model.FeedData{Type: utils.RealtimeDelete, Find: row.find, Payload: row.payload})
+		}
+	}
+	for _, row := range newRows {
+		old, ok := oldKeys[row.key]
+		switch {
+		case !ok:
+			feeds = append(feeds, &model.FeedData{Type: utils.RealtimeInsert, Find: row.find, Payload: row.payload})
+		case !reflect.DeepEqual(old.payload, row.payload):
+			feeds = append(feeds, &model.FeedData{Type: utils.RealtimeUpdate, Find: row.find, Payload: row.payload})
+		}
+	}
+	return feeds
+}
+
+// windowKey identifies a row by its primary keys. The entire row is used if the table doesn't have any
+func windowKey(find, row map[string]interface{}) string {
+	if len(find) == 0 {
+		find = row
+	}
+	data, _ := json.Marshal(find)
+	return string(data)
+}
+
+// compareSortOrder compares two rows in the provided sort order. It returns false if the rows can't be compared
+func compareSortOrder(sort []string, a, b map[string]interface{}) (int, bool) {
+	if len(sort) == 0 {
+		return 0, false
+	}
+
+	for _, field := range sort {
+		key := "row." + strings.TrimPrefix(field, "-")
+		v1, err1 := utils.LoadValue(key, map[string]interface{}{"row": a})
+		v2, err2 := utils.LoadValue(key, map[string]interface{}{"row": b})
+		if err1 != nil || err2 != nil {
+			return 0, false
+		}
+
+		c, ok := compareValues(v1, v2)
+		if !ok {
+			return 0, false
+		}
+		if strings.HasPrefix(field, "-") {
+			c = -c
+		}
+		if c != 0 {
+			return c, true
+		}
+	}
+	return 0, true
+}
+
+func compareValues(v1, v2 interface{}) (int, bool) {
+	if n1, ok := toFloat64(v1); ok {
+		n2, ok := toFloat64(v2)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case n1 < n2:
+			return -1, true
+		case n1 > n2:
+			return 1, true
+		}
+		return 0, true
+	}
+
+	switch t1 := v1.(type) {
+	case string:
+		t2, ok := v2.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(t1, t2), true
+	case time.Time:
+		t2, ok := v2.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case t1.Before(t2):
+			return -1, true
+		case t1.After(t2):
+			return 1, true
+		}
+		return 0, true
+	case bool:
+		t2, ok := v2.(bool)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case t1 == t2:
+			return 0, true
+		case !t1:
+			return -1, true
+		}
+		return 1, true
+	}
+	return 0, false
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
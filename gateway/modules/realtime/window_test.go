@@ -0,0 +1,125 @@
+package realtime
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+type mockSchema struct {
+	schemas map[string]model.Fields
+}
+
+func (m *mockSchema) GetSchema(dbAlias, col string) (model.Fields, bool) {
+	fields, ok := m.schemas[createGroupKey(dbAlias, col)]
+	return fields, ok
+}
+
+func windowRows(rows ...map[string]interface{}) []*windowRow {
+	array := make([]*windowRow, len(rows))
+	for i, row := range rows {
+		find := map[string]interface{}{"id": row["id"]}
+		array[i] = &windowRow{key: windowKey(find, row), find: find, payload: row}
+	}
+	return array
+}
+
+func Test_diffWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldRows []*windowRow
+		newRows []*windowRow
+		want    []*model.FeedData
+	}{
+		{
+			name:    "unchanged window",
+			oldRows: windowRows(map[string]interface{}{"id": "1", "score": 10}),
+			newRows: windowRows(map[string]interface{}{"id": "1", "score": 10}),
+			want:    []*model.FeedData{},
+		},
+		{
+			name:    "row enters and another one leaves the window",
+			oldRows: windowRows(map[string]interface{}{"id": "1", "score": 10}, map[string]interface{}{"id": "2", "score": 5}),
+			newRows: windowRows(map[string]interface{}{"id": "3", "score": 20}, map[string]interface{}{"id": "1", "score": 10}),
+			want: []*model.FeedData{
+				{Type: utils.RealtimeDelete, Find: map[string]interface{}{"id": "2"}, Payload: map[string]interface{}{"id": "2", "score": 5}},
+				{Type: utils.RealtimeInsert, Find: map[string]interface{}{"id": "3"}, Payload: map[string]interface{}{"id": "3", "score": 20}},
+			},
+		},
+		{
+			name:    "row changes within the window",
+			oldRows: windowRows(map[string]interface{}{"id": "1", "score": 10}, map[string]interface{}{"id": "2", "score": 5}),
+			newRows: windowRows(map[string]interface{}{"id": "2", "score": 15}, map[string]interface{}{"id": "1", "score": 10}),
+			want: []*model.FeedData{
+				{Type: utils.RealtimeUpdate, Find: map[string]interface{}{"id": "2"}, Payload: map[string]interface{}{"id": "2", "score": 15}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffWindow(tt.oldRows, tt.newRows); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffWindow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_compareSortOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		sort   []string
+		a, b   map[string]interface{}
+		want   int
+		wantOk bool
+	}{
+		{name: "ascending numbers of different types", sort: []string{"score"}, a: map[string]interface{}{"score": int64(5)}, b: map[string]interface{}{"score": float64(10)}, want: -1, wantOk: true},
+		{name: "descending numbers", sort: []string{"-score"}, a: map[string]interface{}{"score": 5}, b: map[string]interface{}{"score": 10}, want: 1, wantOk: true},
+		{name: "tie broken by the next field", sort: []string{"-score", "name"}, a: map[string]interface{}{"score": 5, "name": "b"}, b: map[string]interface{}{"score": 5, "name": "a"}, want: 1, wantOk: true},
+		{name: "nested fields", sort: []string{"stats.score"}, a: map[string]interface{}{"stats": map[string]interface{}{"score": 5}}, b: map[string]interface{}{"stats": map[string]interface{}{"score": 5}}, want: 0, wantOk: true},
+		{name: "missing field", sort: []string{"score"}, a: map[string]interface{}{}, b: map[string]interface{}{"score": 5}},
+		{name: "values of different types", sort: []string{"score"}, a: map[string]interface{}{"score": "5"}, b: map[string]interface{}{"score": 5}},
+		{name: "no sort", a: map[string]interface{}{"score": 5}, b: map[string]interface{}{"score": 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := compareSortOrder(tt.sort, tt.a, tt.b)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("compareSortOrder() = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestModule_isAffectedBy(t *testing.T) {
+	limit := int64(2)
+	window := &liveQueryWindow{
+		dbAlias: "db",
+		group:   "players",
+		readReq: &model.ReadRequest{Find: map[string]interface{}{"active": true}, Options: &model.ReadOptions{Sort: []string{"-score"}, Limit: &limit}},
+		rows:    windowRows(map[string]interface{}{"id": "1", "active": true, "score": 20}, map[string]interface{}{"id": "2", "active": true, "score": 10}),
+	}
+	m := &Module{schema: &mockSchema{schemas: map[string]model.Fields{"db::players": {"id": &model.FieldType{FieldName: "id", IsPrimary: true}}}}}
+
+	tests := []struct {
+		name string
+		data *model.FeedData
+		want bool
+	}{
+		{name: "row in the window is updated", data: &model.FeedData{Group: "players", Type: utils.RealtimeUpdate, Payload: map[string]interface{}{"id": "2", "active": false, "score": 10}}, want: true},
+		{name: "row in the window is deleted", data: &model.FeedData{Group: "players", Type: utils.RealtimeDelete, Find: map[string]interface{}{"id": "1"}}, want: true},
+		{name: "row outside the window is deleted", data: &model.FeedData{Group: "players", Type: utils.RealtimeDelete, Find: map[string]interface{}{"id": "3"}}},
+		{name: "row not matching the where clause", data: &model.FeedData{Group: "players", Type: utils.RealtimeInsert, Payload: map[string]interface{}{"id": "3", "active": false, "score": 30}}},
+		{name: "row sorted after the last row of a full window", data: &model.FeedData{Group: "players", Type: utils.RealtimeInsert, Payload: map[string]interface{}{"id": "3", "active": true, "score": 5}}},
+		{name: "row entering the window", data: &model.FeedData{Group: "players", Type: utils.RealtimeInsert, Payload: map[string]interface{}{"id": "3", "active": true, "score": 15}}, want: true},
+		{name: "row of another table", data: &model.FeedData{Group: "teams", Type: utils.RealtimeUpdate, Payload: map[string]interface{}{"id": "1"}}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.isAffectedBy(window, tt.data); got != tt.want {
+				t.Errorf("Module.isAffectedBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -28,8 +28,13 @@ func (s *Schema) SchemaCreation(ctx context.Context, dbAlias, tableName, logical
 		return err
 	}
 
-	// Return gracefully if db type is mongo
-	if dbType == string(model.Mongo) || dbType == string(model.EmbeddedDB) {
+	// Mongo collections are schemaless, only the text index used by the $search operator needs to be created
+	if dbType == string(model.Mongo) {
+		return s.crud.CreateTextIndex(ctx, dbAlias, tableName, schemaHelpers.GetFullTextFields(parsedSchema[dbAlias][tableName]))
+	}
+
+	// Return gracefully if db type is embedded
+	if dbType == string(model.EmbeddedDB) {
 		return nil
 	}
 
@@ -89,6 +94,13 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 				IsPrimary:           realColumnInfo.IsPrimary,
 				NestedObject:        realColumnInfo.NestedObject,
 			}
+			// The create table query of sqlite already contains the default & foreign key constraints
+			if model.DBType(dbType) == model.SQLite {
+				temp.IsDefault = realColumnInfo.IsDefault
+				temp.Default = realColumnInfo.Default
+				temp.IsForeign = realColumnInfo.IsForeign
+				temp.JointTable = realColumnInfo.JointTable
+			}
 			currentTableInfo[realColumnName] = &temp
 		}
 	}
@@ -195,6 +207,11 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 					return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf(`Mutation is not allowed on field ("%s") with primary key, Delete the table to change primary key`, c.ColumnName), nil, nil)
 				}
 				// make changes according to the changes in directives
+				if model.DBType(dbType) == model.SQLite {
+					if err := c.checkSQLiteModification(ctx); err != nil {
+						return nil, err
+					}
+				}
 				queries := c.modifyColumn(dbType)
 				batchedQueries = append(batchedQueries, queries...)
 			}
@@ -208,13 +225,21 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 	}
 
 	for indexName, fields := range realIndexMap {
+		addIndexQuery := s.addIndex(dbType, dbAlias, logicalDBName, tableName, indexName, fields.IsIndexUnique, fields.IndexTableProperties)
+		if fields.IsIndexFullText {
+			addIndexQuery, err = s.addFullTextIndex(ctx, dbType, logicalDBName, tableName, indexName, fields.IndexTableProperties)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		if _, ok := currentIndexMap[indexName]; !ok {
-			batchedQueries = append(batchedQueries, s.addIndex(dbType, dbAlias, logicalDBName, tableName, indexName, fields.IsIndexUnique, fields.IndexTableProperties))
+			batchedQueries = append(batchedQueries, addIndexQuery)
 			continue
 		}
 		if arr := deep.Equal(fields.IndexTableProperties, cleanIndexMap(currentIndexMap[indexName].IndexTableProperties)); len(arr) > 0 {
 			batchedQueries = append(batchedQueries, s.removeIndex(dbType, dbAlias, logicalDBName, tableName, currentIndexMap[indexName].IndexName))
-			batchedQueries = append(batchedQueries, s.addIndex(dbType, dbAlias, logicalDBName, tableName, indexName, fields.IsIndexUnique, fields.IndexTableProperties))
+			batchedQueries = append(batchedQueries, addIndexQuery)
 		}
 	}
 
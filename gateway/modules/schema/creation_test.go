@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
+
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/managers/admin"
 	"github.com/spaceuptech/space-cloud/gateway/model"
@@ -50,6 +52,13 @@ func TestSchema_generateCreationQueries(t *testing.T) {
 
 	}
 
+	crudSQLite := crud.Init()
+	crudSQLite.SetAdminManager(adminMan)
+	err = crudSQLite.SetConfig("test", config.DatabaseConfigs{config.GenerateResourceID("chicago", "myproject", config.ResourceDatabaseConfig, "sqlite"): &config.DatabaseConfig{DbAlias: "sqlite", Type: "sql-sqlite", Enabled: false}})
+	if err != nil {
+		t.Fatal("unable to initialize sqlite", err)
+	}
+
 	crudSQLServer := crud.Init()
 	crudSQLServer.SetAdminManager(adminMan)
 	err = crudSQLServer.SetConfig("test", config.DatabaseConfigs{config.GenerateResourceID("chicago", "myproject", config.ResourceDatabaseConfig, "sqlserver"): &config.DatabaseConfig{DbAlias: "sqlserver", Type: "sql-sqlserver", Enabled: false}})
@@ -3886,6 +3895,176 @@ func TestSchema_generateCreationQueries(t *testing.T) {
 		},
 	}
 
+	var sqliteTestCases = []testGenerateCreationQueries{
+		{
+			name: "SQLite adding a table with default and foreign key constraints",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table1": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col1": &model.FieldType{FieldName: "col1", Kind: model.TypeBoolean, IsFieldTypeRequired: true, IsDefault: true, Default: true}}, "table2": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsForeign: true, JointTable: &model.TableProperties{Table: "table1", To: "id", OnDelete: "CASCADE", ConstraintName: "c_table2_col2"}}}}},
+				currentSchema: model.Collection{"table2": model.Fields{}},
+			},
+			fields:  fields{crud: crudSQLite, project: "test"},
+			want:    []string{"CREATE TABLE table1 (id varchar(100) NOT NULL , col1 boolean NOT NULL DEFAULT true ,PRIMARY KEY (id));"},
+			wantErr: false,
+		},
+		{
+			name: "SQLite adding a table with an auto increment primary key",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table1": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeInteger, IsPrimary: true, IsAutoIncrement: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col1": &model.FieldType{FieldName: "col1", Kind: model.TypeDateTime, Args: &model.FieldArgs{Precision: model.DefaultDateTimePrecision}}}}},
+				currentSchema: model.Collection{},
+			},
+			fields:  fields{crud: crudSQLite, project: "test"},
+			want:    []string{"CREATE TABLE table1 (id integer NOT NULL PRIMARY KEY AUTOINCREMENT, col1 datetime);"},
+			wantErr: false,
+		},
+		{
+			name: "SQLite auto increment on a non integer primary key",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table1": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeBigInteger, IsPrimary: true, IsAutoIncrement: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}}}},
+				currentSchema: model.Collection{},
+			},
+			fields:  fields{crud: crudSQLite, project: "test"},
+			wantErr: true,
+		},
+		{
+			name: "SQLite adding a column with a foreign key to an existing table",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table1": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsForeign: true, JointTable: &model.TableProperties{Table: "table2", To: "id", OnDelete: "NO ACTION", ConstraintName: "c_table1_col1"}}}, "table2": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}}}},
+				currentSchema: model.Collection{"table1": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}}, "table2": model.Fields{}},
+			},
+			fields:  fields{crud: crudSQLite, project: "test"},
+			want:    []string{"ALTER TABLE table1 ADD COLUMN col1 varchar(100) REFERENCES table2 (id)"},
+			wantErr: false,
+		},
+		{
+			name: "SQLite changing not null of an existing column",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table1": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString, IsFieldTypeRequired: true}}}},
+				currentSchema: model.Collection{"table1": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString}}},
+			},
+			fields:  fields{crud: crudSQLite, project: "test"},
+			wantErr: true,
+		},
+		{
+			name: "SQLite changing type of an existing column",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table1": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col1": &model.FieldType{FieldName: "col1", Kind: model.TypeInteger, IsDefault: true, Default: 5}}}},
+				currentSchema: model.Collection{"table1": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString, IsDefault: true, Default: "5"}}},
+			},
+			fields:  fields{crud: crudSQLite, project: "test"},
+			want:    []string{"ALTER TABLE table1 DROP COLUMN col1", "ALTER TABLE table1 ADD COLUMN col1 integer DEFAULT 5"},
+			wantErr: false,
+		},
+	}
+
+	var fullTextIndexTestCases = []testGenerateCreationQueries{
+		{
+			name: "adding a fulltext index in postgres",
+			args: args{
+				dbAlias:       "postgres",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"postgres": model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{Field: "col1", IsFullText: true, Group: "search", Order: 1, Sort: "asc"}}}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{Field: "col2", IsFullText: true, Group: "search", Order: 2, Sort: "asc"}}}}}},
+				currentSchema: model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeString}}},
+			},
+			fields:  fields{crud: crudPostgres, project: "test"},
+			want:    []string{"CREATE INDEX index__table1__search ON test.table1 USING GIN (to_tsvector('english', col1), to_tsvector('english', col2))"},
+			wantErr: false,
+		},
+		{
+			name: "adding a fulltext index in mysql",
+			args: args{
+				dbAlias:       "mysql",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"mysql": model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{Field: "col1", IsFullText: true, Group: "col1", Order: 1, Sort: "asc"}}}}}},
+				currentSchema: model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString}}},
+			},
+			fields:  fields{crud: crudMySQL, project: "test"},
+			want:    []string{"CREATE FULLTEXT INDEX index__table1__col1 ON table1 (col1)"},
+			wantErr: false,
+		},
+		{
+			name: "changing a regular index to a fulltext index",
+			args: args{
+				dbAlias:       "mysql",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"mysql": model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{Field: "col1", IsFullText: true, Group: "i1", Order: 1, Sort: "asc"}}}}}},
+				currentSchema: model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{Field: "col1", IsIndex: true, Group: "i1", ConstraintName: getIndexName("table1", "i1"), Order: 1, Sort: "asc"}}}}},
+			},
+			fields:  fields{crud: crudMySQL, project: "test"},
+			want:    []string{"DROP INDEX index__table1__i1 ON table1", "CREATE FULLTEXT INDEX index__table1__i1 ON table1 (col1)"},
+			wantErr: false,
+		},
+		{
+			name: "fulltext index already exists",
+			args: args{
+				dbAlias:       "postgres",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"postgres": model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{Field: "col1", IsFullText: true, Group: "col1", Order: 1, Sort: "asc"}}}}}},
+				currentSchema: model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{Field: "col1", IsFullText: true, Group: "col1", ConstraintName: getIndexName("table1", "col1"), Order: 1, Sort: "asc"}}}}},
+			},
+			fields:  fields{crud: crudPostgres, project: "test"},
+			want:    []string{},
+			wantErr: false,
+		},
+		{
+			name: "fulltext index on a non text column",
+			args: args{
+				dbAlias:       "postgres",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"postgres": model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeInteger, IndexInfo: []*model.TableProperties{{Field: "col1", IsFullText: true, Group: "col1", Order: 1, Sort: "asc"}}}}}},
+				currentSchema: model.Collection{"table1": model.Fields{}},
+			},
+			fields:  fields{crud: crudPostgres, project: "test"},
+			wantErr: true,
+		},
+		{
+			name: "fulltext index grouped with a regular index",
+			args: args{
+				dbAlias:       "postgres",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"postgres": model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{Field: "col1", IsFullText: true, Group: "i1", Order: 1, Sort: "asc"}}}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{Field: "col2", IsIndex: true, Group: "i1", Order: 2, Sort: "asc"}}}}}},
+				currentSchema: model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeString}}},
+			},
+			fields:  fields{crud: crudPostgres, project: "test"},
+			wantErr: true,
+		},
+		{
+			name: "fulltext index in sqlite",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{Field: "col1", IsFullText: true, Group: "col1", Order: 1, Sort: "asc"}}}}}},
+				currentSchema: model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeString}}},
+			},
+			fields:  fields{crud: crudSQLite, project: "test"},
+			wantErr: true,
+		},
+	}
+
 	testCases := make([]testGenerateCreationQueries, 0)
 	testCases = append(testCases, noQueriesGeneratedTestCases...)
 	testCases = append(testCases, createTableTestCases...)
@@ -3898,6 +4077,8 @@ func TestSchema_generateCreationQueries(t *testing.T) {
 	testCases = append(testCases, changingUniqueIndexKeyTestCases...)
 	testCases = append(testCases, changingIndexKeyTestCases...)
 	testCases = append(testCases, miscellaneousTestCases...)
+	testCases = append(testCases, sqliteTestCases...)
+	testCases = append(testCases, fullTextIndexTestCases...)
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3933,6 +4114,38 @@ func TestSchema_generateCreationQueries(t *testing.T) {
 	}
 }
 
+func TestSchema_SchemaCreation_mongo(t *testing.T) {
+	parsedSchema := model.Type{"mongo": model.Collection{
+		"posts": model.Fields{
+			"id":    &model.FieldType{FieldName: "id", Kind: model.TypeID},
+			"title": &model.FieldType{FieldName: "title", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{IsFullText: true}}},
+			"body":  &model.FieldType{FieldName: "body", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{IsFullText: true}}},
+		},
+		"users": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID}},
+	}}
+	tests := []struct {
+		name      string
+		tableName string
+		want      []string
+	}{
+		{name: "text index is created on the fulltext fields", tableName: "posts", want: []string{"body", "title"}},
+		{name: "text index is removed without fulltext fields", tableName: "users", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCrud := &mockCrudSchemaInterface{}
+			mockCrud.On("GetDBType", "mongo").Return(string(model.Mongo))
+			mockCrud.On("CreateTextIndex", mock.Anything, "mongo", tt.tableName, tt.want).Return(nil)
+
+			s := &Schema{crud: mockCrud}
+			if err := s.SchemaCreation(context.Background(), "mongo", tt.tableName, "myproject", parsedSchema); err != nil {
+				t.Fatalf("Schema.SchemaCreation() error = %v", err)
+			}
+			mockCrud.AssertExpectations(t)
+		})
+	}
+}
+
 func sortArray(a []string) []string {
 	l := len(a)
 	for i := 0; i < l; i++ {
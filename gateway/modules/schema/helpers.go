@@ -21,6 +21,9 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 		}
 		return "", helpers.Logger.LogError(helpers.GetRequestID(ctx), "UUID type is only supported by postgres database", nil, nil)
 	case model.TypeTime:
+		if dbType == string(model.SQLite) {
+			return "time", nil
+		}
 		return fmt.Sprintf("time(%d)", realColumnInfo.Args.Precision), nil
 	case model.TypeDate:
 		return "date", nil
@@ -31,7 +34,7 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 				return "character", nil
 			}
 			return fmt.Sprintf("character(%d)", realColumnInfo.TypeIDSize), nil
-		case string(model.MySQL):
+		case string(model.MySQL), string(model.SQLite):
 			return fmt.Sprintf("char(%d)", realColumnInfo.TypeIDSize), nil
 		case string(model.SQLServer):
 			return fmt.Sprintf("nchar(%d)", realColumnInfo.TypeIDSize), nil
@@ -43,7 +46,7 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 				return "character varying", nil
 			}
 			return fmt.Sprintf("character varying(%d)", realColumnInfo.TypeIDSize), nil
-		case string(model.MySQL):
+		case string(model.MySQL), string(model.SQLite):
 			return fmt.Sprintf("varchar(%d)", realColumnInfo.TypeIDSize), nil
 		case string(model.SQLServer):
 			return fmt.Sprintf("nvarchar(%d)", realColumnInfo.TypeIDSize), nil
@@ -56,6 +59,8 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 			return "longtext", nil
 		case string(model.SQLServer):
 			return "nvarchar(max)", nil
+		case string(model.SQLite):
+			return "text", nil
 		}
 	case model.TypeDateTime:
 		switch dbType {
@@ -65,6 +70,9 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 			return fmt.Sprintf("datetime2(%d)", realColumnInfo.Args.Precision), nil
 		case string(model.Postgres):
 			return fmt.Sprintf("timestamp(%d) without time zone", realColumnInfo.Args.Precision), nil
+		case string(model.SQLite):
+			// The sqlite driver only parses columns declared exactly as datetime or timestamp into time values
+			return "datetime", nil
 		}
 	case model.TypeDateTimeWithZone:
 		switch dbType {
@@ -74,10 +82,12 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 			return fmt.Sprintf("datetimeoffset(%d)", realColumnInfo.Args.Precision), nil
 		case string(model.Postgres):
 			return fmt.Sprintf("timestamp(%d) with time zone", realColumnInfo.Args.Precision), nil
+		case string(model.SQLite):
+			return "timestamp", nil
 		}
 	case model.TypeBoolean:
 		switch dbType {
-		case string(model.Postgres):
+		case string(model.Postgres), string(model.SQLite):
 			return "boolean", nil
 		case string(model.MySQL):
 			return "tinyint(1)", nil
@@ -90,7 +100,7 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 		switch dbType {
 		case string(model.Postgres):
 			return "double precision", nil
-		case string(model.MySQL):
+		case string(model.MySQL), string(model.SQLite):
 			return "double", nil
 		case string(model.SQLServer):
 			return "float", nil
@@ -99,7 +109,7 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 		switch dbType {
 		case string(model.Postgres):
 			return fmt.Sprintf("numeric(%d,%d)", realColumnInfo.Args.Precision, realColumnInfo.Args.Scale), nil
-		case string(model.MySQL), string(model.SQLServer):
+		case string(model.MySQL), string(model.SQLServer), string(model.SQLite):
 			return fmt.Sprintf("decimal(%d,%d)", realColumnInfo.Args.Precision, realColumnInfo.Args.Scale), nil
 		}
 	case model.TypeInteger:
@@ -112,7 +122,7 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 		switch dbType {
 		case string(model.Postgres):
 			return "jsonb", nil
-		case string(model.MySQL):
+		case string(model.MySQL), string(model.SQLite):
 			return "json", nil
 		case string(model.SQLServer):
 			return "nvarchar(max)", nil
@@ -123,6 +133,17 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 	return "", helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unknown db type provided (%s)", dbType), nil, nil)
 }
 
+// checkSQLiteModification returns an error if the constraints of an existing column need to be changed,
+// since sqlite can only add & drop columns
+func (c *creationModule) checkSQLiteModification(ctx context.Context) error {
+	realInfo, currentInfo := c.realColumnInfo, c.currentColumnInfo
+	isForeignChanged := realInfo.IsForeign != currentInfo.IsForeign || (realInfo.IsForeign && realInfo.JointTable.OnDelete != currentInfo.JointTable.OnDelete)
+	if realInfo.IsFieldTypeRequired != currentInfo.IsFieldTypeRequired || realInfo.IsDefault != currentInfo.IsDefault || isForeignChanged {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Cannot change constraints of field (%s) in sqlite, delete the field or the table to change its constraints", c.ColumnName), nil, nil)
+	}
+	return nil
+}
+
 func checkErrors(ctx context.Context, realFieldStruct *model.FieldType) error {
 	if realFieldStruct.IsList && !realFieldStruct.IsLinked { // array without directive relation not allowed
 		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid type for field %s - array type without link directive is not supported in sql creation", realFieldStruct.FieldName), nil, nil)
@@ -153,6 +174,9 @@ func checkErrors(ctx context.Context, realFieldStruct *model.FieldType) error {
 				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("cannot set index on field (%s) having type json", realFieldStruct.FieldName), nil, nil)
 			}
 		}
+		if indexInfo.IsFullText && realFieldStruct.Kind != model.TypeString && realFieldStruct.Kind != model.TypeVarChar && realFieldStruct.Kind != model.TypeChar {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("cannot set fulltext index on field (%s) having type (%s), only text types are supported", realFieldStruct.FieldName, realFieldStruct.Kind), nil, nil)
+		}
 	}
 
 	return nil
@@ -278,11 +302,16 @@ func (c *creationModule) typeSwitch() string {
 		return ""
 	}
 
-	switch v := c.realColumnInfo.Default.(type) {
+	return defaultValue(model.DBType(dbType), c.realColumnInfo.Default)
+}
+
+// defaultValue formats the value of a default directive as a sql literal
+func defaultValue(dbType model.DBType, value interface{}) string {
+	switch v := value.(type) {
 	case string:
 		return "'" + fmt.Sprintf("%v", v) + "'"
 	case bool:
-		if model.DBType(dbType) == model.SQLServer {
+		if dbType == model.SQLServer {
 			if v {
 				return "1"
 			}
@@ -350,7 +379,7 @@ func (c *creationModule) removeForeignKey() []string {
 func (s *Schema) addNewTable(ctx context.Context, logicalDBName, dbType, dbAlias, realColName string, realColValue model.Fields) (string, error) {
 
 	var query, primaryKeyQuery string
-	doesPrimaryKeyExists := false
+	doesPrimaryKeyExists, isSQLiteAutoIncrement := false, false
 	compositePrimaryKeys := make(primaryKeyStore, 0)
 	for realFieldKey, realFieldStruct := range realColValue {
 
@@ -376,6 +405,15 @@ func (s *Schema) addNewTable(ctx context.Context, logicalDBName, dbType, dbAlias
 			var autoIncrement string
 			if realFieldStruct.IsAutoIncrement {
 				switch model.DBType(dbType) {
+				case model.SQLite:
+					// Sqlite only allows auto increment on a column declared as an integer primary key
+					if realFieldStruct.Kind != model.TypeInteger {
+						return "", helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Cannot add autoIncrement constraint on non integer column (%s)", realFieldKey), nil, nil)
+					}
+					isSQLiteAutoIncrement = true
+					primaryKeyQuery += fmt.Sprintf("%s integer NOT NULL PRIMARY KEY AUTOINCREMENT, ", realFieldKey)
+					continue
+
 				case model.SQLServer:
 					autoIncrement = " IDENTITY(1,1)"
 
@@ -408,9 +446,21 @@ func (s *Schema) addNewTable(ctx context.Context, logicalDBName, dbType, dbAlias
 			query += " NOT NULL"
 		}
 
+		// Sqlite cannot add constraints to existing columns, hence they are added while creating the table itself
+		if model.DBType(dbType) == model.SQLite {
+			query += sqliteColumnConstraints(realFieldStruct)
+		}
+
 		query += " ,"
 	}
 
+	if isSQLiteAutoIncrement {
+		if len(compositePrimaryKeys) > 1 {
+			return "", helpers.Logger.LogError(helpers.GetRequestID(ctx), "Cannot add autoIncrement constraint on a composite primary key in sqlite", nil, nil)
+		}
+		return `CREATE TABLE ` + s.getTableName(dbType, logicalDBName, realColName) + ` (` + strings.TrimSuffix(primaryKeyQuery+strings.TrimSuffix(query, " ,"), ", ") + `);`, nil
+	}
+
 	if doesPrimaryKeyExists {
 		compositePrimaryKeyQuery, err := getCompositePrimaryKeyQuery(ctx, compositePrimaryKeys)
 		if err != nil {
@@ -422,6 +472,22 @@ func (s *Schema) addNewTable(ctx context.Context, logicalDBName, dbType, dbAlias
 	return `CREATE TABLE ` + s.getTableName(dbType, logicalDBName, realColName) + ` (` + primaryKeyQuery + strings.TrimSuffix(query, " ,") + `);`, nil
 }
 
+// sqliteColumnConstraints returns the default & foreign key constraints of a column in the form they can be
+// specified in a column definition
+func sqliteColumnConstraints(field *model.FieldType) string {
+	var query string
+	if field.IsDefault {
+		query += " DEFAULT " + defaultValue(model.SQLite, field.Default)
+	}
+	if field.IsForeign {
+		query += " REFERENCES " + field.JointTable.Table + " (" + field.JointTable.To + ")"
+		if field.JointTable.OnDelete == "CASCADE" {
+			query += " ON DELETE CASCADE"
+		}
+	}
+	return query
+}
+
 func getCompositePrimaryKeyQuery(ctx context.Context, compositePrimaryKeys primaryKeyStore) (string, error) {
 	finalPrimaryKeyQuery := "PRIMARY KEY ("
 	if len(compositePrimaryKeys) > 1 {
@@ -459,6 +525,20 @@ func (c *creationModule) addColumn(dbType string) []string {
 		Kind:      c.realColumnInfo.Kind,
 	}
 
+	// Sqlite cannot alter the constraints of a column once it has been added. Hence all of them are added along with the column
+	if model.DBType(dbType) == model.SQLite {
+		query := "ALTER TABLE " + c.schemaModule.getTableName(dbType, c.logicalDBName, c.TableName) + " ADD COLUMN " + c.ColumnName + " " + c.columnType
+		if c.realColumnInfo.IsFieldTypeRequired {
+			query += " NOT NULL"
+		}
+		c.currentColumnInfo.IsFieldTypeRequired = c.realColumnInfo.IsFieldTypeRequired
+		c.currentColumnInfo.IsDefault = c.realColumnInfo.IsDefault
+		c.currentColumnInfo.Default = c.realColumnInfo.Default
+		c.currentColumnInfo.IsForeign = c.realColumnInfo.IsForeign
+		c.currentColumnInfo.JointTable = c.realColumnInfo.JointTable
+		return []string{query + sqliteColumnConstraints(c.realColumnInfo)}
+	}
+
 	if c.columnType != "" {
 		// add a new column with data type as columntype
 		queries = append(queries, c.addNewColumn())
@@ -531,6 +611,12 @@ func (c *creationModule) modifyColumn(dbType string) []string {
 func (c *creationModule) removeDirectives(dbType string) []string {
 	var queries []string
 
+	// The foreign key & default constraints of sqlite are a part of the column definition and get dropped along with it
+	if model.DBType(dbType) == model.SQLite {
+		c.currentColumnInfo.IsForeign = false
+		c.currentColumnInfo.IsDefault = false
+	}
+
 	if c.currentColumnInfo.IsForeign {
 		queries = append(queries, c.removeForeignKey()...)
 		c.currentColumnInfo.IsForeign = false
@@ -547,7 +633,7 @@ func (c *creationModule) removeDirectives(dbType string) []string {
 	// }
 
 	for _, indexInfo := range c.currentColumnInfo.IndexInfo {
-		if indexInfo.IsIndex || indexInfo.IsUnique {
+		if indexInfo.IsIndex || indexInfo.IsUnique || indexInfo.IsFullText {
 			if _, p := c.currentIndexMap[indexInfo.Group]; p {
 				queries = append(queries, c.schemaModule.removeIndex(dbType, c.dbAlias, c.logicalDBName, c.TableName, indexInfo.ConstraintName))
 				delete(c.currentIndexMap, indexInfo.Group)
@@ -586,9 +672,31 @@ func (s *Schema) addIndex(dbType, dbAlias, logicalDBName, tableName, indexName s
 	return p
 }
 
+// addFullTextIndex creates an index which is used by the $search operator. Postgres indexes the same tsvector
+// expression which is used while querying, while mysql uses a regular fulltext index
+func (s *Schema) addFullTextIndex(ctx context.Context, dbType, logicalDBName, tableName, indexName string, mapArray []*model.TableProperties) (string, error) {
+	columns := make([]string, len(mapArray))
+	for i, schemaFieldType := range mapArray {
+		columns[i] = schemaFieldType.Field
+		if model.DBType(dbType) == model.Postgres {
+			columns[i] = fmt.Sprintf("to_tsvector('%s', %s)", model.FullTextSearchLanguage, schemaFieldType.Field)
+		}
+	}
+
+	switch model.DBType(dbType) {
+	case model.Postgres:
+		return "CREATE INDEX " + getIndexName(tableName, indexName) + " ON " + s.getTableName(dbType, logicalDBName, tableName) + " USING GIN (" + strings.Join(columns, ", ") + ")", nil
+	case model.MySQL:
+		return "CREATE FULLTEXT INDEX " + getIndexName(tableName, indexName) + " ON " + s.getTableName(dbType, logicalDBName, tableName) + " (" + strings.Join(columns, ", ") + ")", nil
+	}
+	return "", helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Fulltext index (%s) cannot be created, fulltext indexes are not supported for database (%s)", indexName, dbType), nil, nil)
+}
+
 func (s *Schema) removeIndex(dbType, dbAlias, logicalDBName, tableName, indexName string) string {
 
 	switch model.DBType(dbType) {
+	case model.SQLite:
+		return "DROP INDEX " + indexName
 	case model.MySQL:
 		return "DROP INDEX " + indexName + " ON " + s.getTableName(dbType, logicalDBName, tableName)
 	case model.SQLServer:
@@ -606,6 +714,7 @@ func getIndexName(tableName, indexName string) string {
 
 type indexStruct struct {
 	IsIndexUnique        bool
+	IsIndexFullText      bool
 	IndexTableProperties []*model.TableProperties
 	IndexName            string
 }
@@ -618,13 +727,18 @@ func getIndexMap(ctx context.Context, tableInfo model.Fields) (map[string]*index
 
 		for _, indexInfo := range columnInfo.IndexInfo {
 			// We are only interested in the columns which have an index on them
-			if indexInfo.IsIndex || indexInfo.IsUnique {
+			if indexInfo.IsIndex || indexInfo.IsUnique || indexInfo.IsFullText {
 				// Append the column to te index map. Make sure we create an empty array if no index by the provided name exists
 				value, ok := indexMap[indexInfo.Group]
 				if !ok {
-					value = &indexStruct{IndexName: indexInfo.ConstraintName, IndexTableProperties: []*model.TableProperties{}}
+					value = &indexStruct{IndexName: indexInfo.ConstraintName, IndexTableProperties: []*model.TableProperties{}, IsIndexFullText: indexInfo.IsFullText}
 					indexMap[indexInfo.Group] = value
 				}
+
+				// A fulltext index can only contain other fulltext columns
+				if value.IsIndexFullText != indexInfo.IsFullText {
+					return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Fulltext index (%s) cannot be grouped with a regular index", indexInfo.Group), nil, nil)
+				}
 				// value.IndexMap = append(value.IndexMap, columnInfo)
 				value.IndexTableProperties = append(value.IndexTableProperties, indexInfo)
 
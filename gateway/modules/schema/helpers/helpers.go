@@ -68,6 +68,11 @@ func checkType(ctx context.Context, dbAlias, dbType, col string, value interface
 		return v, nil
 
 	case map[string]interface{}:
+		// References to earlier steps of a batch request get resolved right before the step is executed. The resolved
+		// value gets checked against the type of the field then
+		if ref, ok := utils.IsBatchReference(v); ok && utils.AreBatchReferencesAllowed(ctx) {
+			return &utils.BatchReference{Ref: ref, Kind: fieldValue.Kind, IsList: fieldValue.IsList}, nil
+		}
 		if fieldValue.Kind == model.TypeJSON {
 			if model.DBType(dbType) == model.Mongo {
 				return value, nil
@@ -219,7 +224,7 @@ func validateUnsetOperation(ctx context.Context, dbType, col string, doc interfa
 		return nil
 	}
 
-	if dbType == string(model.Postgres) || dbType == string(model.MySQL) || dbType == string(model.SQLServer) {
+	if dbType == string(model.Postgres) || dbType == string(model.MySQL) || dbType == string(model.SQLServer) || dbType == string(model.SQLite) {
 		for fieldName := range v {
 			columnInfo, ok := schemaDoc[strings.Split(fieldName, ".")[0]]
 			if ok {
@@ -388,11 +393,11 @@ func getCollectionSchema(doc *ast.Document, dbName, collectionName string) (mode
 						if fieldTypeStuct.Default == nil {
 							return nil, helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Default directive must be accompanied with value field", nil, nil)
 						}
-					case model.DirectiveIndex, model.DirectiveUnique:
+					case model.DirectiveIndex, model.DirectiveUnique, model.DirectiveFullText:
 						if fieldTypeStuct.IndexInfo == nil {
 							fieldTypeStuct.IndexInfo = make([]*model.TableProperties, 0)
 						}
-						indexInfo := &model.TableProperties{Order: model.DefaultIndexOrder, Sort: model.DefaultIndexSort, IsIndex: directive.Name.Value == model.DirectiveIndex, IsUnique: directive.Name.Value == model.DirectiveUnique}
+						indexInfo := &model.TableProperties{Order: model.DefaultIndexOrder, Sort: model.DefaultIndexSort, IsIndex: directive.Name.Value == model.DirectiveIndex, IsUnique: directive.Name.Value == model.DirectiveUnique, IsFullText: directive.Name.Value == model.DirectiveFullText}
 						var ok bool
 						for _, arg := range directive.Arguments {
 							switch arg.Name.Value {
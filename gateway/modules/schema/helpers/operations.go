@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/graphql-go/graphql/language/parser"
@@ -287,12 +289,22 @@ func AdjustWhereClause(ctx context.Context, dbAlias string, dbType model.DBType,
 		return nil
 	}
 
+	if dbType == model.Mongo {
+		if err := validateMongoTextSearch(ctx, col, tableInfo, find); err != nil {
+			return err
+		}
+	}
+
 	for k, v := range find {
 		field, p := tableInfo[k]
 		if !p {
 			continue
 		}
 
+		if _, ok := utils.IsBatchReference(v); ok && utils.AreBatchReferencesAllowed(ctx) {
+			continue
+		}
+
 		switch field.Kind {
 		case model.TypeBoolean:
 			if dbType == model.SQLServer {
@@ -410,3 +422,41 @@ func Parser(dbSchemas config.DatabaseSchemas) (model.Type, error) {
 func GetConstraintName(tableName, columnName string) string {
 	return fmt.Sprintf("c_%s_%s", tableName, columnName)
 }
+
+// GetFullTextFields returns the sorted names of the fields having a fulltext index
+func GetFullTextFields(fields model.Fields) []string {
+	names := make([]string, 0)
+	for name, field := range fields {
+		for _, indexInfo := range field.IndexInfo {
+			if indexInfo.IsFullText {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateMongoTextSearch checks if the $search operators can be answered by the text index of the collection. The text
+// index of a mongo collection spans all of its fulltext fields, hence a field can only be searched if it is the only one
+func validateMongoTextSearch(ctx context.Context, col string, tableInfo model.Fields, find map[string]interface{}) error {
+	clauses := utils.GetSearchClauses(find)
+	if len(clauses) == 0 {
+		return nil
+	}
+
+	fullTextFields := GetFullTextFields(tableInfo)
+	for _, clause := range clauses {
+		if len(fullTextFields) == 1 && fullTextFields[0] == clause.Field {
+			continue
+		}
+		for _, name := range fullTextFields {
+			if name == clause.Field {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Operator $search cannot be used on field (%s) in mongo, since the text index of collection (%s) searches fields (%s) at once", clause.Field, col, strings.Join(fullTextFields, ", ")), nil, nil)
+			}
+		}
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Operator $search cannot be used on field (%s) of collection (%s) since it does not have a fulltext index", clause.Field, col), nil, nil)
+	}
+	return nil
+}
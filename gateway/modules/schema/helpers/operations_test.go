@@ -248,6 +248,44 @@ func TestSchema_AdjustWhereClause(t *testing.T) {
 			want:    map[string]interface{}{"col2": map[string]interface{}{"time": "string"}},
 			wantErr: true,
 		},
+		{
+			name: "mongo search on the only fulltext field",
+			args: args{
+				dbAlias:   "mongo",
+				dbType:    model.Mongo,
+				col:       "posts",
+				find:      map[string]interface{}{"title": map[string]interface{}{"$search": "space"}},
+				schemaDoc: model.Type{"mongo": model.Collection{"posts": model.Fields{"title": &model.FieldType{FieldName: "title", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{IsFullText: true}}}}}},
+			},
+			want: map[string]interface{}{"title": map[string]interface{}{"$search": "space"}},
+		},
+		{
+			name: "mongo search on a field of a text index spanning multiple fields",
+			args: args{
+				dbAlias: "mongo",
+				dbType:  model.Mongo,
+				col:     "posts",
+				find:    map[string]interface{}{"title": map[string]interface{}{"$search": "space"}},
+				schemaDoc: model.Type{"mongo": model.Collection{"posts": model.Fields{
+					"title": &model.FieldType{FieldName: "title", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{IsFullText: true}}},
+					"body":  &model.FieldType{FieldName: "body", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{IsFullText: true}}},
+				}}},
+			},
+			want:    map[string]interface{}{"title": map[string]interface{}{"$search": "space"}},
+			wantErr: true,
+		},
+		{
+			name: "mongo search on a field without a fulltext index",
+			args: args{
+				dbAlias:   "mongo",
+				dbType:    model.Mongo,
+				col:       "posts",
+				find:      map[string]interface{}{"body": map[string]interface{}{"$search": "space"}},
+				schemaDoc: model.Type{"mongo": model.Collection{"posts": model.Fields{"title": &model.FieldType{FieldName: "title", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{IsFullText: true}}}}}},
+			},
+			want:    map[string]interface{}{"body": map[string]interface{}{"$search": "space"}},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -550,6 +588,19 @@ func TestParseSchema(t *testing.T) {
 								},
 							},
 						},
+						"text": &model.FieldType{
+							FieldName: "text",
+							Kind:      model.TypeString,
+							IndexInfo: []*model.TableProperties{
+								{
+									IsFullText: true,
+									Group:      "text",
+									Order:      1,
+									Sort:       "asc",
+									Field:      "text",
+								},
+							},
+						},
 						"customer_id": &model.FieldType{
 							FieldName:           "customer_id",
 							IsFieldTypeRequired: true,
@@ -597,6 +648,7 @@ func TestParseSchema(t *testing.T) {
 						 role: ID! @default(value: "user")
 						 first_name: ID! @index(group: "user_name", order: 1, sort: "asc")
 						 name: ID! @unique(group: "user_name", order: 1)
+						 text: String @fulltext
 						 customer_id: ID! @foreign(table: "customer", field: "id", onDelete: "cascade")
 						 order_dates: [DateTime] @link(table: "order", field: "order_date", from: "id", to: "customer_id")
 						}`,
@@ -1768,3 +1820,37 @@ func TestSchema_CheckType(t *testing.T) {
 		})
 	}
 }
+
+func TestSchema_CheckType_batchReference(t *testing.T) {
+	field := &model.FieldType{FieldName: "age", Kind: model.TypeInteger}
+	ref := map[string]interface{}{"$ref": "insert.id"}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:    "reference outside a batch request",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+		{
+			name: "reference in a batch request",
+			ctx:  utils.WithBatchReferences(context.Background()),
+			want: &utils.BatchReference{Ref: "insert.id", Kind: model.TypeInteger},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkType(tt.ctx, "mysql", string(model.MySQL), "users", ref, field)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkType() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkType() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
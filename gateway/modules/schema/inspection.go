@@ -100,6 +100,10 @@ func generateInspection(dbType, col string, fields []model.InspectorFieldType, i
 			if err := inspectionSQLServerCheckFieldType(col, field, &fieldDetails); err != nil {
 				return nil, err
 			}
+		case model.SQLite:
+			if err := inspectionSQLiteCheckFieldType(col, field, &fieldDetails); err != nil {
+				return nil, err
+			}
 		}
 
 		// default key
@@ -144,6 +148,10 @@ func generateInspection(dbType, col string, fields []model.InspectorFieldType, i
 						Order: indexValue.Order,
 					}
 					continue
+				} else if indexValue.IsFullText {
+					// The sort order doesn't apply to fulltext indexes
+					temp.IsFullText = true
+					temp.Sort = model.DefaultIndexSort
 				} else if indexValue.IsUnique {
 					temp.IsUnique = true
 				} else {
@@ -366,6 +374,52 @@ func inspectionPostgresCheckFieldType(col string, field model.InspectorFieldType
 	return nil
 }
 
+func inspectionSQLiteCheckFieldType(col string, field model.InspectorFieldType, fieldDetails *model.FieldType) error {
+	result := strings.Split(field.FieldType, "(")
+
+	switch strings.TrimSpace(result[0]) {
+	case "date":
+		fieldDetails.Kind = model.TypeDate
+	case "time":
+		fieldDetails.Kind = model.TypeTime
+	case "varchar":
+		fieldDetails.Kind = model.TypeVarChar
+		fieldDetails.TypeIDSize = field.VarcharSize
+	case "char":
+		fieldDetails.Kind = model.TypeChar
+		fieldDetails.TypeIDSize = field.VarcharSize
+	case "text":
+		fieldDetails.Kind = model.TypeString
+	case "smallint":
+		fieldDetails.Kind = model.TypeSmallInteger
+	case "bigint":
+		fieldDetails.Kind = model.TypeBigInteger
+	case "integer", "int":
+		fieldDetails.Kind = model.TypeInteger
+	case "real", "float", "double":
+		fieldDetails.Kind = model.TypeFloat
+	case "decimal", "numeric":
+		fieldDetails.Kind = model.TypeDecimal
+		if field.NumericPrecision > 0 || field.NumericScale > 0 {
+			fieldDetails.Args = &model.FieldArgs{
+				Precision: field.NumericPrecision,
+				Scale:     field.NumericScale,
+			}
+		}
+	case "datetime":
+		fieldDetails.Kind = model.TypeDateTime
+	case "timestamp":
+		fieldDetails.Kind = model.TypeDateTimeWithZone
+	case "boolean":
+		fieldDetails.Kind = model.TypeBoolean
+	case "json":
+		fieldDetails.Kind = model.TypeJSON
+	default:
+		return helpers.Logger.LogError("", fmt.Sprintf("Cannot track/inspect table (%s)", col), fmt.Errorf("table contains a column (%s) with type (%s) which is not supported by space cloud", fieldDetails.FieldName, result), nil)
+	}
+	return nil
+}
+
 // GetCollectionSchema returns schemas of collection aka tables for specified project & database
 func (s *Schema) GetCollectionSchema(ctx context.Context, project, dbAlias string) (map[string]*config.TableRule, error) {
 
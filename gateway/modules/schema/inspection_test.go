@@ -1970,6 +1970,31 @@ func Test_generateInspection(t *testing.T) {
 		},
 	}
 
+	var fullTextIndexTestCases = []testGenerateInspection{
+		{
+			name: "MySQL field col1 with type String having a fulltext index created through space cloud",
+			args: args{
+				dbType:    "mysql",
+				col:       "table1",
+				fields:    []model.InspectorFieldType{{ColumnName: "column1", FieldType: "text", FieldNull: "YES"}},
+				indexKeys: []model.IndexType{{TableName: "table1", ColumnName: "column1", IndexName: getIndexName("table1", "search"), Order: 1, Sort: "desc", IsFullText: true}},
+			},
+			want:    model.Collection{"table1": model.Fields{"column1": &model.FieldType{FieldName: "column1", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{Field: "column1", IsFullText: true, Group: "search", Order: 1, Sort: model.DefaultIndexSort, ConstraintName: getIndexName("table1", "search")}}}}},
+			wantErr: false,
+		},
+		{
+			name: "Postgres field col1 with type String having a fulltext index created through space cloud",
+			args: args{
+				dbType:    "postgres",
+				col:       "table1",
+				fields:    []model.InspectorFieldType{{ColumnName: "column1", FieldType: "text", FieldNull: "YES"}},
+				indexKeys: []model.IndexType{{TableName: "table1", ColumnName: "column1", IndexName: getIndexName("table1", "search"), Order: 1, Sort: model.DefaultIndexSort, IsFullText: true}},
+			},
+			want:    model.Collection{"table1": model.Fields{"column1": &model.FieldType{FieldName: "column1", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{Field: "column1", IsFullText: true, Group: "search", Order: 1, Sort: model.DefaultIndexSort, ConstraintName: getIndexName("table1", "search")}}}}},
+			wantErr: false,
+		},
+	}
+
 	// Test cases for each database follows a pattern of
 	// 1) Checking each individual column type
 	// 2) Checking each individual column type with not null
@@ -1990,6 +2015,7 @@ func Test_generateInspection(t *testing.T) {
 	testCases = append(testCases, foreignKeyTestCases...)
 	testCases = append(testCases, uniqueKeyTestCases...)
 	testCases = append(testCases, indexKeyTestCases...)
+	testCases = append(testCases, fullTextIndexTestCases...)
 	testCases = append(testCases, miscellaneousTestCases...)
 
 	for _, tt := range testCases {
@@ -80,6 +80,9 @@ func generateSDL(schemaCol model.Collection) (string, error) {
 		"{{if and (eq $sequence 2) $v.IsIndex}}" +
 		"@index(group: \"{{$v.Group}}\", sort: \"{{$v.Sort}}\", order: {{$v.Order}}) " +
 		"{{end}}" +
+		"{{if and (eq $sequence 2) $v.IsFullText}}" +
+		"@fulltext(group: \"{{$v.Group}}\", order: {{$v.Order}}) " +
+		"{{end}}" +
 		"{{end}}" +
 		"{{end}}" +
 		"{{end}}" + // for loop indexInfo
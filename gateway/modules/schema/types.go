@@ -24,3 +24,8 @@ func (m *mockCrudSchemaInterface) DescribeTable(ctx context.Context, dbAlias, co
 func (m *mockCrudSchemaInterface) RawBatch(ctx context.Context, dbAlias string, batchedQueries []string) error {
 	return nil
 }
+
+func (m *mockCrudSchemaInterface) CreateTextIndex(ctx context.Context, dbAlias, col string, fields []string) error {
+	c := m.Called(ctx, dbAlias, col, fields)
+	return c.Error(0)
+}
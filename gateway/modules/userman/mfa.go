@@ -0,0 +1,286 @@
+package userman
+
+import (
+	"context"
+	"crypto/aes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	authHelpers "github.com/spaceuptech/space-cloud/gateway/modules/auth/helpers"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// completeSignIn creates a session for a user whose credentials have been verified. Users who have enabled
+// multi-factor authentication receive an mfa token instead, which needs to be exchanged along with a totp code
+func (m *Module) completeSignIn(ctx context.Context, dbAlias, project, idField string, user map[string]interface{}) (int, map[string]interface{}, error) {
+	if isTruthy(user["mfa_enabled"]) {
+		// The mfa token is bound to a challenge stored for the user, so that it can be invalidated after too many
+		// failed attempts. Starting a new challenge invalidates the previous one
+		challenge := ksuid.New().String()
+		if err := m.updateUser(ctx, dbAlias, project, idField, user[idField], map[string]interface{}{"mfa_challenge": challenge, "mfa_failures": 0}); err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+
+		userID, _ := user[idField].(string)
+		mfaToken, err := m.createUserToken(purposeMFA, dbAlias, userID, fingerprint(challenge), mfaChallengeExpiry)
+		if err != nil {
+			return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create mfa token", err, nil)
+		}
+		return http.StatusOK, map[string]interface{}{"mfaRequired": true, "mfaToken": mfaToken}, nil
+	}
+
+	sanitizeUser(user)
+	status, tokens, err := m.issueTokens(ctx, dbAlias, project, idField, user)
+	if err != nil {
+		return status, nil, err
+	}
+	tokens["user"] = user
+	return http.StatusOK, tokens, nil
+}
+
+// VerifyMFA completes the sign in of a user who has enabled multi-factor authentication
+func (m *Module) VerifyMFA(ctx context.Context, dbAlias, project, mfaToken, code string) (int, map[string]interface{}, error) {
+	if !m.IsEnabled() {
+		return http.StatusNotFound, nil, errors.New("This feature isn't enabled")
+	}
+
+	t, err := m.parseUserToken(mfaToken, purposeMFA, dbAlias)
+	if err != nil {
+		return http.StatusUnauthorized, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Invalid mfa token provided", err, nil)
+	}
+
+	user, idField, err := m.readUserByID(ctx, dbAlias, project, t.UserID)
+	if err != nil {
+		return http.StatusUnauthorized, nil, errors.New("User not found")
+	}
+	if !isTruthy(user["mfa_enabled"]) {
+		return http.StatusBadRequest, nil, errors.New("Multi-factor authentication is not enabled for the user")
+	}
+	if challenge, _ := user["mfa_challenge"].(string); challenge == "" || fingerprint(challenge) != t.Check {
+		return http.StatusUnauthorized, nil, errors.New("Mfa token is no longer valid")
+	}
+
+	// The challenge is dropped once the attempts run out, so the user needs to sign in again
+	attempts, err := m.countMFAAttempt(ctx, dbAlias, project, idField, user[idField])
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	if attempts > maxMFAAttempts {
+		if err := m.updateUser(ctx, dbAlias, project, idField, user[idField], map[string]interface{}{"mfa_challenge": nil}); err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		return http.StatusUnauthorized, nil, errors.New("Too many wrong totp codes have been provided")
+	}
+	if err := m.checkTOTP(ctx, dbAlias, project, idField, user, "mfa_secret", code); err != nil {
+		if attempts == maxMFAAttempts {
+			if err := m.updateUser(ctx, dbAlias, project, idField, user[idField], map[string]interface{}{"mfa_challenge": nil}); err != nil {
+				return http.StatusInternalServerError, nil, err
+			}
+		}
+		return http.StatusUnauthorized, nil, err
+	}
+
+	// An mfa token can only be used once
+	if err := m.updateUser(ctx, dbAlias, project, idField, user[idField], map[string]interface{}{"mfa_challenge": nil, "mfa_failures": 0}); err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	sanitizeUser(user)
+	status, tokens, err := m.issueTokens(ctx, dbAlias, project, idField, user)
+	if err != nil {
+		return status, nil, err
+	}
+	tokens["user"] = user
+	return http.StatusOK, tokens, nil
+}
+
+// SetupMFA generates a new totp secret for the user. The secret is activated only once a code generated from it
+// has been verified via EnableMFA
+func (m *Module) SetupMFA(ctx context.Context, token, dbAlias, project string) (int, map[string]interface{}, error) {
+	user, idField, status, err := m.getTokenUser(ctx, token, dbAlias, project)
+	if err != nil {
+		return status, nil, err
+	}
+	if isTruthy(user["mfa_enabled"]) {
+		return http.StatusConflict, nil, errors.New("Multi-factor authentication has already been enabled")
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to generate totp secret", err, nil)
+	}
+	encrypted, err := m.encryptSecret(secret)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	// A new secret comes with a new set of attempts to enable it
+	if err := m.updateUser(ctx, dbAlias, project, idField, user[idField], map[string]interface{}{"mfa_pending_secret": encrypted, "mfa_failures": 0}); err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	m.RLock()
+	issuer := project
+	if stub, p := m.methods["email"]; p && stub.MFAIssuer != "" {
+		issuer = stub.MFAIssuer
+	}
+	m.RUnlock()
+
+	email, _ := user["email"].(string)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", totpDigits))
+	params.Set("period", fmt.Sprintf("%d", totpPeriod))
+	uri := fmt.Sprintf("otpauth://totp/%s:%s?%s", url.PathEscape(issuer), url.PathEscape(email), params.Encode())
+
+	return http.StatusOK, map[string]interface{}{"secret": secret, "uri": uri}, nil
+}
+
+// EnableMFA activates the totp secret generated by SetupMFA once a valid code is provided
+func (m *Module) EnableMFA(ctx context.Context, token, dbAlias, project, code string) (int, error) {
+	user, idField, status, err := m.getTokenUser(ctx, token, dbAlias, project)
+	if err != nil {
+		return status, err
+	}
+	if _, p := user["mfa_pending_secret"].(string); !p {
+		return http.StatusBadRequest, errors.New("Multi-factor authentication has not been set up")
+	}
+
+	if status, err := m.checkMFAAttempt(ctx, dbAlias, project, idField, user, "mfa_pending_secret", code); err != nil {
+		return status, err
+	}
+
+	set := map[string]interface{}{"mfa_enabled": true, "mfa_secret": user["mfa_pending_secret"], "mfa_pending_secret": nil, "mfa_failures": 0}
+	if err := m.updateUser(ctx, dbAlias, project, idField, user[idField], set); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+// DisableMFA turns off multi-factor authentication once a valid code is provided
+func (m *Module) DisableMFA(ctx context.Context, token, dbAlias, project, code string) (int, error) {
+	user, idField, status, err := m.getTokenUser(ctx, token, dbAlias, project)
+	if err != nil {
+		return status, err
+	}
+	if !isTruthy(user["mfa_enabled"]) {
+		return http.StatusBadRequest, errors.New("Multi-factor authentication is not enabled for the user")
+	}
+
+	if status, err := m.checkMFAAttempt(ctx, dbAlias, project, idField, user, "mfa_secret", code); err != nil {
+		return status, err
+	}
+
+	set := map[string]interface{}{"mfa_enabled": false, "mfa_secret": nil, "mfa_last_step": nil, "mfa_failures": 0}
+	if err := m.updateUser(ctx, dbAlias, project, idField, user[idField], set); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+// checkMFAAttempt validates the code of a signed in user against the secret stored in the field provided. The attempts
+// are limited like the ones of VerifyMFA. Once they run out, the user needs to sign in again or set up mfa again
+func (m *Module) checkMFAAttempt(ctx context.Context, dbAlias, project, idField string, user map[string]interface{}, field, code string) (int, error) {
+	attempts, err := m.countMFAAttempt(ctx, dbAlias, project, idField, user[idField])
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if attempts > maxMFAAttempts {
+		return http.StatusTooManyRequests, errors.New("Too many wrong totp codes have been provided")
+	}
+	if err := m.checkTOTP(ctx, dbAlias, project, idField, user, field, code); err != nil {
+		return http.StatusUnauthorized, err
+	}
+	return http.StatusOK, nil
+}
+
+// countMFAAttempt counts an attempt to provide a totp code before the code gets checked and returns the number of
+// attempts made so far. The count is incremented by the database, hence every concurrent attempt gets a count of its
+// own and no more than maxMFAAttempts of them get to check their code
+func (m *Module) countMFAAttempt(ctx context.Context, dbAlias, project, idField string, id interface{}) (int64, error) {
+	attr := map[string]string{"project": project, "db": dbAlias, "col": "users"}
+	reqParams := model.RequestParams{Resource: "db-update", Op: "access", Attributes: attr}
+	updateReq := &model.UpdateRequest{Find: map[string]interface{}{idField: id}, Operation: utils.One, Update: map[string]interface{}{"$inc": map[string]interface{}{"mfa_failures": 1}}}
+	if err := m.crud.Update(ctx, dbAlias, "users", updateReq, reqParams); err != nil {
+		return 0, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to count totp attempt of user", err, nil)
+	}
+
+	user, _, err := m.readUserByID(ctx, dbAlias, project, id)
+	if err != nil {
+		return 0, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to read totp attempts of user", err, nil)
+	}
+	return toUnixTime(user["mfa_failures"]), nil
+}
+
+// checkTOTP validates the code against the secret stored in the field provided. A code can be used only once
+func (m *Module) checkTOTP(ctx context.Context, dbAlias, project, idField string, user map[string]interface{}, field, code string) error {
+	encrypted, _ := user[field].(string)
+	secret, err := m.decryptSecret(encrypted)
+	if err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to decrypt totp secret of user", err, nil)
+	}
+
+	step, ok := validateTOTPCode(secret, code, time.Now())
+	if !ok {
+		return errors.New("Invalid totp code provided")
+	}
+	if lastStep, p := user["mfa_last_step"]; p && lastStep != nil && step <= toUnixTime(lastStep) {
+		return errors.New("Totp code has already been used")
+	}
+	return m.updateUser(ctx, dbAlias, project, idField, user[idField], map[string]interface{}{"mfa_last_step": step})
+}
+
+// getTokenUser reads the user the token has been issued for
+func (m *Module) getTokenUser(ctx context.Context, token, dbAlias, project string) (map[string]interface{}, string, int, error) {
+	if !m.IsEnabled() {
+		return nil, "", http.StatusNotFound, errors.New("This feature isn't enabled")
+	}
+
+	claims, err := m.auth.ParseToken(ctx, token)
+	if err != nil {
+		return nil, "", http.StatusUnauthorized, err
+	}
+
+	user, idField, err := m.readUserByID(ctx, dbAlias, project, claims["id"])
+	if err != nil {
+		return nil, "", http.StatusNotFound, errors.New("User not found")
+	}
+	return user, idField, http.StatusOK, nil
+}
+
+func (m *Module) encryptSecret(secret string) (string, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	if len(m.aesKey) == 0 {
+		return "", errors.New("aes key of project has not been set")
+	}
+	return utils.Encrypt(m.aesKey, secret)
+}
+
+func (m *Module) decryptSecret(encrypted string) (string, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	if len(m.aesKey) == 0 {
+		return "", errors.New("aes key of project has not been set")
+	}
+	src, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", err
+	}
+	dst := make([]byte, len(src))
+	if err := authHelpers.DecryptAESCFB(dst, src, m.aesKey, m.aesKey[:aes.BlockSize]); err != nil {
+		return "", err
+	}
+	return string(dst), nil
+}
@@ -0,0 +1,139 @@
+package userman
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+func Test_totpCode(t *testing.T) {
+	// Test vectors from RFC 6238 truncated to six digits
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+	for _, tt := range tests {
+		if got := totpCode(secret, tt.unix/totpPeriod); got != tt.want {
+			t.Errorf("totpCode() at %v = %v, want %v", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestModule_MFA(t *testing.T) {
+	m, crud, _ := newVerificationTestModule(t, nil)
+	m.SetConfig(config.Auths{"email": &config.AuthStub{ID: "email", Enabled: true, MFAIssuer: "Acme"}})
+	ctx := context.Background()
+
+	_, result, err := m.EmailSignUp(ctx, "db", "project", "user@example.com", "User", "password", "user")
+	if err != nil {
+		t.Fatalf("EmailSignUp() error = %v", err)
+	}
+	token := result["token"].(string)
+
+	status, setup, err := m.SetupMFA(ctx, token, "db", "project")
+	if err != nil {
+		t.Fatalf("SetupMFA() status = %v error = %v", status, err)
+	}
+	secret := setup["secret"].(string)
+	key, _ := totpEncoding.DecodeString(secret)
+	codeAt := func(offset int64) string {
+		return totpCode(key, time.Now().Unix()/totpPeriod+offset)
+	}
+
+	if status, err := m.EnableMFA(ctx, token, "db", "project", codeAt(5)); err == nil || status != http.StatusUnauthorized {
+		t.Errorf("EnableMFA() with code outside the window status = %v error = %v", status, err)
+	}
+	if status, err := m.EnableMFA(ctx, token, "db", "project", codeAt(0)); err != nil {
+		t.Fatalf("EnableMFA() status = %v error = %v", status, err)
+	}
+	user := crud.tables["users"][0]
+	if user["mfa_enabled"] != true || user["mfa_secret"] == secret || user["mfa_pending_secret"] != nil {
+		t.Fatalf("EnableMFA() stored user = %v", user)
+	}
+
+	// Sign in now requires the totp code
+	_, result, err = m.EmailSignIn(ctx, "db", "project", "user@example.com", "password")
+	if err != nil {
+		t.Fatalf("EmailSignIn() error = %v", err)
+	}
+	if result["mfaRequired"] != true || result["token"] != nil {
+		t.Fatalf("EmailSignIn() result = %v, want an mfa challenge", result)
+	}
+	mfaToken := result["mfaToken"].(string)
+
+	if status, _, err := m.VerifyMFA(ctx, "db", "project", mfaToken, codeAt(0)); err == nil || status != http.StatusUnauthorized {
+		t.Errorf("VerifyMFA() with reused code status = %v error = %v", status, err)
+	}
+	status, result, err = m.VerifyMFA(ctx, "db", "project", mfaToken, codeAt(1))
+	if err != nil {
+		t.Fatalf("VerifyMFA() status = %v error = %v", status, err)
+	}
+	if result["token"] == nil || result["refreshToken"] == nil {
+		t.Errorf("VerifyMFA() result = %v, want tokens", result)
+	}
+	if _, p := result["user"].(map[string]interface{})["mfa_secret"]; p {
+		t.Errorf("VerifyMFA() returned the totp secret")
+	}
+
+	delete(user, "mfa_last_step")
+	if status, _, err := m.VerifyMFA(ctx, "db", "project", mfaToken, codeAt(0)); err == nil || status != http.StatusUnauthorized {
+		t.Errorf("VerifyMFA() with used mfa token status = %v error = %v", status, err)
+	}
+
+	// The mfa token is invalidated after too many wrong codes
+	_, result, err = m.EmailSignIn(ctx, "db", "project", "user@example.com", "password")
+	if err != nil {
+		t.Fatalf("EmailSignIn() error = %v", err)
+	}
+	mfaToken = result["mfaToken"].(string)
+	for i := 0; i < maxMFAAttempts; i++ {
+		if status, _, err := m.VerifyMFA(ctx, "db", "project", mfaToken, codeAt(5)); err == nil || status != http.StatusUnauthorized {
+			t.Fatalf("VerifyMFA() with wrong code status = %v error = %v", status, err)
+		}
+	}
+	if status, _, err := m.VerifyMFA(ctx, "db", "project", mfaToken, codeAt(0)); err == nil || status != http.StatusUnauthorized {
+		t.Errorf("VerifyMFA() after too many wrong codes status = %v error = %v", status, err)
+	}
+
+	// Disabling mfa with a stolen access token can't brute force the code either. The attempts are shared with the
+	// sign in, hence signing in again gives a new set of attempts
+	if _, _, err := m.EmailSignIn(ctx, "db", "project", "user@example.com", "password"); err != nil {
+		t.Fatalf("EmailSignIn() error = %v", err)
+	}
+	for i := 0; i < maxMFAAttempts; i++ {
+		if status, err := m.DisableMFA(ctx, token, "db", "project", codeAt(5)); err == nil || status != http.StatusUnauthorized {
+			t.Fatalf("DisableMFA() with wrong code status = %v error = %v", status, err)
+		}
+	}
+	if status, err := m.DisableMFA(ctx, token, "db", "project", codeAt(1)); err == nil || status != http.StatusTooManyRequests {
+		t.Errorf("DisableMFA() after too many wrong codes status = %v error = %v", status, err)
+	}
+	if user["mfa_enabled"] != true {
+		t.Fatalf("DisableMFA() after too many wrong codes disabled mfa")
+	}
+
+	_, result, err = m.EmailSignIn(ctx, "db", "project", "user@example.com", "password")
+	if err != nil {
+		t.Fatalf("EmailSignIn() error = %v", err)
+	}
+	if status, _, err := m.VerifyMFA(ctx, "db", "project", result["mfaToken"].(string), codeAt(0)); err != nil {
+		t.Fatalf("VerifyMFA() with new mfa token status = %v error = %v", status, err)
+	}
+
+	delete(user, "mfa_last_step")
+	if status, err := m.DisableMFA(ctx, token, "db", "project", codeAt(1)); err != nil {
+		t.Fatalf("DisableMFA() status = %v error = %v", status, err)
+	}
+	if _, result, err := m.EmailSignIn(ctx, "db", "project", "user@example.com", "password"); err != nil || result["token"] == nil {
+		t.Errorf("EmailSignIn() after disabling mfa result = %v error = %v", result, err)
+	}
+}
@@ -0,0 +1,494 @@
+package userman
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// oauthStateExpiry is the time within which the user has to complete the sign in at the provider
+const oauthStateExpiry = 10 * time.Minute
+
+// OAuthBindingCookie is the cookie which binds the state of an oauth sign in to the browser it was started from
+const OAuthBindingCookie = "sc-oauth-binding"
+
+// wellKnownProviders holds the endpoints of the providers which don't need to be configured explicitly
+var wellKnownProviders = map[string]*config.AuthStub{
+	"google": {
+		DiscoveryURL: "https://accounts.google.com/.well-known/openid-configuration",
+		Scopes:       []string{"openid", "email", "profile"},
+	},
+	"github": {
+		AuthURL:     "https://github.com/login/oauth/authorize",
+		TokenURL:    "https://github.com/login/oauth/access_token",
+		UserInfoURL: "https://api.github.com/user",
+		Scopes:      []string{"read:user", "user:email"},
+	},
+}
+
+// oauthProvider holds the resolved endpoints of an OAuth2 / OIDC provider
+type oauthProvider struct {
+	stub        *config.AuthStub
+	issuer      string
+	authURL     string
+	tokenURL    string
+	userInfoURL string
+	scopes      []string
+}
+
+// oauthState is signed and sent to the provider to protect the callback against forgery. The nonce is sent to the
+// provider as well and has to be present in the id token it issues
+type oauthState struct {
+	Provider string `json:"p"`
+	DBAlias  string `json:"db"`
+	Nonce    string `json:"n"`
+	Binding  string `json:"b"` // Fingerprint of the value of the binding cookie
+	Expiry   int64  `json:"e"`
+}
+
+// oauthIdentity is the identity of the user as returned by the provider
+type oauthIdentity struct {
+	subject       string
+	email         string
+	name          string
+	emailVerified bool
+}
+
+// OAuthAuthorizeURL returns the url of the provider where the user needs to be redirected to sign in along with the
+// cookie which has to be set in the browser of the user. The callback is accepted only from the browser having the cookie
+func (m *Module) OAuthAuthorizeURL(ctx context.Context, dbAlias, providerID string) (int, string, *http.Cookie, error) {
+	provider, status, err := m.getOAuthProvider(ctx, providerID)
+	if err != nil {
+		return status, "", nil, err
+	}
+
+	binding, err := generateRandomString()
+	if err != nil {
+		return http.StatusInternalServerError, "", nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create state for oauth sign in", err, nil)
+	}
+	state, nonce, err := m.createOAuthState(providerID, dbAlias, binding)
+	if err != nil {
+		return http.StatusInternalServerError, "", nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create state for oauth sign in", err, nil)
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", provider.stub.ClientID)
+	params.Set("redirect_uri", provider.stub.RedirectURL)
+	params.Set("scope", strings.Join(provider.scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+
+	separator := "?"
+	if strings.Contains(provider.authURL, "?") {
+		separator = "&"
+	}
+	cookie := &http.Cookie{Name: OAuthBindingCookie, Value: binding, Path: "/", MaxAge: int(oauthStateExpiry.Seconds()), HttpOnly: true, SameSite: http.SameSiteLaxMode}
+	return http.StatusOK, provider.authURL + separator + params.Encode(), cookie, nil
+}
+
+// OAuthSignIn completes the authorization code flow. The user linked to the external identity is signed in, and a new
+// user gets created if no such user exists. The binding is the value of the cookie set when the sign in was started
+func (m *Module) OAuthSignIn(ctx context.Context, dbAlias, project, providerID, code, state, binding string) (int, map[string]interface{}, error) {
+	provider, status, err := m.getOAuthProvider(ctx, providerID)
+	if err != nil {
+		return status, nil, err
+	}
+
+	s, err := m.verifyOAuthState(state, providerID, dbAlias, binding)
+	if err != nil {
+		return http.StatusUnauthorized, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Invalid state provided for oauth sign in", err, nil)
+	}
+
+	identity, err := exchangeOAuthCode(ctx, provider, code, s.Nonce)
+	if err != nil {
+		return http.StatusUnauthorized, nil, err
+	}
+
+	status, user, err := m.getOrCreateOAuthUser(ctx, dbAlias, project, providerID, provider.stub.DefaultRole, identity)
+	if err != nil {
+		return status, nil, err
+	}
+
+	idField, err := m.getIDField(dbAlias)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	return m.completeSignIn(ctx, dbAlias, project, idField, user)
+}
+
+func (m *Module) getOAuthProvider(ctx context.Context, providerID string) (*oauthProvider, int, error) {
+	if providerID == "email" || !m.IsActive(providerID) {
+		return nil, http.StatusNotFound, fmt.Errorf("Sign in with (%s) is not enabled", providerID)
+	}
+
+	m.RLock()
+	stub := m.methods[providerID]
+	cached, p := m.oauthProviders[providerID]
+	m.RUnlock()
+	if p {
+		return cached, http.StatusOK, nil
+	}
+
+	provider := &oauthProvider{stub: stub, authURL: stub.AuthURL, tokenURL: stub.TokenURL, userInfoURL: stub.UserInfoURL, scopes: stub.Scopes}
+	discoveryURL := stub.DiscoveryURL
+	if wellKnown, p := wellKnownProviders[providerID]; p {
+		if provider.authURL == "" && provider.tokenURL == "" && discoveryURL == "" {
+			provider.authURL, provider.tokenURL, provider.userInfoURL = wellKnown.AuthURL, wellKnown.TokenURL, wellKnown.UserInfoURL
+			discoveryURL = wellKnown.DiscoveryURL
+		}
+		if len(provider.scopes) == 0 {
+			provider.scopes = wellKnown.Scopes
+		}
+	}
+
+	if discoveryURL != "" {
+		if err := discoverOAuthProvider(ctx, discoveryURL, provider); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+	if len(provider.scopes) == 0 {
+		provider.scopes = []string{"openid", "email", "profile"}
+	}
+
+	if stub.ClientID == "" || provider.authURL == "" || provider.tokenURL == "" {
+		return nil, http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Sign in with (%s) has not been configured properly. The client id, auth url and token url are required", providerID), nil, nil)
+	}
+
+	m.Lock()
+	m.oauthProviders[providerID] = provider
+	m.Unlock()
+	return provider, http.StatusOK, nil
+}
+
+// discoverOAuthProvider loads the endpoints which haven't been configured explicitly from the OIDC discovery document
+func discoverOAuthProvider(ctx context.Context, discoveryURL string, provider *oauthProvider) error {
+	doc := new(struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	})
+	if err := doOAuthRequest(ctx, http.MethodGet, discoveryURL, nil, "", doc); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to load the openid configuration from (%s)", discoveryURL), err, nil)
+	}
+
+	provider.issuer = doc.Issuer
+	if provider.authURL == "" {
+		provider.authURL = doc.AuthorizationEndpoint
+	}
+	if provider.tokenURL == "" {
+		provider.tokenURL = doc.TokenEndpoint
+	}
+	if provider.userInfoURL == "" {
+		provider.userInfoURL = doc.UserInfoEndpoint
+	}
+	return nil
+}
+
+// exchangeOAuthCode exchanges the authorization code for tokens and returns the identity of the user. The id token, if
+// the provider issues one, must carry the nonce sent along with the sign in
+func exchangeOAuthCode(ctx context.Context, provider *oauthProvider, code, nonce string) (*oauthIdentity, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", provider.stub.RedirectURL)
+	form.Set("client_id", provider.stub.ClientID)
+	form.Set("client_secret", provider.stub.Secret)
+
+	tokens := new(struct {
+		AccessToken string `json:"access_token"`
+		IDToken     string `json:"id_token"`
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+	})
+	if err := doOAuthRequest(ctx, http.MethodPost, provider.tokenURL, form, "", tokens); err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to exchange the authorization code", err, nil)
+	}
+	if tokens.Error != "" {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to exchange the authorization code - %s %s", tokens.Error, tokens.Description), nil, nil)
+	}
+
+	// The id token is received directly from the token endpoint, so validating its claims is enough
+	var idTokenClaims map[string]interface{}
+	if tokens.IDToken != "" {
+		var err error
+		idTokenClaims, err = parseIDTokenClaims(tokens.IDToken, provider, nonce)
+		if err != nil {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Invalid id token received from the provider", err, nil)
+		}
+	}
+
+	var claims map[string]interface{}
+	switch {
+	case provider.userInfoURL != "" && tokens.AccessToken != "":
+		if err := doOAuthRequest(ctx, http.MethodGet, provider.userInfoURL, nil, tokens.AccessToken, &claims); err != nil {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to fetch the user info from the provider", err, nil)
+		}
+		if idTokenClaims != nil && fmt.Sprintf("%v", claims["sub"]) != fmt.Sprintf("%v", idTokenClaims["sub"]) {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "User info returned by the provider belongs to another user than the id token", nil, nil)
+		}
+
+	case idTokenClaims != nil:
+		claims = idTokenClaims
+
+	default:
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Provider returned neither an id token nor a user info url has been configured", nil, nil)
+	}
+
+	identity := &oauthIdentity{}
+	for _, key := range []string{"sub", "id"} {
+		if v, p := claims[key]; p && v != nil {
+			identity.subject = fmt.Sprintf("%v", v)
+			break
+		}
+	}
+	if identity.subject == "" {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Provider did not return the id of the user", nil, nil)
+	}
+	identity.email, _ = claims["email"].(string)
+	for _, key := range []string{"name", "login"} {
+		if v, ok := claims[key].(string); ok && v != "" {
+			identity.name = v
+			break
+		}
+	}
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		identity.emailVerified = v
+	case string:
+		identity.emailVerified = v == "true"
+	}
+	return identity, nil
+}
+
+func parseIDTokenClaims(idToken string, provider *oauthProvider, nonce string) (map[string]interface{}, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("id token is not a jwt")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&claims); err != nil {
+		return nil, err
+	}
+
+	if provider.issuer != "" && claims["iss"] != provider.issuer {
+		return nil, fmt.Errorf("id token issued by (%v) instead of (%s)", claims["iss"], provider.issuer)
+	}
+	audienceMatched := false
+	switch aud := claims["aud"].(type) {
+	case string:
+		audienceMatched = aud == provider.stub.ClientID
+	case []interface{}:
+		for _, a := range aud {
+			if a == provider.stub.ClientID {
+				audienceMatched = true
+			}
+		}
+	}
+	if !audienceMatched {
+		return nil, errors.New("id token has not been issued for this client")
+	}
+	if exp, ok := claims["exp"].(json.Number); ok {
+		if expiry, err := exp.Int64(); err != nil || time.Now().Unix() > expiry {
+			return nil, errors.New("id token has expired")
+		}
+	}
+	if n, _ := claims["nonce"].(string); nonce == "" || subtle.ConstantTimeCompare([]byte(n), []byte(nonce)) != 1 {
+		return nil, errors.New("id token has been issued for another sign in")
+	}
+	return claims, nil
+}
+
+func doOAuthRequest(ctx context.Context, method, requestURL string, form url.Values, accessToken string, vPtr interface{}) error {
+	var body *strings.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	} else {
+		body = strings.NewReader("")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer utils.CloseTheCloser(res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("provider responded with status code (%d)", res.StatusCode)
+	}
+
+	// Ids can be large numbers, which shouldn't lose their precision
+	decoder := json.NewDecoder(res.Body)
+	decoder.UseNumber()
+	return decoder.Decode(vPtr)
+}
+
+// getOrCreateOAuthUser returns the user linked to the external identity. A user having the same verified email gets
+// linked to the identity, otherwise a new user is created
+func (m *Module) getOrCreateOAuthUser(ctx context.Context, dbAlias, project, providerID, role string, identity *oauthIdentity) (int, map[string]interface{}, error) {
+	idField, err := m.getIDField(dbAlias)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	attr := map[string]string{"project": project, "db": dbAlias, "col": "users"}
+	reqParams := model.RequestParams{Resource: "db-read", Op: "access", Attributes: attr}
+
+	// Check if the identity has already been linked to a user
+	user, err := m.findOAuthUser(ctx, dbAlias, map[string]interface{}{"provider": providerID, "provider_id": identity.subject}, reqParams)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	if user != nil {
+		return http.StatusOK, user, nil
+	}
+
+	link := map[string]interface{}{"provider": providerID, "provider_id": identity.subject}
+	if identity.email != "" {
+		userObj, err := m.findOAuthUser(ctx, dbAlias, map[string]interface{}{"email": identity.email}, reqParams)
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		if userObj != nil {
+			// Only a provider which has verified the email can take over an existing account
+			if !identity.emailVerified {
+				return http.StatusConflict, nil, errors.New("User with provided email already exists")
+			}
+
+			reqParams.Resource = "db-update"
+			updateReq := &model.UpdateRequest{Find: map[string]interface{}{idField: userObj[idField]}, Operation: utils.One, Update: map[string]interface{}{"$set": link}}
+			if err := m.crud.Update(ctx, dbAlias, "users", updateReq, reqParams); err != nil {
+				return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to link user to (%s) account", providerID), err, nil)
+			}
+
+			for k, v := range link {
+				userObj[k] = v
+			}
+			return http.StatusOK, userObj, nil
+		}
+	}
+
+	if role == "" {
+		role = "user"
+	}
+	doc := map[string]interface{}{idField: uuid.NewV1().String(), "email": identity.email, "name": identity.name, "role": role, "verified": identity.emailVerified}
+	for k, v := range link {
+		doc[k] = v
+	}
+
+	reqParams.Resource = "db-create"
+	createReq := &model.CreateRequest{Operation: utils.One, Document: doc}
+	if err := m.crud.Create(ctx, dbAlias, "users", createReq, reqParams); err != nil {
+		return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Failed to create user account", err, nil)
+	}
+	return http.StatusOK, doc, nil
+}
+
+// findOAuthUser returns the user matching the find clause. Unlike reading a single user, a missing user can be told apart
+// from a failed read, so that an unavailable database never results in a duplicate user getting created
+func (m *Module) findOAuthUser(ctx context.Context, dbAlias string, find map[string]interface{}, reqParams model.RequestParams) (map[string]interface{}, error) {
+	limit := int64(1)
+	readReq := &model.ReadRequest{Find: find, Operation: utils.All, Options: &model.ReadOptions{Limit: &limit}}
+	result, _, err := m.crud.Read(ctx, dbAlias, "users", readReq, reqParams)
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to read user for oauth sign in", err, nil)
+	}
+
+	users, _ := result.([]interface{})
+	if len(users) == 0 {
+		return nil, nil
+	}
+	user, ok := users[0].(map[string]interface{})
+	if !ok {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Invalid user returned by the database", nil, nil)
+	}
+	return user, nil
+}
+
+func (m *Module) getIDField(dbAlias string) (string, error) {
+	actualDbType, err := m.crud.GetDBType(dbAlias)
+	if err != nil {
+		return "", err
+	}
+	if actualDbType == string(model.Mongo) || actualDbType == string(model.EmbeddedDB) {
+		return "_id", nil
+	}
+	return "id", nil
+}
+
+func (m *Module) createOAuthState(providerID, dbAlias, binding string) (string, string, error) {
+	nonce, err := generateRandomString()
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := m.createSignedToken(oauthState{Provider: providerID, DBAlias: dbAlias, Nonce: nonce, Binding: fingerprint(binding), Expiry: time.Now().Add(oauthStateExpiry).Unix()})
+	if err != nil {
+		return "", "", err
+	}
+	return state, nonce, nil
+}
+
+func (m *Module) verifyOAuthState(state, providerID, dbAlias, binding string) (*oauthState, error) {
+	s := new(oauthState)
+	if err := m.parseSignedToken(state, s); err != nil {
+		return nil, err
+	}
+	if s.Provider != providerID || s.DBAlias != dbAlias {
+		return nil, errors.New("state has been issued for another provider")
+	}
+	if binding == "" || subtle.ConstantTimeCompare([]byte(s.Binding), []byte(fingerprint(binding))) != 1 {
+		return nil, errors.New("state has been issued to another browser")
+	}
+	if time.Now().Unix() > s.Expiry {
+		return nil, errors.New("state has expired")
+	}
+	return s, nil
+}
+
+func generateRandomString() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,390 @@
+package userman
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// mockOIDCProvider is a local openid connect provider which issues a fixed identity for every code. An id token is
+// issued as well once the nonce of the sign in is known
+type mockOIDCProvider struct {
+	server   *httptest.Server
+	identity map[string]interface{}
+	nonce    string
+	codes    []string
+}
+
+func newMockOIDCProvider(identity map[string]interface{}) *mockOIDCProvider {
+	p := &mockOIDCProvider{identity: identity}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"userinfo_endpoint":      p.server.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("client_id") != "client" || r.PostForm.Get("client_secret") != "secret" || r.PostForm.Get("code") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		p.codes = append(p.codes, r.PostForm.Get("code"))
+		tokens := map[string]string{"access_token": "access-" + r.PostForm.Get("code"), "token_type": "Bearer"}
+		if p.nonce != "" {
+			claims, _ := json.Marshal(map[string]interface{}{"iss": p.server.URL, "aud": "client", "sub": p.identity["sub"], "nonce": p.nonce, "exp": time.Now().Add(time.Minute).Unix()})
+			tokens["id_token"] = "e30." + base64.RawURLEncoding.EncodeToString(claims) + ".signature"
+		}
+		_ = json.NewEncoder(w).Encode(tokens)
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer access-") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(p.identity)
+	})
+	p.server = httptest.NewServer(mux)
+	return p
+}
+
+// mockUserCrud stores the tables in memory
+type mockUserCrud struct {
+	tables  map[string][]map[string]interface{}
+	readErr error
+
+	// beforeUpdate is invoked before every update to simulate concurrent writes
+	beforeUpdate func(col string)
+}
+
+func newMockUserCrud(users []map[string]interface{}) *mockUserCrud {
+	return &mockUserCrud{tables: map[string][]map[string]interface{}{"users": users}}
+}
+
+func (c *mockUserCrud) GetDBType(dbAlias string) (string, error) {
+	return string(model.Postgres), nil
+}
+
+func (c *mockUserCrud) findAll(col string, find map[string]interface{}) []map[string]interface{} {
+	var rows []map[string]interface{}
+	for _, row := range c.tables[col] {
+		matched := true
+		for k, v := range find {
+			if row[k] != v {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func (c *mockUserCrud) find(col string, find map[string]interface{}) map[string]interface{} {
+	rows := c.findAll(col, find)
+	if len(rows) == 0 {
+		return nil
+	}
+	return rows[0]
+}
+
+func copyRow(row map[string]interface{}) map[string]interface{} {
+	copied := map[string]interface{}{}
+	for k, v := range row {
+		copied[k] = v
+	}
+	return copied
+}
+
+func (c *mockUserCrud) Read(ctx context.Context, dbAlias, col string, req *model.ReadRequest, params model.RequestParams) (interface{}, *model.SQLMetaData, error) {
+	if c.readErr != nil {
+		return nil, nil, c.readErr
+	}
+	if req.Operation == utils.All {
+		result := []interface{}{}
+		for _, row := range c.findAll(col, req.Find) {
+			result = append(result, copyRow(row))
+		}
+		return result, nil, nil
+	}
+
+	row := c.find(col, req.Find)
+	if row == nil {
+		return nil, nil, errors.New("not found")
+	}
+	return copyRow(row), nil, nil
+}
+
+func (c *mockUserCrud) Create(ctx context.Context, dbAlias, col string, req *model.CreateRequest, params model.RequestParams) error {
+	c.tables[col] = append(c.tables[col], copyRow(req.Document.(map[string]interface{})))
+	return nil
+}
+
+func (c *mockUserCrud) Update(ctx context.Context, dbAlias, col string, req *model.UpdateRequest, params model.RequestParams) error {
+	if c.beforeUpdate != nil {
+		c.beforeUpdate(col)
+	}
+	rows := c.findAll(col, req.Find)
+	if req.Operation != utils.All {
+		if len(rows) == 0 {
+			return errors.New("not found")
+		}
+		rows = rows[:1]
+	}
+	for _, row := range rows {
+		set, _ := req.Update["$set"].(map[string]interface{})
+		for k, v := range set {
+			row[k] = v
+		}
+		inc, _ := req.Update["$inc"].(map[string]interface{})
+		for k, v := range inc {
+			row[k] = toUnixTime(row[k]) + int64(v.(int))
+		}
+	}
+	return nil
+}
+
+// mockUserAuth issues opaque tokens whose claims are remembered in memory
+type mockUserAuth struct {
+	claims  model.TokenClaims
+	tokens  map[string]model.TokenClaims
+	revoked []string
+}
+
+func (a *mockUserAuth) IsReadOpAuthorised(ctx context.Context, project, dbType, col, token string, req *model.ReadRequest, stub model.ReturnWhereStub) (*model.PostProcess, model.RequestParams, error) {
+	return nil, model.RequestParams{}, nil
+}
+
+func (a *mockUserAuth) CreateToken(ctx context.Context, tokenClaims model.TokenClaims) (string, error) {
+	if a.tokens == nil {
+		a.tokens = map[string]model.TokenClaims{}
+	}
+	a.claims = tokenClaims
+	token := fmt.Sprintf("token-%d", len(a.tokens))
+	a.tokens[token] = tokenClaims
+	return token, nil
+}
+
+func (a *mockUserAuth) IsUpdateOpAuthorised(ctx context.Context, project, dbType, col, token string, req *model.UpdateRequest) (model.RequestParams, error) {
+	return model.RequestParams{}, nil
+}
+
+func (a *mockUserAuth) ParseToken(ctx context.Context, token string) (map[string]interface{}, error) {
+	claims, p := a.tokens[token]
+	if !p {
+		return nil, errors.New("invalid token")
+	}
+	for _, id := range a.revoked {
+		if claims[model.SessionClaim] == id {
+			return nil, errors.New("session revoked")
+		}
+	}
+	return claims, nil
+}
+
+func (a *mockUserAuth) GetInternalAccessToken(ctx context.Context) (string, error) {
+	return "internal-token", nil
+}
+
+func (a *mockUserAuth) RevokeSessions(ctx context.Context, sessions []string) error {
+	a.revoked = append(a.revoked, sessions...)
+	return nil
+}
+
+func TestModule_OAuthSignIn(t *testing.T) {
+	type testCase struct {
+		name         string
+		enabled      bool
+		identity     map[string]interface{}
+		users        []map[string]interface{}
+		readErr      error
+		tamperState  bool
+		otherBrowser bool
+		otherNonce   bool
+		wantStatus   int
+		wantUserID   string
+		wantUsers    int
+		wantProvider string
+	}
+
+	testCases := []testCase{
+		{
+			name:         "new user is created for an unknown identity",
+			enabled:      true,
+			identity:     map[string]interface{}{"sub": "123", "email": "new@example.com", "email_verified": true, "name": "New"},
+			wantStatus:   http.StatusOK,
+			wantUsers:    1,
+			wantProvider: "acme",
+		},
+		{
+			name:         "existing identity signs in the linked user",
+			enabled:      true,
+			identity:     map[string]interface{}{"sub": "123", "email": "changed@example.com"},
+			users:        []map[string]interface{}{{"id": "1", "email": "old@example.com", "role": "admin", "provider": "acme", "provider_id": "123"}},
+			wantStatus:   http.StatusOK,
+			wantUserID:   "1",
+			wantUsers:    1,
+			wantProvider: "acme",
+		},
+		{
+			name:         "verified email gets linked to the existing user",
+			enabled:      true,
+			identity:     map[string]interface{}{"sub": "123", "email": "user@example.com", "email_verified": "true"},
+			users:        []map[string]interface{}{{"id": "1", "email": "user@example.com", "role": "user", "pass": "hash"}},
+			wantStatus:   http.StatusOK,
+			wantUserID:   "1",
+			wantUsers:    1,
+			wantProvider: "acme",
+		},
+		{
+			name:       "unverified email of an existing user is rejected",
+			enabled:    true,
+			identity:   map[string]interface{}{"sub": "123", "email": "user@example.com"},
+			users:      []map[string]interface{}{{"id": "1", "email": "user@example.com", "role": "user", "pass": "hash"}},
+			wantStatus: http.StatusConflict,
+			wantUsers:  1,
+		},
+		{
+			name:        "tampered state is rejected",
+			enabled:     true,
+			identity:    map[string]interface{}{"sub": "123"},
+			tamperState: true,
+			wantStatus:  http.StatusUnauthorized,
+		},
+		{
+			name:         "state used from another browser is rejected",
+			enabled:      true,
+			identity:     map[string]interface{}{"sub": "123"},
+			otherBrowser: true,
+			wantStatus:   http.StatusUnauthorized,
+		},
+		{
+			name:       "id token issued for another sign in is rejected",
+			enabled:    true,
+			identity:   map[string]interface{}{"sub": "123"},
+			otherNonce: true,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "no user is created if the users can't be read",
+			enabled:    true,
+			identity:   map[string]interface{}{"sub": "123", "email": "user@example.com", "email_verified": true},
+			users:      []map[string]interface{}{{"id": "1", "email": "user@example.com", "role": "user", "provider": "acme", "provider_id": "123"}},
+			readErr:    errors.New("database is unavailable"),
+			wantStatus: http.StatusInternalServerError,
+			wantUsers:  1,
+		},
+		{
+			name:       "disabled provider is rejected",
+			enabled:    false,
+			identity:   map[string]interface{}{"sub": "123"},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newMockOIDCProvider(tt.identity)
+			defer provider.server.Close()
+
+			crud := newMockUserCrud(tt.users)
+			crud.readErr = tt.readErr
+			auth := &mockUserAuth{}
+			m := Init(crud, auth)
+			m.SetConfig(config.Auths{"acme": &config.AuthStub{
+				ID:           "acme",
+				Enabled:      tt.enabled,
+				ClientID:     "client",
+				Secret:       "secret",
+				DiscoveryURL: provider.server.URL + "/.well-known/openid-configuration",
+				RedirectURL:  "http://localhost:4122/v1/api/project/auth/db/oauth/acme/callback",
+			}})
+			if err := m.SetProjectAESKey(base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))); err != nil {
+				t.Fatalf("SetProjectAESKey() error = %v", err)
+			}
+
+			state, binding := "invalid.state", "binding"
+			if tt.enabled {
+				status, authURL, cookie, err := m.OAuthAuthorizeURL(context.Background(), "db", "acme")
+				if err != nil {
+					t.Fatalf("OAuthAuthorizeURL() status = %v error = %v", status, err)
+				}
+				if cookie == nil || cookie.Name != OAuthBindingCookie || cookie.Value == "" || !cookie.HttpOnly {
+					t.Fatalf("OAuthAuthorizeURL() cookie = %v, want an http only binding cookie", cookie)
+				}
+				binding = cookie.Value
+				if tt.otherBrowser {
+					binding = "another-browser"
+				}
+				u, err := url.Parse(authURL)
+				if err != nil {
+					t.Fatalf("OAuthAuthorizeURL() returned invalid url %s", authURL)
+				}
+				if got := u.Scheme + "://" + u.Host + u.Path; got != provider.server.URL+"/authorize" {
+					t.Errorf("OAuthAuthorizeURL() got = %v, want endpoint %v", got, provider.server.URL+"/authorize")
+				}
+				if u.Query().Get("client_id") != "client" || u.Query().Get("response_type") != "code" {
+					t.Errorf("OAuthAuthorizeURL() has invalid query %v", u.Query())
+				}
+				state = u.Query().Get("state")
+				if tt.tamperState {
+					state = strings.Replace(state, ".", "x.", 1)
+				}
+				provider.nonce = u.Query().Get("nonce")
+				if provider.nonce == "" {
+					t.Errorf("OAuthAuthorizeURL() did not send a nonce")
+				}
+				if tt.otherNonce {
+					provider.nonce = "another-nonce"
+				}
+			}
+
+			status, result, err := m.OAuthSignIn(context.Background(), "db", "project", "acme", "code", state, binding)
+			if status != tt.wantStatus {
+				t.Fatalf("OAuthSignIn() status = %v, want %v (error = %v)", status, tt.wantStatus, err)
+			}
+			if len(crud.tables["users"]) != tt.wantUsers {
+				t.Errorf("OAuthSignIn() users = %v, want %v", len(crud.tables["users"]), tt.wantUsers)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if err == nil {
+					t.Errorf("OAuthSignIn() expected an error")
+				}
+				return
+			}
+
+			user := result["user"].(map[string]interface{})
+			if _, p := user["pass"]; p {
+				t.Errorf("OAuthSignIn() returned the password of the user")
+			}
+			if tt.wantUserID != "" && user["id"] != tt.wantUserID {
+				t.Errorf("OAuthSignIn() user id = %v, want %v", user["id"], tt.wantUserID)
+			}
+			if auth.claims["id"] != user["id"] {
+				t.Errorf("OAuthSignIn() token id = %v, want %v", auth.claims["id"], user["id"])
+			}
+			stored := crud.find("users", map[string]interface{}{"id": user["id"]})
+			if stored["provider"] != tt.wantProvider || stored["provider_id"] != "123" {
+				t.Errorf("OAuthSignIn() stored identity = %v, want provider %v", stored, tt.wantProvider)
+			}
+		})
+	}
+}
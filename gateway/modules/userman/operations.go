@@ -11,6 +11,7 @@ import (
 
 	uuid "github.com/satori/go.uuid"
 
+	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	authHelpers "github.com/spaceuptech/space-cloud/gateway/modules/auth/helpers"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
@@ -52,8 +53,8 @@ func (m *Module) Profile(ctx context.Context, token, dbAlias, project, id string
 
 	_ = authHelpers.PostProcessMethod(ctx, m.aesKey, actions, res)
 
-	// Delete password from user object
-	delete(res.(map[string]interface{}), "pass")
+	// Delete credentials from user object
+	sanitizeUser(res.(map[string]interface{}))
 
 	return http.StatusOK, res.(map[string]interface{}), nil
 }
@@ -82,11 +83,10 @@ func (m *Module) Profiles(ctx context.Context, token, dbAlias, project string) (
 
 	_ = authHelpers.PostProcessMethod(ctx, m.aesKey, actions, res)
 
-	// Delete password from user object
+	// Delete credentials from user object
 	if usersArray, ok := res.([]interface{}); ok {
 		for _, user := range usersArray {
-			userObj := user.(map[string]interface{})
-			delete(userObj, "pass")
+			sanitizeUser(user.(map[string]interface{}))
 		}
 	}
 
@@ -112,34 +112,32 @@ func (m *Module) EmailSignIn(ctx context.Context, dbAlias, project, email, passw
 
 	userObj := user.(map[string]interface{})
 
+	// Users signed up via an oauth provider don't have a password
+	hash, ok := userObj["pass"].(string)
+	if !ok {
+		return http.StatusUnauthorized, nil, errors.New("Given credentials are not correct")
+	}
+
 	// Compares if the given password is correct
-	err = bcrypt.CompareHashAndPassword([]byte(userObj["pass"].(string)), []byte(password))
+	err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	if err != nil {
 		return http.StatusUnauthorized, nil, errors.New("Given credentials are not correct")
 	}
 
-	// Delete password from user
-	delete(userObj, "pass")
+	m.RLock()
+	requireVerification := m.methods["email"].RequireVerification
+	m.RUnlock()
+	if requireVerification && !isTruthy(userObj["verified"]) {
+		return http.StatusForbidden, nil, errors.New("Email of the user has not been verified")
+	}
 
-	req := map[string]interface{}{}
-	req["email"] = email
-	actualDbType, err := m.crud.GetDBType(dbAlias)
+	idField, err := m.getIDField(dbAlias)
 	if err != nil {
 		return 0, nil, err
 	}
-	// Create a token
-	if actualDbType == string(model.Mongo) || actualDbType == string(model.EmbeddedDB) {
-		req["id"] = userObj["_id"]
-	} else {
-		req["id"] = userObj["id"]
-	}
-	req["role"] = userObj["role"]
 
-	token, err := m.auth.CreateToken(ctx, req)
-	if err != nil {
-		return http.StatusInternalServerError, nil, errors.New("Failed to create a JWT token")
-	}
-	return http.StatusOK, map[string]interface{}{"user": user, "token": token}, nil
+	// Create a session along with its tokens
+	return m.completeSignIn(ctx, dbAlias, project, idField, userObj)
 }
 
 // EmailSignUp signs up a user and return a JWT token
@@ -171,6 +169,9 @@ func (m *Module) EmailSignUp(ctx context.Context, dbAlias, project, email, name,
 	req["pass"] = password
 	req["name"] = name
 	req["role"] = role
+	if m.isVerificationConfigured() {
+		req["verified"] = false
+	}
 	actualDbType, err := m.crud.GetDBType(dbAlias)
 	if err != nil {
 		return http.StatusInternalServerError, nil, err
@@ -193,17 +194,34 @@ func (m *Module) EmailSignUp(ctx context.Context, dbAlias, project, email, name,
 
 	delete(req, "pass")
 
-	// Create a new token Object
-	tokenObj := map[string]interface{}{
-		"email": email,
-		"role":  role,
-		"id":    id.String()}
+	idField := "id"
+	if actualDbType == string(model.Mongo) || actualDbType == string(model.EmbeddedDB) {
+		idField = "_id"
+	}
+
+	// Send the verification mail if the delivery of mails has been configured
+	m.RLock()
+	delivery := m.methods["email"].Delivery
+	requireVerification := m.methods["email"].RequireVerification
+	m.RUnlock()
+	if delivery != nil {
+		if err := m.sendVerificationEmail(ctx, dbAlias, project, idField, req); err != nil {
+			helpers.Logger.LogWarn(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to send verification mail - %v", err), nil)
+		}
+	}
 
-	token, err := m.auth.CreateToken(ctx, tokenObj)
+	// Users who need to verify their email can't sign in till they have done so
+	if requireVerification {
+		return http.StatusOK, map[string]interface{}{"user": req}, nil
+	}
+
+	// Create a session along with its tokens
+	status, tokens, err := m.issueTokens(ctx, dbAlias, project, idField, req)
 	if err != nil {
-		return http.StatusInternalServerError, nil, errors.New("Failed to create a JWT token")
+		return status, nil, err
 	}
-	return http.StatusOK, map[string]interface{}{"user": req, "token": token}, nil
+	tokens["user"] = req
+	return http.StatusOK, tokens, nil
 }
 
 // EmailEditProfile allows the user to edit a profile
@@ -230,8 +248,21 @@ func (m *Module) EmailEditProfile(ctx context.Context, token, dbAlias, project,
 
 	update := map[string]interface{}{}
 	set := map[string]interface{}{}
+
+	// The new email needs to be verified again
+	emailChanged := false
 	if email != "" {
 		set["email"] = email
+		current, _, err := m.readUser(ctx, dbAlias, project, map[string]interface{}{idString: id})
+		if err != nil {
+			return http.StatusNotFound, nil, errors.New("User not found")
+		}
+		if current["email"] != email {
+			if m.isVerificationConfigured() {
+				set["verified"] = false
+			}
+			emailChanged = true
+		}
 	}
 	if name != "" {
 		set["name"] = name
@@ -268,8 +299,21 @@ func (m *Module) EmailEditProfile(ctx context.Context, token, dbAlias, project,
 
 	userObj := user.(map[string]interface{})
 
-	// Delete password from user
-	delete(userObj, "pass")
+	// Send the verification mail for the new email if the delivery of mails has been configured
+	var delivery *config.AuthDelivery
+	m.RLock()
+	if stub, ok := m.methods["email"]; ok {
+		delivery = stub.Delivery
+	}
+	m.RUnlock()
+	if emailChanged && delivery != nil {
+		if err := m.sendVerificationEmail(ctx, dbAlias, project, idString, userObj); err != nil {
+			helpers.Logger.LogWarn(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to send verification mail - %v", err), nil)
+		}
+	}
+
+	// Delete credentials from user
+	sanitizeUser(userObj)
 
 	req1 := map[string]interface{}{}
 	req1["email"] = userObj["email"]
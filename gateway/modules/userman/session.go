@@ -0,0 +1,329 @@
+package userman
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// sessionsCol is the table in which the sessions of the users are stored
+const sessionsCol = model.SessionsCol
+
+// refreshTokenExpiry is the duration for which an unused refresh token stays valid
+const refreshTokenExpiry = 30 * 24 * time.Hour
+
+// issueTokens creates a new session for the user and returns an access token along with a refresh token for it
+func (m *Module) issueTokens(ctx context.Context, dbAlias, project, idField string, user map[string]interface{}) (int, map[string]interface{}, error) {
+	sessionID := uuid.NewV1().String()
+	secret, err := generateRefreshSecret()
+	if err != nil {
+		return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to generate refresh token", err, nil)
+	}
+
+	now := time.Now()
+	session := map[string]interface{}{
+		idField:                  sessionID,
+		"user_id":                user[idField],
+		"refresh_token":          hashRefreshSecret(secret),
+		"previous_refresh_token": "", // The hash of the refresh token which has been rotated last
+		"created_at":             now.Unix(),
+		"last_used_at":           now.Unix(),
+		"expires_at":             now.Add(refreshTokenExpiry).Unix(),
+		"revoked":                false,
+	}
+
+	reqParams := sessionRequestParams(project, dbAlias, "db-create")
+	createReq := &model.CreateRequest{Operation: utils.One, Document: session}
+	if err := m.crud.Create(ctx, dbAlias, sessionsCol, createReq, reqParams); err != nil {
+		return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create session for user", err, nil)
+	}
+
+	token, err := m.createSessionToken(ctx, dbAlias, idField, sessionID, user)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	return http.StatusOK, map[string]interface{}{"token": token, "refreshToken": sessionID + "." + secret}, nil
+}
+
+// Refresh issues a new access token for the session of the refresh token. The refresh token is rotated on every use,
+// and using the refresh token which has been rotated last revokes the entire session. Any other secret is rejected
+// without revoking the session, since the id of the session isn't a secret
+func (m *Module) Refresh(ctx context.Context, dbAlias, project, refreshToken string) (int, map[string]interface{}, error) {
+	if !m.IsEnabled() {
+		return http.StatusNotFound, nil, errors.New("This feature isn't enabled")
+	}
+
+	arr := strings.Split(refreshToken, ".")
+	if len(arr) != 2 {
+		return http.StatusUnauthorized, nil, errors.New("Invalid refresh token provided")
+	}
+	sessionID, secret := arr[0], arr[1]
+
+	idField, err := m.getIDField(dbAlias)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	reqParams := sessionRequestParams(project, dbAlias, "db-read")
+	readReq := &model.ReadRequest{Find: map[string]interface{}{idField: sessionID}, Operation: utils.One}
+	result, _, err := m.crud.Read(ctx, dbAlias, sessionsCol, readReq, reqParams)
+	if err != nil {
+		return http.StatusUnauthorized, nil, errors.New("Invalid refresh token provided")
+	}
+	session := result.(map[string]interface{})
+
+	if isTruthy(session["revoked"]) || time.Now().Unix() > toUnixTime(session["expires_at"]) {
+		return http.StatusUnauthorized, nil, errors.New("Session has expired or has been revoked")
+	}
+
+	storedHash, _ := session["refresh_token"].(string)
+	if hash := hashRefreshSecret(secret); subtle.ConstantTimeCompare([]byte(storedHash), []byte(hash)) != 1 {
+		// A rotated refresh token is being reused, which means it has been leaked
+		previousHash, _ := session["previous_refresh_token"].(string)
+		if previousHash != "" && subtle.ConstantTimeCompare([]byte(previousHash), []byte(hash)) == 1 {
+			return m.revokeReusedSession(ctx, dbAlias, project, idField, sessionID)
+		}
+		return http.StatusUnauthorized, nil, errors.New("Invalid refresh token provided")
+	}
+
+	readReq = &model.ReadRequest{Find: map[string]interface{}{idField: session["user_id"]}, Operation: utils.One}
+	user, _, err := m.crud.Read(ctx, dbAlias, "users", readReq, sessionRequestParams(project, dbAlias, "db-read"))
+	if err != nil {
+		return http.StatusUnauthorized, nil, errors.New("User not found")
+	}
+
+	newSecret, err := generateRefreshSecret()
+	if err != nil {
+		return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to generate refresh token", err, nil)
+	}
+
+	// The refresh token is rotated only if it hasn't been rotated by a concurrent request in the meantime
+	now := time.Now()
+	newHash := hashRefreshSecret(newSecret)
+	updateReq := &model.UpdateRequest{
+		Find:      map[string]interface{}{idField: sessionID, "refresh_token": storedHash, "revoked": false},
+		Operation: utils.All,
+		Update: map[string]interface{}{"$set": map[string]interface{}{
+			"refresh_token":          newHash,
+			"previous_refresh_token": storedHash,
+			"last_used_at":           now.Unix(),
+			"expires_at":             now.Add(refreshTokenExpiry).Unix(),
+		}},
+	}
+	if err := m.crud.Update(ctx, dbAlias, sessionsCol, updateReq, sessionRequestParams(project, dbAlias, "db-update")); err != nil {
+		return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to rotate refresh token", err, nil)
+	}
+
+	// The update doesn't report the rows it matched, so read the session back to find out if the rotation went through.
+	// A session which wasn't rotated to our hash means the same refresh token was used twice
+	result, _, err = m.crud.Read(ctx, dbAlias, sessionsCol, &model.ReadRequest{Find: map[string]interface{}{idField: sessionID}, Operation: utils.One}, reqParams)
+	if err != nil {
+		return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to read session after rotating refresh token", err, nil)
+	}
+	rotatedHash, _ := result.(map[string]interface{})["refresh_token"].(string)
+	if subtle.ConstantTimeCompare([]byte(rotatedHash), []byte(newHash)) != 1 {
+		return m.revokeReusedSession(ctx, dbAlias, project, idField, sessionID)
+	}
+
+	token, err := m.createSessionToken(ctx, dbAlias, idField, sessionID, user.(map[string]interface{}))
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	return http.StatusOK, map[string]interface{}{"token": token, "refreshToken": sessionID + "." + newSecret}, nil
+}
+
+// Sessions lists the active sessions of the user the token belongs to
+func (m *Module) Sessions(ctx context.Context, token, dbAlias, project string) (int, map[string]interface{}, error) {
+	if !m.IsEnabled() {
+		return http.StatusNotFound, nil, errors.New("This feature isn't enabled")
+	}
+
+	claims, err := m.auth.ParseToken(ctx, token)
+	if err != nil {
+		return http.StatusUnauthorized, nil, err
+	}
+
+	idField, err := m.getIDField(dbAlias)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	sessions, err := m.getActiveSessions(ctx, dbAlias, project, claims["id"])
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	current, _ := claims[model.SessionClaim].(string)
+	result := make([]interface{}, 0, len(sessions))
+	for _, session := range sessions {
+		delete(session, "refresh_token")
+		session["current"] = session[idField] == current
+		result = append(result, session)
+	}
+	return http.StatusOK, map[string]interface{}{"sessions": result}, nil
+}
+
+// Logout revokes the session the token belongs to. All the sessions of the user are revoked if all is set
+func (m *Module) Logout(ctx context.Context, token, dbAlias, project string, all bool) (int, error) {
+	if !m.IsEnabled() {
+		return http.StatusNotFound, errors.New("This feature isn't enabled")
+	}
+
+	claims, err := m.auth.ParseToken(ctx, token)
+	if err != nil {
+		return http.StatusUnauthorized, err
+	}
+
+	idField, err := m.getIDField(dbAlias)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	var ids []string
+	if all {
+		sessions, err := m.getActiveSessions(ctx, dbAlias, project, claims["id"])
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		for _, session := range sessions {
+			if id, ok := session[idField].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+	} else {
+		sessionID, ok := claims[model.SessionClaim].(string)
+		if !ok {
+			return http.StatusBadRequest, errors.New("Token has not been issued for a session")
+		}
+		ids = []string{sessionID}
+	}
+
+	if err := m.revokeSessions(ctx, dbAlias, project, idField, ids); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+// revokeReusedSession revokes a session whose refresh token has been used more than once
+func (m *Module) revokeReusedSession(ctx context.Context, dbAlias, project, idField, sessionID string) (int, map[string]interface{}, error) {
+	if err := m.revokeSessions(ctx, dbAlias, project, idField, []string{sessionID}); err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	return http.StatusUnauthorized, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Refresh token has already been used, the session has been revoked", nil, map[string]interface{}{"session": sessionID})
+}
+
+func (m *Module) getActiveSessions(ctx context.Context, dbAlias, project string, userID interface{}) ([]map[string]interface{}, error) {
+	readReq := &model.ReadRequest{Find: map[string]interface{}{"user_id": userID}, Operation: utils.All}
+	result, _, err := m.crud.Read(ctx, dbAlias, sessionsCol, readReq, sessionRequestParams(project, dbAlias, "db-read"))
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to read the sessions of user", err, nil)
+	}
+
+	arr, _ := result.([]interface{})
+	now := time.Now().Unix()
+	sessions := make([]map[string]interface{}, 0, len(arr))
+	for _, item := range arr {
+		session, ok := item.(map[string]interface{})
+		if !ok || isTruthy(session["revoked"]) || now > toUnixTime(session["expires_at"]) {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func (m *Module) revokeSessions(ctx context.Context, dbAlias, project, idField string, ids []string) error {
+	for _, id := range ids {
+		updateReq := &model.UpdateRequest{
+			Find:      map[string]interface{}{idField: id},
+			Operation: utils.All,
+			Update:    map[string]interface{}{"$set": map[string]interface{}{"revoked": true}},
+		}
+		if err := m.crud.Update(ctx, dbAlias, sessionsCol, updateReq, sessionRequestParams(project, dbAlias, "db-update")); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to revoke session", err, map[string]interface{}{"session": id})
+		}
+	}
+
+	// Reject the access tokens which have already been issued for these sessions
+	return m.auth.RevokeSessions(ctx, ids)
+}
+
+func (m *Module) createSessionToken(ctx context.Context, dbAlias, idField, sessionID string, user map[string]interface{}) (string, error) {
+	token, err := m.auth.CreateToken(ctx, map[string]interface{}{
+		"id":                 user[idField],
+		"email":              user["email"],
+		"role":               user["role"],
+		model.SessionClaim:   sessionID,
+		model.SessionDBClaim: dbAlias,
+	})
+	if err != nil {
+		return "", errors.New("Failed to create a JWT token")
+	}
+	return token, nil
+}
+
+func sessionRequestParams(project, dbAlias, resource string) model.RequestParams {
+	attr := map[string]string{"project": project, "db": dbAlias, "col": sessionsCol}
+	return model.RequestParams{Resource: resource, Op: "access", Attributes: attr}
+}
+
+func generateRefreshSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// hashRefreshSecret hashes the refresh token before it gets stored so that a leaked sessions table can't be used to
+// refresh tokens
+func hashRefreshSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// toUnixTime converts a timestamp stored in seconds to an int64 irrespective of the type returned by the database
+func toUnixTime(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case int32:
+		return int64(v)
+	case int:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case json.Number:
+		i, _ := v.Int64()
+		return i
+	}
+	return 0
+}
+
+func isTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case int64:
+		return v != 0
+	case int:
+		return v != 0
+	case float64:
+		return v != 0
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package userman
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+func newSessionTestModule(t *testing.T) (*Module, *mockUserCrud, *mockUserAuth, map[string]interface{}) {
+	crud := newMockUserCrud(nil)
+	auth := &mockUserAuth{}
+	m := Init(crud, auth)
+	m.SetConfig(config.Auths{"email": &config.AuthStub{ID: "email", Enabled: true}})
+
+	status, result, err := m.EmailSignUp(context.Background(), "db", "project", "user@example.com", "User", "password", "user")
+	if err != nil {
+		t.Fatalf("EmailSignUp() status = %v error = %v", status, err)
+	}
+	return m, crud, auth, result
+}
+
+func TestModule_Refresh(t *testing.T) {
+	m, crud, auth, result := newSessionTestModule(t)
+	refreshToken := result["refreshToken"].(string)
+	sessionID := auth.tokens[result["token"].(string)][model.SessionClaim]
+	if sessionID == nil {
+		t.Fatalf("EmailSignUp() token has not been issued for a session")
+	}
+
+	// Refreshing rotates the refresh token while keeping the session
+	status, refreshed, err := m.Refresh(context.Background(), "db", "project", refreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() status = %v error = %v", status, err)
+	}
+	if refreshed["refreshToken"] == refreshToken {
+		t.Errorf("Refresh() did not rotate the refresh token")
+	}
+	if got := auth.tokens[refreshed["token"].(string)][model.SessionClaim]; got != sessionID {
+		t.Errorf("Refresh() session = %v, want %v", got, sessionID)
+	}
+	if got := auth.tokens[refreshed["token"].(string)]["email"]; got != "user@example.com" {
+		t.Errorf("Refresh() email = %v, want user@example.com", got)
+	}
+
+	// A secret which has never been issued for the session is rejected without revoking the session
+	if status, _, err := m.Refresh(context.Background(), "db", "project", sessionID.(string)+".guessed"); err == nil || status != http.StatusUnauthorized {
+		t.Fatalf("Refresh() with wrong secret status = %v error = %v", status, err)
+	}
+	if session := crud.find(sessionsCol, map[string]interface{}{"id": sessionID}); session["revoked"] != false || len(auth.revoked) != 0 {
+		t.Errorf("Refresh() with wrong secret revoked the session")
+	}
+
+	// Reusing the rotated refresh token revokes the session
+	if status, _, err := m.Refresh(context.Background(), "db", "project", refreshToken); err == nil || status != http.StatusUnauthorized {
+		t.Fatalf("Refresh() with reused token status = %v error = %v", status, err)
+	}
+	if session := crud.find(sessionsCol, map[string]interface{}{"id": sessionID}); session["revoked"] != true {
+		t.Errorf("Refresh() with reused token did not revoke the session")
+	}
+	if len(auth.revoked) != 1 || auth.revoked[0] != sessionID {
+		t.Errorf("Refresh() with reused token revoked sessions = %v, want %v", auth.revoked, sessionID)
+	}
+
+	// The latest refresh token can't be used either once the session is revoked
+	if status, _, err := m.Refresh(context.Background(), "db", "project", refreshed["refreshToken"].(string)); err == nil || status != http.StatusUnauthorized {
+		t.Errorf("Refresh() of revoked session status = %v error = %v", status, err)
+	}
+
+	if status, _, err := m.Refresh(context.Background(), "db", "project", "malformed"); err == nil || status != http.StatusUnauthorized {
+		t.Errorf("Refresh() of malformed token status = %v error = %v", status, err)
+	}
+}
+
+func TestModule_Refresh_concurrentReuse(t *testing.T) {
+	m, crud, auth, result := newSessionTestModule(t)
+	refreshToken := result["refreshToken"].(string)
+	sessionID := auth.tokens[result["token"].(string)][model.SessionClaim]
+
+	// Another request using the same refresh token rotates it after it has been checked but before it gets rotated
+	crud.beforeUpdate = func(col string) {
+		if col != sessionsCol {
+			return
+		}
+		crud.beforeUpdate = nil
+		crud.find(sessionsCol, map[string]interface{}{"id": sessionID})["refresh_token"] = hashRefreshSecret("rotated-concurrently")
+	}
+
+	if status, _, err := m.Refresh(context.Background(), "db", "project", refreshToken); err == nil || status != http.StatusUnauthorized {
+		t.Fatalf("Refresh() racing with a reuse status = %v error = %v", status, err)
+	}
+	if session := crud.find(sessionsCol, map[string]interface{}{"id": sessionID}); session["revoked"] != true {
+		t.Errorf("Refresh() racing with a reuse did not revoke the session")
+	}
+	if session := crud.find(sessionsCol, map[string]interface{}{"id": sessionID}); session["refresh_token"] != hashRefreshSecret("rotated-concurrently") {
+		t.Errorf("Refresh() racing with a reuse overwrote the refresh token of the other request")
+	}
+	if len(auth.revoked) != 1 || auth.revoked[0] != sessionID {
+		t.Errorf("Refresh() racing with a reuse revoked sessions = %v, want %v", auth.revoked, sessionID)
+	}
+}
+
+func TestModule_Logout(t *testing.T) {
+	type testCase struct {
+		name        string
+		all         bool
+		wantRevoked int
+		wantActive  int
+	}
+
+	testCases := []testCase{
+		{name: "logout revokes the current session", all: false, wantRevoked: 1, wantActive: 1},
+		{name: "logout all revokes every session", all: true, wantRevoked: 2, wantActive: 0},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			m, crud, auth, result := newSessionTestModule(t)
+			user := result["user"].(map[string]interface{})
+
+			// Create a second session for the same user
+			if status, _, err := m.issueTokens(context.Background(), "db", "project", "id", user); err != nil {
+				t.Fatalf("issueTokens() status = %v error = %v", status, err)
+			}
+
+			token := result["token"].(string)
+			status, sessions, err := m.Sessions(context.Background(), token, "db", "project")
+			if err != nil {
+				t.Fatalf("Sessions() status = %v error = %v", status, err)
+			}
+			list := sessions["sessions"].([]interface{})
+			if len(list) != 2 {
+				t.Fatalf("Sessions() got %v sessions, want 2", len(list))
+			}
+			current := 0
+			for _, item := range list {
+				session := item.(map[string]interface{})
+				if _, p := session["refresh_token"]; p {
+					t.Errorf("Sessions() returned the refresh token hash")
+				}
+				if session["current"] == true {
+					current++
+				}
+			}
+			if current != 1 {
+				t.Errorf("Sessions() got %v current sessions, want 1", current)
+			}
+
+			if status, err := m.Logout(context.Background(), token, "db", "project", tt.all); err != nil {
+				t.Fatalf("Logout() status = %v error = %v", status, err)
+			}
+			if len(auth.revoked) != tt.wantRevoked {
+				t.Errorf("Logout() revoked = %v, want %v", auth.revoked, tt.wantRevoked)
+			}
+
+			active, err := m.getActiveSessions(context.Background(), "db", "project", user["id"])
+			if err != nil {
+				t.Fatalf("getActiveSessions() error = %v", err)
+			}
+			if len(active) != tt.wantActive {
+				t.Errorf("Logout() active sessions = %v, want %v", len(active), tt.wantActive)
+			}
+			if len(crud.tables[sessionsCol]) != 2 {
+				t.Errorf("Logout() should not delete the sessions")
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package userman
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// createSignedToken encodes the claims and signs them with the aes key of the project. Signed tokens are only
+// understood by the user management module and cannot be used as a JWT
+func (m *Module) createSignedToken(claims interface{}) (string, error) {
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	signature, err := m.sign(payload)
+	if err != nil {
+		return "", err
+	}
+	return payload + "." + signature, nil
+}
+
+// parseSignedToken verifies the signature of the token and decodes its claims in the object provided
+func (m *Module) parseSignedToken(token string, ptr interface{}) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return errors.New("token is malformed")
+	}
+
+	signature, err := m.sign(parts[0])
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(signature), []byte(parts[1])) {
+		return errors.New("token signature does not match")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, ptr)
+}
+
+func (m *Module) sign(payload string) (string, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	if len(m.aesKey) == 0 {
+		return "", errors.New("aes key of project has not been set")
+	}
+	mac := hmac.New(sha256.New, m.aesKey)
+	_, _ = mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -15,13 +15,34 @@ type Module struct {
 	crud    model.CrudUserInterface
 	auth    model.AuthUserInterface
 
+	// Modules used to deliver the verification and password reset mails
+	functions model.FunctionsUserInterface
+	eventing  model.EventingUserInterface
+
+	// oauthProviders caches the resolved endpoints of the oauth providers
+	oauthProviders map[string]*oauthProvider
+
 	// auth module
 	aesKey []byte
 }
 
 // Init creates a new instance of the user management object
 func Init(crud model.CrudUserInterface, auth model.AuthUserInterface) *Module {
-	return &Module{crud: crud, auth: auth}
+	return &Module{crud: crud, auth: auth, oauthProviders: map[string]*oauthProvider{}}
+}
+
+// SetFunctionsModule sets the functions module
+func (m *Module) SetFunctionsModule(functions model.FunctionsUserInterface) {
+	m.Lock()
+	defer m.Unlock()
+	m.functions = functions
+}
+
+// SetEventingModule sets the eventing module
+func (m *Module) SetEventingModule(eventing model.EventingUserInterface) {
+	m.Lock()
+	defer m.Unlock()
+	m.eventing = eventing
 }
 
 // SetConfig sets the config required by the user management module
@@ -30,6 +51,7 @@ func (m *Module) SetConfig(auth config.Auths) {
 	defer m.Unlock()
 
 	m.methods = make(map[string]*config.AuthStub, len(auth))
+	m.oauthProviders = map[string]*oauthProvider{}
 
 	for _, v := range auth {
 		m.methods[v.ID] = v
@@ -0,0 +1,296 @@
+package userman
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+const (
+	purposeVerifyEmail   = "verify-email"
+	purposeResetPassword = "reset-password"
+	purposeMFA           = "mfa"
+
+	verifyEmailExpiry   = 24 * time.Hour
+	resetPasswordExpiry = 1 * time.Hour
+	mfaChallengeExpiry  = 5 * time.Minute
+
+	// maxMFAAttempts is the number of wrong totp codes after which an mfa token is invalidated
+	maxMFAAttempts = 5
+)
+
+// userToken is a signed token issued to a user for a single purpose. The check binds the token to the state of the
+// user it was issued for, so that it can't be used once that state changes
+type userToken struct {
+	Purpose string `json:"p"`
+	DBAlias string `json:"db"`
+	UserID  string `json:"id"`
+	Check   string `json:"c,omitempty"`
+	Expiry  int64  `json:"e"`
+}
+
+func (m *Module) createUserToken(purpose, dbAlias, userID, check string, expiry time.Duration) (string, error) {
+	return m.createSignedToken(userToken{Purpose: purpose, DBAlias: dbAlias, UserID: userID, Check: check, Expiry: time.Now().Add(expiry).Unix()})
+}
+
+func (m *Module) parseUserToken(token, purpose, dbAlias string) (*userToken, error) {
+	t := new(userToken)
+	if err := m.parseSignedToken(token, t); err != nil {
+		return nil, err
+	}
+	if t.Purpose != purpose || t.DBAlias != dbAlias {
+		return nil, errors.New("token has not been issued for this operation")
+	}
+	if time.Now().Unix() > t.Expiry {
+		return nil, errors.New("token has expired")
+	}
+	return t, nil
+}
+
+// fingerprint returns a short hash of a value to which a user token is bound
+func fingerprint(value interface{}) string {
+	str, _ := value.(string)
+	hash := sha256.Sum256([]byte(str))
+	return hex.EncodeToString(hash[:8])
+}
+
+// SendVerificationEmail sends a mail to the user for verifying the email. Unknown emails are ignored so that the
+// endpoint can't be used to find out which emails have signed up
+func (m *Module) SendVerificationEmail(ctx context.Context, dbAlias, project, email string) (int, error) {
+	if !m.IsActive("email") {
+		return http.StatusNotFound, errors.New("Email sign in feature is not enabled")
+	}
+
+	user, idField, err := m.readUser(ctx, dbAlias, project, map[string]interface{}{"email": email})
+	if err != nil {
+		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Skipping verification mail for unknown email", nil)
+		return http.StatusOK, nil
+	}
+	if isTruthy(user["verified"]) {
+		return http.StatusOK, nil
+	}
+
+	if err := m.sendVerificationEmail(ctx, dbAlias, project, idField, user); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+func (m *Module) sendVerificationEmail(ctx context.Context, dbAlias, project, idField string, user map[string]interface{}) error {
+	userID, _ := user[idField].(string)
+	token, err := m.createUserToken(purposeVerifyEmail, dbAlias, userID, fingerprint(user["email"]), verifyEmailExpiry)
+	if err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create email verification token", err, nil)
+	}
+
+	return m.deliver(ctx, project, map[string]interface{}{
+		"type":      purposeVerifyEmail,
+		"email":     user["email"],
+		"name":      user["name"],
+		"token":     token,
+		"expiresAt": time.Now().Add(verifyEmailExpiry).Unix(),
+	})
+}
+
+// VerifyEmail marks the email of the user the verification token was issued for as verified
+func (m *Module) VerifyEmail(ctx context.Context, dbAlias, project, token string) (int, error) {
+	if !m.IsActive("email") {
+		return http.StatusNotFound, errors.New("Email sign in feature is not enabled")
+	}
+
+	t, err := m.parseUserToken(token, purposeVerifyEmail, dbAlias)
+	if err != nil {
+		return http.StatusUnauthorized, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Invalid email verification token provided", err, nil)
+	}
+
+	user, idField, err := m.readUserByID(ctx, dbAlias, project, t.UserID)
+	if err != nil || fingerprint(user["email"]) != t.Check {
+		return http.StatusUnauthorized, errors.New("Email verification token is no longer valid")
+	}
+
+	if err := m.updateUser(ctx, dbAlias, project, idField, t.UserID, map[string]interface{}{"verified": true}); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+// ForgotPassword sends a mail with a password reset token to the user. Unknown emails are ignored so that the
+// endpoint can't be used to find out which emails have signed up
+func (m *Module) ForgotPassword(ctx context.Context, dbAlias, project, email string) (int, error) {
+	if !m.IsActive("email") {
+		return http.StatusNotFound, errors.New("Email sign in feature is not enabled")
+	}
+
+	user, idField, err := m.readUser(ctx, dbAlias, project, map[string]interface{}{"email": email})
+	if err != nil {
+		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Skipping password reset mail for unknown email", nil)
+		return http.StatusOK, nil
+	}
+
+	userID, _ := user[idField].(string)
+	token, err := m.createUserToken(purposeResetPassword, dbAlias, userID, fingerprint(user["pass"]), resetPasswordExpiry)
+	if err != nil {
+		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create password reset token", err, nil)
+	}
+
+	if err := m.deliver(ctx, project, map[string]interface{}{
+		"type":      purposeResetPassword,
+		"email":     user["email"],
+		"name":      user["name"],
+		"token":     token,
+		"expiresAt": time.Now().Add(resetPasswordExpiry).Unix(),
+	}); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+// ResetPassword sets a new password for the user the reset token was issued for. The token can be used only once since
+// it is bound to the old password. All the sessions of the user are revoked
+func (m *Module) ResetPassword(ctx context.Context, dbAlias, project, token, password string) (int, error) {
+	if !m.IsActive("email") {
+		return http.StatusNotFound, errors.New("Email sign in feature is not enabled")
+	}
+	if password == "" {
+		return http.StatusBadRequest, errors.New("Password cannot be empty")
+	}
+
+	t, err := m.parseUserToken(token, purposeResetPassword, dbAlias)
+	if err != nil {
+		return http.StatusUnauthorized, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Invalid password reset token provided", err, nil)
+	}
+
+	user, idField, err := m.readUserByID(ctx, dbAlias, project, t.UserID)
+	if err != nil || fingerprint(user["pass"]) != t.Check {
+		return http.StatusUnauthorized, errors.New("Password reset token is no longer valid")
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("Failed to hash password")
+	}
+
+	// The user has proven the ownership of the email as well
+	if err := m.updateUser(ctx, dbAlias, project, idField, t.UserID, map[string]interface{}{"pass": hash, "verified": true}); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	sessions, err := m.getActiveSessions(ctx, dbAlias, project, t.UserID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	ids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		if id, ok := session[idField].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	if err := m.revokeSessions(ctx, dbAlias, project, idField, ids); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+// deliver sends the message through the remote service or the event type configured for the email sign in method
+func (m *Module) deliver(ctx context.Context, project string, message map[string]interface{}) error {
+	m.RLock()
+	stub := m.methods["email"]
+	functions, eventing := m.functions, m.eventing
+	m.RUnlock()
+
+	if stub == nil || stub.Delivery == nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Delivery of mails has not been configured for the email sign in method", nil, nil)
+	}
+	delivery := stub.Delivery
+
+	switch {
+	case delivery.Service != "" && delivery.Endpoint != "":
+		if functions == nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Remote services are not available to deliver the mail", nil, nil)
+		}
+		token, err := m.auth.GetInternalAccessToken(ctx)
+		if err != nil {
+			return err
+		}
+		attr := map[string]string{"project": project, "service": delivery.Service, "endpoint": delivery.Endpoint}
+		reqParams := model.RequestParams{Resource: "service-call", Op: "access", Attributes: attr}
+		if _, _, err := functions.CallWithContext(ctx, delivery.Service, delivery.Endpoint, token, reqParams, &model.FunctionsRequest{Params: message}); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to deliver the mail via remote service", err, map[string]interface{}{"service": delivery.Service, "endpoint": delivery.Endpoint})
+		}
+		return nil
+
+	case delivery.EventType != "":
+		if eventing == nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Eventing is not available to deliver the mail", nil, nil)
+		}
+		if err := eventing.QueueAdminEvent(ctx, []*model.QueueEventRequest{{Type: delivery.EventType, Payload: message}}); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to deliver the mail via eventing", err, map[string]interface{}{"type": delivery.EventType})
+		}
+		return nil
+
+	default:
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Either a remote service endpoint or an event type is required to deliver mails", nil, nil)
+	}
+}
+
+// readUser reads the user matching the find object
+func (m *Module) readUser(ctx context.Context, dbAlias, project string, find map[string]interface{}) (map[string]interface{}, string, error) {
+	idField, err := m.getIDField(dbAlias)
+	if err != nil {
+		return nil, "", err
+	}
+
+	attr := map[string]string{"project": project, "db": dbAlias, "col": "users"}
+	reqParams := model.RequestParams{Resource: "db-read", Op: "access", Attributes: attr}
+	user, _, err := m.crud.Read(ctx, dbAlias, "users", &model.ReadRequest{Find: find, Operation: utils.One}, reqParams)
+	if err != nil {
+		return nil, "", err
+	}
+	return user.(map[string]interface{}), idField, nil
+}
+
+func (m *Module) readUserByID(ctx context.Context, dbAlias, project string, id interface{}) (map[string]interface{}, string, error) {
+	idField, err := m.getIDField(dbAlias)
+	if err != nil {
+		return nil, "", err
+	}
+	return m.readUser(ctx, dbAlias, project, map[string]interface{}{idField: id})
+}
+
+// isVerificationConfigured checks if the emails of the users get verified. The verified field is written only in that
+// case, since the users table of projects which don't verify emails doesn't need to have it
+func (m *Module) isVerificationConfigured() bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	stub, ok := m.methods["email"]
+	return ok && (stub.Delivery != nil || stub.RequireVerification)
+}
+
+func (m *Module) updateUser(ctx context.Context, dbAlias, project, idField string, id interface{}, set map[string]interface{}) error {
+	attr := map[string]string{"project": project, "db": dbAlias, "col": "users"}
+	reqParams := model.RequestParams{Resource: "db-update", Op: "access", Attributes: attr}
+	updateReq := &model.UpdateRequest{Find: map[string]interface{}{idField: id}, Operation: utils.One, Update: map[string]interface{}{"$set": set}}
+	if err := m.crud.Update(ctx, dbAlias, "users", updateReq, reqParams); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to update user", err, nil)
+	}
+	return nil
+}
+
+// sanitizeUser removes the credentials from the user object
+func sanitizeUser(user map[string]interface{}) {
+	delete(user, "pass")
+	delete(user, "mfa_secret")
+	delete(user, "mfa_pending_secret")
+	delete(user, "mfa_last_step")
+	delete(user, "mfa_challenge")
+	delete(user, "mfa_failures")
+}
@@ -0,0 +1,198 @@
+package userman
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+type mockUserEventing struct {
+	events []*model.QueueEventRequest
+}
+
+func (e *mockUserEventing) QueueAdminEvent(ctx context.Context, reqs []*model.QueueEventRequest) error {
+	e.events = append(e.events, reqs...)
+	return nil
+}
+
+type mockUserFunctions struct {
+	calls []map[string]interface{}
+}
+
+func (f *mockUserFunctions) CallWithContext(ctx context.Context, service, function, token string, reqParams model.RequestParams, req *model.FunctionsRequest) (int, interface{}, error) {
+	f.calls = append(f.calls, map[string]interface{}{"service": service, "endpoint": function, "token": token, "params": req.Params})
+	return http.StatusOK, nil, nil
+}
+
+func newVerificationTestModule(t *testing.T, delivery *config.AuthDelivery) (*Module, *mockUserCrud, *mockUserAuth) {
+	crud := newMockUserCrud(nil)
+	auth := &mockUserAuth{}
+	m := Init(crud, auth)
+	m.SetConfig(config.Auths{"email": &config.AuthStub{ID: "email", Enabled: true, Delivery: delivery, RequireVerification: true}})
+	if err := m.SetProjectAESKey(base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))); err != nil {
+		t.Fatalf("SetProjectAESKey() error = %v", err)
+	}
+	return m, crud, auth
+}
+
+func TestModule_VerifyEmail(t *testing.T) {
+	eventing := &mockUserEventing{}
+	m, _, _ := newVerificationTestModule(t, &config.AuthDelivery{EventType: "user-mail"})
+	m.SetEventingModule(eventing)
+	ctx := context.Background()
+
+	status, result, err := m.EmailSignUp(ctx, "db", "project", "user@example.com", "User", "password", "user")
+	if err != nil {
+		t.Fatalf("EmailSignUp() status = %v error = %v", status, err)
+	}
+	if _, ok := result["token"]; ok {
+		t.Errorf("EmailSignUp() of user who needs verification issued tokens %v", result)
+	}
+	if len(eventing.events) != 1 || eventing.events[0].Type != "user-mail" {
+		t.Fatalf("EmailSignUp() queued events = %v, want a single verification mail", eventing.events)
+	}
+	message := eventing.events[0].Payload.(map[string]interface{})
+	if message["type"] != purposeVerifyEmail || message["email"] != "user@example.com" {
+		t.Fatalf("EmailSignUp() queued message = %v", message)
+	}
+
+	if status, _, err := m.EmailSignIn(ctx, "db", "project", "user@example.com", "password"); err == nil || status != http.StatusForbidden {
+		t.Errorf("EmailSignIn() of unverified user status = %v error = %v", status, err)
+	}
+
+	token := message["token"].(string)
+	if status, err := m.VerifyEmail(ctx, "db", "project", token+"x"); err == nil || status != http.StatusUnauthorized {
+		t.Errorf("VerifyEmail() with tampered token status = %v error = %v", status, err)
+	}
+	if status, err := m.ResetPassword(ctx, "db", "project", token, "new-password"); err == nil || status != http.StatusUnauthorized {
+		t.Errorf("ResetPassword() with verification token status = %v error = %v", status, err)
+	}
+	if status, err := m.VerifyEmail(ctx, "db", "project", token); err != nil {
+		t.Fatalf("VerifyEmail() status = %v error = %v", status, err)
+	}
+
+	if status, _, err := m.EmailSignIn(ctx, "db", "project", "user@example.com", "password"); err != nil {
+		t.Errorf("EmailSignIn() of verified user status = %v error = %v", status, err)
+	}
+}
+
+func TestModule_ResetPassword(t *testing.T) {
+	functions := &mockUserFunctions{}
+	m, crud, auth := newVerificationTestModule(t, &config.AuthDelivery{Service: "mailer", Endpoint: "send"})
+	m.SetFunctionsModule(functions)
+	ctx := context.Background()
+
+	if status, _, err := m.EmailSignUp(ctx, "db", "project", "user@example.com", "User", "password", "user"); err != nil {
+		t.Fatalf("EmailSignUp() status = %v error = %v", status, err)
+	}
+	crud.tables["users"][0]["verified"] = true
+	functions.calls = nil
+	if status, _, err := m.EmailSignIn(ctx, "db", "project", "user@example.com", "password"); err != nil {
+		t.Fatalf("EmailSignIn() status = %v error = %v", status, err)
+	}
+
+	if status, err := m.ForgotPassword(ctx, "db", "project", "unknown@example.com"); err != nil || status != http.StatusOK {
+		t.Errorf("ForgotPassword() of unknown email status = %v error = %v", status, err)
+	}
+	if len(functions.calls) != 0 {
+		t.Errorf("ForgotPassword() of unknown email sent %v mails", len(functions.calls))
+	}
+
+	if status, err := m.ForgotPassword(ctx, "db", "project", "user@example.com"); err != nil {
+		t.Fatalf("ForgotPassword() status = %v error = %v", status, err)
+	}
+	if len(functions.calls) != 1 || functions.calls[0]["service"] != "mailer" || functions.calls[0]["token"] != "internal-token" {
+		t.Fatalf("ForgotPassword() remote service calls = %v", functions.calls)
+	}
+	message := functions.calls[0]["params"].(map[string]interface{})
+	if message["type"] != purposeResetPassword {
+		t.Fatalf("ForgotPassword() message = %v", message)
+	}
+	token := message["token"].(string)
+
+	if status, err := m.ResetPassword(ctx, "db", "project", token, "new-password"); err != nil {
+		t.Fatalf("ResetPassword() status = %v error = %v", status, err)
+	}
+	if len(auth.revoked) != 1 {
+		t.Errorf("ResetPassword() revoked sessions = %v, want the session created on sign in", auth.revoked)
+	}
+	if status, err := m.ResetPassword(ctx, "db", "project", token, "another-password"); err == nil || status != http.StatusUnauthorized {
+		t.Errorf("ResetPassword() with used token status = %v error = %v", status, err)
+	}
+
+	if status, _, err := m.EmailSignIn(ctx, "db", "project", "user@example.com", "password"); err == nil || status != http.StatusUnauthorized {
+		t.Errorf("EmailSignIn() with old password status = %v error = %v", status, err)
+	}
+	if status, _, err := m.EmailSignIn(ctx, "db", "project", "user@example.com", "new-password"); err != nil {
+		t.Errorf("EmailSignIn() with new password status = %v error = %v", status, err)
+	}
+}
+
+func TestModule_EmailEditProfile(t *testing.T) {
+	eventing := &mockUserEventing{}
+	m, crud, _ := newVerificationTestModule(t, &config.AuthDelivery{EventType: "user-mail"})
+	m.SetEventingModule(eventing)
+	ctx := context.Background()
+
+	if status, _, err := m.EmailSignUp(ctx, "db", "project", "user@example.com", "User", "password", "user"); err != nil {
+		t.Fatalf("EmailSignUp() status = %v error = %v", status, err)
+	}
+	user := crud.tables["users"][0]
+	user["verified"] = true
+	eventing.events = nil
+
+	if status, _, err := m.EmailEditProfile(ctx, "token", "db", "project", user["id"].(string), "user@example.com", "New Name", ""); err != nil {
+		t.Fatalf("EmailEditProfile() status = %v error = %v", status, err)
+	}
+	if user["verified"] != true || len(eventing.events) != 0 {
+		t.Errorf("EmailEditProfile() without changing the email verified = %v mails = %v", user["verified"], eventing.events)
+	}
+
+	if status, _, err := m.EmailEditProfile(ctx, "token", "db", "project", user["id"].(string), "new@example.com", "", ""); err != nil {
+		t.Fatalf("EmailEditProfile() status = %v error = %v", status, err)
+	}
+	if user["verified"] != false {
+		t.Errorf("EmailEditProfile() with new email verified = %v, want false", user["verified"])
+	}
+	if len(eventing.events) != 1 || eventing.events[0].Payload.(map[string]interface{})["email"] != "new@example.com" {
+		t.Errorf("EmailEditProfile() with new email queued events = %v, want a verification mail to the new email", eventing.events)
+	}
+	if status, _, err := m.EmailSignIn(ctx, "db", "project", "new@example.com", "password"); err == nil || status != http.StatusForbidden {
+		t.Errorf("EmailSignIn() with unverified new email status = %v error = %v", status, err)
+	}
+}
+
+func TestModule_EmailSignUp_verifiedField(t *testing.T) {
+	tests := []struct {
+		name         string
+		stub         *config.AuthStub
+		wantVerified bool
+	}{
+		{name: "verification not configured", stub: &config.AuthStub{ID: "email", Enabled: true}},
+		{name: "delivery of mails configured", stub: &config.AuthStub{ID: "email", Enabled: true, Delivery: &config.AuthDelivery{EventType: "user-mail"}}, wantVerified: true},
+		{name: "verification required", stub: &config.AuthStub{ID: "email", Enabled: true, RequireVerification: true}, wantVerified: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crud := newMockUserCrud(nil)
+			m := Init(crud, &mockUserAuth{})
+			m.SetConfig(config.Auths{"email": tt.stub})
+			m.SetEventingModule(&mockUserEventing{})
+			if err := m.SetProjectAESKey(base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))); err != nil {
+				t.Fatalf("SetProjectAESKey() error = %v", err)
+			}
+
+			if status, _, err := m.EmailSignUp(context.Background(), "db", "project", "user@example.com", "User", "password", "user"); err != nil {
+				t.Fatalf("EmailSignUp() status = %v error = %v", status, err)
+			}
+			user := crud.find("users", map[string]interface{}{"email": "user@example.com"})
+			if verified, p := user["verified"]; p != tt.wantVerified || (p && verified != false) {
+				t.Errorf("EmailSignUp() verified = %v (present %v), want present %v", verified, p, tt.wantVerified)
+			}
+		})
+	}
+}
@@ -198,11 +198,17 @@ func HandleCrudRead(modules *modules.Modules) http.HandlerFunc {
 			return
 		}
 
+		// The cursor to fetch the next page is generated before post processing removes or masks the sort fields
+		response := map[string]interface{}{"result": result}
+		if cursor := crud.GenerateCursor(meta.dbType, meta.col, &req, result); cursor != "" {
+			response["cursor"] = cursor
+		}
+
 		// function to do postProcessing on result
 		_ = authHelpers.PostProcessMethod(ctx, auth.GetAESKey(), actions, result)
 
 		// Give positive acknowledgement
-		_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, map[string]interface{}{"result": result})
+		_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, response)
 	}
 }
 
@@ -409,27 +415,70 @@ func HandleCrudBatch(modules *modules.Modules) http.HandlerFunc {
 		_ = json.NewDecoder(r.Body).Decode(&txRequest)
 		defer utils.CloseTheCloser(r.Body)
 
-		var reqParams model.RequestParams
-		for _, req := range txRequest.Requests {
-			// Make error variables
-			var err error
+		// NOTE: meta.dbType is actually the dbAlias
+		dbType, _ := crud.GetDBType(meta.dbType)
 
+		// authorise checks the security rules of a step. It populates the where clause and the post processing of read steps
+		authorise := func(ctx context.Context, req *model.AllRequest) (model.RequestParams, error) {
 			switch req.Type {
+			case string(model.Read):
+				if req.Options == nil {
+					req.Options = new(model.ReadOptions)
+				}
+				r := model.ReadRequest{Find: req.Find, Operation: req.Operation, Options: req.Options}
+				returnWhere := model.ReturnWhereStub{Col: req.Col, PrefixColName: len(req.Options.Join) > 0, ReturnWhere: dbType != string(model.Mongo), Where: map[string]interface{}{}}
+				actions, reqParams, err := auth.IsReadOpAuthorised(ctx, meta.projectID, meta.dbType, req.Col, meta.token, &r, returnWhere)
+				if err != nil {
+					return reqParams, err
+				}
+				if len(returnWhere.Where) > 0 {
+					r.MatchWhere = append(r.MatchWhere, returnWhere.Where)
+				}
+				r.PostProcess = map[string]*model.PostProcess{req.Col: actions}
+				err = auth.RunAuthForJoins(ctx, meta.projectID, dbType, meta.dbType, meta.token, &r, req.Options.Join)
+				req.MatchWhere, req.PostProcess = r.MatchWhere, r.PostProcess
+				return reqParams, err
+
 			case string(model.Create):
 				r := model.CreateRequest{Document: req.Document, Operation: req.Operation}
-				reqParams, err = auth.IsCreateOpAuthorised(ctx, meta.projectID, meta.dbType, req.Col, meta.token, &r)
+				return auth.IsCreateOpAuthorised(ctx, meta.projectID, meta.dbType, req.Col, meta.token, &r)
 
 			case string(model.Update):
 				r := model.UpdateRequest{Find: req.Find, Update: req.Update, Operation: req.Operation}
-				reqParams, err = auth.IsUpdateOpAuthorised(ctx, meta.projectID, meta.dbType, req.Col, meta.token, &r)
+				return auth.IsUpdateOpAuthorised(ctx, meta.projectID, meta.dbType, req.Col, meta.token, &r)
 
 			case string(model.Delete):
 				r := model.DeleteRequest{Find: req.Find, Operation: req.Operation}
-				reqParams, err = auth.IsDeleteOpAuthorised(ctx, meta.projectID, meta.dbType, req.Col, meta.token, &r)
+				return auth.IsDeleteOpAuthorised(ctx, meta.projectID, meta.dbType, req.Col, meta.token, &r)
+			}
+			return model.RequestParams{}, nil
+		}
+
+		var reqParams model.RequestParams
+		var stepErr error
+		for _, req := range txRequest.Requests {
+			// Results of read steps are post processed before the later steps get to reference them
+			if req.Type == string(model.Read) {
+				req.ProcessResult = func(ctx context.Context, step *model.AllRequest, result interface{}) error {
+					return authHelpers.PostProcessMethod(ctx, auth.GetAESKey(), step.PostProcess[step.Col], result)
+				}
+			}
 
+			// The security rules of steps referencing earlier steps have to see the values being referenced. Hence they
+			// are checked once the references have been resolved
+			if utils.HasBatchReferences(req) {
+				req.Authorise = func(ctx context.Context, step *model.AllRequest) error {
+					_, err := authorise(ctx, step)
+					if err != nil {
+						stepErr = err
+					}
+					return err
+				}
+				continue
 			}
 
-			// Send error response
+			var err error
+			reqParams, err = authorise(ctx, req)
 			if err != nil {
 				// Send http response
 				_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusForbidden, err)
@@ -440,13 +489,17 @@ func HandleCrudBatch(modules *modules.Modules) http.HandlerFunc {
 		reqParams.Resource = "db-batch"
 		reqParams = utils.ExtractRequestParams(r, reqParams, txRequest)
 
-		err = crud.Batch(ctx, meta.dbType, &txRequest, reqParams)
+		results, err := crud.Batch(ctx, meta.dbType, &txRequest, reqParams)
 		if err != nil {
-			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusInternalServerError, err)
+			status := http.StatusInternalServerError
+			if stepErr != nil {
+				status = http.StatusForbidden
+			}
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
 			return
 		}
 
 		// Give positive acknowledgement
-		_ = helpers.Response.SendOkayResponse(ctx, http.StatusOK, w)
+		_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, map[string]interface{}{"result": results})
 	}
 }
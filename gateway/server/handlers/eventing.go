@@ -114,3 +114,46 @@ func HandleQueueEvent(modules *modules.Modules) http.HandlerFunc {
 		_ = helpers.Response.SendOkayResponse(ctx, http.StatusOK, w)
 	}
 }
+
+// HandleReplayEvents queues logged events again with their original payloads
+func HandleReplayEvents(adminMan *admin.Manager, modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+
+		eventing, err := modules.Eventing(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(r.Context(), w, http.StatusBadRequest, err)
+			return
+		}
+
+		// Load the params from the body
+		req := new(model.ReplayEventsRequest)
+		_ = json.NewDecoder(r.Body).Decode(req)
+		defer utils.CloseTheCloser(r.Body)
+
+		// Return if the eventing module is not enabled
+		if !eventing.IsEnabled() {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(r.Context()), "error handling replay events request eventing feature isn't enabled", nil, nil)
+			_ = helpers.Response.SendErrorResponse(r.Context(), w, http.StatusNotFound, errors.New("This feature isn't enabled"))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+		defer cancel()
+
+		// Get the JWT token from header
+		if err := adminMan.CheckIfAdmin(ctx, utils.GetTokenFromHeader(r)); err != nil {
+			_ = helpers.Response.SendErrorResponse(r.Context(), w, http.StatusForbidden, err)
+			return
+		}
+
+		count, err := eventing.ReplayEvents(ctx, req)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, map[string]interface{}{"result": count})
+	}
+}
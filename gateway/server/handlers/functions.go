@@ -14,6 +14,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/modules"
 	authHelpers "github.com/spaceuptech/space-cloud/gateway/modules/auth/helpers"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/ratelimit"
 )
 
 // HandleFunctionCall creates a functions request endpoint
@@ -66,6 +67,15 @@ func HandleFunctionCall(modules *modules.Modules) http.HandlerFunc {
 			return
 		}
 
+		// Rate limit the calls once the claims of the client are known
+		if status := functions.ApplyRateLimits(ctx, serviceID, function, ratelimit.NewArgs(r, reqParams.Claims, token)); status != nil {
+			ratelimit.SetHeaders(w.Header(), status)
+			if !status.Allowed {
+				_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusTooManyRequests, fmt.Errorf("rate limit exceeded: retry after %v", status.RetryAfter))
+				return
+			}
+		}
+
 		reqParams = utils.ExtractRequestParams(r, reqParams, req)
 
 		status, result, err := functions.CallWithContext(ctx, serviceID, function, token, reqParams, &req)
@@ -3,16 +3,21 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/spaceuptech/helpers"
 
+	"github.com/spaceuptech/space-cloud/gateway/managers/admin"
 	"github.com/spaceuptech/space-cloud/gateway/managers/syncman"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/modules"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/graphql"
+	"github.com/spaceuptech/space-cloud/gateway/utils/ratelimit"
 )
 
 // HandleGraphQLRequest executes graphql queries
@@ -35,6 +40,9 @@ func HandleGraphQLRequest(modules *modules.Modules, syncMan *syncman.Manager) ht
 		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(projectConfig.ContextTimeGraphQL)*time.Second)
 		defer cancel()
 
+		// Keep the attributes of the client to rate limit the remote services it calls
+		ctx = ratelimit.WithRequest(ctx, r)
+
 		graphql, err := modules.GraphQL(projectID)
 		if err != nil {
 			w.Header().Set("Content-Type", "application/json")
@@ -56,8 +64,7 @@ func HandleGraphQLRequest(modules *modules.Modules, syncMan *syncman.Manager) ht
 		graphql.ExecGraphQLQuery(ctx, &req, token, func(op interface{}, err error) {
 			defer func() { ch <- struct{}{} }()
 			if err != nil {
-				errMes := map[string]interface{}{"message": err.Error()}
-				_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, map[string]interface{}{"errors": []interface{}{errMes}})
+				_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, map[string]interface{}{"errors": []interface{}{graphqlError(err)}})
 				return
 			}
 			_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, map[string]interface{}{"data": op})
@@ -75,3 +82,71 @@ func HandleGraphQLRequest(modules *modules.Modules, syncMan *syncman.Manager) ht
 	}
 
 }
+
+// graphqlError returns the error object sent in graphql responses
+func graphqlError(err error) map[string]interface{} {
+	errMes := map[string]interface{}{"message": err.Error()}
+	switch {
+	case errors.Is(err, graphql.ErrPersistedQueryNotFound):
+		errMes["extensions"] = map[string]interface{}{"code": "PERSISTED_QUERY_NOT_FOUND"}
+	case errors.Is(err, graphql.ErrQueryNotAllowed):
+		errMes["extensions"] = map[string]interface{}{"code": "QUERY_NOT_ALLOWED"}
+	case errors.Is(err, graphql.ErrQueryTooComplex):
+		errMes["extensions"] = map[string]interface{}{"code": "QUERY_TOO_COMPLEX"}
+	case errors.Is(err, graphql.ErrRateLimited):
+		errMes["extensions"] = map[string]interface{}{"code": "RATE_LIMITED"}
+	}
+	return errMes
+}
+
+// HandleGraphQLSDL returns the graphql schema of the project in the schema definition language. The schema is only
+// returned for tokens of the project or admin tokens which can read the database schema
+func HandleGraphQLSDL(adminMan *admin.Manager, modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Check if the request is authorised
+		token := utils.GetTokenFromHeader(r)
+		if !isProjectToken(ctx, modules, projectID, token) {
+			if _, err := adminMan.IsTokenValid(ctx, token, "db-schema", "read", map[string]string{"project": projectID}); err != nil {
+				_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Failed to validate token for getting graphql schema", err, nil)
+				_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+				return
+			}
+		}
+
+		graphql, err := modules.GraphQL(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		sdl, err := graphql.GetSDL(ctx)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/graphql; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", projectID+".graphql"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(sdl))
+	}
+}
+
+// isProjectToken checks if the token has been signed by the project
+func isProjectToken(ctx context.Context, modules *modules.Modules, projectID, token string) bool {
+	if token == "" {
+		return false
+	}
+	a, err := modules.Auth(projectID)
+	if err != nil {
+		return false
+	}
+	_, err = a.ParseToken(ctx, token)
+	return err == nil
+}
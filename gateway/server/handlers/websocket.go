@@ -135,11 +135,12 @@ type graphqlMessage struct {
 }
 
 type payloadObject struct {
-	Query     string                 `json:"query,omitempty"`
-	Token     string                 `json:"authToken"`
-	Variables map[string]interface{} `json:"variables"`
-	Error     []gqlError             `json:"errors,omitempty"`
-	Data      interface{}            `json:"data,omitempty"`
+	Query      string                   `json:"query,omitempty"`
+	Token      string                   `json:"authToken"`
+	Variables  map[string]interface{}   `json:"variables"`
+	Extensions *model.GraphQLExtensions `json:"extensions,omitempty"`
+	Error      []gqlError               `json:"errors,omitempty"`
+	Data       interface{}              `json:"data,omitempty"`
 }
 
 type gqlError struct {
@@ -235,8 +236,15 @@ func HandleGraphqlSocket(modules WebsocketModulesInterface) http.HandlerFunc {
 
 			case utils.GqlStart:
 
+				// Load the persisted query and check the allow list
+				req := &model.GraphQLRequest{Query: m.Payload.Query, Variables: m.Payload.Variables, Extensions: m.Payload.Extensions}
+				if err := graph.ResolveQuery(ctx, req); err != nil {
+					channel <- &graphqlMessage{ID: m.ID, Type: utils.GqlError, Payload: payloadObject{Error: []gqlError{{Message: err.Error()}}}}
+					continue
+				}
+
 				// parse the source
-				doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(m.Payload.Query)})})
+				doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query)})})
 				if err != nil {
 					channel <- &graphqlMessage{ID: m.ID, Type: utils.GqlError, Payload: payloadObject{Error: []gqlError{{Message: err.Error()}}}}
 					continue
@@ -266,11 +274,29 @@ func HandleGraphqlSocket(modules WebsocketModulesInterface) http.HandlerFunc {
 					continue
 				}
 
+				readOptions, err := graphql.ExtractReadOptions(ctx, v.Arguments, utils.M{"vars": m.Payload.Variables})
+				if err != nil {
+					channel <- &graphqlMessage{ID: m.ID, Type: utils.GqlError, Payload: payloadObject{Error: []gqlError{{Message: err.Error()}}}}
+					continue
+				}
+
 				data := &model.RealtimeRequest{Token: m.Payload.Token, Where: whereData, DBType: dbAlias, Project: projectID, Group: v.Name.Value, Type: m.Type, ID: m.ID}
+				data.Options = model.LiveQueryOptions{Sort: readOptions.Sort, Limit: readOptions.Limit, Join: readOptions.Join}
 				for _, dirValue := range v.Arguments {
 					if dirValue.Name.Value == "skipInitial" {
 						if boolVal, ok := dirValue.Value.(*ast.BooleanValue); ok {
-							data.Options = model.LiveQueryOptions{SkipInitial: boolVal.Value}
+							data.Options.SkipInitial = boolVal.Value
+						}
+					}
+					if dirValue.Name.Value == "resumeFrom" {
+						temp, _ := utils.ParseGraphqlValue(dirValue.Value, utils.M{"vars": m.Payload.Variables})
+						switch offset := temp.(type) {
+						case int:
+							resumeFrom := int64(offset)
+							data.ResumeFrom = &resumeFrom
+						case float64:
+							resumeFrom := int64(offset)
+							data.ResumeFrom = &resumeFrom
 						}
 					}
 				}
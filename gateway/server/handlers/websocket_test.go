@@ -691,3 +691,12 @@ func (m *mockGraphQLModule) GetDBAlias(ctx context.Context, field *ast.Field, to
 func (m *mockGraphQLModule) ExecGraphQLQuery(ctx context.Context, req *model.GraphQLRequest, token string, cb model.GraphQLCallback) {
 	m.Called(ctx, req, token, cb)
 }
+
+func (m *mockGraphQLModule) ResolveQuery(ctx context.Context, req *model.GraphQLRequest) error {
+	return nil
+}
+
+func (m *mockGraphQLModule) GetSDL(ctx context.Context) (string, error) {
+	c := m.Called(ctx)
+	return c.String(0), c.Error(1)
+}
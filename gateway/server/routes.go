@@ -48,6 +48,15 @@ func (s *Server) routes(profiler bool, staticPath string, restrictedHosts []stri
 	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/user-management/provider/{id}").HandlerFunc(handlers.HandleSetUserManagement(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}/user-management/provider/{id}").HandlerFunc(handlers.HandleDeleteUserManagement(s.managers.Admin(), s.managers.Sync()))
 
+	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/security/roles").HandlerFunc(handlers.HandleGetSecurityRoles(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/security/roles/{id}").HandlerFunc(handlers.HandleSetSecurityRole(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}/security/roles/{id}").HandlerFunc(handlers.HandleDeleteSecurityRole(s.managers.Admin(), s.managers.Sync()))
+
+	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/graphql/queries").HandlerFunc(handlers.HandleGetGraphQLQueries(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/graphql/queries/{id}").HandlerFunc(handlers.HandleSetGraphQLQuery(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}/graphql/queries/{id}").HandlerFunc(handlers.HandleDeleteGraphQLQuery(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/security/explain").HandlerFunc(handlers.HandleSecurityExplain(s.managers.Admin(), s.modules))
+
 	router.Methods(http.MethodGet).Path("/v1/config/caching/config").HandlerFunc(handlers.HandleGetCacheConfig(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodPost).Path("/v1/config/caching/config/{id}").HandlerFunc(handlers.HandleSetCacheConfig(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodGet).Path("/v1/external/caching/connection-state").HandlerFunc(handlers.HandleGetCacheConnectionState(s.managers.Admin(), s.modules.Caching()))
@@ -65,6 +74,9 @@ func (s *Server) routes(profiler bool, staticPath string, restrictedHosts []stri
 	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/eventing/rules").HandlerFunc(handlers.HandleGetEventingSecurityRules(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/eventing/rules/{id}").HandlerFunc(handlers.HandleAddEventingSecurityRule(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}/eventing/rules/{id}").HandlerFunc(handlers.HandleDeleteEventingSecurityRule(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/eventing/sources").HandlerFunc(handlers.HandleGetEventingSources(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/eventing/sources/{id}").HandlerFunc(handlers.HandleSetEventingSource(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}/eventing/sources/{id}").HandlerFunc(handlers.HandleDeleteEventingSource(s.managers.Admin(), s.managers.Sync()))
 
 	router.Methods(http.MethodGet).Path("/v1/external/projects/{project}/file-storage/connection-state").HandlerFunc(handlers.HandleGetFileState(s.managers.Admin(), s.modules))
 	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/file-storage/config").HandlerFunc(handlers.HandleGetFileStore(s.managers.Admin(), s.managers.Sync()))
@@ -109,6 +121,7 @@ func (s *Server) routes(profiler bool, staticPath string, restrictedHosts []stri
 
 	// Initialize route for graphql
 	router.Path("/v1/api/{project}/graphql").HandlerFunc(handlers.HandleGraphQLRequest(s.modules, s.managers.Sync()))
+	router.Methods(http.MethodGet).Path("/v1/api/{project}/graphql/sdl").HandlerFunc(handlers.HandleGraphQLSDL(s.managers.Admin(), s.modules))
 
 	// Initialize the route for websocket
 	router.HandleFunc("/v1/api/{project}/socket/json", handlers.HandleWebsocket(s.modules))
@@ -125,6 +138,7 @@ func (s *Server) routes(profiler bool, staticPath string, restrictedHosts []stri
 	// Initialize the routes for eventing service
 	router.Methods(http.MethodPost).Path("/v1/api/{project}/eventing/queue").HandlerFunc(handlers.HandleQueueEvent(s.modules))
 	router.Methods(http.MethodPost).Path("/v1/api/{project}/eventing/admin-queue").HandlerFunc(handlers.HandleAdminQueueEvent(s.managers.Admin(), s.modules))
+	router.Methods(http.MethodPost).Path("/v1/api/{project}/eventing/replay").HandlerFunc(handlers.HandleReplayEvents(s.managers.Admin(), s.modules))
 
 	// Initialize the routes for the crud operations
 	router.Methods(http.MethodPost).Path("/v1/api/{project}/crud/{dbAlias}/batch").HandlerFunc(handlers.HandleCrudBatch(s.modules))
@@ -140,6 +154,20 @@ func (s *Server) routes(profiler bool, staticPath string, restrictedHosts []stri
 	userRouter := router.PathPrefix("/v1/api/{project}/auth/{dbAlias}").Subrouter()
 	userRouter.Methods(http.MethodPost).Path("/email/signin").HandlerFunc(handlers.HandleEmailSignIn(s.modules))
 	userRouter.Methods(http.MethodPost).Path("/email/signup").HandlerFunc(handlers.HandleEmailSignUp(s.modules))
+	userRouter.Methods(http.MethodPost).Path("/email/verification").HandlerFunc(handlers.HandleSendVerificationEmail(s.modules))
+	userRouter.Methods(http.MethodPost).Path("/email/verify").HandlerFunc(handlers.HandleVerifyEmail(s.modules))
+	userRouter.Methods(http.MethodPost).Path("/email/forgot-password").HandlerFunc(handlers.HandleForgotPassword(s.modules))
+	userRouter.Methods(http.MethodPost).Path("/email/reset-password").HandlerFunc(handlers.HandleResetPassword(s.modules))
+	userRouter.Methods(http.MethodPost).Path("/mfa/setup").HandlerFunc(handlers.HandleMFASetup(s.modules))
+	userRouter.Methods(http.MethodPost).Path("/mfa/enable").HandlerFunc(handlers.HandleMFAEnable(s.modules))
+	userRouter.Methods(http.MethodPost).Path("/mfa/disable").HandlerFunc(handlers.HandleMFADisable(s.modules))
+	userRouter.Methods(http.MethodPost).Path("/mfa/verify").HandlerFunc(handlers.HandleMFAVerify(s.modules))
+	userRouter.Methods(http.MethodGet).Path("/oauth/{provider}/authorize").HandlerFunc(handlers.HandleOAuthAuthorize(s.modules))
+	userRouter.Methods(http.MethodGet).Path("/oauth/{provider}/callback").HandlerFunc(handlers.HandleOAuthCallback(s.modules))
+	userRouter.Methods(http.MethodPost).Path("/refresh").HandlerFunc(handlers.HandleUserRefreshToken(s.modules))
+	userRouter.Methods(http.MethodGet).Path("/sessions").HandlerFunc(handlers.HandleSessions(s.modules))
+	userRouter.Methods(http.MethodPost).Path("/logout").HandlerFunc(handlers.HandleLogout(s.modules, false))
+	userRouter.Methods(http.MethodPost).Path("/logout-all").HandlerFunc(handlers.HandleLogout(s.modules, true))
 	userRouter.Methods(http.MethodGet).Path("/profile/{id}").HandlerFunc(handlers.HandleProfile(s.modules))
 	userRouter.Methods(http.MethodGet).Path("/profiles").HandlerFunc(handlers.HandleProfiles(s.modules))
 	userRouter.Methods(http.MethodPost).Path("/edit_profile/{id}").HandlerFunc(handlers.HandleEmailEditProfile(s.modules))
@@ -8,6 +8,8 @@ import (
 	"strconv"
 
 	"github.com/spaceuptech/helpers"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/managers"
@@ -94,5 +96,7 @@ func (s *Server) Start(profiler bool, staticPath string, port int, restrictedHos
 	}
 
 	helpers.Logger.LogInfo(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Space cloud is running on the specified ports :%v", port), nil)
-	return http.ListenAndServe(":"+strconv.Itoa(port), handler)
+
+	// Accept HTTP/2 requests without TLS so that gRPC requests can be routed to services
+	return http.ListenAndServe(":"+strconv.Itoa(port), h2c.NewHandler(handler, &http2.Server{}))
 }
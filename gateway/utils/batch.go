@@ -0,0 +1,232 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// BatchReferenceKey is the key used to reference the result of an earlier step of a batch request
+const BatchReferenceKey = "$ref"
+
+var batchIdentifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// IsValidBatchIdentifier checks if the provided step id or savepoint name can be used safely
+func IsValidBatchIdentifier(name string) bool {
+	return batchIdentifierRegex.MatchString(name) && name != "utils"
+}
+
+type batchReferencesKey struct{}
+
+// WithBatchReferences marks the context used to validate the steps of a batch request. References to earlier steps are
+// only allowed in the steps of a batch request
+func WithBatchReferences(ctx context.Context) context.Context {
+	return context.WithValue(ctx, batchReferencesKey{}, true)
+}
+
+// AreBatchReferencesAllowed checks if references to earlier steps of a batch request are allowed in the context
+func AreBatchReferencesAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(batchReferencesKey{}).(bool)
+	return allowed
+}
+
+// BatchReference is a reference which has been validated against the schema of the field it is used for. The value
+// it resolves to must be of the type of the field
+type BatchReference struct {
+	Ref    string `json:"$ref"`
+	Kind   string `json:"-"`
+	IsList bool   `json:"-"`
+}
+
+// IsBatchReference checks if the value is of the form {"$ref": "<step id>.<path>"} and returns the path
+func IsBatchReference(value interface{}) (string, bool) {
+	if ref, ok := value.(*BatchReference); ok {
+		return ref.Ref, true
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok || len(obj) != 1 {
+		return "", false
+	}
+	ref, ok := obj[BatchReferenceKey].(string)
+	return ref, ok
+}
+
+// HasBatchReferences checks if the step references the results of earlier steps
+func HasBatchReferences(req *model.AllRequest) bool {
+	return hasBatchReferences(req.Document) || hasBatchReferences(req.Find) || hasBatchReferences(req.Update)
+}
+
+func hasBatchReferences(value interface{}) bool {
+	if _, ok := IsBatchReference(value); ok {
+		return true
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, val := range v {
+			if hasBatchReferences(val) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, val := range v {
+			if hasBatchReferences(val) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResolveBatchReferences returns a copy of the request with all references replaced by the results of earlier steps
+func ResolveBatchReferences(ctx context.Context, req *model.AllRequest, results map[string]interface{}) (*model.AllRequest, error) {
+	newReq := *req
+
+	doc, err := resolveBatchReferences(ctx, req.Document, results)
+	if err != nil {
+		return nil, err
+	}
+	newReq.Document = doc
+
+	if req.Find != nil {
+		find, err := resolveBatchReferences(ctx, req.Find, results)
+		if err != nil {
+			return nil, err
+		}
+		newReq.Find = find.(map[string]interface{})
+	}
+
+	if req.Update != nil {
+		update, err := resolveBatchReferences(ctx, req.Update, results)
+		if err != nil {
+			return nil, err
+		}
+		newReq.Update = update.(map[string]interface{})
+	}
+
+	return &newReq, nil
+}
+
+func resolveBatchReferences(ctx context.Context, value interface{}, results map[string]interface{}) (interface{}, error) {
+	if ref, ok := IsBatchReference(value); ok {
+		stepID := strings.SplitN(ref, ".", 2)[0]
+		if _, p := results[stepID]; !p {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid reference (%s) provided in batch request", ref), fmt.Errorf("step (%s) has not been executed before the referencing step", stepID), nil)
+		}
+
+		resolved, err := LoadValue(ref, results)
+		if err != nil {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid reference (%s) provided in batch request", ref), err, nil)
+		}
+
+		// The resolved value ends up in the query as is. Objects would be treated as operators in a where clause, hence
+		// only scalars are allowed. Validated references must also resolve to the type of their field
+		var kind string
+		isList := true
+		if r, ok := value.(*BatchReference); ok {
+			kind, isList = r.Kind, r.IsList
+		}
+		if err := checkBatchReferenceValue(resolved, kind, isList); err != nil {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid value resolved for reference (%s) provided in batch request", ref), err, nil)
+		}
+		return resolved, nil
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		newObj := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			newVal, err := resolveBatchReferences(ctx, val, results)
+			if err != nil {
+				return nil, err
+			}
+			newObj[key] = newVal
+		}
+		return newObj, nil
+
+	case []interface{}:
+		newArray := make([]interface{}, len(v))
+		for i, val := range v {
+			newVal, err := resolveBatchReferences(ctx, val, results)
+			if err != nil {
+				return nil, err
+			}
+			newArray[i] = newVal
+		}
+		return newArray, nil
+
+	default:
+		return v, nil
+	}
+}
+
+func checkBatchReferenceValue(value interface{}, kind string, isList bool) error {
+	if v, ok := value.(primitive.A); ok {
+		value = []interface{}(v)
+	}
+
+	array, ok := value.([]interface{})
+	if !ok {
+		return checkBatchReferenceScalar(value, kind)
+	}
+	if !isList {
+		return errors.New("array provided for a field which is not a list")
+	}
+	for _, item := range array {
+		if err := checkBatchReferenceScalar(item, kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkBatchReferenceScalar(value interface{}, kind string) error {
+	var isString, isInteger, isFloat, isBool, isTime, isObjectID bool
+	switch value.(type) {
+	case string:
+		isString = true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		isInteger = true
+	case float32, float64:
+		isFloat = true
+	case bool:
+		isBool = true
+	case time.Time:
+		isTime = true
+	case primitive.ObjectID:
+		isObjectID = true
+	default:
+		return fmt.Errorf("value of type (%T) cannot be referenced", value)
+	}
+
+	var valid bool
+	switch kind {
+	case "":
+		valid = true
+	case model.TypeID:
+		valid = isString || isInteger || isObjectID
+	case model.TypeString, model.TypeVarChar, model.TypeChar, model.TypeUUID, model.TypeDate, model.TypeTime, model.TypeEnum:
+		valid = isString
+	case model.TypeInteger, model.TypeSmallInteger, model.TypeBigInteger:
+		valid = isInteger
+	case model.TypeFloat, model.TypeDecimal:
+		valid = isInteger || isFloat
+	case model.TypeBoolean:
+		valid = isBool
+	case model.TypeDateTime, model.TypeDateTimeWithZone:
+		valid = isTime || isString
+	}
+	if !valid {
+		return fmt.Errorf("value of type (%T) provided for field of type (%s)", value, kind)
+	}
+	return nil
+}
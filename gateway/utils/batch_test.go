@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+func TestResolveBatchReferences(t *testing.T) {
+	results := map[string]interface{}{
+		"insert": map[string]interface{}{"count": int64(1), "id": int64(7), "ids": []interface{}{int64(7)}, "docs": []interface{}{map[string]interface{}{"name": "foo"}}},
+		"read":   []interface{}{map[string]interface{}{"age": 20}},
+	}
+	tests := []struct {
+		name    string
+		req     *model.AllRequest
+		want    *model.AllRequest
+		wantErr bool
+	}{
+		{
+			name: "references in document, find & update",
+			req: &model.AllRequest{
+				Document: map[string]interface{}{"userId": map[string]interface{}{"$ref": "insert.id"}, "names": []interface{}{map[string]interface{}{"$ref": "insert.docs.0.name"}}},
+				Find:     map[string]interface{}{"age": map[string]interface{}{"$gte": map[string]interface{}{"$ref": "read.0.age"}}},
+				Update:   map[string]interface{}{"$set": map[string]interface{}{"count": map[string]interface{}{"$ref": "insert.count"}}},
+			},
+			want: &model.AllRequest{
+				Document: map[string]interface{}{"userId": int64(7), "names": []interface{}{"foo"}},
+				Find:     map[string]interface{}{"age": map[string]interface{}{"$gte": 20}},
+				Update:   map[string]interface{}{"$set": map[string]interface{}{"count": int64(1)}},
+			},
+		},
+		{
+			name: "validated references",
+			req:  &model.AllRequest{Document: map[string]interface{}{"userId": &BatchReference{Ref: "insert.id", Kind: model.TypeID}, "age": &BatchReference{Ref: "read.0.age", Kind: model.TypeFloat}, "ids": &BatchReference{Ref: "insert.ids", Kind: model.TypeID, IsList: true}}},
+			want: &model.AllRequest{Document: map[string]interface{}{"userId": int64(7), "age": 20, "ids": []interface{}{int64(7)}}},
+		},
+		{
+			name:    "reference resolving to an object",
+			req:     &model.AllRequest{Find: map[string]interface{}{"id": map[string]interface{}{"$ref": "insert.docs.0"}}},
+			wantErr: true,
+		},
+		{
+			name:    "reference to an entire step",
+			req:     &model.AllRequest{Update: map[string]interface{}{"$set": map[string]interface{}{"result": map[string]interface{}{"$ref": "insert"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "validated reference resolving to another type",
+			req:     &model.AllRequest{Document: map[string]interface{}{"name": &BatchReference{Ref: "insert.id", Kind: model.TypeString}}},
+			wantErr: true,
+		},
+		{
+			name:    "validated reference resolving to an array for a field which is not a list",
+			req:     &model.AllRequest{Document: map[string]interface{}{"id": &BatchReference{Ref: "insert.ids", Kind: model.TypeID}}},
+			wantErr: true,
+		},
+		{
+			name:    "reference to a step which has not been executed",
+			req:     &model.AllRequest{Find: map[string]interface{}{"id": map[string]interface{}{"$ref": "update.count"}}},
+			wantErr: true,
+		},
+		{
+			name:    "reference to a field which does not exist",
+			req:     &model.AllRequest{Find: map[string]interface{}{"id": map[string]interface{}{"$ref": "insert.docs.1.name"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveBatchReferences(context.Background(), tt.req, results)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResolveBatchReferences() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveBatchReferences() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAreBatchReferencesAllowed(t *testing.T) {
+	if AreBatchReferencesAllowed(context.Background()) {
+		t.Errorf("AreBatchReferencesAllowed() = true for a context which is not of a batch request")
+	}
+	if !AreBatchReferencesAllowed(WithBatchReferences(context.Background())) {
+		t.Errorf("AreBatchReferencesAllowed() = false for the context of a batch request")
+	}
+}
+
+func TestHasBatchReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *model.AllRequest
+		want bool
+	}{
+		{name: "no references", req: &model.AllRequest{Document: map[string]interface{}{"id": "1"}, Find: map[string]interface{}{"id": "1"}}},
+		{name: "reference in document", req: &model.AllRequest{Document: []interface{}{map[string]interface{}{"id": map[string]interface{}{"$ref": "a.id"}}}}, want: true},
+		{name: "reference in find", req: &model.AllRequest{Find: map[string]interface{}{"id": map[string]interface{}{"$in": []interface{}{map[string]interface{}{"$ref": "a.id"}}}}}, want: true},
+		{name: "validated reference in update", req: &model.AllRequest{Update: map[string]interface{}{"$set": map[string]interface{}{"age": &BatchReference{Ref: "a.age"}}}}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasBatchReferences(tt.req); got != tt.want {
+				t.Errorf("HasBatchReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -62,6 +62,9 @@ const (
 
 	// RealtimeInitial is for the initial data
 	RealtimeInitial string = "initial"
+
+	// RealtimeResync tells the client to drop its copy of the data since the subscription couldn't be resumed
+	RealtimeResync string = "resync"
 )
 const (
 	// TypeRealtimeSubscribe is the request type for live query subscription
@@ -151,6 +154,9 @@ const (
 
 	// EventFileDelete is fired for delete request
 	EventFileDelete string = "FILE_DELETE"
+
+	// EventCron is fired on the schedule of the trigger
+	EventCron string = "CRON"
 )
 
 const (
@@ -0,0 +1,207 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression. Each field holds a bit set of the values it matches
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar track if the day fields were a wildcard. Like in cron, a day matches either of the day fields
+	// when both of them are restricted
+	domStar, dowStar bool
+}
+
+type bounds struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteBounds = bounds{min: 0, max: 59}
+	hourBounds   = bounds{min: 0, max: 23}
+	domBounds    = bounds{min: 1, max: 31}
+	monthBounds  = bounds{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowBounds = bounds{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a standard cron expression with the minute, hour, day of month, month and day of week fields. Lists,
+// ranges, steps, names of months and days, and descriptors like `@daily` are supported
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if e, p := descriptors[strings.ToLower(expr)]; p {
+		expr = e
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression (%s) provided: expected 5 fields got %d", expr, len(fields))
+	}
+
+	s := new(Schedule)
+	var err error
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, fmt.Errorf("invalid minute in cron expression (%s): %v", expr, err)
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, fmt.Errorf("invalid hour in cron expression (%s): %v", expr, err)
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, fmt.Errorf("invalid day of month in cron expression (%s): %v", expr, err)
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, fmt.Errorf("invalid month in cron expression (%s): %v", expr, err)
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, fmt.Errorf("invalid day of week in cron expression (%s): %v", expr, err)
+	}
+
+	// Sunday can be written as 0 or 7
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = fields[2] == "*" || fields[2] == "?"
+	s.dowStar = fields[4] == "*" || fields[4] == "?"
+	return s, nil
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step (%s) provided", part[i+1:])
+			}
+		}
+
+		start, end := b.min, b.max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			i := strings.Index(rangePart, "-")
+			var err error
+			if start, err = parseValue(rangePart[:i], b); err != nil {
+				return 0, err
+			}
+			if end, err = parseValue(rangePart[i+1:], b); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range (%s) provided", rangePart)
+			}
+		default:
+			var err error
+			if start, err = parseValue(rangePart, b); err != nil {
+				return 0, err
+			}
+			// A single value with a step runs till the end of the field
+			end = start
+			if strings.Contains(part, "/") {
+				end = b.max
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(value string, b bounds) (int, error) {
+	if v, p := b.names[strings.ToLower(value)]; p {
+		return v, nil
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value (%s) provided", value)
+	}
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("value (%d) is out of range [%d, %d]", v, b.min, b.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after the one provided which matches the schedule. The schedule is evaluated in the
+// location of the time provided. A zero time is returned if no such time exists in the next five years.
+//
+// Like cron, times whose wall clock repeats when the clocks are turned back match only once, unless the schedule runs
+// every hour
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+
+	// Truncate the instant instead of building it from the wall clock, which is ambiguous while the clocks are turned back
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		if s.hour != everyHour && isRepeatedWallClock(t) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// everyHour is the hour field of schedules which run every hour
+const everyHour = 1<<24 - 1
+
+// isRepeatedWallClock checks if the wall clock of the time already occurred earlier, which happens once the clocks are
+// turned back. The clocks are assumed to be turned back at most once a day
+func isRepeatedWallClock(t time.Time) bool {
+	_, offset := t.Zone()
+	_, earlierOffset := t.Add(-24 * time.Hour).Zone()
+	if earlierOffset <= offset {
+		return false
+	}
+
+	earlier := t.Add(-time.Duration(earlierOffset-offset) * time.Second)
+	return earlier.Day() == t.Day() && earlier.Hour() == t.Hour() && earlier.Minute() == t.Minute()
+}
+
+func (s *Schedule) matchDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,141 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{name: "wildcards", expr: "* * * * *"},
+		{name: "lists ranges and steps", expr: "0,30 9-17/2 1-15 */3 mon-fri"},
+		{name: "names", expr: "0 0 * JAN,jul SUN"},
+		{name: "descriptor", expr: "@daily"},
+		{name: "sunday as 7", expr: "0 0 * * 7"},
+		{name: "too few fields", expr: "* * * *", wantErr: true},
+		{name: "too many fields", expr: "0 * * * * *", wantErr: true},
+		{name: "out of range", expr: "60 * * * *", wantErr: true},
+		{name: "invalid range", expr: "* 10-5 * * *", wantErr: true},
+		{name: "invalid step", expr: "*/0 * * * *", wantErr: true},
+		{name: "invalid name", expr: "* * * foo *", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.expr); (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Skipf("timezone database not available: %v", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone database not available: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+			from: time.Date(2020, 1, 1, 10, 10, 30, 0, time.UTC),
+			want: time.Date(2020, 1, 1, 10, 11, 0, 0, time.UTC),
+		},
+		{
+			name: "exact match is skipped",
+			expr: "*/15 * * * *",
+			from: time.Date(2020, 1, 1, 10, 15, 0, 0, time.UTC),
+			want: time.Date(2020, 1, 1, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "step from a value",
+			expr: "5/20 * * * *",
+			from: time.Date(2020, 1, 1, 10, 26, 0, 0, time.UTC),
+			want: time.Date(2020, 1, 1, 10, 45, 0, 0, time.UTC),
+		},
+		{
+			name: "next day",
+			expr: "30 9 * * *",
+			from: time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+			want: time.Date(2020, 1, 2, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "weekdays only",
+			expr: "0 9 * * mon-fri",
+			from: time.Date(2020, 1, 3, 10, 0, 0, 0, time.UTC), // Friday
+			want: time.Date(2020, 1, 6, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "either day field when both are restricted",
+			expr: "0 0 13 * 5",
+			from: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "leap day",
+			expr: "0 0 29 2 *",
+			from: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "yearly",
+			expr: "@yearly",
+			from: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "in the location of the time provided",
+			expr: "0 9 * * *",
+			from: time.Date(2020, 1, 1, 4, 0, 0, 0, time.UTC).In(kolkata),
+			want: time.Date(2020, 1, 2, 3, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "repeated wall clock fires once when the clocks are turned back",
+			expr: "30 1 * * *",
+			from: time.Date(2021, 11, 7, 5, 30, 0, 0, time.UTC).In(newYork), // 01:30 EDT
+			want: time.Date(2021, 11, 8, 6, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "hourly schedule fires in both the repeated hours",
+			expr: "30 * * * *",
+			from: time.Date(2021, 11, 7, 5, 30, 0, 0, time.UTC).In(newYork), // 01:30 EDT
+			want: time.Date(2021, 11, 7, 6, 30, 0, 0, time.UTC),             // 01:30 EST
+		},
+		{
+			name: "from the second occurrence of a repeated wall clock",
+			expr: "45 * * * *",
+			from: time.Date(2021, 11, 7, 6, 35, 0, 0, time.UTC).In(newYork), // 01:35 EST
+			want: time.Date(2021, 11, 7, 6, 45, 0, 0, time.UTC),
+		},
+		{
+			name: "impossible date",
+			expr: "0 0 31 2 *",
+			from: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := s.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
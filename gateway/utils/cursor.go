@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	cursorTypeDate     = "date"
+	cursorTypeObjectID = "oid"
+)
+
+type cursorValue struct {
+	Type  string      `json:"t,omitempty"`
+	Value interface{} `json:"v"`
+}
+
+type cursor struct {
+	Sort   []string      `json:"s"`
+	Values []cursorValue `json:"v"`
+}
+
+// EncodeCursor generates an opaque cursor out of the values of the sort fields of the provided row. The values are
+// sealed with the provided key so that clients can neither read nor forge them. It returns false if the row doesn't
+// contain all the sort fields
+func EncodeCursor(key []byte, sort []string, row interface{}) (string, bool) {
+	obj, ok := row.(map[string]interface{})
+	if !ok || len(sort) == 0 {
+		return "", false
+	}
+
+	c := cursor{Sort: sort, Values: make([]cursorValue, len(sort))}
+	for i, field := range sort {
+		value, err := LoadValue("row."+strings.TrimPrefix(field, "-"), map[string]interface{}{"row": obj})
+		if err != nil {
+			return "", false
+		}
+
+		// Dates and object ids need to retain their type to be compared correctly by the database
+		switch v := value.(type) {
+		case time.Time:
+			c.Values[i] = cursorValue{Type: cursorTypeDate, Value: v.Format(time.RFC3339Nano)}
+		case primitive.DateTime:
+			c.Values[i] = cursorValue{Type: cursorTypeDate, Value: v.Time().UTC().Format(time.RFC3339Nano)}
+		case primitive.ObjectID:
+			c.Values[i] = cursorValue{Type: cursorTypeObjectID, Value: v.Hex()}
+		default:
+			c.Values[i] = cursorValue{Value: v}
+		}
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", false
+	}
+
+	gcm, err := newCursorCipher(key)
+	if err != nil {
+		return "", false
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", false
+	}
+	return base64.RawURLEncoding.EncodeToString(gcm.Seal(nonce, nonce, data, nil)), true
+}
+
+// DecodeCursor returns the values of the sort fields stored in the cursor. Cursors which weren't sealed with the
+// provided key are rejected
+func DecodeCursor(key []byte, cursorString string, sort []string) ([]interface{}, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(cursorString)
+	if err != nil {
+		return nil, errors.New("invalid cursor provided")
+	}
+
+	gcm, err := newCursorCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("invalid cursor provided")
+	}
+	data, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("invalid cursor provided")
+	}
+
+	// Use json numbers so that large integers don't lose their precision
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var c cursor
+	if err := decoder.Decode(&c); err != nil {
+		return nil, errors.New("invalid cursor provided")
+	}
+	if !reflect.DeepEqual(c.Sort, sort) || len(c.Values) != len(sort) {
+		return nil, errors.New("cursor was generated for a different sort order")
+	}
+
+	values := make([]interface{}, len(c.Values))
+	for i, v := range c.Values {
+		switch v.Type {
+		case cursorTypeDate:
+			s, _ := v.Value.(string)
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return nil, errors.New("invalid cursor provided")
+			}
+			values[i] = t
+		case cursorTypeObjectID:
+			s, _ := v.Value.(string)
+			id, err := primitive.ObjectIDFromHex(s)
+			if err != nil {
+				return nil, errors.New("invalid cursor provided")
+			}
+			values[i] = id
+		default:
+			values[i] = v.Value
+			if n, ok := v.Value.(json.Number); ok {
+				if integer, err := n.Int64(); err == nil {
+					values[i] = integer
+				} else {
+					values[i], _ = n.Float64()
+				}
+			}
+		}
+	}
+	return values, nil
+}
+
+// GenerateCursorWhereClause generates a where clause which matches the rows which come after the cursor
+// in the provided sort order. For sort (a, -b) it matches a > va OR (a = va AND b < vb)
+func GenerateCursorWhereClause(key []byte, sort []string, cursorString string) (map[string]interface{}, error) {
+	if len(sort) == 0 {
+		return nil, errors.New("sort must be provided to use a cursor")
+	}
+
+	values, err := DecodeCursor(key, cursorString, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	or := make([]interface{}, len(sort))
+	for i, field := range sort {
+		clause := make(map[string]interface{}, i+1)
+		for j := 0; j < i; j++ {
+			clause[strings.TrimPrefix(sort[j], "-")] = values[j]
+		}
+
+		op := "$gt"
+		if strings.HasPrefix(field, "-") {
+			op = "$lt"
+		}
+		clause[strings.TrimPrefix(field, "-")] = map[string]interface{}{op: values[i]}
+		or[i] = clause
+	}
+	return map[string]interface{}{"$or": or}, nil
+}
+
+// newCursorCipher returns the cipher cursors are sealed with. A key of its own is derived from the provided key so
+// that keys of any length can be used
+func newCursorCipher(key []byte) (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte("space-cloud-cursor"))
+
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
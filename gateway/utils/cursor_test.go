@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGenerateCursorWhereClause(t *testing.T) {
+	key := []byte("project-aes-key")
+	date := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
+	objectID := primitive.NewObjectID()
+	row := map[string]interface{}{"id": objectID, "age": int64(9007199254740993), "created": date, "name": "foo", "address": map[string]interface{}{"city": "pune"}}
+
+	tests := []struct {
+		name    string
+		sort    []string
+		cursor  func() string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "cursor with a single sort field",
+			sort: []string{"name"},
+			want: map[string]interface{}{"$or": []interface{}{map[string]interface{}{"name": map[string]interface{}{"$gt": "foo"}}}},
+		},
+		{
+			name: "cursor with multiple sort fields retains the types of the values",
+			sort: []string{"-created", "age", "-id"},
+			want: map[string]interface{}{"$or": []interface{}{
+				map[string]interface{}{"created": map[string]interface{}{"$lt": date}},
+				map[string]interface{}{"created": date, "age": map[string]interface{}{"$gt": int64(9007199254740993)}},
+				map[string]interface{}{"created": date, "age": int64(9007199254740993), "id": map[string]interface{}{"$lt": objectID}},
+			}},
+		},
+		{
+			name: "cursor with a nested sort field",
+			sort: []string{"address.city"},
+			want: map[string]interface{}{"$or": []interface{}{map[string]interface{}{"address.city": map[string]interface{}{"$gt": "pune"}}}},
+		},
+		{
+			name: "cursor generated for a different sort order",
+			sort: []string{"name"},
+			cursor: func() string {
+				c, _ := EncodeCursor(key, []string{"-name"}, row)
+				return c
+			},
+			wantErr: true,
+		},
+		{
+			name: "cursor sealed with a different key",
+			sort: []string{"name"},
+			cursor: func() string {
+				c, _ := EncodeCursor([]byte("another-key"), []string{"name"}, row)
+				return c
+			},
+			wantErr: true,
+		},
+		{
+			name: "tampered cursor",
+			sort: []string{"name"},
+			cursor: func() string {
+				c, _ := EncodeCursor(key, []string{"name"}, row)
+				data, _ := base64.RawURLEncoding.DecodeString(c)
+				data[len(data)-1] ^= 1
+				return base64.RawURLEncoding.EncodeToString(data)
+			},
+			wantErr: true,
+		},
+		{
+			name: "forged cursor",
+			sort: []string{"name"},
+			cursor: func() string {
+				return base64.RawURLEncoding.EncodeToString([]byte(`{"s":["name"],"v":[{"v":"foo"}]}`))
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid cursor",
+			sort:    []string{"name"},
+			cursor:  func() string { return "not-a-cursor" },
+			wantErr: true,
+		},
+		{
+			name:    "cursor without sort",
+			cursor:  func() string { return "" },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, ok := "", true
+			if tt.cursor != nil {
+				cursor = tt.cursor()
+			} else {
+				cursor, ok = EncodeCursor(key, tt.sort, row)
+			}
+			if !ok {
+				t.Fatalf("EncodeCursor() unable to generate cursor")
+			}
+
+			got, err := GenerateCursorWhereClause(key, tt.sort, cursor)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateCursorWhereClause() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateCursorWhereClause() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeCursor_MissingField(t *testing.T) {
+	if _, ok := EncodeCursor(nil, []string{"name"}, map[string]interface{}{"id": "1"}); ok {
+		t.Errorf("EncodeCursor() generated a cursor for a row without the sort field")
+	}
+}
+
+func TestEncodeCursor_Opaque(t *testing.T) {
+	c, ok := EncodeCursor([]byte("key"), []string{"name"}, map[string]interface{}{"name": "secret-value"})
+	if !ok {
+		t.Fatalf("EncodeCursor() unable to generate cursor")
+	}
+	data, _ := base64.RawURLEncoding.DecodeString(c)
+	if strings.Contains(string(data), "secret-value") {
+		t.Errorf("EncodeCursor() exposes the values of the sort fields")
+	}
+}
@@ -29,6 +29,7 @@ const (
 		status: String
 		remark: String
 		trigger_type: ID @size(value: 10)
+		partition_key: String
 		invocations: [invocation_logs]! @link(table: "invocation_logs", from: "_id", to: "event_id")
 	  }`
 )
@@ -3,6 +3,7 @@ package graphql
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/graphql-go/graphql/language/ast"
@@ -10,6 +11,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	authHelpers "github.com/spaceuptech/space-cloud/gateway/modules/auth/helpers"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/ratelimit"
 )
 
 func (graph *Module) execFuncCall(ctx context.Context, token string, field *ast.Field, store utils.M, cb model.GraphQLCallback) {
@@ -50,6 +52,11 @@ func (graph *Module) execFuncCall(ctx context.Context, token string, field *ast.
 	// which is not possible in graphql, we can only set the body of req params object
 	reqParams.Payload = params
 
+	if status := graph.functions.ApplyRateLimits(ctx, serviceName, funcName, ratelimit.NewArgsFromContext(ctx, reqParams.Claims, token)); status != nil && !status.Allowed {
+		cb(nil, fmt.Errorf("%w: retry after %v", ErrRateLimited, status.RetryAfter))
+		return
+	}
+
 	go func() {
 		var ctx2 = ctx
 		if timeout != 0 {
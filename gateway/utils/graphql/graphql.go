@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"sync"
 
+	gql "github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/kinds"
 	"github.com/graphql-go/graphql/language/parser"
 	"github.com/graphql-go/graphql/language/source"
 
+	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
@@ -23,9 +25,30 @@ type Module struct {
 	crud      CrudInterface
 	functions FunctionInterface
 	schema    SchemaInterface
+	adminMan  AdminInterface
 
 	// 	Auth module
 	aesKey []byte
+
+	// Config used to generate the typed schema served for introspection
+	typedLock       sync.RWMutex
+	typedSchema     *gql.Schema
+	dbAliases       map[string]struct{}
+	dbSchemas       model.Type
+	preparedQueries config.DatabasePreparedQueries
+	services        config.Services
+
+	// Persisted queries
+	persistedLock        sync.RWMutex
+	allowList            bool
+	registeredByHash     map[string]*registeredQuery
+	registeredByDocument map[string]*registeredQuery
+	automaticQueries     map[string]string
+
+	// Query limits
+	limitsLock  sync.RWMutex
+	limits      *config.GraphQLLimits
+	rateLimiter RateLimiterInterface
 }
 
 // New creates a new GraphQL module
@@ -48,6 +71,11 @@ func (graph *Module) SetProjectAESKey(aesKey string) error {
 	return nil
 }
 
+// SetAdminManager sets the admin manager used to authorise introspection queries made with admin tokens
+func (graph *Module) SetAdminManager(a AdminInterface) {
+	graph.adminMan = a
+}
+
 // GetProjectID sets the project configuration
 func (graph *Module) GetProjectID() string {
 	return graph.project
@@ -55,6 +83,10 @@ func (graph *Module) GetProjectID() string {
 
 // ExecGraphQLQuery executes the provided graphql query
 func (graph *Module) ExecGraphQLQuery(ctx context.Context, req *model.GraphQLRequest, token string, cb model.GraphQLCallback) {
+	if err := graph.ResolveQuery(ctx, req); err != nil {
+		cb(nil, err)
+		return
+	}
 
 	s := source.NewSource(&source.Source{
 		Body: []byte(req.Query),
@@ -67,6 +99,17 @@ func (graph *Module) ExecGraphQLQuery(ctx context.Context, req *model.GraphQLReq
 		return
 	}
 
+	if err := graph.checkQueryLimits(ctx, doc, req, token); err != nil {
+		cb(nil, err)
+		return
+	}
+
+	// Introspection queries are answered from the schema generated out of the project config
+	if isIntrospectionQuery(doc, req.OperationName) {
+		graph.execIntrospectionQuery(ctx, req, token, cb)
+		return
+	}
+
 	graph.execGraphQLDocument(ctx, doc, token, utils.M{"vars": req.Variables, "path": "", "_query": utils.NewArray(0), "directive": ""}, nil, createCallback(cb))
 }
 
@@ -141,6 +184,15 @@ func (graph *Module) execGraphQLDocument(ctx context.Context, node ast.Node, tok
 			var wg sync.WaitGroup
 			wg.Add(len(op.SelectionSet.Selections))
 
+			// The page info is only collected if it has been requested
+			var _pageInfoField *ast.Field
+			for _, v := range op.SelectionSet.Selections {
+				if field := v.(*ast.Field); field.Name.Value == "_pageInfo" {
+					_pageInfoField = field
+					store["_pageInfo"] = utils.NewObject()
+				}
+			}
+
 			var _queryField *ast.Field
 			for _, v := range op.SelectionSet.Selections {
 
@@ -176,6 +228,19 @@ func (graph *Module) execGraphQLDocument(ctx context.Context, node ast.Node, tok
 				}))
 			}
 
+			// process _pageInfo graphql query to show the cursors of the read requests
+			if _pageInfoField != nil {
+				graph.execGraphQLDocument(ctx, _pageInfoField, token, store, nil, createCallback(func(result interface{}, err error) {
+					if err != nil {
+						cb(nil, err)
+						return
+					}
+
+					// Set the result in the field
+					obj.Set(getFieldName(_pageInfoField), result)
+				}))
+			}
+
 			cb(obj.GetAll(), nil)
 			return
 		case ast.OperationTypeMutation:
@@ -258,6 +323,12 @@ func (graph *Module) execGraphQLDocument(ctx context.Context, node ast.Node, tok
 			return
 		}
 
+		if field.Name.Value == "_pageInfo" {
+			val := store["_pageInfo"]
+			graph.processQueryResult(ctx, field, token, store, val.(*utils.Object).GetAll(), nil, cb)
+			return
+		}
+
 		currentValue, err := utils.LoadValue(fmt.Sprintf("%s.%s", store["coreParentKey"], field.Name.Value), store)
 		if err != nil {
 			// This part of code won't be executed until called by post process result
@@ -0,0 +1,593 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+var graphqlNameRegex = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// jsonScalar is used for values whose structure isn't known ahead of time
+var jsonScalar = gql.NewScalar(gql.ScalarConfig{
+	Name:         "JSON",
+	Description:  "The `JSON` scalar type represents arbitrary JSON values",
+	Serialize:    func(value interface{}) interface{} { return value },
+	ParseValue:   func(value interface{}) interface{} { return value },
+	ParseLiteral: func(valueAST ast.Value) interface{} { return valueAST.GetValue() },
+})
+
+// SetDatabaseConfig sets the databases for which directives are to be declared in the typed schema
+func (graph *Module) SetDatabaseConfig(databaseConfigs config.DatabaseConfigs) {
+	graph.typedLock.Lock()
+	defer graph.typedLock.Unlock()
+
+	graph.dbAliases = make(map[string]struct{}, len(databaseConfigs))
+	for _, dbConfig := range databaseConfigs {
+		if dbConfig.Enabled {
+			graph.dbAliases[dbConfig.DbAlias] = struct{}{}
+		}
+	}
+	graph.typedSchema = nil
+}
+
+// SetDatabaseSchemas sets the parsed database schemas used to generate the typed schema
+func (graph *Module) SetDatabaseSchemas(schemaDoc model.Type) {
+	graph.typedLock.Lock()
+	defer graph.typedLock.Unlock()
+
+	graph.dbSchemas = schemaDoc
+	graph.typedSchema = nil
+}
+
+// SetPreparedQueries sets the prepared queries used to generate the typed schema
+func (graph *Module) SetPreparedQueries(preparedQueries config.DatabasePreparedQueries) {
+	graph.typedLock.Lock()
+	defer graph.typedLock.Unlock()
+
+	graph.preparedQueries = preparedQueries
+	graph.typedSchema = nil
+}
+
+// SetRemoteServices sets the remote services used to generate the typed schema
+func (graph *Module) SetRemoteServices(services config.Services) {
+	graph.typedLock.Lock()
+	defer graph.typedLock.Unlock()
+
+	graph.services = services
+	graph.typedSchema = nil
+}
+
+// GetSDL returns the typed schema of the project in the schema definition language
+func (graph *Module) GetSDL(ctx context.Context) (string, error) {
+	schema, err := graph.getTypedSchema(ctx)
+	if err != nil {
+		return "", err
+	}
+	return printSchema(schema), nil
+}
+
+// isIntrospectionQuery checks if the operation to be executed only selects introspection fields
+func isIntrospectionQuery(doc *ast.Document, operationName string) bool {
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName != "" && (op.Name == nil || op.Name.Value != operationName) {
+			continue
+		}
+		if op.Operation != ast.OperationTypeQuery || op.SelectionSet == nil || len(op.SelectionSet.Selections) == 0 {
+			return false
+		}
+		for _, sel := range op.SelectionSet.Selections {
+			field, ok := sel.(*ast.Field)
+			if !ok || !strings.HasPrefix(field.Name.Value, "__") {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// execIntrospectionQuery answers introspection queries from the typed schema. Just like the sdl endpoint, the schema is
+// only returned for tokens of the project or admin tokens which can read the database schema
+func (graph *Module) execIntrospectionQuery(ctx context.Context, req *model.GraphQLRequest, token string, cb model.GraphQLCallback) {
+	if err := graph.authoriseIntrospection(ctx, token); err != nil {
+		cb(nil, err)
+		return
+	}
+
+	schema, err := graph.getTypedSchema(ctx)
+	if err != nil {
+		cb(nil, err)
+		return
+	}
+
+	result := gql.Do(gql.Params{Schema: *schema, RequestString: req.Query, VariableValues: req.Variables, OperationName: req.OperationName, Context: ctx})
+	if len(result.Errors) > 0 {
+		cb(nil, errors.New(result.Errors[0].Message))
+		return
+	}
+	cb(result.Data, nil)
+}
+
+// authoriseIntrospection checks if the token is allowed to read the schema of the project
+func (graph *Module) authoriseIntrospection(ctx context.Context, token string) error {
+	if token != "" && graph.auth != nil {
+		if _, err := graph.auth.ParseToken(ctx, token); err == nil {
+			return nil
+		}
+	}
+	if graph.adminMan != nil {
+		if _, err := graph.adminMan.IsTokenValid(ctx, token, "db-schema", "read", map[string]string{"project": graph.project}); err == nil {
+			return nil
+		}
+	}
+	return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Introspection queries require a token of the project or an admin token which can read the database schema", nil, nil)
+}
+
+// getTypedSchema returns the typed schema of the project. The schema is generated once after every config change
+func (graph *Module) getTypedSchema(ctx context.Context) (*gql.Schema, error) {
+	graph.typedLock.RLock()
+	schema := graph.typedSchema
+	graph.typedLock.RUnlock()
+	if schema != nil {
+		return schema, nil
+	}
+
+	graph.typedLock.Lock()
+	defer graph.typedLock.Unlock()
+	if graph.typedSchema != nil {
+		return graph.typedSchema, nil
+	}
+
+	schema, err := newSchemaBuilder(graph.dbAliases, graph.dbSchemas, graph.preparedQueries, graph.services).build()
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to generate graphql schema from the project config", err, nil)
+	}
+	graph.typedSchema = schema
+	return schema, nil
+}
+
+type schemaBuilder struct {
+	dbAliases       []string
+	dbSchemas       model.Type
+	preparedQueries config.DatabasePreparedQueries
+	services        config.Services
+
+	// collections maps the name of a type to the fields of the collections it has been generated from
+	collections map[string][]model.Fields
+	objects     map[string]*gql.Object
+}
+
+func newSchemaBuilder(dbAliases map[string]struct{}, dbSchemas model.Type, preparedQueries config.DatabasePreparedQueries, services config.Services) *schemaBuilder {
+	aliases := make(map[string]struct{}, len(dbAliases))
+	for dbAlias := range dbAliases {
+		aliases[dbAlias] = struct{}{}
+	}
+	for dbAlias := range dbSchemas {
+		aliases[dbAlias] = struct{}{}
+	}
+	for _, preparedQuery := range preparedQueries {
+		aliases[preparedQuery.DbAlias] = struct{}{}
+	}
+
+	return &schemaBuilder{
+		dbAliases:       sortedKeys(aliases),
+		dbSchemas:       dbSchemas,
+		preparedQueries: preparedQueries,
+		services:        services,
+		collections:     map[string][]model.Fields{},
+		objects:         map[string]*gql.Object{},
+	}
+}
+
+func (b *schemaBuilder) build() (*gql.Schema, error) {
+	// Collections with the same name in different databases share a type since the type name is derived from the collection
+	for _, dbAlias := range b.dbAliases {
+		collections := b.dbSchemas[dbAlias]
+		for _, col := range sortedKeys(collections) {
+			if name, ok := typeName(col); ok && len(collections[col]) > 0 {
+				b.collections[name] = append(b.collections[name], collections[col])
+			}
+		}
+	}
+
+	query := gql.Fields{
+		"_query":    &gql.Field{Type: gql.NewList(jsonScalar), Description: "Queries executed by the database fields of this request which had debug enabled"},
+		"_pageInfo": &gql.Field{Type: jsonScalar, Description: "Cursors of the database fields of this request"},
+	}
+	mutation := gql.Fields{}
+	mutationResponse := gql.NewObject(gql.ObjectConfig{
+		Name: "MutationResponse",
+		Fields: gql.Fields{
+			"status":    &gql.Field{Type: gql.Int},
+			"error":     &gql.Field{Type: gql.String},
+			"returning": &gql.Field{Type: gql.NewList(jsonScalar)},
+		},
+	})
+
+	for _, dbAlias := range b.dbAliases {
+		for _, col := range sortedKeys(b.dbSchemas[dbAlias]) {
+			name, ok := typeName(col)
+			if !ok || !graphqlNameRegex.MatchString(col) || len(b.dbSchemas[dbAlias][col]) == 0 {
+				continue
+			}
+			if _, p := query[col]; p {
+				continue
+			}
+
+			query[col] = &gql.Field{Type: gql.NewList(b.object(name)), Args: readArgs(), Description: fmt.Sprintf("Reads documents of the `%s` collection", col)}
+			mutation["insert_"+col] = &gql.Field{Type: mutationResponse, Args: insertArgs(), Description: fmt.Sprintf("Inserts documents in the `%s` collection", col)}
+			mutation["update_"+col] = &gql.Field{Type: mutationResponse, Args: updateArgs(), Description: fmt.Sprintf("Updates documents of the `%s` collection", col)}
+			mutation["delete_"+col] = &gql.Field{Type: mutationResponse, Args: deleteArgs(), Description: fmt.Sprintf("Deletes documents of the `%s` collection", col)}
+		}
+	}
+
+	for _, key := range sortedKeys(b.preparedQueries) {
+		preparedQuery := b.preparedQueries[key]
+		if _, p := query[preparedQuery.ID]; p || !graphqlNameRegex.MatchString(preparedQuery.ID) {
+			continue
+		}
+		query[preparedQuery.ID] = &gql.Field{Type: gql.NewList(jsonScalar), Args: preparedQueryArgs(preparedQuery), Description: fmt.Sprintf("Executes the `%s` prepared query of the `%s` database", preparedQuery.ID, preparedQuery.DbAlias)}
+	}
+
+	for _, service := range sortedKeys(b.services) {
+		for _, endpoint := range sortedKeys(b.services[service].Endpoints) {
+			if _, p := query[endpoint]; p || !graphqlNameRegex.MatchString(endpoint) {
+				continue
+			}
+			query[endpoint] = &gql.Field{Type: jsonScalar, Description: fmt.Sprintf("Calls the `%s` endpoint of the `%s` remote service. Its arguments are forwarded as the request body", endpoint, service)}
+		}
+	}
+
+	schemaConfig := gql.SchemaConfig{
+		Query:      gql.NewObject(gql.ObjectConfig{Name: "Query", Fields: query}),
+		Directives: append(append([]*gql.Directive{}, gql.SpecifiedDirectives...), b.directives()...),
+		Types:      []gql.Type{jsonScalar},
+	}
+	if len(mutation) > 0 {
+		schemaConfig.Mutation = gql.NewObject(gql.ObjectConfig{Name: "Mutation", Fields: mutation})
+	}
+
+	schema, err := gql.NewSchema(schemaConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// object returns the type generated for the collections with the provided type name
+func (b *schemaBuilder) object(name string) *gql.Object {
+	if obj, p := b.objects[name]; p {
+		return obj
+	}
+
+	obj := gql.NewObject(gql.ObjectConfig{
+		Name: name,
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			fields := gql.Fields{}
+			for _, schemaFields := range b.collections[name] {
+				b.addFields(name, fields, schemaFields)
+			}
+			return fields
+		}),
+	})
+	b.objects[name] = obj
+	return obj
+}
+
+func (b *schemaBuilder) addFields(parent string, fields gql.Fields, schemaFields model.Fields) {
+	for _, fieldName := range sortedKeys(schemaFields) {
+		if _, p := fields[fieldName]; p || !graphqlNameRegex.MatchString(fieldName) {
+			continue
+		}
+		if fieldType := b.outputType(parent, fieldName, schemaFields[fieldName]); fieldType != nil {
+			fields[fieldName] = &gql.Field{Type: fieldType}
+		}
+	}
+}
+
+func (b *schemaBuilder) outputType(parent, fieldName string, field *model.FieldType) gql.Output {
+	var t gql.Output
+	switch {
+	case field.IsLinked:
+		// Linked fields are resolved separately and hence are never guaranteed to be present
+		t = jsonScalar
+		if field.LinkedTable != nil {
+			if name, ok := typeName(field.LinkedTable.Table); ok {
+				if _, p := b.collections[name]; p {
+					t = b.object(name)
+				}
+			}
+		}
+		if field.IsList {
+			return gql.NewList(t)
+		}
+		return t
+
+	case field.Kind == model.TypeObject && len(field.NestedObject) > 0:
+		name := parent + "_" + strings.Title(fieldName)
+		nested := field.NestedObject
+		t = gql.NewObject(gql.ObjectConfig{
+			Name: name,
+			Fields: gql.FieldsThunk(func() gql.Fields {
+				fields := gql.Fields{}
+				b.addFields(name, fields, nested)
+				return fields
+			}),
+		})
+
+	default:
+		t = scalarType(field.Kind)
+	}
+
+	if field.IsList {
+		t = gql.NewList(t)
+	}
+	if field.IsFieldTypeRequired {
+		t = gql.NewNonNull(t)
+	}
+	return t
+}
+
+// directives declares the database and remote service directives along with the ones supported on all fields
+func (b *schemaBuilder) directives() []*gql.Directive {
+	directives := []*gql.Directive{
+		gql.NewDirective(gql.DirectiveConfig{
+			Name:        "template",
+			Description: "Picks the database or remote service directive by evaluating a go template",
+			Locations:   []string{gql.DirectiveLocationField},
+			Args:        gql.FieldConfigArgument{"value": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.String)}},
+		}),
+		gql.NewDirective(gql.DirectiveConfig{
+			Name:        "aggregate",
+			Description: "Returns the result of an aggregation on the field",
+			Locations:   []string{gql.DirectiveLocationField},
+			Args: gql.FieldConfigArgument{
+				"op":    &gql.ArgumentConfig{Type: gql.NewNonNull(gql.String)},
+				"field": &gql.ArgumentConfig{Type: gql.String},
+			},
+		}),
+	}
+	names := map[string]struct{}{"template": {}, "aggregate": {}, "include": {}, "skip": {}, "deprecated": {}}
+
+	for _, dbAlias := range b.dbAliases {
+		if _, p := names[dbAlias]; p || !graphqlNameRegex.MatchString(dbAlias) {
+			continue
+		}
+		names[dbAlias] = struct{}{}
+		directives = append(directives, gql.NewDirective(gql.DirectiveConfig{
+			Name:        dbAlias,
+			Description: fmt.Sprintf("Executes the field on the `%s` database", dbAlias),
+			Locations:   []string{gql.DirectiveLocationField},
+			Args: gql.FieldConfigArgument{
+				"col":   &gql.ArgumentConfig{Type: gql.String, Description: "Name of the collection if it differs from the field name"},
+				"cache": &gql.ArgumentConfig{Type: jsonScalar},
+			},
+		}))
+	}
+
+	for _, service := range sortedKeys(b.services) {
+		if _, p := names[service]; p || !graphqlNameRegex.MatchString(service) {
+			continue
+		}
+		names[service] = struct{}{}
+		directives = append(directives, gql.NewDirective(gql.DirectiveConfig{
+			Name:        service,
+			Description: fmt.Sprintf("Calls an endpoint of the `%s` remote service", service),
+			Locations:   []string{gql.DirectiveLocationField},
+			Args: gql.FieldConfigArgument{
+				"func":    &gql.ArgumentConfig{Type: gql.String, Description: "Name of the endpoint if it differs from the field name"},
+				"timeout": &gql.ArgumentConfig{Type: gql.Int},
+			},
+		}))
+	}
+	return directives
+}
+
+func readArgs() gql.FieldConfigArgument {
+	return gql.FieldConfigArgument{
+		"where":                      &gql.ArgumentConfig{Type: jsonScalar},
+		"op":                         &gql.ArgumentConfig{Type: gql.String},
+		utils.GraphQLGroupByArgument: &gql.ArgumentConfig{Type: gql.NewList(gql.String)},
+		"sort":                       &gql.ArgumentConfig{Type: gql.NewList(gql.String)},
+		"skip":                       &gql.ArgumentConfig{Type: gql.Int},
+		"limit":                      &gql.ArgumentConfig{Type: gql.Int},
+		"distinct":                   &gql.ArgumentConfig{Type: gql.String},
+		"cursor":                     &gql.ArgumentConfig{Type: gql.String},
+		"join":                       &gql.ArgumentConfig{Type: gql.NewList(jsonScalar)},
+		"returnType":                 &gql.ArgumentConfig{Type: gql.String},
+		"debug":                      &gql.ArgumentConfig{Type: gql.Boolean},
+	}
+}
+
+func insertArgs() gql.FieldConfigArgument {
+	return gql.FieldConfigArgument{
+		"docs": &gql.ArgumentConfig{Type: gql.NewList(jsonScalar)},
+	}
+}
+
+func updateArgs() gql.FieldConfigArgument {
+	args := gql.FieldConfigArgument{
+		"where": &gql.ArgumentConfig{Type: jsonScalar},
+		"op":    &gql.ArgumentConfig{Type: gql.String},
+	}
+	for _, op := range []string{"set", "inc", "mul", "max", "min", "currentTimestamp", "currentDate", "push", "rename", "unset"} {
+		args[op] = &gql.ArgumentConfig{Type: jsonScalar}
+	}
+	return args
+}
+
+func deleteArgs() gql.FieldConfigArgument {
+	return gql.FieldConfigArgument{
+		"where": &gql.ArgumentConfig{Type: jsonScalar},
+		"op":    &gql.ArgumentConfig{Type: gql.String},
+	}
+}
+
+// preparedQueryArgs declares the arguments referred to as `args.*` in the prepared query
+func preparedQueryArgs(preparedQuery *config.DatbasePreparedQuery) gql.FieldConfigArgument {
+	args := gql.FieldConfigArgument{"debug": &gql.ArgumentConfig{Type: gql.Boolean}}
+	for _, arg := range preparedQuery.Arguments {
+		if !strings.HasPrefix(arg, "args.") {
+			continue
+		}
+		name := strings.Split(strings.TrimPrefix(arg, "args."), ".")[0]
+		if graphqlNameRegex.MatchString(name) {
+			args[name] = &gql.ArgumentConfig{Type: jsonScalar}
+		}
+	}
+	return args
+}
+
+func scalarType(kind string) gql.Output {
+	switch kind {
+	case model.TypeID:
+		return gql.ID
+	case model.TypeInteger, model.TypeSmallInteger, model.TypeBigInteger:
+		return gql.Int
+	case model.TypeFloat, model.TypeDecimal:
+		return gql.Float
+	case model.TypeBoolean:
+		return gql.Boolean
+	case model.TypeString, model.TypeChar, model.TypeVarChar, model.TypeUUID, model.TypeEnum,
+		model.TypeDate, model.TypeTime, model.TypeDateTime, model.TypeDateTimeWithZone:
+		return gql.String
+	default:
+		return jsonScalar
+	}
+}
+
+// typeName returns the name of the type generated for a collection. It matches the value returned for `__typename`
+func typeName(col string) (string, bool) {
+	name := strings.Title(col)
+	switch name {
+	case "Query", "Mutation", "MutationResponse", "JSON", "String", "Int", "Float", "Boolean", "ID":
+		name += "_"
+	}
+	return name, graphqlNameRegex.MatchString(name) && !strings.HasPrefix(name, "__")
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch v := m.(type) {
+	case map[string]struct{}:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case model.Collection:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case model.Fields:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case config.DatabasePreparedQueries:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case config.Services:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case map[string]*config.Endpoint:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// printSchema prints the types and directives of the schema in the schema definition language
+func printSchema(schema *gql.Schema) string {
+	var sb strings.Builder
+
+	specified := map[string]struct{}{}
+	for _, directive := range gql.SpecifiedDirectives {
+		specified[directive.Name] = struct{}{}
+	}
+	for _, directive := range schema.Directives() {
+		if _, p := specified[directive.Name]; p {
+			continue
+		}
+		printDescription(&sb, "", directive.Description)
+		sb.WriteString("directive @" + directive.Name + printArgs(directive.Args) + " on " + strings.Join(directive.Locations, " | ") + "\n\n")
+	}
+
+	typeMap := schema.TypeMap()
+	names := make([]string, 0, len(typeMap))
+	for name := range typeMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		switch t := typeMap[name].(type) {
+		case *gql.Scalar:
+			switch name {
+			case "String", "Int", "Float", "Boolean", "ID":
+				continue
+			}
+			printDescription(&sb, "", t.Description())
+			sb.WriteString("scalar " + name + "\n\n")
+
+		case *gql.Object:
+			printDescription(&sb, "", t.Description())
+			sb.WriteString("type " + name + " {\n")
+			fields := t.Fields()
+			fieldNames := make([]string, 0, len(fields))
+			for fieldName := range fields {
+				fieldNames = append(fieldNames, fieldName)
+			}
+			sort.Strings(fieldNames)
+			for _, fieldName := range fieldNames {
+				field := fields[fieldName]
+				printDescription(&sb, "  ", field.Description)
+				sb.WriteString("  " + fieldName + printArgs(field.Args) + ": " + field.Type.String() + "\n")
+			}
+			sb.WriteString("}\n\n")
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func printArgs(args []*gql.Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	sorted := append([]*gql.Argument{}, args...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].PrivateName < sorted[j].PrivateName })
+
+	arr := make([]string, len(sorted))
+	for i, arg := range sorted {
+		arr[i] = arg.PrivateName + ": " + arg.Type.String()
+	}
+	return "(" + strings.Join(arr, ", ") + ")"
+}
+
+func printDescription(sb *strings.Builder, indent, description string) {
+	if description == "" {
+		return
+	}
+	sb.WriteString(indent + `"""` + strings.ReplaceAll(description, `"""`, `\"""`) + `"""` + "\n")
+}
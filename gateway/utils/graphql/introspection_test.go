@@ -0,0 +1,191 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils/graphql"
+)
+
+type fakeIntrospectionAuth struct {
+	graphql.AuthInterface
+}
+
+func (a *fakeIntrospectionAuth) ParseToken(ctx context.Context, token string) (map[string]interface{}, error) {
+	if token != "project-token" {
+		return nil, errors.New("invalid token")
+	}
+	return map[string]interface{}{"id": "1"}, nil
+}
+
+type fakeIntrospectionAdmin struct{}
+
+func (a *fakeIntrospectionAdmin) IsTokenValid(ctx context.Context, token, resource, op string, attr map[string]string) (model.RequestParams, error) {
+	if token != "admin-token" || resource != "db-schema" || op != "read" || attr["project"] != "project" {
+		return model.RequestParams{}, errors.New("unauthorized")
+	}
+	return model.RequestParams{}, nil
+}
+
+func newIntrospectionModule() *graphql.Module {
+	m := graphql.New(&fakeIntrospectionAuth{}, nil, nil, nil)
+	m.SetAdminManager(&fakeIntrospectionAdmin{})
+	m.SetConfig("project")
+	m.SetDatabaseConfig(config.DatabaseConfigs{"db": &config.DatabaseConfig{DbAlias: "db", Enabled: true}, "mongo": &config.DatabaseConfig{DbAlias: "mongo", Enabled: true}})
+	m.SetDatabaseSchemas(model.Type{
+		"db": model.Collection{
+			"users": model.Fields{
+				"id":      &model.FieldType{FieldName: "id", Kind: model.TypeID, IsFieldTypeRequired: true},
+				"age":     &model.FieldType{FieldName: "age", Kind: model.TypeInteger},
+				"tags":    &model.FieldType{FieldName: "tags", Kind: model.TypeVarChar, IsList: true},
+				"address": &model.FieldType{FieldName: "address", Kind: model.TypeObject, NestedObject: model.Fields{"city": &model.FieldType{FieldName: "city", Kind: model.TypeString}}},
+				"posts":   &model.FieldType{FieldName: "posts", Kind: "posts", IsList: true, IsLinked: true, LinkedTable: &model.TableProperties{Table: "posts", DBType: "db"}},
+			},
+			"posts": model.Fields{
+				"id":    &model.FieldType{FieldName: "id", Kind: model.TypeID, IsFieldTypeRequired: true},
+				"score": &model.FieldType{FieldName: "score", Kind: model.TypeFloat},
+			},
+		},
+	})
+	m.SetPreparedQueries(config.DatabasePreparedQueries{"db-topUsers": &config.DatbasePreparedQuery{ID: "topUsers", DbAlias: "db", Arguments: []string{"args.limit", "auth.id"}}})
+	m.SetRemoteServices(config.Services{"payments": &config.Service{ID: "payments", Endpoints: map[string]*config.Endpoint{"charge": {}}}})
+	return m
+}
+
+func TestModule_Introspection(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "collection type",
+			query: `{ __type(name: "Users") { name fields { name type { kind name ofType { kind name } } } } }`,
+			want:  []string{"Users", "address", "Users_Address", "tags", "LIST", "posts", "Posts", "id", "NON_NULL", "ID"},
+		},
+		{
+			name:  "root fields",
+			query: `query Root { __schema { queryType { fields { name args { name } } } mutationType { fields { name } } } }`,
+			want:  []string{"users", "posts", "topUsers", "limit", "charge", "insert_users", "update_posts", "delete_users"},
+		},
+		{
+			name:  "directives",
+			query: `{ __schema { directives { name } } }`,
+			want:  []string{"db", "mongo", "payments", "aggregate", "template"},
+		},
+		{
+			name:    "invalid introspection query",
+			query:   `{ __type(name: "Users") { unknown } }`,
+			wantErr: true,
+		},
+	}
+
+	m := newIntrospectionModule()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result interface{}
+			var err error
+			m.ExecGraphQLQuery(context.Background(), &model.GraphQLRequest{Query: tt.query}, "project-token", func(op interface{}, e error) {
+				result, err = op, e
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExecGraphQLQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			str := toString(result)
+			for _, want := range tt.want {
+				if !strings.Contains(str, want) {
+					t.Errorf("ExecGraphQLQuery() result = %v, want it to contain %v", str, want)
+				}
+			}
+		})
+	}
+}
+
+func TestModule_Introspection_authorisation(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "token of the project", token: "project-token"},
+		{name: "admin token", token: "admin-token"},
+		{name: "no token", token: "", wantErr: true},
+		{name: "invalid token", token: "invalid", wantErr: true},
+	}
+
+	m := newIntrospectionModule()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result interface{}
+			var err error
+			m.ExecGraphQLQuery(context.Background(), &model.GraphQLRequest{Query: `{ __schema { queryType { name } } }`}, tt.token, func(op interface{}, e error) {
+				result, err = op, e
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExecGraphQLQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && result != nil {
+				t.Errorf("ExecGraphQLQuery() result = %v, want no schema to be returned", result)
+			}
+		})
+	}
+}
+
+func TestModule_GetSDL(t *testing.T) {
+	m := newIntrospectionModule()
+	sdl, err := m.GetSDL(context.Background())
+	if err != nil {
+		t.Fatalf("GetSDL() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"directive @db(cache: JSON, col: String) on FIELD",
+		"directive @payments(func: String, timeout: Int) on FIELD",
+		"scalar JSON",
+		"type Users {\n",
+		"  posts: [Posts]\n",
+		"  id: ID!\n",
+		"  users(cursor: String, debug: Boolean, distinct: String, group: [String], join: [JSON], limit: Int, op: String, returnType: String, skip: Int, sort: [String], where: JSON): [Users]\n",
+		"  topUsers(debug: Boolean, limit: JSON): [JSON]\n",
+		"  insert_users(docs: [JSON]): MutationResponse\n",
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("GetSDL() = %v, want it to contain %q", sdl, want)
+		}
+	}
+
+	// The schema is regenerated once the config changes
+	m.SetRemoteServices(nil)
+	sdl, _ = m.GetSDL(context.Background())
+	if strings.Contains(sdl, "payments") {
+		t.Errorf("GetSDL() after removing remote services = %v", sdl)
+	}
+}
+
+func toString(v interface{}) string {
+	var sb strings.Builder
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			for key, value := range t {
+				sb.WriteString(key + " ")
+				walk(value)
+			}
+		case []interface{}:
+			for _, value := range t {
+				walk(value)
+			}
+		case string:
+			sb.WriteString(t + " ")
+		}
+	}
+	walk(v)
+	return sb.String()
+}
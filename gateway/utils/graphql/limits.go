@@ -0,0 +1,292 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// defaultListSize is the number of items assumed for lists without a limit argument when not configured
+const defaultListSize = 10
+
+// maxListSize caps the number of items assumed for a list so that huge limits can't overflow the cost
+const maxListSize = 10000
+
+// maxQueryCost is the cost at which the analysis saturates
+const maxQueryCost = math.MaxInt32
+
+var (
+	// ErrQueryTooComplex is returned for queries which are nested too deep or are too expensive to execute
+	ErrQueryTooComplex = errors.New("query is too complex")
+
+	// ErrRateLimited is returned once the rate limit of the client has been exhausted
+	ErrRateLimited = errors.New("rate limit exceeded")
+)
+
+// SetQueryLimits sets the limits enforced on queries before they get executed
+func (graph *Module) SetQueryLimits(limits *config.GraphQLLimits) {
+	graph.limitsLock.Lock()
+	defer graph.limitsLock.Unlock()
+
+	graph.limits = limits
+}
+
+// SetRateLimiter sets the token buckets used to rate limit queries. The buckets are shared by all gateways
+func (graph *Module) SetRateLimiter(rateLimiter RateLimiterInterface) {
+	graph.limitsLock.Lock()
+	defer graph.limitsLock.Unlock()
+
+	graph.rateLimiter = rateLimiter
+}
+
+// CloseConfig closes the connection used for rate limiting
+func (graph *Module) CloseConfig() {
+	graph.limitsLock.Lock()
+	defer graph.limitsLock.Unlock()
+
+	if graph.rateLimiter != nil {
+		graph.rateLimiter.Close()
+		graph.rateLimiter = nil
+	}
+}
+
+// checkQueryLimits rejects queries which exceed the depth or cost configured for the project and then applies the
+// rate limits on the client
+func (graph *Module) checkQueryLimits(ctx context.Context, doc *ast.Document, req *model.GraphQLRequest, token string) error {
+	graph.limitsLock.RLock()
+	limits, rateLimiter := graph.limits, graph.rateLimiter
+	graph.limitsLock.RUnlock()
+
+	if limits == nil {
+		return nil
+	}
+
+	depth, cost := graph.analyzeQuery(doc, req, limits)
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return fmt.Errorf("%w: depth (%d) is more than the maximum depth (%d) allowed", ErrQueryTooComplex, depth, limits.MaxDepth)
+	}
+	if limits.MaxCost > 0 && cost > limits.MaxCost {
+		return fmt.Errorf("%w: cost (%d) is more than the maximum cost (%d) allowed", ErrQueryTooComplex, cost, limits.MaxCost)
+	}
+
+	if len(limits.RateLimits) == 0 || rateLimiter == nil {
+		return nil
+	}
+
+	// Requests with an invalid token share the bucket of anonymous requests
+	var claims map[string]interface{}
+	if token != "" {
+		claims, _ = graph.auth.ParseToken(ctx, token)
+	}
+
+	for i, rateLimit := range limits.RateLimits {
+		value := "anonymous"
+		if v, p := claims[rateLimit.Claim]; p {
+			value = fmt.Sprintf("%v", v)
+		}
+
+		tokens := 1
+		if rateLimit.UseCost && cost > 1 {
+			tokens = cost
+		}
+		if tokens > rateLimit.Burst {
+			return fmt.Errorf("%w: cost (%d) of the query is more than the burst (%d) of the rate limit on claim (%s)", ErrRateLimited, tokens, rateLimit.Burst, rateLimit.Claim)
+		}
+
+		// Rate limiting is skipped rather than failing the request if the buckets can't be reached
+		allowed, wait, err := rateLimiter.TakeTokens(ctx, fmt.Sprintf("graphql-%d-%s-%s", i, rateLimit.Claim, value), tokens, rateLimit.Rate, rateLimit.Burst)
+		if err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to apply graphql rate limit", err, map[string]interface{}{"claim": rateLimit.Claim})
+			continue
+		}
+		if !allowed {
+			return fmt.Errorf("%w: retry after %v", ErrRateLimited, wait)
+		}
+	}
+	return nil
+}
+
+type queryAnalysis struct {
+	graph     *Module
+	limits    *config.GraphQLLimits
+	store     utils.M
+	fragments map[string]*ast.FragmentDefinition
+}
+
+// analyzeQuery returns the depth and cost of the operation to be executed. Every field which triggers a database read
+// or a remote call weighs 1 by default, while the cost of the fields of a list gets multiplied by its size
+func (graph *Module) analyzeQuery(doc *ast.Document, req *model.GraphQLRequest, limits *config.GraphQLLimits) (int, int) {
+	a := &queryAnalysis{graph: graph, limits: limits, store: utils.M{"vars": req.Variables}, fragments: map[string]*ast.FragmentDefinition{}}
+
+	var op *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch d := def.(type) {
+		case *ast.FragmentDefinition:
+			a.fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if op != nil {
+				continue
+			}
+			if req.OperationName == "" || (d.Name != nil && d.Name.Value == req.OperationName) {
+				op = d
+			}
+		}
+	}
+	if op == nil {
+		return 0, 0
+	}
+
+	return a.selectionSet(op.SelectionSet, 0, "", nil, map[string]bool{})
+}
+
+func (a *queryAnalysis) selectionSet(set *ast.SelectionSet, depth int, parent string, schema model.Fields, visited map[string]bool) (int, int) {
+	if set == nil {
+		return depth, 0
+	}
+
+	maxDepth, cost := depth, 0
+	for _, sel := range set.Selections {
+		var d, c int
+		switch s := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			d, c = a.field(s, depth+1, parent, schema, visited)
+
+		case *ast.InlineFragment:
+			d, c = a.selectionSet(s.SelectionSet, depth, parent, schema, visited)
+
+		case *ast.FragmentSpread:
+			fragment, p := a.fragments[s.Name.Value]
+			if !p || visited[s.Name.Value] {
+				continue
+			}
+			visited[s.Name.Value] = true
+			d, c = a.selectionSet(fragment.SelectionSet, depth, parent, schema, visited)
+			delete(visited, s.Name.Value)
+		}
+
+		if d > maxDepth {
+			maxDepth = d
+		}
+		cost = addCost(cost, c)
+	}
+	return maxDepth, cost
+}
+
+func (a *queryAnalysis) field(field *ast.Field, depth int, parent string, schema model.Fields, visited map[string]bool) (int, int) {
+	name := field.Name.Value
+	weight, isList := 0, false
+	var childSchema model.Fields
+
+	switch {
+	case len(field.Directives) > 0 && field.Directives[0].Name.Value != "aggregate":
+		// Fields with a directive are database queries, prepared queries, remote calls or mutations. Nested ones are
+		// executed for every item of their parent
+		weight = 1
+		isList = field.SelectionSet != nil && !(parent == "" && isMutationField(name))
+		if col, err := getCollection(field); err == nil && a.graph.schema != nil {
+			childSchema, _ = a.graph.schema.GetSchema(field.Directives[0].Name.Value, col)
+		}
+
+	case schema != nil && schema[name] != nil:
+		fieldType := schema[name]
+		isList = fieldType.IsList
+		if fieldType.IsLinked && fieldType.LinkedTable != nil {
+			weight = 1
+			if a.graph.schema != nil {
+				childSchema, _ = a.graph.schema.GetSchema(fieldType.LinkedTable.DBType, fieldType.LinkedTable.Table)
+			}
+		} else if fieldType.Kind == model.TypeObject {
+			childSchema = fieldType.NestedObject
+		}
+	}
+
+	if w, p := a.limits.FieldWeights[parent+"."+name]; p && parent != "" {
+		weight = w
+	} else if w, p := a.limits.FieldWeights[name]; p {
+		weight = w
+	}
+
+	childDepth, childCost := a.selectionSet(field.SelectionSet, depth, name, childSchema, visited)
+	if isList {
+		childCost = mulCost(childCost, a.listSize(field))
+	}
+	return childDepth, addCost(weight, childCost)
+}
+
+// listSize returns the number of items expected in the list returned by the field. The limit may be provided
+// as an argument of the field or of its db directive
+func (a *queryAnalysis) listSize(field *ast.Field) int {
+	args := field.Arguments
+	for _, directive := range field.Directives {
+		args = append(args, directive.Arguments...)
+	}
+
+	for _, arg := range args {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		value, err := utils.ParseGraphqlValue(arg.Value, a.store)
+		if err != nil {
+			break
+		}
+		switch v := value.(type) {
+		case int:
+			return clampListSize(int64(v))
+		case float64:
+			return clampListSize(int64(math.Min(v, maxListSize)))
+		}
+	}
+
+	if a.limits.DefaultListSize > 0 {
+		return clampListSize(int64(a.limits.DefaultListSize))
+	}
+	return defaultListSize
+}
+
+func clampListSize(size int64) int {
+	if size < 0 {
+		return 0
+	}
+	if size > maxListSize {
+		return maxListSize
+	}
+	return int(size)
+}
+
+// addCost adds two costs saturating at maxQueryCost
+func addCost(a, b int) int {
+	if a < 0 || b < 0 {
+		return 0
+	}
+	if a > maxQueryCost-b {
+		return maxQueryCost
+	}
+	return a + b
+}
+
+// mulCost multiplies a cost by the size of a list saturating at maxQueryCost
+func mulCost(cost, size int) int {
+	if cost <= 0 || size <= 0 {
+		return 0
+	}
+	if cost > maxQueryCost/size {
+		return maxQueryCost
+	}
+	return cost * size
+}
+
+func isMutationField(name string) bool {
+	return strings.HasPrefix(name, "insert_") || strings.HasPrefix(name, "update_") || strings.HasPrefix(name, "delete_")
+}
@@ -0,0 +1,253 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+type fakeLimitsSchema map[string]model.Fields
+
+func (s fakeLimitsSchema) GetSchema(dbAlias, col string) (model.Fields, bool) {
+	fields, p := s[dbAlias+"."+col]
+	return fields, p
+}
+
+type fakeLimitsAuth struct {
+	AuthInterface
+}
+
+func (a *fakeLimitsAuth) ParseToken(ctx context.Context, token string) (map[string]interface{}, error) {
+	if token == "invalid" {
+		return nil, errors.New("invalid token")
+	}
+	return map[string]interface{}{"id": token}, nil
+}
+
+type fakeRateLimiter struct {
+	buckets map[string]int
+	keys    []string
+	err     error
+}
+
+func (r *fakeRateLimiter) TakeTokens(ctx context.Context, key string, tokens int, rate float64, burst int) (bool, time.Duration, error) {
+	if r.err != nil {
+		return false, 0, r.err
+	}
+	r.keys = append(r.keys, key)
+	if _, p := r.buckets[key]; !p {
+		r.buckets[key] = burst
+	}
+	if r.buckets[key] < tokens {
+		return false, time.Second, nil
+	}
+	r.buckets[key] -= tokens
+	return true, 0, nil
+}
+
+func (r *fakeRateLimiter) Close() {}
+
+func newLimitsTestModule() *Module {
+	schema := fakeLimitsSchema{
+		"db.users": model.Fields{
+			"id":      &model.FieldType{FieldName: "id", Kind: model.TypeID},
+			"address": &model.FieldType{FieldName: "address", Kind: model.TypeObject, NestedObject: model.Fields{"city": &model.FieldType{FieldName: "city", Kind: model.TypeString}}},
+			"posts":   &model.FieldType{FieldName: "posts", Kind: "posts", IsList: true, IsLinked: true, LinkedTable: &model.TableProperties{DBType: "db", Table: "posts"}},
+		},
+		"db.posts": model.Fields{
+			"id":     &model.FieldType{FieldName: "id", Kind: model.TypeID},
+			"author": &model.FieldType{FieldName: "author", Kind: "users", IsLinked: true, LinkedTable: &model.TableProperties{DBType: "db", Table: "users"}},
+		},
+	}
+	return New(&fakeLimitsAuth{}, nil, nil, schema)
+}
+
+func Test_analyzeQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		vars      map[string]interface{}
+		limits    *config.GraphQLLimits
+		wantDepth int
+		wantCost  int
+	}{
+		{
+			name:      "leaf fields of a list",
+			query:     `query { users @db { id address { city } } }`,
+			limits:    &config.GraphQLLimits{},
+			wantDepth: 3,
+			wantCost:  1,
+		},
+		{
+			name:      "linked list multiplied by the default list size",
+			query:     `query { users @db { id posts { id } } }`,
+			limits:    &config.GraphQLLimits{},
+			wantDepth: 3,
+			wantCost:  1 + defaultListSize*1,
+		},
+		{
+			name:      "limits from arguments and variables",
+			query:     `query($l: Int) { users @db(limit: 2) { posts(limit: $l) { id author { id } } } }`,
+			vars:      map[string]interface{}{"l": 5},
+			limits:    &config.GraphQLLimits{},
+			wantDepth: 4,
+			wantCost:  1 + 2*(1+5*1),
+		},
+		{
+			name:      "limit argument of the top level field",
+			query:     `query { users @db(limit: 3) { posts(limit: 4) { id } } }`,
+			limits:    &config.GraphQLLimits{DefaultListSize: 50},
+			wantDepth: 3,
+			wantCost:  1 + 3*1,
+		},
+		{
+			name:      "field weights and fragments",
+			query:     `query { users @db(limit: 3) { ...f } } fragment f on users { id address { city } }`,
+			limits:    &config.GraphQLLimits{FieldWeights: map[string]int{"users": 5, "address.city": 2}},
+			wantDepth: 3,
+			wantCost:  5 + 3*2,
+		},
+		{
+			name:      "negative limits are treated as empty lists",
+			query:     `query($l: Int) { users @db(limit: -5) { posts(limit: $l) { id } } }`,
+			vars:      map[string]interface{}{"l": -3},
+			limits:    &config.GraphQLLimits{},
+			wantDepth: 3,
+			wantCost:  1,
+		},
+		{
+			name:      "huge limits saturate the cost",
+			query:     `query { users @db(limit: 2147483647) { posts(limit: 2147483647) { author { posts(limit: 2147483647) { author { posts(limit: 2147483647) { id } } } } } } }`,
+			limits:    &config.GraphQLLimits{},
+			wantDepth: 7,
+			wantCost:  maxQueryCost,
+		},
+		{
+			name:      "nested joins with directives",
+			query:     `query { users @db(limit: 2) { id orders @db(where: {user_id: "users.id"}) { id items @db(limit: 3) { id } } pay @payments { id } } }`,
+			limits:    &config.GraphQLLimits{},
+			wantDepth: 4,
+			wantCost:  1 + 2*((1+defaultListSize*1)+1),
+		},
+		{
+			name:      "mutations and remote calls",
+			query:     `mutation { insert_users @db(docs: []) { status } delete_posts @db { status } }`,
+			limits:    &config.GraphQLLimits{},
+			wantDepth: 2,
+			wantCost:  2,
+		},
+		{
+			name:      "introspection fields are ignored",
+			query:     `query { __schema { types { fields { type { ofType { name } } } } } }`,
+			limits:    &config.GraphQLLimits{},
+			wantDepth: 0,
+			wantCost:  0,
+		},
+	}
+
+	m := newLimitsTestModule()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(tt.query)})})
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			depth, cost := m.analyzeQuery(doc, &model.GraphQLRequest{Query: tt.query, Variables: tt.vars}, tt.limits)
+			if depth != tt.wantDepth || cost != tt.wantCost {
+				t.Errorf("analyzeQuery() = (%v, %v), want (%v, %v)", depth, cost, tt.wantDepth, tt.wantCost)
+			}
+		})
+	}
+}
+
+func TestModule_checkQueryLimits(t *testing.T) {
+	const query = `query { users @db { id posts { id } } }`
+	tests := []struct {
+		name     string
+		limits   *config.GraphQLLimits
+		tokens   []string
+		limitErr error
+		wantErrs []error
+		wantKeys []string
+	}{
+		{
+			name:     "no limits",
+			tokens:   []string{""},
+			wantErrs: []error{nil},
+		},
+		{
+			name:     "too deep",
+			limits:   &config.GraphQLLimits{MaxDepth: 2},
+			tokens:   []string{""},
+			wantErrs: []error{ErrQueryTooComplex},
+		},
+		{
+			name:     "too expensive",
+			limits:   &config.GraphQLLimits{MaxDepth: 3, MaxCost: 10},
+			tokens:   []string{""},
+			wantErrs: []error{ErrQueryTooComplex},
+		},
+		{
+			name:     "rate limited per claim",
+			limits:   &config.GraphQLLimits{RateLimits: []*config.GraphQLRateLimit{{Claim: "id", Rate: 1, Burst: 2}}},
+			tokens:   []string{"1", "1", "2", "1", "", "invalid", "invalid"},
+			wantErrs: []error{nil, nil, nil, ErrRateLimited, nil, nil, ErrRateLimited},
+			wantKeys: []string{"graphql-0-id-1", "graphql-0-id-1", "graphql-0-id-2", "graphql-0-id-1", "graphql-0-id-anonymous", "graphql-0-id-anonymous", "graphql-0-id-anonymous"},
+		},
+		{
+			name:     "rate limited by cost",
+			limits:   &config.GraphQLLimits{RateLimits: []*config.GraphQLRateLimit{{Claim: "id", Rate: 1, Burst: 25, UseCost: true}}},
+			tokens:   []string{"1", "1", "1"},
+			wantErrs: []error{nil, nil, ErrRateLimited},
+		},
+		{
+			name:     "cost more than burst",
+			limits:   &config.GraphQLLimits{RateLimits: []*config.GraphQLRateLimit{{Claim: "id", Rate: 1, Burst: 5, UseCost: true}}},
+			tokens:   []string{"1"},
+			wantErrs: []error{ErrRateLimited},
+		},
+		{
+			name:     "requests are allowed if the buckets can't be reached",
+			limits:   &config.GraphQLLimits{RateLimits: []*config.GraphQLRateLimit{{Claim: "id", Rate: 1, Burst: 1}}},
+			limitErr: errors.New("redis is down"),
+			tokens:   []string{"1", "1"},
+			wantErrs: []error{nil, nil},
+		},
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(query)})})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := &fakeRateLimiter{buckets: map[string]int{}, err: tt.limitErr}
+			m := newLimitsTestModule()
+			m.SetQueryLimits(tt.limits)
+			m.SetRateLimiter(limiter)
+
+			for i, token := range tt.tokens {
+				err := m.checkQueryLimits(context.Background(), doc, &model.GraphQLRequest{Query: query}, token)
+				if !errors.Is(err, tt.wantErrs[i]) || (tt.wantErrs[i] == nil && err != nil) {
+					t.Errorf("checkQueryLimits() request %d error = %v, want %v", i, err, tt.wantErrs[i])
+				}
+			}
+			if tt.wantKeys != nil && len(limiter.keys) != len(tt.wantKeys) {
+				t.Fatalf("checkQueryLimits() used buckets %v, want %v", limiter.keys, tt.wantKeys)
+			}
+			for i, key := range tt.wantKeys {
+				if limiter.keys[i] != key {
+					t.Errorf("checkQueryLimits() used bucket %v for request %d, want %v", limiter.keys[i], i, key)
+				}
+			}
+		})
+	}
+}
@@ -78,7 +78,8 @@ func (graph *Module) execAllReq(ctx context.Context, dbAlias, project string, re
 		}
 	}
 	params.Resource = "db-batch"
-	return map[string]interface{}{"status": 200, "error": nil}, graph.crud.Batch(ctx, dbAlias, req, params)
+	_, err := graph.crud.Batch(ctx, dbAlias, req, params)
+	return map[string]interface{}{"status": 200, "error": nil}, err
 }
 
 func (graph *Module) handleMutation(ctx context.Context, node ast.Node, token string, store utils.M, cb model.GraphQLCallback) {
@@ -0,0 +1,180 @@
+package graphql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// maxAutomaticPersistedQueries is the maximum number of automatically persisted queries kept in memory
+const maxAutomaticPersistedQueries = 1000
+
+var (
+	// ErrPersistedQueryNotFound is returned when the hash of a persisted query is not known. Clients are expected to
+	// retry the request along with the complete query
+	ErrPersistedQueryNotFound = errors.New("PersistedQueryNotFound")
+
+	// ErrQueryNotAllowed is returned for queries which haven't been registered when the allow list is enabled
+	ErrQueryNotAllowed = errors.New("query has not been registered for this project")
+)
+
+type registeredQuery struct {
+	id    string
+	query string
+}
+
+// SetQueryAllowList enables or disables the allow list. Only registered queries are accepted once it is enabled
+func (graph *Module) SetQueryAllowList(enabled bool) {
+	graph.persistedLock.Lock()
+	defer graph.persistedLock.Unlock()
+
+	graph.allowList = enabled
+}
+
+// SetPersistedQueries sets the queries registered for the graphql endpoint
+func (graph *Module) SetPersistedQueries(queries config.GraphQLQueries) error {
+	byHash := make(map[string]*registeredQuery, len(queries))
+	byDocument := make(map[string]*registeredQuery, len(queries))
+	for _, q := range queries {
+		document, err := printQuery(q.Query)
+		if err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid graphql query (%s) provided", q.ID), err, nil)
+		}
+
+		registered := &registeredQuery{id: q.ID, query: q.Query}
+		byHash[hashQuery(q.Query)] = registered
+		byDocument[document] = registered
+	}
+
+	graph.persistedLock.Lock()
+	defer graph.persistedLock.Unlock()
+
+	graph.registeredByHash = byHash
+	graph.registeredByDocument = byDocument
+	return nil
+}
+
+// ResolveQuery loads the query of requests which refer to a persisted query by its hash. It also rejects queries
+// which haven't been registered when the allow list is enabled
+func (graph *Module) ResolveQuery(ctx context.Context, req *model.GraphQLRequest) error {
+	graph.persistedLock.RLock()
+	allowList := graph.allowList
+	graph.persistedLock.RUnlock()
+
+	if req.Extensions != nil && req.Extensions.PersistedQuery != nil {
+		persisted := req.Extensions.PersistedQuery
+		if persisted.Version != 1 {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unsupported persisted query version (%d) provided", persisted.Version), nil, nil)
+		}
+		hash := strings.ToLower(persisted.Sha256Hash)
+
+		// Only the hash has been sent
+		if req.Query == "" {
+			query, ok := graph.getPersistedQuery(hash, allowList)
+			if !ok {
+				if allowList {
+					return ErrQueryNotAllowed
+				}
+				return ErrPersistedQueryNotFound
+			}
+			req.Query = query
+			return nil
+		}
+
+		if hashQuery(req.Query) != hash {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Provided sha256 hash does not match the query", nil, nil)
+		}
+
+		// Persist the query so that subsequent requests can skip sending it
+		if !allowList {
+			graph.storeAutomaticPersistedQuery(hash, req.Query)
+			return nil
+		}
+	}
+
+	if !allowList {
+		return nil
+	}
+
+	graph.persistedLock.RLock()
+	_, ok := graph.registeredByHash[hashQuery(req.Query)]
+	graph.persistedLock.RUnlock()
+	if ok {
+		return nil
+	}
+
+	// Queries which only differ in formatting from a registered query are accepted as well
+	document, err := printQuery(req.Query)
+	if err != nil {
+		return err
+	}
+	graph.persistedLock.RLock()
+	_, ok = graph.registeredByDocument[document]
+	graph.persistedLock.RUnlock()
+	if !ok {
+		return ErrQueryNotAllowed
+	}
+	return nil
+}
+
+func (graph *Module) getPersistedQuery(hash string, onlyRegistered bool) (string, bool) {
+	graph.persistedLock.RLock()
+	defer graph.persistedLock.RUnlock()
+
+	if registered, p := graph.registeredByHash[hash]; p {
+		return registered.query, true
+	}
+	if onlyRegistered {
+		return "", false
+	}
+	query, p := graph.automaticQueries[hash]
+	return query, p
+}
+
+func (graph *Module) storeAutomaticPersistedQuery(hash, query string) {
+	graph.persistedLock.Lock()
+	defer graph.persistedLock.Unlock()
+
+	if _, p := graph.automaticQueries[hash]; p {
+		return
+	}
+	if graph.automaticQueries == nil {
+		graph.automaticQueries = map[string]string{}
+	}
+
+	// Evict an arbitrary query once the limit is reached. Clients simply resend the complete query for evicted ones
+	if len(graph.automaticQueries) >= maxAutomaticPersistedQueries {
+		for key := range graph.automaticQueries {
+			delete(graph.automaticQueries, key)
+			break
+		}
+	}
+	graph.automaticQueries[hash] = query
+}
+
+// hashQuery returns the hex encoded sha256 hash of the query as used by automatic persisted queries
+func hashQuery(query string) string {
+	hash := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(hash[:])
+}
+
+// printQuery returns the query in a canonical format so that queries differing only in formatting can be matched
+func printQuery(query string) (string, error) {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(query)})})
+	if err != nil {
+		return "", err
+	}
+	document, _ := printer.Print(doc).(string)
+	return document, nil
+}
@@ -0,0 +1,123 @@
+package graphql_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils/graphql"
+)
+
+func TestModule_ResolveQuery(t *testing.T) {
+	const registered = `query { users @db { id } }`
+	const other = `query { posts @db { id } }`
+	hash := func(query string) string {
+		sum := sha256.Sum256([]byte(query))
+		return hex.EncodeToString(sum[:])
+	}
+	persisted := func(query, sha string) *model.GraphQLRequest {
+		return &model.GraphQLRequest{Query: query, Extensions: &model.GraphQLExtensions{PersistedQuery: &model.PersistedQuery{Version: 1, Sha256Hash: sha}}}
+	}
+
+	type step struct {
+		req       *model.GraphQLRequest
+		wantQuery string
+		wantErr   error
+		anyErr    bool
+	}
+	tests := []struct {
+		name      string
+		allowList bool
+		steps     []step
+	}{
+		{
+			name: "plain queries are accepted without allow list",
+			steps: []step{
+				{req: &model.GraphQLRequest{Query: other}, wantQuery: other},
+			},
+		},
+		{
+			name: "registered query is loaded by its hash",
+			steps: []step{
+				{req: persisted("", hash(registered)), wantQuery: registered},
+			},
+		},
+		{
+			name: "unknown hash is persisted automatically on retry",
+			steps: []step{
+				{req: persisted("", hash(other)), wantErr: graphql.ErrPersistedQueryNotFound},
+				{req: persisted(other, hash(other)), wantQuery: other},
+				{req: persisted("", hash(other)), wantQuery: other},
+			},
+		},
+		{
+			name: "hash not matching the query",
+			steps: []step{
+				{req: persisted(other, hash(registered)), anyErr: true},
+				{req: persisted("", hash(registered)), wantQuery: registered},
+			},
+		},
+		{
+			name:      "allow list rejects unregistered queries",
+			allowList: true,
+			steps: []step{
+				{req: &model.GraphQLRequest{Query: other}, wantErr: graphql.ErrQueryNotAllowed},
+				{req: persisted(other, hash(other)), wantErr: graphql.ErrQueryNotAllowed},
+				{req: persisted("", hash(other)), wantErr: graphql.ErrQueryNotAllowed},
+				{req: &model.GraphQLRequest{Query: `{ __schema { types { name } } }`}, wantErr: graphql.ErrQueryNotAllowed},
+			},
+		},
+		{
+			name:      "allow list accepts registered queries",
+			allowList: true,
+			steps: []step{
+				{req: &model.GraphQLRequest{Query: registered}, wantQuery: registered},
+				{req: &model.GraphQLRequest{Query: "query {\n  users @db {\n    id\n  }\n}"}, wantQuery: "query {\n  users @db {\n    id\n  }\n}"},
+				{req: persisted("", hash(registered)), wantQuery: registered},
+			},
+		},
+		{
+			name: "unsupported version",
+			steps: []step{
+				{req: &model.GraphQLRequest{Extensions: &model.GraphQLExtensions{PersistedQuery: &model.PersistedQuery{Version: 2, Sha256Hash: hash(registered)}}}, anyErr: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := graphql.New(nil, nil, nil, nil)
+			m.SetQueryAllowList(tt.allowList)
+			if err := m.SetPersistedQueries(config.GraphQLQueries{"users": &config.GraphQLQuery{ID: "users", Query: registered}}); err != nil {
+				t.Fatalf("SetPersistedQueries() error = %v", err)
+			}
+
+			for i, s := range tt.steps {
+				err := m.ResolveQuery(context.Background(), s.req)
+				if s.anyErr || s.wantErr != nil {
+					if err == nil || (s.wantErr != nil && err != s.wantErr) {
+						t.Errorf("ResolveQuery() step %d error = %v, want %v", i, err, s.wantErr)
+					}
+					continue
+				}
+				if err != nil {
+					t.Errorf("ResolveQuery() step %d error = %v", i, err)
+					continue
+				}
+				if s.req.Query != s.wantQuery {
+					t.Errorf("ResolveQuery() step %d query = %v, want %v", i, s.req.Query, s.wantQuery)
+				}
+			}
+		})
+	}
+}
+
+func TestModule_SetPersistedQueries(t *testing.T) {
+	m := graphql.New(nil, nil, nil, nil)
+	if err := m.SetPersistedQueries(config.GraphQLQueries{"invalid": &config.GraphQLQuery{ID: "invalid", Query: "query { users"}}); err == nil {
+		t.Errorf("SetPersistedQueries() with invalid query error = nil")
+	}
+}
@@ -147,6 +147,11 @@ func (graph *Module) execReadRequest(ctx context.Context, field *ast.Field, toke
 			val.(*utils.Array).Append(structs.Map(metaData))
 		}
 
+		// The page info is generated before post processing removes or masks the sort fields the cursor is made of
+		if val, p := store["_pageInfo"]; p && len(req.Options.Sort) > 0 {
+			val.(*utils.Object).Set(getFieldName(field), generatePageInfo(graph.crud.GenerateCursor(dbAlias, col, req, result), req.Options.Limit, result))
+		}
+
 		// Post process only if joins were not enabled
 		if isPostProcessingEnabled(req.PostProcess) && len(req.Options.Join) == 0 {
 			_ = authHelpers.PostProcessMethod(ctx, graph.aesKey, req.PostProcess[col], result)
@@ -156,6 +161,21 @@ func (graph *Module) execReadRequest(ctx context.Context, field *ast.Field, toke
 	}()
 }
 
+// generatePageInfo generates relay style page info for the result of a read request. A page which
+// is as big as the limit is assumed to be followed by another one
+func generatePageInfo(cursor string, limit *int64, result interface{}) map[string]interface{} {
+	pageInfo := map[string]interface{}{"endCursor": nil, "hasNextPage": false}
+	if cursor == "" {
+		return pageInfo
+	}
+
+	pageInfo["endCursor"] = cursor
+	if docs, ok := result.([]interface{}); ok && limit != nil {
+		pageInfo["hasNextPage"] = int64(len(docs)) == *limit
+	}
+	return pageInfo
+}
+
 func isDataLoaderDisabled(ctx context.Context, field *ast.Field, store utils.M) (bool, error) {
 	for _, arg := range field.Arguments {
 		switch arg.Name.Value {
@@ -288,7 +308,7 @@ func generateArguments(ctx context.Context, field *ast.Field, store utils.M) map
 	obj := map[string]interface{}{}
 	for _, arg := range field.Arguments {
 		switch arg.Name.Value {
-		case "where", "group", "skip", "limit", "sort", "distinct": // read & delete
+		case "where", "group", "skip", "limit", "sort", "distinct", "cursor": // read & delete
 			continue
 		case "op", "set", "inc", "mul", "max", "min", "currentTimestamp", "currentDate", "push", "rename", "unset": // update
 			continue
@@ -635,6 +655,12 @@ func ExtractWhereClause(args []*ast.Argument, store utils.M) (map[string]interfa
 	return utils.M{}, nil
 }
 
+// ExtractReadOptions returns the read options (sort, limit, join, etc.) provided in the args of graphql schema
+func ExtractReadOptions(ctx context.Context, args []*ast.Argument, store utils.M) (*model.ReadOptions, error) {
+	options, _, err := generateOptions(ctx, args, store)
+	return options, err
+}
+
 func generateCacheOptions(ctx context.Context, directives []*ast.Directive, store utils.M) (*config.ReadCacheOptions, error) {
 	for _, directive := range directives {
 		for _, argument := range directive.Arguments {
@@ -787,6 +813,20 @@ func generateOptions(ctx context.Context, args []*ast.Argument, store utils.M) (
 			}
 
 			options.Distinct = &tempString
+		case "cursor":
+			hasOptions = true // Set the flag to true
+
+			temp, err := utils.ParseGraphqlValue(v.Value, store)
+			if err != nil {
+				return nil, hasOptions, err
+			}
+
+			cursor, ok := temp.(string)
+			if !ok {
+				return nil, hasOptions, fmt.Errorf("invalid type (%s) for cursor", reflect.TypeOf(temp))
+			}
+			options.Cursor = cursor
+
 		case "debug":
 			hasOptions = true // Set the flag to true
 
@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"context"
+	"time"
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
 )
@@ -12,7 +13,8 @@ type CrudInterface interface {
 	Read(ctx context.Context, dbAlias, collection string, request *model.ReadRequest, params model.RequestParams) (interface{}, *model.SQLMetaData, error)
 	Update(ctx context.Context, dbAlias, collection string, request *model.UpdateRequest, params model.RequestParams) error
 	Delete(ctx context.Context, dbAlias, collection string, request *model.DeleteRequest, params model.RequestParams) error
-	Batch(ctx context.Context, dbAlias string, req *model.BatchRequest, params model.RequestParams) error
+	Batch(ctx context.Context, dbAlias string, req *model.BatchRequest, params model.RequestParams) ([]interface{}, error)
+	GenerateCursor(dbAlias, col string, req *model.ReadRequest, result interface{}) string
 	GetDBType(dbAlias string) (string, error)
 	IsPreparedQueryPresent(directive, fieldName string) bool
 	ExecPreparedQuery(ctx context.Context, dbAlias, id string, req *model.PreparedQueryRequest, params model.RequestParams) (interface{}, *model.SQLMetaData, error)
@@ -29,12 +31,24 @@ type AuthInterface interface {
 	IsPreparedQueryAuthorised(ctx context.Context, project, dbAlias, id, token string, req *model.PreparedQueryRequest) (*model.PostProcess, model.RequestParams, error)
 }
 
+// AdminInterface is an interface consisting of functions of admin manager used by graphql module
+type AdminInterface interface {
+	IsTokenValid(ctx context.Context, token, resource, op string, attr map[string]string) (model.RequestParams, error)
+}
+
 // FunctionInterface is an interface consisting of functions of function module used by graphql module
 type FunctionInterface interface {
 	CallWithContext(ctx context.Context, service, function, token string, reqParams model.RequestParams, req *model.FunctionsRequest) (int, interface{}, error)
+	ApplyRateLimits(ctx context.Context, service, function string, args map[string]interface{}) *model.RateLimitStatus
 }
 
 // SchemaInterface is an interface consisting of functions of schema module used by graphql module
 type SchemaInterface interface {
 	GetSchema(dbAlias, col string) (model.Fields, bool)
 }
+
+// RateLimiterInterface is an interface consisting of functions of pubsub module used by graphql module to rate limit queries
+type RateLimiterInterface interface {
+	TakeTokens(ctx context.Context, key string, tokens int, rate float64, burst int) (bool, time.Duration, error)
+	Close()
+}
@@ -1820,6 +1820,88 @@ var queryTestCases = []tests{
 		wantErr:    false,
 		wantResult: map[string]interface{}{"trainers": []interface{}{map[string]interface{}{"id": "1", "name": "ash"}, map[string]interface{}{"id": "2", "name": "james"}}},
 	},
+	{
+		name: "Query: Cursor is generated before post processing removes the sort field",
+		crudMockArgs: []mockArgs{
+			{
+				method:         "GetDBType",
+				args:           []interface{}{"db"},
+				paramsReturned: []interface{}{"postgres", nil},
+			},
+			{
+				method:         "IsPreparedQueryPresent",
+				args:           []interface{}{"db", "trainers"},
+				paramsReturned: []interface{}{false},
+			},
+			{
+				method:         "GetDBType",
+				args:           []interface{}{"db"},
+				paramsReturned: []interface{}{"postgres", nil},
+			},
+			{
+				method: "Read",
+				args: []interface{}{mock.Anything, "db", "trainers", &model.ReadRequest{
+					Extras:    map[string]interface{}{},
+					Find:      map[string]interface{}{},
+					Aggregate: map[string][]string{},
+					GroupBy:   []interface{}{},
+					Operation: utils.All,
+					Options: &model.ReadOptions{
+						Select:     map[string]int32{"trainers.id": 1, "trainers.name": 1},
+						Sort:       []string{"name"},
+						HasOptions: true,
+					},
+					IsBatch:     true,
+					PostProcess: map[string]*model.PostProcess{"trainers": {PostProcessAction: []model.PostProcessAction{{Action: "remove", Field: "res.name"}}}},
+				}, model.RequestParams{}},
+				paramsReturned: []interface{}{[]interface{}{map[string]interface{}{"id": "1", "name": "ash"}, map[string]interface{}{"id": "2", "name": "james"}}, new(model.SQLMetaData), nil},
+			},
+			{
+				method:         "GenerateCursor",
+				args:           []interface{}{"db", "trainers", mock.Anything, []interface{}{map[string]interface{}{"id": "1", "name": "ash"}, map[string]interface{}{"id": "2", "name": "james"}}},
+				paramsReturned: []interface{}{"cursor"},
+			},
+		},
+		schemaMockArgs: []mockArgs{
+			{
+				method:         "GetSchema",
+				args:           []interface{}{"db", "trainers"},
+				paramsReturned: []interface{}{model.Fields{}, true},
+			},
+		},
+		authMockArgs: []mockArgs{
+			{
+				method:         "IsReadOpAuthorised",
+				args:           []interface{}{mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything},
+				paramsReturned: []interface{}{&model.PostProcess{PostProcessAction: []model.PostProcessAction{{Action: "remove", Field: "res.name"}}}, model.RequestParams{}, nil},
+			},
+		},
+		args: args{
+			req: &model.GraphQLRequest{
+				OperationName: "query",
+				Query: `query {
+								trainers(
+									sort : ["name"]
+								) @db {
+									id
+									name
+								}
+								_pageInfo {
+									trainers {
+										endCursor
+									}
+								}
+							}`,
+				Variables: nil,
+			},
+			token: "",
+		},
+		wantErr: false,
+		wantResult: map[string]interface{}{
+			"trainers":  []interface{}{map[string]interface{}{"id": "1", "name": nil}, map[string]interface{}{"id": "2", "name": nil}},
+			"_pageInfo": map[string]interface{}{"trainers": map[string]interface{}{"endCursor": "cursor"}},
+		},
+	},
 	{
 		name: "Query: Sorting by multiple fields",
 		crudMockArgs: []mockArgs{
@@ -2,6 +2,7 @@ package graphql_test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -19,6 +20,11 @@ var functionTestCases = []tests{
 			},
 		},
 		functionMockArgs: []mockArgs{
+			{
+				method:         "ApplyRateLimits",
+				args:           []interface{}{mock.Anything, mock.Anything, mock.Anything, mock.Anything},
+				paramsReturned: []interface{}{(*model.RateLimitStatus)(nil)},
+			},
 			{
 				method:         "CallWithContext",
 				args:           []interface{}{mock.Anything, "arithmetic", "adder", "", model.RequestParams{Payload: map[string]interface{}{"num1": 10, "num2": 20}}, &model.FunctionsRequest{Timeout: 10, Params: map[string]interface{}{"num1": 10, "num2": 20}}},
@@ -60,6 +66,11 @@ var functionTestCases = []tests{
 			},
 		},
 		functionMockArgs: []mockArgs{
+			{
+				method:         "ApplyRateLimits",
+				args:           []interface{}{mock.Anything, mock.Anything, mock.Anything, mock.Anything},
+				paramsReturned: []interface{}{(*model.RateLimitStatus)(nil)},
+			},
 			{
 				method:         "CallWithContext",
 				args:           []interface{}{mock.Anything, "arithmetic", "adder", "", &model.RequestParams{Payload: map[string]interface{}{"num1": 10, "num2": 20}}, &model.FunctionsRequest{Timeout: 10, Params: map[string]interface{}{"num1": 10, "num2": 20}}},
@@ -106,6 +117,11 @@ var functionTestCases = []tests{
 			},
 		},
 		functionMockArgs: []mockArgs{
+			{
+				method:         "ApplyRateLimits",
+				args:           []interface{}{mock.Anything, mock.Anything, mock.Anything, mock.Anything},
+				paramsReturned: []interface{}{(*model.RateLimitStatus)(nil)},
+			},
 			{
 				method:         "CallWithContext",
 				args:           []interface{}{mock.Anything, "arithmetic", "adder", "", &model.RequestParams{Payload: map[string]interface{}{"num1": 10, "num2": 20}}, &model.FunctionsRequest{Timeout: 10, Params: map[string]interface{}{"num1": 10, "num2": 20}}},
@@ -153,6 +169,11 @@ var functionTestCases = []tests{
 			},
 		},
 		functionMockArgs: []mockArgs{
+			{
+				method:         "ApplyRateLimits",
+				args:           []interface{}{mock.Anything, mock.Anything, mock.Anything, mock.Anything},
+				paramsReturned: []interface{}{(*model.RateLimitStatus)(nil)},
+			},
 			{
 				method:         "CallWithContext",
 				args:           []interface{}{mock.Anything, "arithmetic", "adder", "", &model.RequestParams{Payload: map[string]interface{}{"num1": 10, "num2": 20}}, &model.FunctionsRequest{Timeout: 10, Params: map[string]interface{}{"num1": 10, "num2": 20}}},
@@ -194,6 +215,11 @@ var functionTestCases = []tests{
 			},
 		},
 		functionMockArgs: []mockArgs{
+			{
+				method:         "ApplyRateLimits",
+				args:           []interface{}{mock.Anything, mock.Anything, mock.Anything, mock.Anything},
+				paramsReturned: []interface{}{(*model.RateLimitStatus)(nil)},
+			},
 			{
 				method:         "CallWithContext",
 				args:           []interface{}{mock.Anything, "arithmetic", "adder", "", &model.RequestParams{Payload: map[string]interface{}{"num1": 10, "num2": 20}}, &model.FunctionsRequest{Timeout: 10, Params: map[string]interface{}{"num1": 10, "num2": 20}}},
@@ -235,6 +261,11 @@ var functionTestCases = []tests{
 			},
 		},
 		functionMockArgs: []mockArgs{
+			{
+				method:         "ApplyRateLimits",
+				args:           []interface{}{mock.Anything, mock.Anything, mock.Anything, mock.Anything},
+				paramsReturned: []interface{}{(*model.RateLimitStatus)(nil)},
+			},
 			{
 				method:         "CallWithContext",
 				args:           []interface{}{mock.Anything, "arithmetic", "adder", "", &model.RequestParams{Payload: map[string]interface{}{"num1": 10, "num2": 20}}, &model.FunctionsRequest{Timeout: 10, Params: map[string]interface{}{"num1": 10, "num2": 20}}},
@@ -266,4 +297,45 @@ var functionTestCases = []tests{
 		wantErr:    true,
 		wantResult: nil,
 	},
+	{
+		name: "Function: Querying rate limited endpoint",
+		crudMockArgs: []mockArgs{
+			{
+				method:         "GetDBType",
+				args:           []interface{}{"arithmetic"},
+				paramsReturned: []interface{}{"", errors.New("invalid db alias provided")},
+			},
+		},
+		functionMockArgs: []mockArgs{
+			{
+				method:         "ApplyRateLimits",
+				args:           []interface{}{mock.Anything, "arithmetic", "adder", mock.Anything},
+				paramsReturned: []interface{}{&model.RateLimitStatus{Allowed: false, Limit: 10, RetryAfter: time.Second}},
+			},
+		},
+		authMockArgs: []mockArgs{
+			{
+				method:         "IsFuncCallAuthorised",
+				args:           []interface{}{mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything},
+				paramsReturned: []interface{}{&model.PostProcess{}, model.RequestParams{}, nil},
+			},
+		},
+		args: args{
+			req: &model.GraphQLRequest{
+				OperationName: "query",
+				Query: `query {
+								adder(
+									num1 : 10,
+									num2 : 20,
+								) @arithmetic {
+									sum
+								}
+							}`,
+				Variables: nil,
+			},
+			token: "",
+		},
+		wantErr:    true,
+		wantResult: nil,
+	},
 }
@@ -28,9 +28,13 @@ func (m *mockGraphQLCrudInterface) Delete(ctx context.Context, dbAlias, collecti
 	args := m.Called(ctx, dbAlias, collection, request, params)
 	return args.Error(0)
 }
-func (m *mockGraphQLCrudInterface) Batch(ctx context.Context, dbAlias string, req *model.BatchRequest, params model.RequestParams) error {
+func (m *mockGraphQLCrudInterface) Batch(ctx context.Context, dbAlias string, req *model.BatchRequest, params model.RequestParams) ([]interface{}, error) {
 	args := m.Called(ctx, dbAlias, req, params)
-	return args.Error(0)
+	return nil, args.Error(0)
+}
+func (m *mockGraphQLCrudInterface) GenerateCursor(dbAlias, col string, req *model.ReadRequest, result interface{}) string {
+	args := m.Called(dbAlias, col, req, result)
+	return args.String(0)
 }
 func (m *mockGraphQLCrudInterface) GetDBType(dbAlias string) (string, error) {
 	args := m.Called(dbAlias)
@@ -91,6 +95,10 @@ func (m *mockGraphQLFunctionInterface) CallWithContext(ctx context.Context, serv
 	args := m.Called(ctx, service, function, token, reqParams, req)
 	return 0, args.Get(0).(interface{}), args.Error(1)
 }
+func (m *mockGraphQLFunctionInterface) ApplyRateLimits(ctx context.Context, service, function string, args map[string]interface{}) *model.RateLimitStatus {
+	c := m.Called(ctx, service, function, args)
+	return c.Get(0).(*model.RateLimitStatus)
+}
 
 type mockGraphQLSchemaInterface struct {
 	mock.Mock
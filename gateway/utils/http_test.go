@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetClientIP(t *testing.T) {
+	if err := SetTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", ""}); err != nil {
+		t.Fatalf("SetTrustedProxies() error = %v", err)
+	}
+	defer func() { trustedProxies = nil }()
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string][]string
+		want       string
+	}{
+		{
+			name:       "forwarded headers of untrusted clients are ignored",
+			remoteAddr: "1.2.3.4:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"5.6.7.8"}, "X-Real-Ip": {"5.6.7.8"}},
+			want:       "1.2.3.4",
+		},
+		{
+			name:       "right most untrusted hop is used",
+			remoteAddr: "10.0.0.1:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"5.6.7.8, 1.2.3.4, 192.168.1.1"}},
+			want:       "1.2.3.4",
+		},
+		{
+			name:       "hops split across headers",
+			remoteAddr: "10.0.0.1:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"5.6.7.8", "1.2.3.4, 10.0.0.2"}},
+			want:       "1.2.3.4",
+		},
+		{
+			name:       "left most hop is used if all of them are trusted",
+			remoteAddr: "10.0.0.1:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"10.0.0.3, 10.0.0.2"}},
+			want:       "10.0.0.3",
+		},
+		{
+			name:       "real ip of trusted proxy",
+			remoteAddr: "192.168.1.1:5000",
+			headers:    map[string][]string{"X-Real-Ip": {"1.2.3.4"}},
+			want:       "1.2.3.4",
+		},
+		{
+			name:       "trusted proxy without forwarded headers",
+			remoteAddr: "10.0.0.1:5000",
+			want:       "10.0.0.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				r.Header[k] = v
+			}
+			if got := GetClientIP(r); got != tt.want {
+				t.Errorf("GetClientIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetTrustedProxies(t *testing.T) {
+	defer func() { trustedProxies = nil }()
+
+	for _, proxies := range [][]string{{"not-an-ip"}, {"10.0.0.0/33"}} {
+		if err := SetTrustedProxies(proxies); err == nil {
+			t.Errorf("SetTrustedProxies(%v) expected an error", proxies)
+		}
+	}
+}
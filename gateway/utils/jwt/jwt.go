@@ -33,6 +33,9 @@ type jwkSecret struct {
 
 const defaultRefreshTime = 1 * time.Hour
 
+// TokenExpiry is the duration for which the tokens created by space cloud are valid
+const TokenExpiry = 30 * time.Minute
+
 // New initializes the package
 func New() *JWT {
 	ch := make(chan struct{}, 1)
@@ -77,8 +77,8 @@ func (j *JWT) CreateToken(ctx context.Context, tokenClaims model.TokenClaims) (s
 	}
 	var tokenString string
 	var err error
-	// Add expiry of one week
-	claims["exp"] = time.Now().Add(30 * time.Minute).Unix()
+	// Add expiry of the token
+	claims["exp"] = time.Now().Add(TokenExpiry).Unix()
 	for _, s := range j.staticSecrets {
 		if s.IsPrimary {
 			switch s.Alg {
@@ -0,0 +1,81 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// appendToLogScript increments the offset of the log and stores the entry in a capped stream with the offset as its
+// id. Doing both in a script makes sure that entries are stored in the order of their offsets
+var appendToLogScript = redis.NewScript(`
+local offset = redis.call('INCR', KEYS[1])
+redis.call('XADD', KEYS[2], 'MAXLEN', ARGV[2], offset .. '-0', 'data', ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+redis.call('PEXPIRE', KEYS[2], ARGV[3])
+return offset
+`)
+
+// LogEntry is an entry of a log along with its offset
+type LogEntry struct {
+	Offset int64
+	Data   string
+}
+
+// AppendToLog appends an entry to the log stored at the key and returns its offset. Offsets of a log are monotonic
+// and only the last size entries are retained. The log expires if nothing is appended to it for the ttl provided
+func (m *Module) AppendToLog(ctx context.Context, key, data string, size int64, ttl time.Duration) (int64, error) {
+	offsetKey, logKey := m.getTopicName("log-offset-"+key), m.getTopicName("log-"+key)
+	return appendToLogScript.Run(ctx, m.client, []string{offsetKey, logKey}, data, size, ttl.Milliseconds()).Int64()
+}
+
+// GetLogOffset returns the offset of the last entry appended to the log stored at the key
+func (m *Module) GetLogOffset(ctx context.Context, key string) (int64, error) {
+	offset, err := m.client.Get(ctx, m.getTopicName("log-offset-"+key)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return offset, err
+}
+
+// ReadLog returns the entries of the log stored at the key which come after the offset provided. It returns false if
+// some of those entries are no longer retained by the log
+func (m *Module) ReadLog(ctx context.Context, key string, after int64) ([]*LogEntry, bool, error) {
+	last, err := m.GetLogOffset(ctx, key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The log might have expired or been reset if the offset is ahead of it
+	if after > last {
+		return nil, false, nil
+	}
+	if after == last {
+		return []*LogEntry{}, true, nil
+	}
+
+	messages, err := m.client.XRange(ctx, m.getTopicName("log-"+key), fmt.Sprintf("%d-0", after+1), "+").Result()
+	if err != nil {
+		return nil, false, err
+	}
+
+	entries := make([]*LogEntry, 0, len(messages))
+	for _, msg := range messages {
+		offset, err := strconv.ParseInt(strings.TrimSuffix(msg.ID, "-0"), 10, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid id (%s) of log entry", msg.ID)
+		}
+		data, _ := msg.Values["data"].(string)
+		entries = append(entries, &LogEntry{Offset: offset, Data: data})
+	}
+
+	// The entry right after the offset must be present for the log to be complete
+	if len(entries) == 0 || entries[0].Offset != after+1 {
+		return nil, false, nil
+	}
+	return entries, true, nil
+}
@@ -0,0 +1,188 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// fixedWindowScript counts the request in the window which started with the first request made after the last one
+// expired. It returns the count of the window along with the milliseconds till it expires
+var fixedWindowScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return {count, redis.call('PTTL', KEYS[1])}
+`)
+
+// slidingWindowScript estimates the requests made in the sliding window from the counts of the current and the previous
+// fixed windows. The count of the previous window is weighed by how much of it overlaps the sliding window. Denied
+// requests aren't counted. It returns whether the request was allowed, the requests left, the milliseconds till the
+// current window ends and the milliseconds to wait before the next request would be allowed
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local start = now - (now % window)
+
+local state = redis.call('HMGET', KEYS[1], 'start', 'current', 'previous')
+local lastStart = tonumber(state[1])
+local current = tonumber(state[2]) or 0
+local previous = tonumber(state[3]) or 0
+if lastStart == nil or lastStart < start - window then
+	current, previous = 0, 0
+elseif lastStart < start then
+	current, previous = 0, current
+end
+
+local elapsed = now - start
+local count = previous * (window - elapsed) / window + current
+
+local allowed = 0
+local wait = 0
+if count + 1 <= limit then
+	allowed = 1
+	current = current + 1
+	count = count + 1
+elseif previous > 0 and limit - 1 - current >= 0 then
+	wait = math.ceil(window * (1 - (limit - 1 - current) / previous)) - elapsed
+else
+	wait = window - elapsed
+end
+
+redis.call('HSET', KEYS[1], 'start', start, 'current', current, 'previous', previous)
+redis.call('PEXPIRE', KEYS[1], 2 * window)
+return {allowed, math.max(0, math.floor(limit - count)), window - elapsed, math.max(0, wait)}
+`)
+
+// tokenBucketScript refills the bucket based on the time elapsed since the last request and then tries to take the
+// requested tokens out of it. It returns whether the tokens were taken along with the milliseconds to wait otherwise,
+// the tokens left and the milliseconds till the bucket is full. The time of the redis server is used so that the
+// clocks of the gateways don't matter
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local requested = tonumber(ARGV[3])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 0
+local wait = 0
+if tokens >= requested then
+	tokens = tokens - requested
+	allowed = 1
+else
+	wait = math.ceil((requested - tokens) * 1000 / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return {allowed, wait, math.floor(tokens), math.ceil((burst - tokens) * 1000 / rate)}
+`)
+
+// TakeTokens takes tokens out of the token bucket stored at the key. The bucket holds up to burst tokens and gets
+// refilled at the rate provided per second. The time to wait for the tokens is returned if they aren't available
+func (m *Module) TakeTokens(ctx context.Context, key string, tokens int, rate float64, burst int) (bool, time.Duration, error) {
+	if rate <= 0 || burst <= 0 {
+		return false, 0, fmt.Errorf("invalid token bucket with rate (%v) and burst (%v)", rate, burst)
+	}
+
+	result, err := tokenBucketScript.Run(ctx, m.client, []string{m.getTopicName("rate-limit-" + key)}, rate, burst, tokens).Result()
+	if err != nil {
+		return false, 0, err
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 4 {
+		return false, 0, fmt.Errorf("invalid response (%v) received from token bucket script", result)
+	}
+	allowed, _ := values[0].(int64)
+	wait, _ := values[1].(int64)
+	return allowed == 1, time.Duration(wait) * time.Millisecond, nil
+}
+
+// CountRequest counts a request against the rate limit stored at the key and returns the state of the limit
+func (m *Module) CountRequest(ctx context.Context, key string, rateLimit *config.RateLimit) (*model.RateLimitStatus, error) {
+	if rateLimit.Limit <= 0 || rateLimit.Window <= 0 {
+		return nil, fmt.Errorf("invalid rate limit with limit (%v) and window (%v)", rateLimit.Limit, rateLimit.Window)
+	}
+	window := time.Duration(rateLimit.Window) * time.Second
+	status := &model.RateLimitStatus{Limit: rateLimit.Limit}
+
+	switch rateLimit.Algorithm {
+	case "", config.RateLimitFixedWindow:
+		values, err := m.runCounterScript(ctx, fixedWindowScript, "rate-limit-fixed-"+key, 2, window.Milliseconds())
+		if err != nil {
+			return nil, err
+		}
+		status.Allowed = values[0] <= int64(rateLimit.Limit)
+		if remaining := int64(rateLimit.Limit) - values[0]; remaining > 0 {
+			status.Remaining = int(remaining)
+		}
+		status.Reset = time.Duration(values[1]) * time.Millisecond
+		if status.Reset < 0 {
+			status.Reset = window
+		}
+
+	case config.RateLimitSlidingWindow:
+		values, err := m.runCounterScript(ctx, slidingWindowScript, "rate-limit-sliding-"+key, 4, rateLimit.Limit, window.Milliseconds())
+		if err != nil {
+			return nil, err
+		}
+		status.Allowed, status.Remaining = values[0] == 1, int(values[1])
+		status.Reset, status.RetryAfter = time.Duration(values[2])*time.Millisecond, time.Duration(values[3])*time.Millisecond
+
+	case config.RateLimitTokenBucket:
+		rate := float64(rateLimit.Limit) / float64(rateLimit.Window)
+		values, err := m.runCounterScript(ctx, tokenBucketScript, "rate-limit-"+key, 4, rate, rateLimit.Limit, 1)
+		if err != nil {
+			return nil, err
+		}
+		status.Allowed, status.RetryAfter = values[0] == 1, time.Duration(values[1])*time.Millisecond
+		status.Remaining, status.Reset = int(values[2]), time.Duration(values[3])*time.Millisecond
+
+	default:
+		return nil, fmt.Errorf("invalid rate limit algorithm (%s) provided", rateLimit.Algorithm)
+	}
+
+	if !status.Allowed && status.RetryAfter == 0 {
+		status.RetryAfter = status.Reset
+	}
+	return status, nil
+}
+
+// runCounterScript runs a script which returns a list of integers of the size provided
+func (m *Module) runCounterScript(ctx context.Context, script *redis.Script, key string, size int, args ...interface{}) ([]int64, error) {
+	result, err := script.Run(ctx, m.client, []string{m.getTopicName(key)}, args...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) != size {
+		return nil, fmt.Errorf("invalid response (%v) received from rate limit script", result)
+	}
+	out := make([]int64, size)
+	for i, value := range values {
+		out[i], _ = value.(int64)
+	}
+	return out, nil
+}
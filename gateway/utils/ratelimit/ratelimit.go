@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+const defaultKey = "args.ip"
+
+// Counter counts requests against rate limits. The counters are kept in redis so that they are shared by all gateways
+type Counter interface {
+	CountRequest(ctx context.Context, key string, rateLimit *config.RateLimit) (*model.RateLimitStatus, error)
+}
+
+// Validate checks if the rate limits provided are valid
+func Validate(ctx context.Context, rateLimits []*config.RateLimit) error {
+	for _, rateLimit := range rateLimits {
+		switch rateLimit.Algorithm {
+		case "", config.RateLimitFixedWindow, config.RateLimitSlidingWindow, config.RateLimitTokenBucket:
+		default:
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid rate limit algorithm (%s) provided", rateLimit.Algorithm), nil, nil)
+		}
+		if rateLimit.Limit <= 0 || rateLimit.Window <= 0 {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Rate limit with key (%s) must have a positive limit and window", rateLimit.Key), nil, nil)
+		}
+	}
+	return nil
+}
+
+// Apply counts the request against the rate limits provided. The status of the first limit which denies the request
+// is returned, otherwise the status of the limit with the least requests remaining. Rate limiting is skipped rather
+// than failing the request if the counters can't be reached. It returns nil if there are no rate limits
+func Apply(ctx context.Context, counter Counter, prefix string, rateLimits []*config.RateLimit, args map[string]interface{}) *model.RateLimitStatus {
+	if counter == nil {
+		return nil
+	}
+
+	var result *model.RateLimitStatus
+	for i, rateLimit := range rateLimits {
+		key := rateLimit.Key
+		if key == "" {
+			key = defaultKey
+		}
+
+		// Requests without a value for the key share a limit
+		value := "anonymous"
+		if v, err := utils.LoadValue(key, map[string]interface{}{"args": args}); err == nil && v != nil {
+			value = fmt.Sprintf("%v", v)
+		}
+
+		status, err := counter.CountRequest(ctx, fmt.Sprintf("%s-%d-%s-%s", prefix, i, key, value), rateLimit)
+		if err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to apply rate limit", err, map[string]interface{}{"key": key})
+			continue
+		}
+		if !status.Allowed {
+			return status
+		}
+		if result == nil || status.Remaining < result.Remaining {
+			result = status
+		}
+	}
+	return result
+}
+
+// SetHeaders sets the RateLimit headers describing the status of a rate limit. The Retry-After header is only set if
+// the request was denied
+func SetHeaders(header http.Header, status *model.RateLimitStatus) {
+	if status == nil {
+		return
+	}
+
+	header.Set("RateLimit-Limit", strconv.Itoa(status.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(status.Remaining))
+	header.Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil(status.Reset.Seconds()))))
+	if !status.Allowed {
+		header.Set("Retry-After", strconv.Itoa(int(math.Ceil(status.RetryAfter.Seconds()))))
+	}
+}
+
+type clientKey struct{}
+
+type client struct {
+	ip      string
+	headers map[string]interface{}
+}
+
+// WithRequest stores the attributes of the client making the request in the context. It is used to rate limit the
+// operations which don't have access to the request
+func WithRequest(ctx context.Context, request *http.Request) context.Context {
+	return context.WithValue(ctx, clientKey{}, newClient(request))
+}
+
+// NewArgs returns the attributes of a request which can be used as the key of a rate limit
+func NewArgs(request *http.Request, claims map[string]interface{}, token string) map[string]interface{} {
+	return newClient(request).args(claims, token)
+}
+
+// NewArgsFromContext returns the attributes of the request stored in the context which can be used as the key of a
+// rate limit
+func NewArgsFromContext(ctx context.Context, claims map[string]interface{}, token string) map[string]interface{} {
+	c, ok := ctx.Value(clientKey{}).(*client)
+	if !ok {
+		c = &client{headers: map[string]interface{}{}}
+	}
+	return c.args(claims, token)
+}
+
+func newClient(request *http.Request) *client {
+	headers := make(map[string]interface{}, len(request.Header))
+	for k, v := range request.Header {
+		if len(v) > 0 {
+			headers[strings.ToLower(k)] = v[0]
+		}
+	}
+	return &client{ip: utils.GetClientIP(request), headers: headers}
+}
+
+func (c *client) args(claims map[string]interface{}, token string) map[string]interface{} {
+	args := map[string]interface{}{"auth": claims, "token": token, "headers": c.headers}
+	if c.ip != "" {
+		args["ip"] = c.ip
+	}
+	return args
+}
@@ -0,0 +1,172 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+type fakeCounter struct {
+	keys     []string
+	statuses map[string]*model.RateLimitStatus
+	err      error
+}
+
+func (f *fakeCounter) CountRequest(ctx context.Context, key string, rateLimit *config.RateLimit) (*model.RateLimitStatus, error) {
+	f.keys = append(f.keys, key)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if status, ok := f.statuses[key]; ok {
+		return status, nil
+	}
+	return &model.RateLimitStatus{Allowed: true, Limit: rateLimit.Limit, Remaining: rateLimit.Limit - 1}, nil
+}
+
+func TestApply(t *testing.T) {
+	args := map[string]interface{}{"ip": "1.2.3.4", "auth": map[string]interface{}{"id": "user1"}, "headers": map[string]interface{}{}}
+	denied := &model.RateLimitStatus{Allowed: false, Limit: 5, RetryAfter: time.Second}
+
+	tests := []struct {
+		name       string
+		rateLimits []*config.RateLimit
+		counter    *fakeCounter
+		want       *model.RateLimitStatus
+		wantKeys   []string
+	}{
+		{
+			name:    "no rate limits",
+			counter: &fakeCounter{},
+		},
+		{
+			name:       "ip is the default key",
+			rateLimits: []*config.RateLimit{{Limit: 10, Window: 60}},
+			counter:    &fakeCounter{},
+			want:       &model.RateLimitStatus{Allowed: true, Limit: 10, Remaining: 9},
+			wantKeys:   []string{"route-0-args.ip-1.2.3.4"},
+		},
+		{
+			name:       "missing values share the anonymous limit",
+			rateLimits: []*config.RateLimit{{Key: "args.headers.x-api-key", Limit: 10, Window: 60}},
+			counter:    &fakeCounter{},
+			want:       &model.RateLimitStatus{Allowed: true, Limit: 10, Remaining: 9},
+			wantKeys:   []string{"route-0-args.headers.x-api-key-anonymous"},
+		},
+		{
+			name:       "limit with the least requests remaining is returned",
+			rateLimits: []*config.RateLimit{{Key: "args.auth.id", Limit: 100, Window: 3600}, {Limit: 10, Window: 60}},
+			counter:    &fakeCounter{},
+			want:       &model.RateLimitStatus{Allowed: true, Limit: 10, Remaining: 9},
+			wantKeys:   []string{"route-0-args.auth.id-user1", "route-1-args.ip-1.2.3.4"},
+		},
+		{
+			name:       "first limit denying the request is returned",
+			rateLimits: []*config.RateLimit{{Key: "args.auth.id", Limit: 5, Window: 60}, {Limit: 10, Window: 60}},
+			counter:    &fakeCounter{statuses: map[string]*model.RateLimitStatus{"route-0-args.auth.id-user1": denied}},
+			want:       denied,
+			wantKeys:   []string{"route-0-args.auth.id-user1"},
+		},
+		{
+			name:       "limits are skipped if the counters can't be reached",
+			rateLimits: []*config.RateLimit{{Limit: 10, Window: 60}},
+			counter:    &fakeCounter{err: errors.New("connection refused")},
+			wantKeys:   []string{"route-0-args.ip-1.2.3.4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Apply(context.Background(), tt.counter, "route", tt.rateLimits, args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Apply() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(tt.counter.keys, tt.wantKeys) {
+				t.Errorf("Apply() counted keys = %v, want %v", tt.counter.keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestSetHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		status *model.RateLimitStatus
+		want   http.Header
+	}{
+		{
+			name: "no status",
+			want: http.Header{},
+		},
+		{
+			name:   "allowed",
+			status: &model.RateLimitStatus{Allowed: true, Limit: 10, Remaining: 4, Reset: 1500 * time.Millisecond},
+			want:   http.Header{"Ratelimit-Limit": {"10"}, "Ratelimit-Remaining": {"4"}, "Ratelimit-Reset": {"2"}},
+		},
+		{
+			name:   "denied",
+			status: &model.RateLimitStatus{Limit: 10, Reset: 30 * time.Second, RetryAfter: 200 * time.Millisecond},
+			want:   http.Header{"Ratelimit-Limit": {"10"}, "Ratelimit-Remaining": {"0"}, "Ratelimit-Reset": {"30"}, "Retry-After": {"1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			SetHeaders(header, tt.status)
+			if !reflect.DeepEqual(header, tt.want) {
+				t.Errorf("SetHeaders() = %v, want %v", header, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		rateLimits []*config.RateLimit
+		wantErr    bool
+	}{
+		{name: "valid", rateLimits: []*config.RateLimit{{Limit: 10, Window: 60}, {Algorithm: config.RateLimitSlidingWindow, Limit: 1, Window: 1}, {Algorithm: config.RateLimitTokenBucket, Limit: 5, Window: 10}}},
+		{name: "invalid algorithm", rateLimits: []*config.RateLimit{{Algorithm: "leaky-bucket", Limit: 10, Window: 60}}, wantErr: true},
+		{name: "missing limit", rateLimits: []*config.RateLimit{{Window: 60}}, wantErr: true},
+		{name: "missing window", rateLimits: []*config.RateLimit{{Limit: 10}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(context.Background(), tt.rateLimits); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewArgs(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/v1/api", nil)
+	request.RemoteAddr = "10.0.0.1:5000"
+	request.Header.Set("X-Forwarded-For", "5.6.7.8, 1.2.3.4, 10.0.0.2")
+	request.Header.Set("X-Api-Key", "key1")
+	claims := map[string]interface{}{"id": "user1"}
+
+	if err := utils.SetTrustedProxies([]string{"10.0.0.0/8"}); err != nil {
+		t.Fatalf("SetTrustedProxies() error = %v", err)
+	}
+	defer func() { _ = utils.SetTrustedProxies(nil) }()
+
+	want := map[string]interface{}{"ip": "1.2.3.4", "auth": claims, "token": "token", "headers": map[string]interface{}{"x-forwarded-for": "5.6.7.8, 1.2.3.4, 10.0.0.2", "x-api-key": "key1"}}
+	if got := NewArgs(request, claims, "token"); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewArgs() = %v, want %v", got, want)
+	}
+	if got := NewArgsFromContext(WithRequest(context.Background(), request), claims, "token"); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewArgsFromContext() = %v, want %v", got, want)
+	}
+
+	want = map[string]interface{}{"auth": claims, "token": "token", "headers": map[string]interface{}{}}
+	if got := NewArgsFromContext(context.Background(), claims, "token"); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewArgsFromContext() without request = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SearchClause describes a full text search on a single field of the where clause
+type SearchClause struct {
+	Field string
+	Text  string
+}
+
+// GetSearchClauses returns the full text searches present at the top level of the where clause sorted by the field name.
+// These are the ones used to rank the results
+func GetSearchClauses(find map[string]interface{}) []SearchClause {
+	clauses := make([]SearchClause, 0)
+	for field, value := range find {
+		cond, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if text, ok := cond["$search"].(string); ok {
+			clauses = append(clauses, SearchClause{Field: field, Text: text})
+		}
+	}
+	sort.Slice(clauses, func(i, j int) bool { return clauses[i].Field < clauses[j].Field })
+	return clauses
+}
+
+// TokenizeText splits the text into lower cased words
+func TokenizeText(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// TextSearchScore returns the number of occurrences of the words of the search text in the provided value.
+// A score of zero is returned if any of the words is missing, since all the words need to match like in plainto_tsquery
+func TextSearchScore(value, text string) int {
+	words := TokenizeText(text)
+	if len(words) == 0 {
+		return 0
+	}
+
+	frequency := map[string]int{}
+	for _, token := range TokenizeText(value) {
+		frequency[token]++
+	}
+
+	score := 0
+	for _, word := range words {
+		if frequency[word] == 0 {
+			return 0
+		}
+		score += frequency[word]
+	}
+	return score
+}
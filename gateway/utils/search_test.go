@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetSearchClauses(t *testing.T) {
+	find := map[string]interface{}{
+		"title":  map[string]interface{}{"$search": "space cloud"},
+		"body":   map[string]interface{}{"$search": "graphql"},
+		"author": "foo",
+		"age":    map[string]interface{}{"$gt": 10},
+		"$or":    []interface{}{map[string]interface{}{"tags": map[string]interface{}{"$search": "ignored"}}},
+	}
+	want := []SearchClause{{Field: "body", Text: "graphql"}, {Field: "title", Text: "space cloud"}}
+	if got := GetSearchClauses(find); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSearchClauses() = %v, want %v", got, want)
+	}
+}
+
+func TestTextSearchScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		text  string
+		want  int
+	}{
+		{name: "all words match", value: "Space Cloud is a cloud native backend", text: "cloud backend", want: 3},
+		{name: "a word is missing", value: "Space Cloud is a cloud native backend", text: "cloud frontend", want: 0},
+		{name: "punctuation is ignored", value: "graphql, rest & websockets!", text: "REST websockets", want: 2},
+		{name: "empty search text", value: "space cloud", text: " ", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TextSearchScore(tt.value, tt.text); got != tt.want {
+				t.Errorf("TextSearchScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
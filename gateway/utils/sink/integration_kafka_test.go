@@ -0,0 +1,118 @@
+//go:build integration
+// +build integration
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+var kafkaBrokers = flag.String("kafka_brokers", "localhost:9092", "comma separated brokers of the kafka cluster to run the tests against")
+
+// createKafkaTopic creates a topic of its own for the test on the cluster
+func createKafkaTopic(t *testing.T, partitions int) string {
+	conn, err := kafka.Dial("tcp", strings.Split(*kafkaBrokers, ",")[0])
+	if err != nil {
+		t.Fatalf("Unable to connect to kafka - %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	controller, err := conn.Controller()
+	if err != nil {
+		t.Fatalf("Unable to find kafka controller - %v", err)
+	}
+	controllerConn, err := kafka.Dial("tcp", net.JoinHostPort(controller.Host, strconv.Itoa(controller.Port)))
+	if err != nil {
+		t.Fatalf("Unable to connect to kafka controller - %v", err)
+	}
+	defer func() { _ = controllerConn.Close() }()
+
+	topic := fmt.Sprintf("space-cloud-test-%d", time.Now().UnixNano())
+	if err := controllerConn.CreateTopics(kafka.TopicConfig{Topic: topic, NumPartitions: partitions, ReplicationFactor: 1}); err != nil {
+		t.Fatalf("Unable to create kafka topic - %v", err)
+	}
+	return topic
+}
+
+func TestKafka_publishConsume(t *testing.T) {
+	brokers := strings.Split(*kafkaBrokers, ",")
+	topic := createKafkaTopic(t, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	producer, err := NewKafka(brokers, topic, nil, nil)
+	if err != nil {
+		t.Fatalf("NewKafka() error = %v", err)
+	}
+	defer func() { _ = producer.Close() }()
+
+	// The first message of every partition fails to be processed, which stops the consumer without committing it
+	received := make(chan string, 100)
+	failed := map[string]bool{}
+	handler := func(ctx context.Context, payload []byte) error {
+		if !failed[string(payload)] && strings.HasPrefix(string(payload), "fail") {
+			failed[string(payload)] = true
+			return errors.New("unable to process message")
+		}
+		received <- string(payload)
+		return nil
+	}
+
+	consumer := NewKafkaConsumer(brokers, topic, topic)
+	consumeErrs := make(chan error, 10)
+	go func() {
+		for ctx.Err() == nil {
+			consumeErrs <- consumer.Consume(ctx, handler)
+		}
+	}()
+
+	// A new consumer group starts from the latest offsets once it has joined. Keep publishing till it has joined
+	for joined := false; !joined; {
+		if err := producer.Publish(ctx, "ready", []byte("ready")); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+		select {
+		case <-received:
+			joined = true
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			t.Fatalf("Consume() did not join the consumer group")
+		}
+	}
+
+	want := map[string]bool{}
+	for i := 0; i < 10; i++ {
+		payload := fmt.Sprintf(`{"id":"%d"}`, i)
+		if i == 5 {
+			payload = "fail"
+		}
+		want[payload] = true
+		if err := producer.Publish(ctx, strconv.Itoa(i), []byte(payload)); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	// The message which failed is consumed again by the next call to Consume
+	for len(want) > 0 {
+		select {
+		case payload := <-received:
+			delete(want, payload)
+		case <-ctx.Done():
+			t.Fatalf("Consume() did not receive messages %v", want)
+		}
+	}
+	if err := <-consumeErrs; err == nil {
+		t.Errorf("Consume() returned without an error for a message which failed to be processed")
+	}
+}
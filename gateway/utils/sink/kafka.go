@@ -0,0 +1,88 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+const (
+	kafkaClientID = "space-cloud"
+
+	// kafkaBatchTimeout is the time the messages published concurrently are collected for before being sent together
+	kafkaBatchTimeout = 10 * time.Millisecond
+)
+
+// Kafka publishes messages to a kafka topic. Messages with the same key are published to the same partition using the
+// partitioner of the java client. A message is acknowledged once all in sync replicas have received it
+type Kafka struct {
+	writer *kafka.Writer
+}
+
+// NewKafka creates a producer for the topic. The brokers are only used to discover the cluster. The connections are
+// made over tls and authenticated with SASL if the respective configs are provided
+func NewKafka(brokers []string, topic string, tlsConfig *config.EventingTLS, auth *config.EventingAuth) (*Kafka, error) {
+	transport := &kafka.Transport{ClientID: kafkaClientID}
+	if tlsConfig != nil {
+		c, err := newTLSConfig(tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLS = c
+	}
+	if auth != nil {
+		mechanism, err := newKafkaMechanism(auth)
+		if err != nil {
+			return nil, err
+		}
+		transport.SASL = mechanism
+	}
+
+	return &Kafka{writer: &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Murmur2Balancer{}, // Empty keys are spread randomly across partitions
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: kafkaBatchTimeout,
+		Transport:    transport,
+	}}, nil
+}
+
+// Publish publishes the payload to the partition of the key and waits for all in sync replicas to acknowledge it
+func (k *Kafka) Publish(ctx context.Context, key string, payload []byte) error {
+	msg := kafka.Message{Value: payload}
+	if key != "" {
+		msg.Key = []byte(key)
+	}
+	return k.writer.WriteMessages(ctx, msg)
+}
+
+// Close closes the connections to the brokers
+func (k *Kafka) Close() error {
+	return k.writer.Close()
+}
+
+func newKafkaMechanism(auth *config.EventingAuth) (sasl.Mechanism, error) {
+	if auth.Username == "" {
+		return nil, errors.New("username of the kafka credentials has not been provided")
+	}
+
+	switch auth.Mechanism {
+	case "", config.EventingAuthPlain:
+		return plain.Mechanism{Username: auth.Username, Password: auth.Password}, nil
+	case config.EventingAuthScramSHA256:
+		return scram.Mechanism(scram.SHA256, auth.Username, auth.Password)
+	case config.EventingAuthScramSHA512:
+		return scram.Mechanism(scram.SHA512, auth.Username, auth.Password)
+	default:
+		return nil, fmt.Errorf("invalid sasl mechanism (%s) provided", auth.Mechanism)
+	}
+}
@@ -0,0 +1,66 @@
+package sink
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	kafkaFetchMaxWait  = 500 * time.Millisecond
+	kafkaFetchMaxBytes = 1 << 20
+)
+
+// KafkaConsumer consumes all the partitions of a kafka topic as a member of a consumer group and commits the offsets of
+// the processed messages for the group. Record batches compressed with any of the codecs supported by kafka are consumed
+type KafkaConsumer struct {
+	config kafka.ReaderConfig
+}
+
+// NewKafkaConsumer creates a consumer for the topic. The brokers are only used to discover the cluster
+func NewKafkaConsumer(brokers []string, topic, group string) *KafkaConsumer {
+	return &KafkaConsumer{config: kafka.ReaderConfig{
+		Brokers:     brokers,
+		Topic:       topic,
+		GroupID:     group,
+		StartOffset: kafka.LastOffset, // Partitions without a committed offset are consumed from their latest offset
+		MinBytes:    1,
+		MaxBytes:    kafkaFetchMaxBytes,
+		MaxWait:     kafkaFetchMaxWait,
+	}}
+}
+
+// Consume processes the messages of the topic starting from the committed offsets of the group. A message is committed
+// only once the handler succeeds, so the message the handler failed on is consumed again by the next call
+func (c *KafkaConsumer) Consume(ctx context.Context, handler Handler) error {
+	// The reader fetches messages ahead of the ones processed. It gets created on every call so that consuming resumes
+	// from the committed offsets instead of the ones which were fetched
+	reader := kafka.NewReader(c.config)
+	defer func() { _ = reader.Close() }()
+
+	for {
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		if err := handler(ctx, msg.Value); err != nil {
+			return err
+		}
+		if err := reader.CommitMessages(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// Close releases the resources of the consumer. The connections to the brokers are closed once Consume returns
+func (c *KafkaConsumer) Close() error {
+	return nil
+}
@@ -0,0 +1,28 @@
+package sink
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestNewKafkaConsumer(t *testing.T) {
+	c := NewKafkaConsumer([]string{"localhost:9092"}, "orders", "project-orders")
+
+	want := kafka.ReaderConfig{
+		Brokers:     []string{"localhost:9092"},
+		Topic:       "orders",
+		GroupID:     "project-orders",
+		StartOffset: kafka.LastOffset,
+		MinBytes:    1,
+		MaxBytes:    kafkaFetchMaxBytes,
+		MaxWait:     kafkaFetchMaxWait,
+	}
+	if !reflect.DeepEqual(c.config, want) {
+		t.Errorf("NewKafkaConsumer() config = %+v, want %+v", c.config, want)
+	}
+	if err := c.config.Validate(); err != nil {
+		t.Errorf("NewKafkaConsumer() config is invalid: %v", err)
+	}
+}
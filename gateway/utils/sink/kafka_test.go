@@ -0,0 +1,58 @@
+package sink
+
+import (
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+func TestNewKafka(t *testing.T) {
+	tests := []struct {
+		name          string
+		tls           *config.EventingTLS
+		auth          *config.EventingAuth
+		wantTLS       bool
+		wantMechanism string
+		wantErr       bool
+	}{
+		{name: "plain connections"},
+		{name: "tls connections", tls: &config.EventingTLS{ServerName: "kafka.local"}, wantTLS: true},
+		{name: "plain credentials by default", auth: &config.EventingAuth{Username: "user", Password: "pass"}, wantMechanism: "PLAIN"},
+		{name: "scram credentials", tls: &config.EventingTLS{}, auth: &config.EventingAuth{Mechanism: config.EventingAuthScramSHA512, Username: "user", Password: "pass"}, wantTLS: true, wantMechanism: "SCRAM-SHA-512"},
+		{name: "invalid mechanism", auth: &config.EventingAuth{Mechanism: "gssapi", Username: "user"}, wantErr: true},
+		{name: "credentials without username", auth: &config.EventingAuth{Password: "pass"}, wantErr: true},
+		{name: "invalid client certificate", tls: &config.EventingTLS{Cert: "invalid", Key: "invalid"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := NewKafka([]string{"localhost:9092"}, "orders", tt.tls, tt.auth)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewKafka() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if k.writer.Topic != "orders" || k.writer.Addr.String() != "localhost:9092" || k.writer.RequiredAcks != kafka.RequireAll {
+				t.Errorf("NewKafka() writer = %+v", k.writer)
+			}
+			if _, ok := k.writer.Balancer.(*kafka.Murmur2Balancer); !ok {
+				t.Errorf("NewKafka() balancer = %T, want murmur2 balancer", k.writer.Balancer)
+			}
+
+			transport := k.writer.Transport.(*kafka.Transport)
+			if (transport.TLS != nil) != tt.wantTLS {
+				t.Errorf("NewKafka() tls = %v, want %v", transport.TLS != nil, tt.wantTLS)
+			}
+			var mechanism string
+			if transport.SASL != nil {
+				mechanism = transport.SASL.Name()
+			}
+			if mechanism != tt.wantMechanism {
+				t.Errorf("NewKafka() sasl mechanism = %v, want %v", mechanism, tt.wantMechanism)
+			}
+		})
+	}
+}
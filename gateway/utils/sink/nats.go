@@ -0,0 +1,135 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+const (
+	natsClientName = "space-cloud"
+
+	// natsFlushTimeout is the time the server gets to process a message if the context doesn't have a deadline
+	natsFlushTimeout = 10 * time.Second
+)
+
+// NATS publishes messages to a subject of a nats server. Nats doesn't acknowledge messages, hence the connection is
+// flushed after every message so that the errors reported by the server for the message, like permission violations,
+// are returned
+type NATS struct {
+	lock    sync.Mutex
+	url     string
+	subject string
+	options []nats.Option
+
+	conn *nats.Conn
+}
+
+// NewNATS creates a publisher for the subject. The connection is established lazily. It is made over tls and
+// authenticated with the credentials provided if the respective configs are provided. Credentials can also be provided
+// as the user info of the url
+func NewNATS(rawURL, subject string, tlsConfig *config.EventingTLS, auth *config.EventingAuth) (*NATS, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nats url (%s) provided: %v", rawURL, err)
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return nil, fmt.Errorf("unsupported scheme (%s) in nats url", u.Scheme)
+	}
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return nil, fmt.Errorf("invalid nats subject (%s) provided", subject)
+	}
+
+	options := []nats.Option{nats.Name(natsClientName)}
+	if tlsConfig != nil {
+		c, err := newTLSConfig(tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, nats.Secure(c))
+	}
+	if auth != nil {
+		switch {
+		case auth.Token != "":
+			options = append(options, nats.Token(auth.Token))
+		case auth.Username != "":
+			options = append(options, nats.UserInfo(auth.Username, auth.Password))
+		default:
+			return nil, errors.New("token or username of the nats credentials has not been provided")
+		}
+	}
+	return &NATS{url: rawURL, subject: subject, options: options}, nil
+}
+
+// Publish publishes the payload to the subject and waits for the server to process it
+func (n *NATS) Publish(ctx context.Context, key string, payload []byte) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.conn == nil {
+		conn, err := n.connect()
+		if err != nil {
+			return err
+		}
+		n.conn = conn
+	}
+
+	if err := n.publish(ctx, payload); err != nil {
+		n.closeConn()
+		return err
+	}
+	return nil
+}
+
+func (n *NATS) publish(ctx context.Context, payload []byte) error {
+	// The server reports errors asynchronously. They are recorded as the last error of the connection by the time the
+	// flush completes
+	lastErr := n.conn.LastError()
+
+	if err := n.conn.Publish(n.subject, payload); err != nil {
+		return err
+	}
+
+	var err error
+	if _, ok := ctx.Deadline(); ok {
+		err = n.conn.FlushWithContext(ctx)
+	} else {
+		err = n.conn.FlushTimeout(natsFlushTimeout)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := n.conn.LastError(); err != nil && err != lastErr {
+		return err
+	}
+	return nil
+}
+
+func (n *NATS) connect(options ...nats.Option) (*nats.Conn, error) {
+	return nats.Connect(n.url, append(options, n.options...)...)
+}
+
+func (n *NATS) closeConn() {
+	if n.conn != nil {
+		n.conn.Close()
+	}
+	n.conn = nil
+}
+
+// Close closes the connection to the server
+func (n *NATS) Close() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.closeConn()
+	return nil
+}
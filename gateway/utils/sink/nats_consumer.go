@@ -0,0 +1,71 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsConsumerBuffer is the number of messages received from the server which are waiting to be processed
+const natsConsumerBuffer = 64
+
+// NATSConsumer consumes the messages of a nats subject. Nats doesn't redeliver messages, so a message is lost if the
+// handler fails to process it. Gateways consuming with the same queue group share the messages of the subject
+type NATSConsumer struct {
+	nats  *NATS
+	queue string
+}
+
+// NewNATSConsumer creates a consumer for the subject. The connection is established once consuming starts
+func NewNATSConsumer(rawURL, subject, queue string) (*NATSConsumer, error) {
+	n, err := NewNATS(rawURL, subject, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ContainsAny(queue, " \t\r\n") {
+		return nil, fmt.Errorf("invalid nats queue group (%s) provided", queue)
+	}
+	return &NATSConsumer{nats: n, queue: queue}, nil
+}
+
+// Consume subscribes to the subject and processes its messages. The client reconnects to the server by itself, hence
+// Consume only returns once the context is cancelled or the client gives up on reconnecting
+func (c *NATSConsumer) Consume(ctx context.Context, handler Handler) error {
+	closed := make(chan struct{})
+	conn, err := c.nats.connect(nats.ClosedHandler(func(*nats.Conn) { close(closed) }))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	msgs := make(chan *nats.Msg, natsConsumerBuffer)
+	if c.queue == "" {
+		_, err = conn.ChanSubscribe(c.nats.subject, msgs)
+	} else {
+		_, err = conn.ChanQueueSubscribe(c.nats.subject, c.queue, msgs)
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-closed:
+			if err := conn.LastError(); err != nil {
+				return err
+			}
+			return nats.ErrConnectionClosed
+		case msg := <-msgs:
+			_ = handler(ctx, msg.Data)
+		}
+	}
+}
+
+// Close releases the resources of the consumer. The connection to the server is closed once Consume returns
+func (c *NATSConsumer) Close() error {
+	return c.nats.Close()
+}
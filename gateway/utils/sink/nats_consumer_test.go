@@ -0,0 +1,74 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNATSConsumer_Consume(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to start fake server: %v", err)
+	}
+	defer func() { _ = l.Close() }()
+
+	subs := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		r := bufio.NewReader(conn)
+		_, _ = fmt.Fprint(conn, "INFO {}\r\n")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case line == "PING":
+				_, _ = fmt.Fprint(conn, "PONG\r\n")
+			case strings.HasPrefix(line, "SUB "):
+				subs <- line
+				_, _ = fmt.Fprint(conn, "+OK\r\nPING\r\n")
+				_, _ = fmt.Fprint(conn, "MSG orders 1 10\r\n{\"id\":\"1\"}\r\n")
+				_, _ = fmt.Fprint(conn, "MSG orders 1 reply 10\r\n{\"id\":\"2\"}\r\n")
+			}
+		}
+	}()
+
+	c, err := NewNATSConsumer("nats://"+l.Addr().String(), "orders", "space-cloud")
+	if err != nil {
+		t.Fatalf("NewNATSConsumer() error = %v", err)
+	}
+	defer func() { _ = c.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []string
+	err = c.Consume(ctx, func(ctx context.Context, payload []byte) error {
+		got = append(got, string(payload))
+		if len(got) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+
+	if sub := <-subs; sub != "SUB orders space-cloud 1" {
+		t.Errorf("Consume() subscribed with (%s)", sub)
+	}
+	if len(got) != 2 || got[0] != `{"id":"1"}` || got[1] != `{"id":"2"}` {
+		t.Errorf("Consume() consumed messages = %v", got)
+	}
+}
@@ -0,0 +1,122 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	natsTest "github.com/nats-io/nats-server/v2/test"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+// runNATSServer starts an in-process nats server on which the publisher may only publish to the orders subject
+func runNATSServer(t *testing.T) *server.Server {
+	opts := natsTest.DefaultTestOptions
+	opts.Port = server.RANDOM_PORT
+	opts.Users = []*server.User{
+		{Username: "publisher", Password: "secret", Permissions: &server.Permissions{Publish: &server.SubjectPermission{Allow: []string{"orders"}}}},
+		{Username: "consumer", Password: "secret"},
+	}
+	s := natsTest.RunServer(&opts)
+	t.Cleanup(s.Shutdown)
+	return s
+}
+
+func TestNATS_publishConsume(t *testing.T) {
+	s := runNATSServer(t)
+	url := fmt.Sprintf("nats://consumer:secret@%s", s.Addr().String())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Consumers of the same queue group share the messages of the subject
+	received := make(chan string, 10)
+	for i := 0; i < 2; i++ {
+		c, err := NewNATSConsumer(url, "orders", "space-cloud")
+		if err != nil {
+			t.Fatalf("NewNATSConsumer() error = %v", err)
+		}
+		defer func() { _ = c.Close() }()
+
+		go func() {
+			_ = c.Consume(ctx, func(ctx context.Context, payload []byte) error {
+				received <- string(payload)
+				return nil
+			})
+		}()
+	}
+	for s.NumSubscriptions() < 2 {
+		if ctx.Err() != nil {
+			t.Fatalf("Consume() did not subscribe to the subject")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	publisher, err := NewNATS(fmt.Sprintf("nats://%s", s.Addr().String()), "orders", nil, &config.EventingAuth{Username: "publisher", Password: "secret"})
+	if err != nil {
+		t.Fatalf("NewNATS() error = %v", err)
+	}
+	defer func() { _ = publisher.Close() }()
+
+	want := map[string]bool{}
+	for i := 0; i < 5; i++ {
+		payload := fmt.Sprintf(`{"id":"%d"}`, i)
+		want[payload] = true
+		if err := publisher.Publish(ctx, "key", []byte(payload)); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	for len(want) > 0 {
+		select {
+		case payload := <-received:
+			if !want[payload] {
+				t.Fatalf("Consume() received unexpected or duplicate message (%s)", payload)
+			}
+			delete(want, payload)
+		case <-ctx.Done():
+			t.Fatalf("Consume() did not receive messages %v", want)
+		}
+	}
+
+	// Messages are delivered to only one consumer of the queue group
+	select {
+	case payload := <-received:
+		t.Errorf("Consume() received message (%s) more than once", payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestNATS_Publish_server(t *testing.T) {
+	s := runNATSServer(t)
+	url := fmt.Sprintf("nats://%s", s.Addr().String())
+
+	tests := []struct {
+		name    string
+		subject string
+		auth    *config.EventingAuth
+		wantErr bool
+	}{
+		{name: "allowed subject", subject: "orders", auth: &config.EventingAuth{Username: "publisher", Password: "secret"}},
+		{name: "permission violation is reported", subject: "payments", auth: &config.EventingAuth{Username: "publisher", Password: "secret"}, wantErr: true},
+		{name: "invalid credentials", subject: "orders", auth: &config.EventingAuth{Username: "publisher", Password: "wrong"}, wantErr: true},
+		{name: "missing credentials", subject: "orders", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := NewNATS(url, tt.subject, nil, tt.auth)
+			if err != nil {
+				t.Fatalf("NewNATS() error = %v", err)
+			}
+			defer func() { _ = n.Close() }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := n.Publish(ctx, "", []byte(`{"id":"1"}`)); (err != nil) != tt.wantErr {
+				t.Errorf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+type publishedMessage struct {
+	subject string
+	payload string
+}
+
+// fakeNATSServer accepts connections and records the messages published to it
+func fakeNATSServer(t *testing.T, reject string) (string, chan map[string]interface{}, chan publishedMessage) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to start fake server: %v", err)
+	}
+	connects := make(chan map[string]interface{}, 10)
+	messages := make(chan publishedMessage, 10)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				r := bufio.NewReader(conn)
+				_, _ = fmt.Fprint(conn, "INFO {\"server_id\":\"fake\",\"max_payload\":1048576}\r\n")
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					line = strings.TrimRight(line, "\r\n")
+					switch {
+					case strings.HasPrefix(line, "CONNECT "):
+						options := map[string]interface{}{}
+						_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "CONNECT ")), &options)
+						connects <- options
+						if options["verbose"] == true {
+							_, _ = fmt.Fprint(conn, "+OK\r\n")
+						}
+					case line == "PING":
+						_, _ = fmt.Fprint(conn, "PONG\r\n")
+					case strings.HasPrefix(line, "PUB "):
+						parts := strings.Fields(line)
+						size, _ := strconv.Atoi(parts[len(parts)-1])
+						payload := make([]byte, size+2)
+						if _, err := io.ReadFull(r, payload); err != nil {
+							return
+						}
+						messages <- publishedMessage{subject: parts[1], payload: string(payload[:size])}
+						// Servers may ping clients at any time
+						_, _ = fmt.Fprint(conn, "PING\r\n")
+						if reject != "" {
+							_, _ = fmt.Fprintf(conn, "-ERR '%s'\r\n", reject)
+						}
+					}
+				}
+			}()
+		}
+	}()
+	t.Cleanup(func() { _ = l.Close() })
+	return l.Addr().String(), connects, messages
+}
+
+func TestNATS_Publish(t *testing.T) {
+	tests := []struct {
+		name        string
+		userInfo    string
+		auth        *config.EventingAuth
+		reject      string
+		wantConnect map[string]interface{}
+		wantErr     bool
+	}{
+		{name: "message is processed", wantConnect: map[string]interface{}{"name": "space-cloud"}},
+		{name: "credentials of the url are sent", userInfo: "user:pass@", wantConnect: map[string]interface{}{"user": "user", "pass": "pass"}},
+		{name: "token of the url is sent", userInfo: "token@", wantConnect: map[string]interface{}{"auth_token": "token"}},
+		{name: "credentials are sent", auth: &config.EventingAuth{Username: "user", Password: "pass"}, wantConnect: map[string]interface{}{"user": "user", "pass": "pass"}},
+		{name: "token is sent", auth: &config.EventingAuth{Token: "token"}, wantConnect: map[string]interface{}{"auth_token": "token"}},
+		{name: "server rejects the message", reject: "Permissions Violation for Publish to orders.created", wantConnect: map[string]interface{}{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, connects, messages := fakeNATSServer(t, tt.reject)
+			n, err := NewNATS("nats://"+tt.userInfo+addr, "orders.created", nil, tt.auth)
+			if err != nil {
+				t.Fatalf("NewNATS() error = %v", err)
+			}
+			defer func() { _ = n.Close() }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			err = n.Publish(ctx, "", []byte(`{"id":"1"}`))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			options := <-connects
+			for k, v := range tt.wantConnect {
+				if options[k] != v {
+					t.Errorf("Publish() connect option (%s) = %v, want %v", k, options[k], v)
+				}
+			}
+			if msg := <-messages; msg.subject != "orders.created" || msg.payload != `{"id":"1"}` {
+				t.Errorf("Publish() published message = %v", msg)
+			}
+			if tt.wantErr && n.conn != nil {
+				t.Errorf("Publish() connection not closed after error")
+			}
+		})
+	}
+}
+
+func TestNewNATS(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		subject string
+		tls     *config.EventingTLS
+		auth    *config.EventingAuth
+		wantErr bool
+	}{
+		{name: "valid url", url: "nats://localhost", subject: "orders"},
+		{name: "tls url", url: "tls://localhost", subject: "orders", tls: &config.EventingTLS{ServerName: "nats.local"}},
+		{name: "invalid scheme", url: "http://localhost:4222", subject: "orders", wantErr: true},
+		{name: "invalid subject", url: "nats://localhost", subject: "orders created", wantErr: true},
+		{name: "invalid ca certificate", url: "nats://localhost", subject: "orders", tls: &config.EventingTLS{CACert: "invalid"}, wantErr: true},
+		{name: "credentials without username or token", url: "nats://localhost", subject: "orders", auth: &config.EventingAuth{Password: "pass"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNATS(tt.url, tt.subject, tt.tls, tt.auth); (err != nil) != tt.wantErr {
+				t.Errorf("NewNATS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
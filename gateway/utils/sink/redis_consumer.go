@@ -0,0 +1,96 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// redisConsumerName is shared by all gateways. Entries left pending by a gateway which stopped consuming get redelivered
+// to the one which takes over
+const redisConsumerName = "space-cloud"
+
+// RedisConsumer consumes the entries of a redis stream as a member of a consumer group. Entries are acknowledged once
+// they have been processed. Entries which have a `data` field are consumed as the value of that field. The fields of
+// other entries are consumed as a json object
+type RedisConsumer struct {
+	client *redis.Client
+	stream string
+	group  string
+}
+
+// NewRedisConsumer creates a consumer for the stream. The connection is established lazily
+func NewRedisConsumer(rawURL, stream, group string) (*RedisConsumer, error) {
+	opts, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url (%s) provided: %v", rawURL, err)
+	}
+	return &RedisConsumer{client: redis.NewClient(opts), stream: stream, group: group}, nil
+}
+
+// Consume processes the entries of the stream. The entries which were delivered earlier but never acknowledged are
+// processed before the new ones
+func (c *RedisConsumer) Consume(ctx context.Context, handler Handler) error {
+	// Errors caused by the context getting cancelled mean the consumer was stopped
+	if err := c.consume(ctx, handler); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+func (c *RedisConsumer) consume(ctx context.Context, handler Handler) error {
+	if err := c.client.XGroupCreateMkStream(ctx, c.stream, c.group, "$").Err(); err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	id := "0"
+	for ctx.Err() == nil {
+		streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{Group: c.group, Consumer: redisConsumerName, Streams: []string{c.stream, id}, Count: 100, Block: time.Second}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		processed := 0
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				processed++
+
+				// Pending entries which have been deleted from the stream don't have any fields
+				if msg.Values != nil {
+					if err := handler(ctx, redisPayload(msg.Values)); err != nil {
+						return err
+					}
+				}
+				if err := c.client.XAck(ctx, c.stream, c.group, msg.ID).Err(); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Switch to new entries once there are no pending ones left
+		if id == "0" && processed == 0 {
+			id = ">"
+		}
+	}
+	return nil
+}
+
+func redisPayload(values map[string]interface{}) []byte {
+	if data, ok := values["data"].(string); ok {
+		return []byte(data)
+	}
+	data, _ := json.Marshal(values)
+	return data
+}
+
+// Close closes the connections to the redis server
+func (c *RedisConsumer) Close() error {
+	return c.client.Close()
+}
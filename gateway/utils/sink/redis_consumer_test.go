@@ -0,0 +1,127 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRedisServer replies to the stream commands used by the consumer. The first read of new entries returns one entry
+// with a data field and one without
+func fakeRedisServer(t *testing.T) (string, chan []string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to start fake server: %v", err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+
+	commands := make(chan []string, 20)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				r := bufio.NewReader(conn)
+				delivered := false
+				for {
+					cmd, err := readRESPCommand(r)
+					if err != nil {
+						return
+					}
+					commands <- cmd
+
+					switch strings.ToLower(cmd[0]) {
+					case "xgroup":
+						_, _ = fmt.Fprint(conn, "-BUSYGROUP Consumer Group name already exists\r\n")
+					case "xreadgroup":
+						switch {
+						case cmd[len(cmd)-1] == "0":
+							_, _ = fmt.Fprint(conn, "*1\r\n*2\r\n$6\r\norders\r\n*0\r\n")
+						case !delivered:
+							delivered = true
+							_, _ = fmt.Fprint(conn, "*1\r\n*2\r\n$6\r\norders\r\n*2\r\n"+
+								"*2\r\n$3\r\n1-0\r\n*2\r\n$4\r\ndata\r\n$10\r\n{\"id\":\"1\"}\r\n"+
+								"*2\r\n$3\r\n2-0\r\n*2\r\n$2\r\nid\r\n$1\r\n2\r\n")
+						default:
+							time.Sleep(10 * time.Millisecond)
+							_, _ = fmt.Fprint(conn, "*-1\r\n")
+						}
+					case "xack":
+						_, _ = fmt.Fprint(conn, ":1\r\n")
+					default:
+						_, _ = fmt.Fprint(conn, "+OK\r\n")
+					}
+				}
+			}()
+		}
+	}()
+	return l.Addr().String(), commands
+}
+
+func readRESPCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	cmd := make([]string, n)
+	for i := range cmd {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		b := make([]byte, size+2)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		cmd[i] = string(b[:size])
+	}
+	return cmd, nil
+}
+
+func TestRedisConsumer_Consume(t *testing.T) {
+	addr, commands := fakeRedisServer(t)
+	c, err := NewRedisConsumer("redis://"+addr, "orders", "project-orders")
+	if err != nil {
+		t.Fatalf("NewRedisConsumer() error = %v", err)
+	}
+	defer func() { _ = c.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []string
+	err = c.Consume(ctx, func(ctx context.Context, payload []byte) error {
+		got = append(got, string(payload))
+		if len(got) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != `{"id":"1"}` || got[1] != `{"id":"2"}` {
+		t.Errorf("Consume() consumed messages = %v", got)
+	}
+
+	var acked []string
+	for len(commands) > 0 {
+		if cmd := <-commands; strings.ToLower(cmd[0]) == "xack" {
+			acked = append(acked, cmd[3:]...)
+		}
+	}
+	if len(acked) < 1 || acked[0] != "1-0" {
+		t.Errorf("Consume() acknowledged entries = %v", acked)
+	}
+}
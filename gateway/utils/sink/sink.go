@@ -0,0 +1,123 @@
+// Package sink connects the eventing module to message brokers. Sinks publish the events of triggers while consumers
+// queue the messages of event sources
+package sink
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+// Sink publishes messages to a message broker. A message is considered delivered only once the broker acknowledges it
+type Sink interface {
+	Publish(ctx context.Context, key string, payload []byte) error
+	Close() error
+}
+
+// Consumer consumes messages from a message broker. Consume blocks till the context is cancelled or the connection to
+// the broker fails
+type Consumer interface {
+	Consume(ctx context.Context, handler Handler) error
+	Close() error
+}
+
+// Handler processes a message. Messages are acknowledged only if the handler succeeds
+type Handler func(ctx context.Context, payload []byte) error
+
+// New creates a sink which publishes to the target provided
+func New(target *config.EventingTarget) (Sink, error) {
+	if err := Validate(target); err != nil {
+		return nil, err
+	}
+
+	switch target.Type {
+	case config.EventingTargetKafka:
+		return NewKafka(target.Brokers, target.Topic, target.TLS, target.Auth)
+	case config.EventingTargetNATS:
+		return NewNATS(target.URL, target.Subject, target.TLS, target.Auth)
+	default:
+		return nil, fmt.Errorf("invalid target type (%s) provided", target.Type)
+	}
+}
+
+// Validate checks if the target has all the fields required by its type
+func Validate(target *config.EventingTarget) error {
+	switch target.Type {
+	case config.EventingTargetKafka:
+		if len(target.Brokers) == 0 || target.Topic == "" {
+			return errors.New("brokers and topic of the kafka target have not been provided")
+		}
+	case config.EventingTargetNATS:
+		if target.URL == "" || target.Subject == "" {
+			return errors.New("url and subject of the nats target have not been provided")
+		}
+	default:
+		return fmt.Errorf("invalid target type (%s) provided", target.Type)
+	}
+	return nil
+}
+
+// NewConsumer creates a consumer for the source provided
+func NewConsumer(source *config.EventingSource) (Consumer, error) {
+	if err := ValidateSource(source); err != nil {
+		return nil, err
+	}
+
+	switch source.Type {
+	case config.EventingSourceRedis:
+		return NewRedisConsumer(source.URL, source.Stream, source.Group)
+	case config.EventingSourceNATS:
+		return NewNATSConsumer(source.URL, source.Subject, source.Group)
+	case config.EventingSourceKafka:
+		return NewKafkaConsumer(source.Brokers, source.Topic, source.Group), nil
+	default:
+		return nil, fmt.Errorf("invalid source type (%s) provided", source.Type)
+	}
+}
+
+// ValidateSource checks if the source has all the fields required by its type
+func ValidateSource(source *config.EventingSource) error {
+	switch source.Type {
+	case config.EventingSourceRedis:
+		if source.URL == "" || source.Stream == "" {
+			return errors.New("url and stream of the redis source have not been provided")
+		}
+	case config.EventingSourceNATS:
+		if source.URL == "" || source.Subject == "" {
+			return errors.New("url and subject of the nats source have not been provided")
+		}
+	case config.EventingSourceKafka:
+		if len(source.Brokers) == 0 || source.Topic == "" {
+			return errors.New("brokers and topic of the kafka source have not been provided")
+		}
+	default:
+		return fmt.Errorf("invalid source type (%s) provided", source.Type)
+	}
+	return nil
+}
+
+// newTLSConfig creates the config of the tls connections made to a message broker
+func newTLSConfig(c *config.EventingTLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: c.ServerName, InsecureSkipVerify: c.InsecureSkipVerify, MinVersion: tls.VersionTLS12}
+
+	if c.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CACert)) {
+			return nil, errors.New("invalid ca certificate provided")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.Cert != "" || c.Key != "" {
+		cert, err := tls.X509KeyPair([]byte(c.Cert), []byte(c.Key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate provided: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
@@ -273,6 +273,12 @@ func Validate(dbType string, where map[string]interface{}, obj interface{}) bool
 						return false
 					}
 					return r.MatchString(vString)
+				case "$search":
+					vString, ok1 := val.(string)
+					text, ok2 := v2.(string)
+					if !ok1 || !ok2 || TextSearchScore(vString, text) == 0 {
+						return false
+					}
 				default:
 					log.Printf("Invalid operator (%s) provided\n", k2)
 					return false
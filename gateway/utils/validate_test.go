@@ -575,6 +575,24 @@ func TestValidate(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "valid search",
+			args: args{
+				dbType: string(model.Postgres),
+				where:  map[string]interface{}{"op2": map[string]interface{}{"$search": "Quick FOX"}},
+				obj:    map[string]interface{}{"op2": "The quick brown fox, jumps over the lazy dog"},
+			},
+			want: true,
+		},
+		{
+			name: "invalid search with a missing word",
+			args: args{
+				dbType: string(model.Postgres),
+				where:  map[string]interface{}{"op2": map[string]interface{}{"$search": "quick cat"}},
+				obj:    map[string]interface{}{"op2": "The quick brown fox, jumps over the lazy dog"},
+			},
+			want: false,
+		},
 		{
 			name: "valid contains single field match",
 			args: args{